@@ -0,0 +1,160 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+// Cooldowns for clusters that failed to connect: the first failure keeps a cluster
+// out of the next half minute of commands, and every further failure in a row
+// doubles that, up to maxConnectCooldown
+const (
+	baseConnectCooldown = 30 * time.Second
+	maxConnectCooldown  = 10 * time.Minute
+)
+
+// connectBreaker remembers clusters that recently failed to connect
+// Every mcm command is a fresh process that dials every cluster, so without a
+// memory a cluster that's down costs each command the full connect timeout.
+// Like a circuit breaker, a failure trips it for a while; once the cooldown
+// passes the next command tries again, and a success resets it
+type connectBreaker struct {
+	Clusters map[string]breakerEntry `json:"clusters"`
+}
+
+// breakerEntry is one cluster's run of consecutive connection failures
+type breakerEntry struct {
+	Failures    int       `json:"failures"`
+	LastError   string    `json:"lastError"`
+	RetryAfter  time.Time `json:"retryAfter"`
+	ConfigHash  string    `json:"configHash"` // Editing the cluster's config entry resets the breaker
+	LastAttempt time.Time `json:"lastAttempt"`
+}
+
+// connectBreakerPath returns where the breaker state is kept
+func connectBreakerPath() (string, error) {
+	dir, err := config.CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "connect-breaker.json"), nil
+}
+
+// loadConnectBreaker reads the breaker state, starting empty if there is none
+func loadConnectBreaker() (*connectBreaker, error) {
+	breaker := &connectBreaker{Clusters: make(map[string]breakerEntry)}
+
+	path, err := connectBreakerPath()
+	if err != nil {
+		return breaker, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return breaker, nil
+	}
+	if err != nil {
+		return breaker, err
+	}
+	if err := json.Unmarshal(data, breaker); err != nil {
+		return &connectBreaker{Clusters: make(map[string]breakerEntry)}, fmt.Errorf("ignoring unreadable %s: %w", path, err)
+	}
+	if breaker.Clusters == nil {
+		breaker.Clusters = make(map[string]breakerEntry)
+	}
+	return breaker, nil
+}
+
+// save writes the breaker state, or removes the file when no cluster is failing
+func (b *connectBreaker) save() error {
+	path, err := connectBreakerPath()
+	if err != nil {
+		return err
+	}
+
+	if len(b.Clusters) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// skips returns the clusters still cooling down, with the reason shown to the user
+func (b *connectBreaker) skips(clusters []config.ClusterConfig, now time.Time) map[string]string {
+	skip := make(map[string]string)
+	for _, clusterConfig := range clusters {
+		entry, ok := b.Clusters[clusterConfig.Name]
+		if !ok || entry.ConfigHash != clusterConfigHash(clusterConfig) || !now.Before(entry.RetryAfter) {
+			continue
+		}
+		skip[clusterConfig.Name] = fmt.Sprintf("recently failed, retry after %s (--force-reconnect to try now): %s",
+			entry.RetryAfter.Sub(now).Round(time.Second), entry.LastError)
+	}
+	return skip
+}
+
+// record updates the breaker with the outcome of one connection attempt
+func (b *connectBreaker) record(client *cluster.ClusterClient, now time.Time) {
+	name := client.Config.Name
+	if client.Connected {
+		delete(b.Clusters, name)
+		return
+	}
+
+	entry := b.Clusters[name]
+	hash := clusterConfigHash(client.Config)
+	if entry.ConfigHash != hash {
+		entry = breakerEntry{ConfigHash: hash}
+	}
+	entry.Failures++
+	entry.LastAttempt = now
+	entry.RetryAfter = now.Add(connectCooldown(entry.Failures))
+	if client.Error != nil {
+		entry.LastError = client.Error.Error()
+	}
+	b.Clusters[name] = entry
+}
+
+// prune forgets clusters that are no longer configured
+func (b *connectBreaker) prune(clusters []config.ClusterConfig) {
+	configured := make(map[string]bool, len(clusters))
+	for _, clusterConfig := range clusters {
+		configured[clusterConfig.Name] = true
+	}
+	for name := range b.Clusters {
+		if !configured[name] {
+			delete(b.Clusters, name)
+		}
+	}
+}
+
+// connectCooldown is how long a cluster is skipped after its n-th failure in a row
+func connectCooldown(failures int) time.Duration {
+	cooldown := baseConnectCooldown
+	for i := 1; i < failures && cooldown < maxConnectCooldown; i++ {
+		cooldown *= 2
+	}
+	return min(cooldown, maxConnectCooldown)
+}
+
+// clusterConfigHash fingerprints a cluster's config entry, so fixing a broken
+// entry (say, a wrong context) takes effect immediately instead of after the cooldown
+func clusterConfigHash(clusterConfig config.ClusterConfig) string {
+	data, _ := json.Marshal(clusterConfig)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
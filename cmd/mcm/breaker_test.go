@@ -0,0 +1,74 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+func TestConnectBreakerCoolsDownFailingClusters(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	down := config.ClusterConfig{Name: "down", Context: "down"}
+	up := config.ClusterConfig{Name: "up", Context: "up"}
+	clusters := []config.ClusterConfig{down, up}
+
+	breaker, err := loadConnectBreaker()
+	if err != nil {
+		t.Fatal(err)
+	}
+	breaker.record(&cluster.ClusterClient{Config: down, Error: errors.New("dial tcp: i/o timeout")}, now)
+	breaker.record(&cluster.ClusterClient{Config: up, Connected: true}, now)
+	if err := breaker.save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The next process sees the failure and skips only that cluster
+	breaker, err = loadConnectBreaker()
+	if err != nil {
+		t.Fatal(err)
+	}
+	skip := breaker.skips(clusters, now.Add(10*time.Second))
+	if len(skip) != 1 || !strings.Contains(skip["down"], "retry after 20s") || !strings.Contains(skip["down"], "i/o timeout") {
+		t.Errorf("Expected 'down' to be skipped for another 20s, got %v", skip)
+	}
+
+	if skip := breaker.skips(clusters, now.Add(baseConnectCooldown)); len(skip) != 0 {
+		t.Errorf("Expected the cooldown to have passed, got %v", skip)
+	}
+
+	// Fixing the cluster's config entry retries it straight away
+	edited := []config.ClusterConfig{{Name: "down", Context: "fixed"}, up}
+	if skip := breaker.skips(edited, now.Add(time.Second)); len(skip) != 0 {
+		t.Errorf("Expected an edited entry not to be skipped, got %v", skip)
+	}
+
+	// A successful connection resets the breaker, and an empty breaker leaves no file behind
+	breaker.record(&cluster.ClusterClient{Config: down, Connected: true}, now.Add(time.Minute))
+	if err := breaker.save(); err != nil {
+		t.Fatal(err)
+	}
+	if breaker, _ = loadConnectBreaker(); len(breaker.Clusters) != 0 {
+		t.Errorf("Expected the breaker to be reset, got %v", breaker.Clusters)
+	}
+}
+
+func TestConnectCooldownBacksOff(t *testing.T) {
+	tests := map[int]time.Duration{
+		1:  30 * time.Second,
+		2:  time.Minute,
+		3:  2 * time.Minute,
+		5:  8 * time.Minute,
+		6:  maxConnectCooldown,
+		40: maxConnectCooldown,
+	}
+	for failures, want := range tests {
+		if got := connectCooldown(failures); got != want {
+			t.Errorf("connectCooldown(%d) = %s, want %s", failures, got, want)
+		}
+	}
+}
@@ -1,11 +1,21 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/celikgo/autoz-control-tower/internal/cluster"
+	"github.com/celikgo/autoz-control-tower/internal/config"
+	"github.com/celikgo/autoz-control-tower/internal/output"
+	"github.com/celikgo/autoz-control-tower/internal/workload"
 	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -24,12 +34,17 @@ Use this to check cluster connectivity, view cluster status, and manage cluster
 Examples:
   mcm clusters list                    # Show all clusters with their status
   mcm clusters test                    # Test connectivity to all clusters
+  mcm clusters connectivity --service=gw  # Test cross-cluster reachability
+  mcm clusters summary                 # Count nodes, pods and more per cluster
   mcm clusters list --output=json     # Show cluster info in JSON format`,
 	}
 
 	// Add subcommands for different cluster operations
 	clustersCmd.AddCommand(newClustersListCmd())
 	clustersCmd.AddCommand(newClustersTestCmd())
+	clustersCmd.AddCommand(newClustersPingCmd())
+	clustersCmd.AddCommand(newClustersConnectivityCmd())
+	clustersCmd.AddCommand(newClustersSummaryCmd())
 
 	return clustersCmd
 }
@@ -37,7 +52,7 @@ Examples:
 // newClustersListCmd creates the 'clusters list' subcommand
 // This shows all configured clusters and their current connection status
 func newClustersListCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all configured clusters and their status",
 		Long: `Display information about all clusters defined in your configuration file.
@@ -48,31 +63,161 @@ The output shows:
 - Connection status (connected/disconnected)
 - Region or location information
 - Whether it's marked as the default cluster
-- Any error messages if connection failed`,
+- Any error messages if connection failed
+
+Clusters can also carry a cloud provider and account in the configuration.
+--wide adds them as columns, and --provider/--account narrow the list to one
+cloud or billing account; --environment/--region do the same for the environment
+and region each cluster is tagged with. --wide also shows the API server URL
+each cluster's context resolved to (with any credentials in it removed), for
+when you need to reach a cluster directly.
+
+Examples:
+  mcm clusters list
+  mcm clusters list --wide
+  mcm clusters list --provider=aws --account=123456789012
+  mcm clusters list --environment=production --region=eu-west-1
+  mcm clusters list --provider=gcp --output=name   # Cluster names, for scripting`,
 
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Get cluster status information from our cluster manager
-			clusters := clusterManager.ListClusters()
+			provider, _ := cmd.Flags().GetString("provider")
+			account, _ := cmd.Flags().GetString("account")
+			clusters := filterClustersByMetadata(clusterManager.ListClusters(), provider, account)
+			environment, _ := cmd.Flags().GetString("environment")
+			region, _ := cmd.Flags().GetString("region")
+			if environment != "" || region != "" {
+				clusters = filterClustersByName(clusters, clusterManager.ClustersByEnvironment(environment, region))
+			}
 
 			// Determine output format from flags
 			outputFormat := viper.GetString("output")
 
 			switch outputFormat {
 			case "json":
-				return outputClustersJSON(clusters)
+				return output.ClustersJSON(os.Stdout, clusters)
 			case "yaml":
-				return outputClustersYAML(clusters)
+				return output.ClustersYAML(os.Stdout, clusters)
+			case "name":
+				return output.Names(os.Stdout, clusterNames(clusters))
 			default:
-				return outputClustersTable(clusters)
+				wide, _ := cmd.Flags().GetBool("wide")
+				return output.ClustersTable(os.Stdout, clusters, output.ClustersTableOptions{Wide: wide})
 			}
 		},
 	}
+
+	cmd.Flags().Bool("wide", false, "also show each cluster's cloud provider, account and API server URL")
+	cmd.Flags().String("provider", "", "only list clusters with this provider, e.g. aws (case-insensitive)")
+	cmd.Flags().String("account", "", "only list clusters in this cloud account")
+	addLocationFlags(cmd)
+
+	return cmd
+}
+
+// newClustersSummaryCmd creates the 'clusters summary' subcommand
+// This is the "how big is everything?" view for capacity reviews and audits
+func newClustersSummaryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "summary",
+		Short: "Count nodes, namespaces, deployments, services and pods per cluster",
+		Long: `Show one row per cluster with how many nodes, namespaces, deployments,
+services and pods it has, with pods broken down by phase, and a TOTAL row for
+the whole fleet. Only counts are shown - use 'deployments list' or 'pods list'
+for the objects themselves.
+
+Lists are read in pages and, where the API server reports how many items are
+left, counted without fetching them, so the summary stays fast on big clusters.
+--namespace limits deployments, services and pods to one namespace; nodes and
+namespaces are always counted cluster-wide.
+
+Clusters that can't be counted are named below the table and make the command
+exit non-zero, like the other fleet-wide listings.
+
+Examples:
+  mcm clusters summary
+  mcm clusters summary --clusters=prod-us,prod-eu
+  mcm clusters summary -n production
+  mcm clusters summary --output=json     # Per-cluster counts plus fleet totals`,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := applyListTimeouts(cmd); err != nil {
+				return err
+			}
+			clusters := parseClusterList(cmd.Flag("clusters").Value.String())
+			namespace := cmd.Flag("namespace").Value.String()
+
+			result := workloadManager.CountResources(clusters, namespace)
+
+			var err error
+			switch viper.GetString("output") {
+			case "json":
+				err = output.ResourceCountsJSON(os.Stdout, result.Items, result.ErrorMessages())
+			case "yaml":
+				err = output.ResourceCountsYAML(os.Stdout, result.Items, result.ErrorMessages())
+			default:
+				err = output.ResourceCountsTable(os.Stdout, result.Items)
+				if err == nil {
+					printFleetFailures(os.Stdout, result)
+				}
+			}
+			if err != nil {
+				return err
+			}
+
+			return fleetError(cmd, result)
+		},
+	}
+
+	cmd.Flags().String("clusters", "", "comma-separated list of cluster names or @groups")
+	cmd.Flags().StringP("namespace", "n", "", "only count deployments, services and pods in this namespace (default: all namespaces)")
+	addListTimeoutFlags(cmd)
+
+	return cmd
+}
+
+// filterClustersByMetadata keeps the clusters matching the given provider and
+// account; an empty filter matches everything
+// Provider names are matched case-insensitively since "AWS" and "aws" are the
+// same cloud, while account IDs must match exactly
+func filterClustersByMetadata(clusters []cluster.ClusterStatus, provider, account string) []cluster.ClusterStatus {
+	if provider == "" && account == "" {
+		return clusters
+	}
+
+	var matched []cluster.ClusterStatus
+	for _, status := range clusters {
+		if provider != "" && !strings.EqualFold(status.Provider, provider) {
+			continue
+		}
+		if account != "" && status.Account != account {
+			continue
+		}
+		matched = append(matched, status)
+	}
+	return matched
+}
+
+// filterClustersByName keeps the clusters whose names are listed
+func filterClustersByName(clusters []cluster.ClusterStatus, names []string) []cluster.ClusterStatus {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	var matched []cluster.ClusterStatus
+	for _, status := range clusters {
+		if wanted[status.Name] {
+			matched = append(matched, status)
+		}
+	}
+	return matched
 }
 
 // newClustersTestCmd creates the 'clusters test' subcommand
 // This actively tests connectivity to all clusters
 func newClustersTestCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "test",
 		Short: "Test connectivity to all configured clusters",
 		Long: `Actively test the connection to each configured cluster by making a simple API call.
@@ -82,9 +227,35 @@ This command will:
 - Attempt to connect to each cluster's Kubernetes API server
 - Verify that authentication is working
 - Report any clusters that are unreachable
-- Show response times for each cluster`,
+- Show response times for each cluster
+
+With --repeat the test runs again every --interval until interrupted, printing
+one timestamped status line per round - a simple fleet availability monitor
+for a terminal or a container, without 'mcm serve'. Clusters that were down
+are dialed again each round, so recoveries show up. --changes-only prints a
+round only when a cluster went up or down since the one before. With
+--output=json each round is one line of JSON (JSON Lines); with --output=yaml,
+one YAML document.
+
+Examples:
+  mcm clusters test
+  mcm clusters test --repeat --interval=1m
+  mcm clusters test --repeat --changes-only --output=json >> availability.jsonl`,
 
 		RunE: func(cmd *cobra.Command, args []string) error {
+			repeat, _ := cmd.Flags().GetBool("repeat")
+			interval, _ := cmd.Flags().GetDuration("interval")
+			changesOnly, _ := cmd.Flags().GetBool("changes-only")
+			if !repeat && (changesOnly || cmd.Flags().Changed("interval")) {
+				return fmt.Errorf("--interval and --changes-only only apply with --repeat")
+			}
+			if repeat {
+				if interval <= 0 {
+					return fmt.Errorf("--interval must be positive, got %s", interval)
+				}
+				return monitorConnections(interval, changesOnly)
+			}
+
 			fmt.Println("Testing cluster connections...")
 
 			err := clusterManager.TestConnections()
@@ -97,105 +268,329 @@ This command will:
 			return nil
 		},
 	}
+
+	cmd.Flags().Bool("repeat", false, "keep testing, every --interval, until interrupted; prints one status line per round")
+	cmd.Flags().Duration("interval", 30*time.Second, "with --repeat, how long to wait between rounds")
+	cmd.Flags().Bool("changes-only", false, "with --repeat, only print a round when a cluster's health changed")
+
+	return cmd
 }
 
-// outputClustersTable displays cluster information in a human-readable table format
-// This is the default output format that most users will see
-func outputClustersTable(clusters []cluster.ClusterStatus) error {
-	// Create a tabwriter for nicely formatted columns
-	// This is like creating a spreadsheet that auto-adjusts column widths
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	defer w.Flush()
-
-	// Print table headers
-	fmt.Fprintln(w, "NAME\tENVIRONMENT\tREGION\tSTATUS\tDEFAULT\tERROR")
-	fmt.Fprintln(w, "----\t-----------\t------\t------\t-------\t-----")
-
-	// Print each cluster's information
-	for _, cluster := range clusters {
-		// Format the status with visual indicators
-		status := "❌ Disconnected"
-		if cluster.Connected {
-			status = "✅ Connected"
-		}
+// monitorConnections tests every cluster each interval until interrupted
+// The first round always prints; from the second on, clusters that aren't
+// connected are dialed again before they're tested
+func monitorConnections(interval time.Duration, changesOnly bool) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-		// Show if this is the default cluster
-		defaultMarker := ""
-		if cluster.IsDefault {
-			defaultMarker = "⭐ Yes"
-		}
+	printRound := output.ConnectionStatusLine
+	switch viper.GetString("output") {
+	case "json":
+		printRound = output.ConnectionStatusJSONLine
+	case "yaml":
+		printRound = output.ConnectionStatusYAML
+	}
 
-		// Format environment and region with fallbacks
-		environment := cluster.Environment
-		if environment == "" {
-			environment = "-"
+	var previous string
+	first := true
+	err := watchLoop(ctx, interval, 0, func() (bool, error) {
+		checks := clusterManager.CheckConnections(!first)
+		state := connectionState(checks)
+		if first || !changesOnly || state != previous {
+			if err := printRound(os.Stdout, time.Now(), checks); err != nil {
+				return false, err
+			}
 		}
+		previous, first = state, false
+		return false, nil
+	})
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
 
-		region := cluster.Region
-		if region == "" {
-			region = "-"
-		}
+// connectionState sums up which clusters are healthy, for telling rounds apart
+// Latency is left out: it changes every round without anything happening
+func connectionState(checks []cluster.ConnectionCheck) string {
+	var state strings.Builder
+	for _, check := range checks {
+		fmt.Fprintf(&state, "%s=%t;", check.Name, check.Healthy)
+	}
+	return state.String()
+}
 
-		// Truncate long error messages for table display
-		errorMsg := cluster.Error
-		if len(errorMsg) > 50 {
-			errorMsg = errorMsg[:47] + "..."
-		}
-		if errorMsg == "" {
-			errorMsg = "-"
-		}
+// newClustersPingCmd creates the 'clusters ping' subcommand
+// Where 'clusters test' asks "can mcm use this cluster?", ping only asks "is
+// anything listening at this address?" - the first question to settle when a
+// cluster is down, before suspecting credentials
+func newClustersPingCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ping",
+		Short: "Check that each cluster's API server is reachable over the network",
+		Long: `Open a plain TCP connection to each cluster's API server and report whether
+it answered and how long that took. No TLS handshake or authentication is
+attempted, and mcm does not connect to the clusters first, so this is fast and
+works even when credentials are broken.
+
+Reading the result together with 'clusters list' tells the two apart:
+- unreachable here: a network problem - VPN, firewall, DNS, or the API server
+  is down
+- reachable here but failing to connect: the network is fine; look at
+  credentials, certificates or RBAC
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
-			cluster.Name,
-			environment,
-			region,
-			status,
-			defaultMarker,
-			errorMsg,
-		)
+The address dialed is the server of each cluster's kubeconfig context; proxy
+settings are not applied. The command exits non-zero when any cluster is
+unreachable.
+
+Examples:
+  mcm clusters ping
+  mcm clusters ping --clusters=prod-us,prod-eu --timeout=5s
+  mcm clusters ping --output=json`,
+
+		// Load the configuration but don't connect: connecting is what might be broken
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return loadAppConfig(cmd)
+		},
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+			if timeout <= 0 {
+				return fmt.Errorf("--timeout must be positive, got %s", timeout)
+			}
+
+			clusters := appConfig.Clusters
+			if names := parseClusterList(cmd.Flag("clusters").Value.String()); len(names) > 0 {
+				var err error
+				if clusters, err = configuredClusters(appConfig.Clusters, names); err != nil {
+					return err
+				}
+			}
+
+			results := cluster.PingClusters(clusters, timeout)
+			sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+
+			var err error
+			switch viper.GetString("output") {
+			case "json":
+				err = output.PingJSON(os.Stdout, results)
+			case "yaml":
+				err = output.PingYAML(os.Stdout, results)
+			default:
+				err = output.PingTable(os.Stdout, results)
+			}
+			if err != nil {
+				return err
+			}
+
+			var unreachable []string
+			for _, result := range results {
+				if !result.Reachable {
+					unreachable = append(unreachable, result.Name)
+				}
+			}
+			if len(unreachable) > 0 {
+				return fmt.Errorf("%d/%d clusters are unreachable: %s",
+					len(unreachable), len(results), summarizeNames(unreachable, 5))
+			}
+			return nil
+		},
 	}
 
-	return nil
+	cmd.Flags().String("clusters", "", "comma-separated list of cluster names or @groups (default: all configured clusters)")
+	cmd.Flags().Duration("timeout", cluster.DefaultPingTimeout, "how long to wait for each API server to accept the connection")
+
+	return cmd
 }
 
-// outputClustersJSON displays cluster information in JSON format
-// This is useful for programmatic consumption or integration with other tools
-func outputClustersJSON(clusters []cluster.ClusterStatus) error {
-	// Create a wrapper structure for better JSON organization
-	output := struct {
-		Clusters []cluster.ClusterStatus `json:"clusters"`
-		Count    int                     `json:"count"`
-	}{
-		Clusters: clusters,
-		Count:    len(clusters),
+// configuredClusters picks the named entries out of the configuration
+func configuredClusters(clusters []config.ClusterConfig, names []string) ([]config.ClusterConfig, error) {
+	byName := make(map[string]config.ClusterConfig, len(clusters))
+	for _, clusterConfig := range clusters {
+		byName[clusterConfig.Name] = clusterConfig
 	}
 
-	// Marshal to JSON with indentation for readability
-	jsonData, err := json.MarshalIndent(output, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal clusters to JSON: %w", err)
+	selected := make([]config.ClusterConfig, 0, len(names))
+	for _, name := range names {
+		clusterConfig, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("cluster '%s' is not in the configuration", name)
+		}
+		selected = append(selected, clusterConfig)
 	}
+	return selected, nil
+}
+
+// newClustersConnectivityCmd creates the 'clusters connectivity' subcommand
+// This checks the data plane between clusters, not just our access to each control plane
+func newClustersConnectivityCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "connectivity",
+		Short: "Test cross-cluster network reachability",
+		Long: `Build a reachability matrix showing which clusters can reach each other.
+'clusters test' only proves that mcm can talk to each API server. Multi-cluster
+applications also need the clusters to reach each other's services, which
+depends on firewalls, peering and load balancers that mcm never exercises.
+
+For every source cluster this command starts a short-lived probe pod that opens
+a TCP connection to an endpoint in every target cluster, then reports the
+results as a matrix. Probe pods are deleted afterwards, even on failure.
+
+The endpoint for each target cluster is either:
+- the external address (load balancer ingress or external IP) of --service
+  in the probe namespace, or
+- an explicit host:port from the endpoints map of a --probe-spec file
+
+Probe spec file format (YAML):
+  namespace: mesh-system
+  service: mesh-gateway
+  port: 15443
+  image: busybox:1.36
+  timeoutSeconds: 3
+  endpoints:
+    onprem-dc1: 10.20.0.15:15443
 
-	fmt.Println(string(jsonData))
-	return nil
+Examples:
+  mcm clusters connectivity --service=mesh-gateway --namespace=mesh-system
+  mcm clusters connectivity --clusters=prod-us,prod-eu --service=api --port=443
+  mcm clusters connectivity --probe-spec=probe.yaml --output=json`,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spec := workload.ConnectivitySpec{}
+
+			// A spec file provides the baseline; explicit flags override it
+			if specFile, _ := cmd.Flags().GetString("probe-spec"); specFile != "" {
+				data, err := os.ReadFile(specFile)
+				if err != nil {
+					return fmt.Errorf("failed to read probe spec %s: %w", specFile, err)
+				}
+				if err := yaml.Unmarshal(data, &spec); err != nil {
+					return fmt.Errorf("failed to parse probe spec %s: %w", specFile, err)
+				}
+			}
+
+			if cmd.Flags().Changed("namespace") || spec.Namespace == "" {
+				spec.Namespace = cmd.Flag("namespace").Value.String()
+			}
+			if spec.Namespace == "" {
+				spec.Namespace = appConfig.DefaultNamespace
+			}
+			if cmd.Flags().Changed("service") {
+				spec.Service, _ = cmd.Flags().GetString("service")
+			}
+			if cmd.Flags().Changed("port") {
+				spec.Port, _ = cmd.Flags().GetInt32("port")
+			}
+			if cmd.Flags().Changed("image") {
+				spec.Image, _ = cmd.Flags().GetString("image")
+			}
+			if cmd.Flags().Changed("timeout") {
+				spec.TimeoutSeconds, _ = cmd.Flags().GetInt("timeout")
+			}
+
+			if spec.Service == "" && len(spec.Endpoints) == 0 {
+				return fmt.Errorf("specify --service or a --probe-spec with endpoints to probe")
+			}
+
+			clusters := parseClusterList(cmd.Flag("clusters").Value.String())
+			if len(clusters) == 0 {
+				for _, status := range clusterManager.ListClusters() {
+					if status.Connected {
+						clusters = append(clusters, status.Name)
+					}
+				}
+			}
+			sort.Strings(clusters)
+
+			fmt.Printf("Probing connectivity between %d clusters (this starts a pod in each)...\n\n", len(clusters))
+			results := workloadManager.CheckConnectivity(clusters, spec)
+
+			switch viper.GetString("output") {
+			case "json":
+				jsonData, err := json.MarshalIndent(struct {
+					Results []workload.ConnectivityResult `json:"results"`
+				}{Results: results}, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal connectivity results to JSON: %w", err)
+				}
+				fmt.Println(string(jsonData))
+			case "yaml":
+				yamlData, err := yaml.Marshal(struct {
+					Results []workload.ConnectivityResult `json:"results"`
+				}{Results: results})
+				if err != nil {
+					return fmt.Errorf("failed to marshal connectivity results to YAML: %w", err)
+				}
+				fmt.Print(string(yamlData))
+			default:
+				outputConnectivityMatrix(clusters, results)
+			}
+
+			// A non-zero exit lets CI pipelines gate on mesh reachability
+			unreachable := 0
+			for _, result := range results {
+				if !result.Reachable {
+					unreachable++
+				}
+			}
+			if unreachable > 0 {
+				return fmt.Errorf("%d/%d cluster pairs are unreachable", unreachable, len(results))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("clusters", "", "comma-separated list of cluster names or @groups (default: all connected clusters)")
+	cmd.Flags().StringP("namespace", "n", "", "namespace for probe pods and the target service (default: from config)")
+	cmd.Flags().String("service", "", "service whose external address is probed in each cluster")
+	cmd.Flags().Int32("port", 0, "port to probe (default: the service's first port)")
+	cmd.Flags().String("image", "", "probe container image, must provide sh and nc (default: busybox:1.36)")
+	cmd.Flags().Int("timeout", 0, "per-connection timeout in seconds (default: 3)")
+	cmd.Flags().String("probe-spec", "", "YAML file describing the probe (flags override its values)")
+
+	return cmd
 }
 
-// outputClustersYAML displays cluster information in YAML format
-// This is useful for configuration management or when YAML is preferred over JSON
-func outputClustersYAML(clusters []cluster.ClusterStatus) error {
-	output := struct {
-		Clusters []cluster.ClusterStatus `yaml:"clusters"`
-		Count    int                     `yaml:"count"`
-	}{
-		Clusters: clusters,
-		Count:    len(clusters),
+// outputConnectivityMatrix renders results as a source × target grid
+func outputConnectivityMatrix(clusters []string, results []workload.ConnectivityResult) {
+	cells := make(map[[2]string]workload.ConnectivityResult)
+	for _, result := range results {
+		cells[[2]string{result.Source, result.Target}] = result
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "FROM \\ TO\t%s\n", strings.Join(clusters, "\t"))
+	for _, source := range clusters {
+		row := []string{source}
+		for _, target := range clusters {
+			cell := "❌"
+			if cells[[2]string{source, target}].Reachable {
+				cell = "✅"
+			}
+			row = append(row, cell)
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+
+	// List the reasons behind every failed cell so the matrix stays compact
+	var failures []string
+	for _, source := range clusters {
+		for _, target := range clusters {
+			cell := cells[[2]string{source, target}]
+			if !cell.Reachable {
+				failures = append(failures, fmt.Sprintf("   %s → %s (%s): %s",
+					source, target, getValueOrDefault(cell.Endpoint, "no endpoint"), cell.Error))
+			}
+		}
 	}
 
-	yamlData, err := yaml.Marshal(output)
-	if err != nil {
-		return fmt.Errorf("failed to marshal clusters to YAML: %w", err)
+	fmt.Println()
+	if len(failures) == 0 {
+		fmt.Println("✅ All clusters can reach each other")
+		return
 	}
 
-	fmt.Print(string(yamlData))
-	return nil
+	fmt.Printf("⚠️  %d unreachable paths:\n%s\n", len(failures), strings.Join(failures, "\n"))
 }
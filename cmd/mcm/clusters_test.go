@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+	"github.com/celikgo/autoz-control-tower/internal/config"
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+func TestFilterClustersByMetadata(t *testing.T) {
+	clusters := []cluster.ClusterStatus{
+		{Name: "prod-us", Provider: "aws", Account: "111111111111"},
+		{Name: "prod-eu", Provider: "AWS", Account: "222222222222"},
+		{Name: "analytics", Provider: "gcp", Account: "data-prod"},
+		{Name: "lab"},
+	}
+
+	tests := []struct {
+		provider, account string
+		want              []string
+	}{
+		{"", "", []string{"prod-us", "prod-eu", "analytics", "lab"}},
+		{"aws", "", []string{"prod-us", "prod-eu"}},
+		{"aws", "222222222222", []string{"prod-eu"}},
+		{"", "data-prod", []string{"analytics"}},
+		{"azure", "", nil},
+	}
+
+	for _, tt := range tests {
+		got := clusterNames(filterClustersByMetadata(clusters, tt.provider, tt.account))
+		if len(got) != len(tt.want) {
+			t.Errorf("provider=%q account=%q: got %v, want %v", tt.provider, tt.account, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("provider=%q account=%q: got %v, want %v", tt.provider, tt.account, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestSelectClusters(t *testing.T) {
+	fleet := []config.ClusterConfig{
+		{Name: "prod-us", Environment: "production", Region: "us-east-1"},
+		{Name: "prod-eu", Environment: "production", Region: "eu-west-1"},
+		{Name: "staging", Environment: "staging", Region: "us-east-1"},
+	}
+	byEnvironment := func(environment, region string) []string {
+		var names []string
+		for _, c := range fleet {
+			if (environment == "" || c.Environment == environment) && (region == "" || c.Region == region) {
+				names = append(names, c.Name)
+			}
+		}
+		return names
+	}
+
+	tests := []struct {
+		named               []string
+		environment, region string
+		want                string
+		wantErr             string
+	}{
+		{nil, "production", "", "prod-us,prod-eu", ""},
+		{nil, "", "us-east-1", "prod-us,staging", ""},
+		{[]string{"staging", "prod-eu"}, "production", "", "prod-eu", ""},
+		{nil, "development", "", "", "--environment=development"},
+		{[]string{"staging"}, "production", "", "", "--clusters=staging"},
+	}
+	for _, tt := range tests {
+		got, err := selectClusters(tt.named, tt.environment, tt.region, byEnvironment)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%v %q %q: got %v, %v, want an error naming %s", tt.named, tt.environment, tt.region, got, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || strings.Join(got, ",") != tt.want {
+			t.Errorf("%v %q %q: got %v, %v, want %s", tt.named, tt.environment, tt.region, got, err, tt.want)
+		}
+	}
+}
+
+func TestExcludedNamespaces(t *testing.T) {
+	previous := appConfig
+	defer func() { appConfig = previous }()
+	appConfig = &config.MultiClusterConfig{ExcludeNamespaces: []string{"kube-system", "kube-public"}}
+
+	pods := []workload.PodInfo{
+		{ClusterName: "prod-eu", Namespace: "kube-system", Name: "coredns"},
+		{ClusterName: "prod-eu", Namespace: "shop", Name: "web"},
+		{ClusterName: "prod-us", Namespace: "monitoring", Name: "prometheus"},
+		{ClusterName: "prod-us", Namespace: "kube-public", Name: "probe"},
+	}
+	namesOf := func(args []string, allNamespaces bool) string {
+		cmd := newPodsListCmd()
+		if err := cmd.Flags().Parse(args); err != nil {
+			t.Fatal(err)
+		}
+		kept := withoutNamespaces(pods, excludedNamespaces(cmd, allNamespaces), func(pod workload.PodInfo) string { return pod.Namespace })
+		var names []string
+		for _, pod := range kept {
+			names = append(names, pod.Name)
+		}
+		return strings.Join(names, ",")
+	}
+
+	tests := []struct {
+		args          []string
+		allNamespaces bool
+		want          string
+	}{
+		{nil, true, "web,prometheus"},
+		{[]string{"--exclude-namespace=monitoring", "--exclude-namespace=shop"}, true, "coredns,probe"},
+		{[]string{"--exclude-namespace="}, true, "coredns,web,prometheus,probe"},
+		{[]string{"--exclude-namespace=shop"}, false, "coredns,web,prometheus,probe"},
+	}
+	for _, tt := range tests {
+		if got := namesOf(tt.args, tt.allNamespaces); got != tt.want {
+			t.Errorf("%v (all namespaces: %v): got %s, want %s", tt.args, tt.allNamespaces, got, tt.want)
+		}
+	}
+}
+
+// captureOutput runs fn and returns what it wrote to stdout and stderr
+func captureOutput(t *testing.T, fn func()) (stdout, stderr string) {
+	t.Helper()
+
+	capture := func(target **os.File) func() string {
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		original := *target
+		*target = w
+		done := make(chan string)
+		go func() {
+			data, _ := io.ReadAll(r)
+			done <- string(data)
+		}()
+		return func() string {
+			w.Close()
+			*target = original
+			return <-done
+		}
+	}
+
+	finishStdout := capture(&os.Stdout)
+	finishStderr := capture(&os.Stderr)
+	fn()
+	return finishStdout(), finishStderr()
+}
+
+func TestConnectProgressStaysOffStdout(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(dir, "cache"))
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "config"))
+	t.Chdir(dir)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"major":"1","minor":"33","gitVersion":"v1.33.1"}`)
+	}))
+	defer server.Close()
+
+	kubeconfig := filepath.Join(dir, "kubeconfig")
+	if err := os.WriteFile(kubeconfig, []byte(fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- {name: up, cluster: {server: %s}}
+- {name: down, cluster: {server: "http://127.0.0.1:1"}}
+contexts:
+- {name: up, context: {cluster: up, user: test}}
+- {name: down, context: {cluster: down, user: test}}
+users:
+- {name: test, user: {token: test-token}}
+current-context: up
+`, server.URL)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(dir, "mcm.yaml")
+	if err := os.WriteFile(configPath, []byte(fmt.Sprintf(`timeout: 5
+clusters:
+  - {name: up, context: up, kubeconfig: %q}
+  - {name: down, context: down, kubeconfig: %q}
+`, kubeconfig, kubeconfig)), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	rootCmd.SetArgs([]string{"clusters", "list", "--output=name", "--config", configPath})
+	defer rootCmd.SetArgs(nil)
+
+	var err error
+	stdout, stderr := captureOutput(t, func() { err = rootCmd.Execute() })
+	if err != nil {
+		t.Fatalf("clusters list failed: %v\n%s", err, stderr)
+	}
+
+	if stdout != "down\nup\n" && stdout != "up\ndown\n" {
+		t.Errorf("Expected only cluster names on stdout, got:\n%s", stdout)
+	}
+	for _, want := range []string{"Connecting to clusters", "Connected to cluster: up", "Some clusters are unavailable"} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("Expected %q on stderr, got:\n%s", want, stderr)
+		}
+	}
+}
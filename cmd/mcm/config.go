@@ -4,8 +4,15 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+	"github.com/celikgo/autoz-control-tower/internal/config"
 )
 
 // newConfigCmd creates the config command for managing tool configuration
@@ -43,7 +50,11 @@ Examples:
   mcm config init                    # Create a sample configuration file
   mcm config show                    # Display current configuration
   mcm config validate                # Check configuration for errors
-  mcm config path                    # Show where config file is located`,
+  mcm config validate --schema       # Also check every field against the JSON Schema
+  mcm config schema > mcm.schema.json  # Schema for your editor's YAML language server
+  mcm config path                    # Show where config file is located
+  mcm config restore                 # Roll back to the previous configuration
+  mcm config export-kubeconfig fleet.kubeconfig  # One kubeconfig for the whole fleet`,
 	}
 
 	// Add subcommands for different configuration operations
@@ -51,6 +62,9 @@ Examples:
 	configCmd.AddCommand(newConfigShowCmd())
 	configCmd.AddCommand(newConfigValidateCmd())
 	configCmd.AddCommand(newConfigPathCmd())
+	configCmd.AddCommand(newConfigRestoreCmd())
+	configCmd.AddCommand(newConfigExportKubeconfigCmd())
+	configCmd.AddCommand(newConfigSchemaCmd())
 
 	return configCmd
 }
@@ -108,8 +122,8 @@ other operating systems.`,
 			// or generate it dynamically based on detected kubeconfig contexts
 			sampleConfig := generateSampleConfig()
 
-			// Write the configuration file
-			if err := os.WriteFile(configPath, []byte(sampleConfig), 0644); err != nil {
+			// Write the configuration file atomically, keeping any previous version as a backup
+			if err := config.WriteConfigFile(configPath, []byte(sampleConfig)); err != nil {
 				return fmt.Errorf("failed to write config file: %w", err)
 			}
 
@@ -168,9 +182,17 @@ like authentication tokens are not displayed for security reasons.`,
 
 			for i, cluster := range appConfig.Clusters {
 				fmt.Printf("%d. %s\n", i+1, cluster.Name)
-				fmt.Printf("   Context: %s\n", cluster.Context)
+				if len(cluster.Contexts) > 0 {
+					fmt.Printf("   Contexts: %s (tried in order)\n", strings.Join(cluster.Contexts, ", "))
+				} else {
+					fmt.Printf("   Context: %s\n", cluster.Context)
+				}
 				fmt.Printf("   Environment: %s\n", getValueOrDefault(cluster.Environment, "not specified"))
 				fmt.Printf("   Region: %s\n", getValueOrDefault(cluster.Region, "not specified"))
+				if cluster.Provider != "" || cluster.Account != "" {
+					fmt.Printf("   Provider: %s\n", getValueOrDefault(cluster.Provider, "not specified"))
+					fmt.Printf("   Account: %s\n", getValueOrDefault(cluster.Account, "not specified"))
+				}
 				fmt.Printf("   Kubeconfig: %s\n", getValueOrDefault(cluster.KubeConfig, "default (~/.kube/config)"))
 
 				if cluster.IsDefault {
@@ -180,6 +202,19 @@ like authentication tokens are not displayed for security reasons.`,
 				fmt.Println()
 			}
 
+			if len(appConfig.Groups) > 0 {
+				fmt.Println("Cluster Groups:")
+				fmt.Println("--------------")
+				groups := make([]string, 0, len(appConfig.Groups))
+				for name := range appConfig.Groups {
+					groups = append(groups, name)
+				}
+				sort.Strings(groups)
+				for _, name := range groups {
+					fmt.Printf("%s%s: %s\n", config.GroupPrefix, name, strings.Join(appConfig.Groups[name], ", "))
+				}
+			}
+
 			return nil
 		},
 	}
@@ -188,7 +223,7 @@ like authentication tokens are not displayed for security reasons.`,
 // newConfigValidateCmd creates the 'config validate' subcommand
 // This checks the configuration for common problems and connectivity issues
 func newConfigValidateCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "validate",
 		Short: "Validate configuration and test cluster connectivity",
 		Long: `Validate the multi-cluster configuration file and test connectivity to all clusters.
@@ -209,7 +244,27 @@ This is particularly useful when:
 
 The validation process will report specific errors and suggestions for fixing
 any problems it discovers. This helps ensure your configuration will work
-reliably for actual operations.`,
+reliably for actual operations.
+
+With --schema the file is first checked against mcm's JSON Schema (see
+'mcm config schema'), before it is loaded. Every problem is reported with its
+path in the file, e.g. "clusters[2].timeout must be an integer", including
+misspelled field names that loading the file would silently ignore.
+
+Examples:
+  mcm config validate
+  mcm config validate --schema --config=./mcm-config.yaml`,
+
+		// The schema check runs before the configuration is loaded, so it can
+		// point at the exact field even when loading would fail
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if schema, _ := cmd.Flags().GetBool("schema"); schema {
+				if err := checkConfigSchema(cmd); err != nil {
+					return err
+				}
+			}
+			return rootCmd.PersistentPreRunE(cmd, args)
+		},
 
 		RunE: func(cmd *cobra.Command, args []string) error {
 			fmt.Println("Validating multi-cluster configuration...")
@@ -223,6 +278,9 @@ reliably for actual operations.`,
 
 			fmt.Printf("✅ Configuration file syntax is valid\n")
 			fmt.Printf("✅ Found %d cluster(s) defined\n", len(appConfig.Clusters))
+			for _, skipped := range appConfig.Skipped {
+				fmt.Printf("❌ Skipped invalid cluster entry: %v\n", skipped)
+			}
 
 			// Test cluster connectivity (this was done during initialization)
 			if clusterManager == nil {
@@ -245,7 +303,11 @@ reliably for actual operations.`,
 
 			fmt.Println()
 
-			if connectedCount == len(clusterStatuses) {
+			if connectedCount == len(clusterStatuses) && len(appConfig.Skipped) > 0 {
+				fmt.Printf("⚠️  All %d loaded clusters are connected, but %d cluster entries were skipped as invalid\n",
+					connectedCount, len(appConfig.Skipped))
+				fmt.Println("\nFix or remove the skipped entries above, then run this again without --skip-invalid-clusters.")
+			} else if connectedCount == len(clusterStatuses) {
 				fmt.Printf("🎉 All %d clusters are connected and ready!\n", connectedCount)
 				fmt.Println("\nYour configuration is working perfectly. You can now use:")
 				fmt.Println("- mcm clusters list       # View cluster status")
@@ -263,6 +325,76 @@ reliably for actual operations.`,
 			return nil
 		},
 	}
+
+	cmd.Flags().Bool("schema", false, "check the file against the config JSON Schema before loading it, reporting every problem by path")
+
+	return cmd
+}
+
+// checkConfigSchema checks the raw config file against config.Schema and
+// reports each violation by its path in the file
+func checkConfigSchema(cmd *cobra.Command) error {
+	configPath := viper.GetString("config")
+	if configPath == "" {
+		configPath = findConfigPath()
+	}
+	if configPath == "" {
+		return fmt.Errorf("no configuration file found; use --config to specify one")
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", configPath, err)
+	}
+	fmt.Printf("Checking %s against the configuration schema...\n", configPath)
+	problems, err := config.ValidateSchema(data)
+	if err != nil {
+		return fmt.Errorf("config file %s: %w", configPath, err)
+	}
+
+	if len(problems) == 0 {
+		fmt.Println("✅ Configuration matches the schema")
+		fmt.Println()
+		return nil
+	}
+	for _, problem := range problems {
+		fmt.Printf("❌ %v\n", problem)
+	}
+	cmd.SilenceUsage = true
+	return fmt.Errorf("%s has %d schema problem(s)", configPath, len(problems))
+}
+
+// newConfigSchemaCmd creates the 'config schema' subcommand
+// Editors use the schema for completion and inline errors while the file is written
+func newConfigSchemaCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON Schema of the configuration file",
+		Long: `Print the JSON Schema that describes mcm's configuration file, for editor
+completion and inline validation. Save it and point your editor's YAML language
+server at it, for example in VS Code's settings.json:
+
+  "yaml.schemas": {"/path/to/mcm.schema.json": ["mcm-config.yaml", ".mcm.yaml"]}
+
+or with a modeline at the top of the config file:
+
+  # yaml-language-server: $schema=/path/to/mcm.schema.json
+
+'mcm config validate --schema' checks a file against the same schema.
+
+Examples:
+  mcm config schema > ~/.mcm/config.schema.json`,
+
+		// Printing the schema needs no configuration or clusters
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, err := os.Stdout.Write(config.Schema())
+			return err
+		},
+	}
 }
 
 // newConfigPathCmd creates the 'config path' subcommand
@@ -285,14 +417,15 @@ This is helpful for:
 			if configPath == "" {
 				fmt.Println("No configuration file found.")
 				fmt.Println("\nThe tool looks for configuration in these locations (in order):")
-				fmt.Println("1. ./mcm-config.yaml (current directory)")
+				fmt.Printf("1. ./%s (current directory or any parent, nearest first; only if trusted)\n", config.ProjectConfigName)
+				fmt.Println("2. ./mcm-config.yaml (current directory)")
 				if homeDir, err := os.UserHomeDir(); err == nil {
-					fmt.Printf("2. %s/.mcm/config.yaml (user home directory)\n", homeDir)
+					fmt.Printf("3. %s/.mcm/config.yaml (user home directory)\n", homeDir)
 
 					if xdgConfig := os.Getenv("XDG_CONFIG_HOME"); xdgConfig != "" {
-						fmt.Printf("3. %s/mcm/config.yaml (XDG config directory)\n", xdgConfig)
+						fmt.Printf("4. %s/mcm/config.yaml (XDG config directory)\n", xdgConfig)
 					} else {
-						fmt.Printf("3. %s/.config/mcm/config.yaml (XDG config directory)\n", homeDir)
+						fmt.Printf("4. %s/.config/mcm/config.yaml (XDG config directory)\n", homeDir)
 					}
 				}
 				fmt.Println("\nRun 'mcm config init' to create a configuration file.")
@@ -318,6 +451,108 @@ This is helpful for:
 	}
 }
 
+// newConfigRestoreCmd creates the 'config restore' subcommand
+// This is the "undo" button for configuration changes
+func newConfigRestoreCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore",
+		Short: "Restore the previous configuration from its backup",
+		Long: `Roll the configuration file back to the version saved before the last write.
+Every time mcm writes the configuration (for example 'mcm config init --force')
+the previous version is kept next to it with a .bak suffix.
+
+Restoring swaps the two versions, so running restore a second time undoes the
+restore. This works even when the current configuration is too broken to load.
+
+Examples:
+  mcm config restore                           # Restore the active config file
+  mcm config restore --config=./mcm-config.yaml`,
+
+		// Skip loading the configuration and connecting to clusters -
+		// the whole point is to recover from a config that may not load
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configPath := viper.GetString("config")
+			if configPath == "" {
+				configPath = findConfigPath()
+			}
+			if configPath == "" {
+				return fmt.Errorf("no configuration file found; use --config to specify one")
+			}
+
+			if err := config.RestoreConfigFile(configPath); err != nil {
+				return fmt.Errorf("failed to restore %s: %w", configPath, err)
+			}
+
+			fmt.Printf("✅ Restored %s from %s\n", configPath, config.BackupPath(configPath))
+			fmt.Println("Run 'mcm config validate' to check the restored configuration.")
+			return nil
+		},
+	}
+}
+
+// newConfigExportKubeconfigCmd creates the 'config export-kubeconfig' subcommand
+// This bridges mcm's configuration back to the format every other tool understands
+func newConfigExportKubeconfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-kubeconfig FILE",
+		Short: "Write a kubeconfig with a context per configured cluster",
+		Long: `Write one kubeconfig holding a context for every cluster in the configuration,
+so tools that only understand kubeconfigs can work with the whole fleet.
+
+Contexts, clusters and users are named after the mcm clusters, so
+'kubectl --context=prod-eu' reaches the same cluster as 'mcm --clusters=prod-eu'.
+The default cluster becomes the current context.
+
+Credentials are copied from the kubeconfigs the clusters come from, as they are
+there: exec plugins (aws, gcloud, kubelogin, ...) stay exec plugins, with the
+cluster's execEnv added, so no short-lived token is written to the file.
+Certificate paths are made absolute. The file is written with 0600 permissions.
+
+Examples:
+  mcm config export-kubeconfig ./fleet.kubeconfig
+  KUBECONFIG=./fleet.kubeconfig kubectl --context=prod-eu get nodes
+  mcm config export-kubeconfig ~/.kube/mcm --force     # Replace an earlier export`,
+
+		Args: cobra.ExactArgs(1),
+
+		// Only the configuration and the kubeconfigs are read; no cluster is dialed
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return loadAppConfig(cmd)
+		},
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+			if force, _ := cmd.Flags().GetBool("force"); !force {
+				if _, err := os.Stat(path); err == nil {
+					return fmt.Errorf("%s already exists; use --force to replace it", path)
+				}
+			}
+
+			kubeconfig, err := cluster.ExportKubeconfig(appConfig.Clusters)
+			if err != nil {
+				return fmt.Errorf("failed to export kubeconfig:\n%w", err)
+			}
+			if err := clientcmd.WriteToFile(*kubeconfig, path); err != nil {
+				return fmt.Errorf("failed to write %s: %w", path, err)
+			}
+
+			fmt.Printf("✅ Wrote %d contexts to %s\n", len(kubeconfig.Contexts), path)
+			if kubeconfig.CurrentContext != "" {
+				fmt.Printf("Current context: %s\n", kubeconfig.CurrentContext)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("force", false, "replace FILE if it already exists")
+
+	return cmd
+}
+
 // Helper functions for configuration management
 
 // getConfigInitPath determines where to create a new configuration file
@@ -337,35 +572,13 @@ func getConfigInitPath() (string, error) {
 }
 
 // findConfigPath attempts to locate the current configuration file
+// It makes the same choice as loading the config, project config included
 func findConfigPath() string {
-	// Check current directory
-	if _, err := os.Stat("./mcm-config.yaml"); err == nil {
-		return "./mcm-config.yaml"
+	path, note := config.FindConfigPath(viper.GetBool("trust-project-config"))
+	if note != "" {
+		fmt.Fprintln(os.Stderr, note)
 	}
-
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return ""
-	}
-
-	// Check ~/.mcm/config.yaml
-	path := filepath.Join(homeDir, ".mcm", "config.yaml")
-	if _, err := os.Stat(path); err == nil {
-		return path
-	}
-
-	// Check XDG config directory
-	configDir := os.Getenv("XDG_CONFIG_HOME")
-	if configDir == "" {
-		configDir = filepath.Join(homeDir, ".config")
-	}
-
-	path = filepath.Join(configDir, "mcm", "config.yaml")
-	if _, err := os.Stat(path); err == nil {
-		return path
-	}
-
-	return ""
+	return path
 }
 
 // generateSampleConfig creates a sample configuration file content
@@ -376,6 +589,8 @@ func generateSampleConfig() string {
 # Global settings
 defaultNamespace: "default"
 timeout: 30
+concurrency: 10    # max parallel per-object API calls, e.g. pod log downloads
+managedByLabel: "app.kubernetes.io/managed-by=mcm"  # ownership label used by --managed-only
 
 # Your clusters - customize these for your environment
 clusters:
@@ -400,6 +615,9 @@ clusters:
     kubeconfig: "~/.kube/prod-config"   # separate kubeconfig for production
     environment: "production"
     region: "us-east-1"
+    # server: "https://prod-us-east.example.com:6443"  # optional: warn if the context points elsewhere
+    # execEnv:                          # optional: env vars for exec auth plugins (aws, gcloud)
+    #   AWS_PROFILE: "production"
 
   - name: "prod-eu-west"
     context: "production-eu-west"
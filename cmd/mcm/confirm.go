@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+// addYesFlag registers --yes, which satisfies a yes-flag confirmationPolicy
+func addYesFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolP("yes", "y", false, "confirm changes to clusters whose environment's confirmationPolicy is yes-flag")
+}
+
+// confirmChanges enforces the configuration's confirmationPolicy before a
+// command changes the given clusters; action says what is about to happen,
+// e.g. "deploy app.yaml"
+// It is a guardrail, not a permission system: it makes sure nobody changes
+// production by accident, not that they can't change it on purpose
+func confirmChanges(cmd *cobra.Command, action string, clusters []string) error {
+	yes, _ := cmd.Flags().GetBool("yes")
+	interactive := term.IsTerminal(int(os.Stdin.Fd()))
+	return checkConfirmation(appConfig.RequiredConfirmation(clusters), action, yes, interactive, os.Stdin, os.Stderr)
+}
+
+// checkConfirmation asks for, or checks, the confirmation a requirement calls for
+// The prompt goes to out (stderr) so it never ends up in piped JSON
+func checkConfirmation(requirement config.ConfirmationRequirement, action string, yes, interactive bool, in io.Reader, out io.Writer) error {
+	targets := fmt.Sprintf("%s clusters (%s)",
+		strings.Join(requirement.Environments, "/"), summarizeNames(requirement.Clusters, 5))
+
+	switch requirement.Level {
+	case config.ConfirmYesFlag:
+		if !yes {
+			return fmt.Errorf("%s touches %s; the confirmationPolicy requires --yes", action, targets)
+		}
+		return nil
+
+	case config.ConfirmTyped:
+		// --yes is exactly what typed confirmation exists to rule out
+		if !interactive {
+			return fmt.Errorf("%s touches %s; the confirmationPolicy requires typing the environment name at a terminal", action, targets)
+		}
+
+		fmt.Fprintf(out, "⚠️  About to %s on %s.\n", action, targets)
+		reader := bufio.NewReader(in)
+		for _, environment := range requirement.Environments {
+			fmt.Fprintf(out, "Type '%s' to continue: ", environment)
+			answer, err := reader.ReadString('\n')
+			if err != nil && answer == "" {
+				return fmt.Errorf("confirmation aborted; nothing was changed")
+			}
+			if strings.TrimSpace(answer) != environment {
+				return fmt.Errorf("confirmation did not match '%s'; nothing was changed", environment)
+			}
+		}
+		return nil
+
+	default:
+		return nil
+	}
+}
+
+// confirmRecreate makes sure --force-recreate is meant: a recreated resource, and
+// everything it runs, is deleted before it's created again
+// At a terminal the user is asked; anywhere else --yes has to say so up front
+func confirmRecreate(cmd *cobra.Command, clusters []string) error {
+	yes, _ := cmd.Flags().GetBool("yes")
+	interactive := term.IsTerminal(int(os.Stdin.Fd()))
+	return checkRecreateConfirmation(clusters, yes, interactive, os.Stdin, os.Stderr)
+}
+
+// checkRecreateConfirmation asks for, or checks, the go-ahead for --force-recreate
+func checkRecreateConfirmation(clusters []string, yes, interactive bool, in io.Reader, out io.Writer) error {
+	if yes {
+		return nil
+	}
+	if !interactive {
+		return fmt.Errorf("--force-recreate deletes and recreates resources whose immutable fields changed, taking them down in between; add --yes to confirm")
+	}
+
+	fmt.Fprintf(out, "⚠️  --force-recreate will delete and recreate any resource whose immutable fields changed on %s.\n", summarizeNames(clusters, 5))
+	fmt.Fprintf(out, "Those resources, and the pods they run, are unavailable in between. Continue? [y/N]: ")
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	}
+	return fmt.Errorf("--force-recreate not confirmed; nothing was changed")
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+func TestCheckConfirmation(t *testing.T) {
+	yesFlag := config.ConfirmationRequirement{Level: config.ConfirmYesFlag, Clusters: []string{"stage-1"}, Environments: []string{"staging"}}
+	typed := config.ConfirmationRequirement{Level: config.ConfirmTyped, Clusters: []string{"prod-eu"}, Environments: []string{"production"}}
+
+	tests := []struct {
+		name        string
+		requirement config.ConfirmationRequirement
+		yes         bool
+		interactive bool
+		input       string
+		wantErr     bool
+	}{
+		{"no policy", config.ConfirmationRequirement{Level: config.ConfirmNone}, false, false, "", false},
+		{"yes-flag without --yes", yesFlag, false, true, "", true},
+		{"yes-flag with --yes", yesFlag, true, false, "", false},
+		{"typed with --yes but no terminal", typed, true, false, "production\n", true},
+		{"typed matching name", typed, false, true, "production\n", false},
+		{"typed wrong name", typed, false, true, "prod\n", true},
+		{"typed no answer", typed, false, true, "", true},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		err := checkConfirmation(tt.requirement, "deploy app.yaml", tt.yes, tt.interactive, strings.NewReader(tt.input), &out)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: got error %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCheckRecreateConfirmation(t *testing.T) {
+	tests := []struct {
+		name        string
+		yes         bool
+		interactive bool
+		input       string
+		wantErr     bool
+	}{
+		{"--yes", true, false, "", false},
+		{"no terminal, no --yes", false, false, "y\n", true},
+		{"answered yes", false, true, "y\n", false},
+		{"answered no", false, true, "n\n", true},
+		{"no answer", false, true, "", true},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		err := checkRecreateConfirmation([]string{"prod-us"}, tt.yes, tt.interactive, strings.NewReader(tt.input), &out)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: got error %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"sigs.k8s.io/yaml"
+
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// copyResult is one target cluster's outcome of a copy
+type copyResult struct {
+	Cluster  string
+	Err      error
+	Duration time.Duration
+}
+
+// newCopyCmd creates the copy command
+// Promoting what runs in staging to production shouldn't take a YAML export,
+// a hand edit and an import
+func newCopyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "copy KIND NAME --from=CLUSTER --to=CLUSTERS",
+		Short: "Copy a resource from one cluster to others",
+		Long: `Copy one object from a source cluster to one or more target clusters - the
+usual way to promote a deployment that was tried in staging.
+
+KIND is anything kubectl get accepts (deployment, configmap, svc,
+certificates.cert-manager.io...). The object is read from --from, and what
+belongs to that cluster is stripped before it is applied to each --to cluster:
+
+- resourceVersion, uid, creationTimestamp, generation, managedFields and status,
+  which the target's API server fills in itself
+- owner references, which point at objects by their uid in the source cluster
+- a Service's allocated clusterIP and the node ports the API server picked
+  (ones set explicitly are kept), and a Pod's nodeName
+- the deployment revision and last-applied-configuration annotations
+
+It is then applied like 'mcm deploy' applies a manifest: with server-side
+apply, into the same namespace, skipping clusters under a change freeze and
+honoring the confirmationPolicy of the target environments. --dry-run prints
+the manifest that would be applied instead.
+
+Examples:
+  mcm copy deployment web -n shop --from=staging --to=prod-us,prod-eu
+  mcm copy configmap app-settings -n shop --from=staging --to=@prod-all --yes
+  mcm copy deployment web -n shop --from=staging --to=prod-us --dry-run`,
+
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kind, name := args[0], args[1]
+			namespace := cmd.Flag("namespace").Value.String()
+			if namespace == "" {
+				namespace = appConfig.DefaultNamespace
+			}
+
+			source := cmd.Flag("from").Value.String()
+			if source == "" {
+				return fmt.Errorf("--from is required: the cluster to copy from")
+			}
+			targets := parseClusterList(cmd.Flag("to").Value.String())
+			if len(targets) == 0 {
+				return fmt.Errorf("--to is required: the clusters to copy to")
+			}
+			for _, target := range targets {
+				if target == source {
+					return fmt.Errorf("--to includes the source cluster %s", source)
+				}
+			}
+
+			obj, err := workloadManager.FetchForCopy(source, kind, name, namespace)
+			if err != nil {
+				return err
+			}
+			manifest, err := yaml.Marshal(obj.Object)
+			if err != nil {
+				return fmt.Errorf("failed to encode %s %s: %w", kind, name, err)
+			}
+
+			if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+				fmt.Print(string(manifest))
+				return nil
+			}
+
+			ignoreFreeze, _ := cmd.Flags().GetBool("ignore-freeze")
+			targets, err = filterFrozenClusters(targets, obj.GetNamespace(), ignoreFreeze)
+			if err != nil {
+				return err
+			}
+			what := fmt.Sprintf("copy %s %s from %s", kind, name, source)
+			if err := confirmChanges(cmd, what, targets); err != nil {
+				return err
+			}
+
+			var opts workload.DeployOptions
+			opts.ForceConflicts, _ = cmd.Flags().GetBool("force-conflicts")
+			if createNamespace, _ := cmd.Flags().GetBool("create-namespace"); createNamespace {
+				opts.CreateNamespace = true
+				opts.CreatedBy = deployingUser()
+			}
+			results := copyToClusters(targets, string(manifest), opts)
+
+			if err := outputCopyResults(os.Stdout, results, source, obj.GetKind(), obj.GetNamespace(), obj.GetName()); err != nil {
+				return err
+			}
+			if failed := countFailedCopies(results); failed > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("copy failed on %d of %d clusters", failed, len(results))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("from", "", "cluster to copy the object from")
+	cmd.Flags().String("to", "", "comma-separated list of cluster names or @groups to copy it to")
+	cmd.Flags().StringP("namespace", "n", "", "namespace of the object (default from config)")
+	cmd.Flags().Bool("dry-run", false, "print the manifest that would be applied, without applying it")
+	cmd.Flags().Bool("force-conflicts", false, "take over fields another field manager (kubectl edit, an autoscaler) owns in a target cluster")
+	cmd.Flags().Bool("create-namespace", false, "create the namespace in target clusters that don't have it, labeled mcm.io/created-by=mcm")
+	cmd.Flags().Bool("ignore-freeze", false, "copy even to clusters or namespaces under a change freeze")
+	addYesFlag(cmd)
+
+	return cmd
+}
+
+// copyToClusters applies the manifest to every target in parallel and returns
+// each target's outcome, sorted by cluster
+func copyToClusters(targets []string, manifest string, opts workload.DeployOptions) []copyResult {
+	// The deploy's own per-cluster log lines would interleave with the report
+	// (or end up in JSON output), so they're routed to a progress channel nobody reads
+	progress := make(chan workload.DeployEvent)
+	drained := make(chan struct{})
+	go func() {
+		for range progress {
+		}
+		close(drained)
+	}()
+	opts.Progress = progress
+
+	var results []copyResult
+	var mutex sync.Mutex
+	workloadManager.DeployToMultipleClustersWithCallback(targets, "", manifest, opts, func(cluster string, err error, duration time.Duration) {
+		mutex.Lock()
+		results = append(results, copyResult{Cluster: cluster, Err: err, Duration: duration})
+		mutex.Unlock()
+	})
+	close(progress)
+	<-drained
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Cluster < results[j].Cluster })
+	return results
+}
+
+// countFailedCopies counts the targets the object couldn't be applied to
+func countFailedCopies(results []copyResult) int {
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+// outputCopyResults reports each target cluster's outcome
+func outputCopyResults(out io.Writer, results []copyResult, source, kind, namespace, name string) error {
+	switch outputFormat := viper.GetString("output"); outputFormat {
+	case "json", "yaml":
+		var copied []string
+		failures := make(map[string]string)
+		for _, result := range results {
+			if result.Err != nil {
+				failures[result.Cluster] = result.Err.Error()
+				continue
+			}
+			copied = append(copied, result.Cluster)
+		}
+		report := struct {
+			Source    string            `json:"source"`
+			Kind      string            `json:"kind"`
+			Namespace string            `json:"namespace,omitempty"`
+			Name      string            `json:"name"`
+			Copied    []string          `json:"copied"`
+			Errors    map[string]string `json:"errors,omitempty"`
+		}{Source: source, Kind: kind, Namespace: namespace, Name: name, Copied: copied, Errors: failures}
+
+		var data []byte
+		var err error
+		if outputFormat == "json" {
+			data, err = json.MarshalIndent(report, "", "  ")
+			data = append(data, '\n')
+		} else {
+			data, err = yaml.Marshal(report)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to marshal copy results: %w", err)
+		}
+		_, err = out.Write(data)
+		return err
+	}
+
+	fmt.Fprintf(out, "Copying %s %s from %s:\n", kind, objectName(namespace, name), source)
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Fprintf(out, "❌ %s: FAILED - %v\n", result.Cluster, result.Err)
+			continue
+		}
+		fmt.Fprintf(out, "✅ %s: copied (%s)\n", result.Cluster, result.Duration.Round(100*time.Millisecond))
+	}
+
+	copied := len(results) - countFailedCopies(results)
+	fmt.Fprintf(out, "\nCopied to %d/%d clusters\n", copied, len(results))
+	return nil
+}
+
+// objectName names an object as namespace/name, or just name when it's cluster-scoped
+func objectName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+func TestOutputCopyResults(t *testing.T) {
+	results := []copyResult{
+		{Cluster: "prod-eu", Err: errors.New("namespaces \"shop\" not found")},
+		{Cluster: "prod-us", Duration: 1240 * time.Millisecond},
+	}
+
+	var buf bytes.Buffer
+	if err := outputCopyResults(&buf, results, "staging", "Deployment", "shop", "web"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"Copying Deployment shop/web from staging:",
+		`❌ prod-eu: FAILED - namespaces "shop" not found`,
+		"✅ prod-us: copied (1.2s)",
+		"Copied to 1/2 clusters",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected %q in:\n%s", want, buf.String())
+		}
+	}
+	if failed := countFailedCopies(results); failed != 1 {
+		t.Errorf("Expected 1 failed copy, got %d", failed)
+	}
+}
+
+func TestCopyChecksTargetGroups(t *testing.T) {
+	cfg := &config.MultiClusterConfig{Groups: map[string][]string{"prod-all": {"prod-us", "prod-eu"}}}
+
+	cmd := newCopyCmd()
+	if err := cmd.Flags().Set("to", "@prod-all"); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkClusterGroups(cmd, cfg); err != nil {
+		t.Errorf("Expected a known group to pass, got %v", err)
+	}
+
+	if err := cmd.Flags().Set("to", "@prod-al"); err != nil {
+		t.Fatal(err)
+	}
+	err := checkClusterGroups(cmd, cfg)
+	if err == nil || !strings.Contains(err.Error(), "--to") {
+		t.Errorf("Expected an unknown group in --to to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+// applyCommandDefaults replaces the built-in defaults of cmd's flags with the ones
+// the config's defaults section gives for this command
+//
+// Only the default moves: a flag given on the command line is left alone, and
+// viper still prefers an MCM_* environment variable over a flag's default, so
+// the usual precedence (flag, environment, config, built-in) holds
+func applyCommandDefaults(cmd *cobra.Command, defaults map[string]map[string]config.FlagValue) error {
+	warnUnknownDefaultCommands(cmd.Root(), defaults)
+
+	path := commandPathKey(cmd)
+	var flags map[string]config.FlagValue
+	for key, keyFlags := range defaults {
+		if normalizeCommandPath(key) == path {
+			flags = keyFlags
+		}
+	}
+
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if reason, ok := commandLineOnlyFlags[name]; ok {
+			return fmt.Errorf("config defaults for '%s': --%s can't be set here; %s", path, name, reason)
+		}
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			return fmt.Errorf("config defaults for '%s': the command has no --%s flag", path, name)
+		}
+		if flag.Changed {
+			continue
+		}
+		if err := setFlagDefault(flag, string(flags[name])); err != nil {
+			return fmt.Errorf("config defaults for '%s': invalid value %q for --%s: %w", path, flags[name], name, err)
+		}
+	}
+	return nil
+}
+
+// commandLineOnlyFlags are the flags the defaults section may not set, with why
+// Confirmations and safety overrides have to be given each time, or a config -
+// perhaps a repository's - could answer for the user. The rest would be
+// ignored: they are read before the defaults apply, or only when given on the
+// command line, in favour of a config setting
+var commandLineOnlyFlags = map[string]string{
+	"yes":                   "it confirms changes, so it has to be given on the command line",
+	"allow-hooks":           "it allows a repository's hooks, so it has to be given on the command line",
+	"force-conflicts":       "it overrides other field managers, so it has to be given on the command line",
+	"force-recreate":        "it deletes resources, so it has to be given on the command line",
+	"ignore-freeze":         "it overrides change freezes, so it has to be given on the command line",
+	"trust-project-config":  "it trusts a repository's config, so it has to be given on the command line (or use trustedProjects)",
+	"pre-deploy":            "use hooks.preDeploy instead",
+	"post-deploy":           "use hooks.postDeploy instead",
+	"notify-webhook":        "use notify.webhook instead",
+	"notify-on":             "use notify.when instead",
+	"connect-mode":          "use connectMode instead",
+	"config":                "the config is already loaded by then",
+	"skip-invalid-clusters": "use skipInvalidClusters instead",
+	"strict-config":         "use strict instead",
+}
+
+// setFlagDefault gives an unset flag a new default without marking it as changed
+// Slice flags are replaced rather than appended to, so a later --flag on the
+// command line would still start from scratch
+func setFlagDefault(flag *pflag.Flag, value string) error {
+	if slice, ok := flag.Value.(pflag.SliceValue); ok {
+		items, err := csv.NewReader(strings.NewReader(value)).Read()
+		if err != nil && value != "" {
+			return err
+		}
+		if err := slice.Replace(items); err != nil {
+			return err
+		}
+	} else if err := flag.Value.Set(value); err != nil {
+		return err
+	}
+
+	flag.DefValue = flag.Value.String()
+	return nil
+}
+
+// warnUnknownDefaultCommands points out defaults entries that can never apply,
+// most likely because of a typo in the command path
+func warnUnknownDefaultCommands(root *cobra.Command, defaults map[string]map[string]config.FlagValue) {
+	for path := range defaults {
+		found, _, err := root.Find(strings.Fields(path))
+		if err != nil || commandPathKey(found) != normalizeCommandPath(path) {
+			fmt.Fprintf(os.Stderr, "Warning: config defaults for '%s' match no mcm command\n", path)
+		}
+	}
+}
+
+// commandPathKey is the command path as written in the config, without the binary name
+func commandPathKey(cmd *cobra.Command) string {
+	return strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+}
+
+// normalizeCommandPath collapses stray whitespace in a configured command path
+func normalizeCommandPath(path string) string {
+	return strings.Join(strings.Fields(path), " ")
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+// runWithDefaults runs "mcm deployments list <args>" with the given config defaults
+// and returns the flag values the command saw
+func runWithDefaults(t *testing.T, defaults map[string]map[string]config.FlagValue, args ...string) (map[string]string, error) {
+	t.Helper()
+
+	seen := make(map[string]string)
+	root := &cobra.Command{
+		Use: "mcm",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return applyCommandDefaults(cmd, defaults)
+		},
+	}
+	root.PersistentFlags().String("output", "table", "")
+
+	list := &cobra.Command{
+		Use: "list",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, name := range []string{"output", "full-image", "limit", "labels"} {
+				seen[name] = cmd.Flag(name).Value.String()
+			}
+			return nil
+		},
+	}
+	list.Flags().Bool("full-image", false, "")
+	list.Flags().Int("limit", 0, "")
+	list.Flags().StringSlice("labels", nil, "")
+
+	deployments := &cobra.Command{Use: "deployments"}
+	deployments.AddCommand(list)
+	root.AddCommand(deployments)
+
+	root.SetArgs(append([]string{"deployments", "list"}, args...))
+	root.SilenceErrors, root.SilenceUsage = true, true
+	return seen, root.Execute()
+}
+
+func TestApplyCommandDefaults(t *testing.T) {
+	defaults := map[string]map[string]config.FlagValue{
+		"deployments list": {"output": "json", "full-image": "true", "limit": "5", "labels": "app,tier"},
+		"clusters list":    {"output": "table"},
+	}
+
+	seen, err := runWithDefaults(t, defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"output": "json", "full-image": "true", "limit": "5", "labels": "[app,tier]"}
+	for name, value := range want {
+		if seen[name] != value {
+			t.Errorf("Expected --%s default %s from the config, got %s", name, value, seen[name])
+		}
+	}
+
+	// Flags on the command line still win, and slices start over instead of appending
+	seen, err = runWithDefaults(t, defaults, "--output=yaml", "--labels=team")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seen["output"] != "yaml" || seen["labels"] != "[team]" {
+		t.Errorf("Expected explicit flags to win, got output=%s labels=%s", seen["output"], seen["labels"])
+	}
+}
+
+func TestApplyCommandDefaultsRejectsUnknownFlags(t *testing.T) {
+	_, err := runWithDefaults(t, map[string]map[string]config.FlagValue{"deployments list": {"colour": "red"}})
+	if err == nil || !strings.Contains(err.Error(), "no --colour flag") {
+		t.Errorf("Expected an unknown flag error, got %v", err)
+	}
+
+	// A config can't confirm on the user's behalf, nor set flags it would ignore
+	for flag, want := range map[string]string{"yes": "on the command line", "notify-webhook": "notify.webhook"} {
+		_, err := runWithDefaults(t, map[string]map[string]config.FlagValue{"deployments list": {flag: "true"}})
+		if err == nil || !strings.Contains(err.Error(), "--"+flag+" can't be set here") || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected a default for --%s to be refused, got %v", flag, err)
+		}
+	}
+
+	_, err = runWithDefaults(t, map[string]map[string]config.FlagValue{"deployments list": {"limit": "lots"}})
+	if err == nil || !strings.Contains(err.Error(), "invalid value") {
+		t.Errorf("Expected an invalid value error, got %v", err)
+	}
+}
@@ -1,11 +1,24 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+	"github.com/celikgo/autoz-control-tower/internal/config"
+	"github.com/celikgo/autoz-control-tower/internal/output"
+	"github.com/celikgo/autoz-control-tower/internal/workload"
 )
 
 // newDeployCmd creates the deploy command for multi-cluster deployments
@@ -38,20 +51,107 @@ The deploy command handles several critical scenarios that are common in product
 The command provides detailed feedback about each deployment, showing you
 exactly which clusters succeeded and which had problems. This visibility
 is crucial for understanding the state of your rollout and taking corrective
-action if needed.
+action if needed. On an interactive terminal each cluster gets a live status
+line while the deploy runs; in pipes and CI logs only the final report is printed.
+
+The manifest may be a Deployment, StatefulSet, DaemonSet or any other kind the
+cluster serves (ConfigMaps, Services, custom resources). Every object is sent
+with server-side apply as field manager "mcm", so fields other tools own - an
+autoscaler's replicas, a label added by hand - are merged with, not wiped. If
+the manifest changes a field another manager owns, the deploy to that cluster
+fails with a conflict; --force-conflicts takes ownership instead. --explain,
+--dry-run, --diff, --wait and --progress-deadline only work with Deployments so far.
+
+Some fields can only be set when a resource is created - a Job's selector, a
+Service's clusterIP, most of a StatefulSet's spec - and changing them fails.
+--force-recreate deletes such a resource and creates it again from the manifest.
+This is disruptive: the resource and the pods it runs are gone until the new
+one is up, and a StatefulSet's pods restart one by one from scratch. Only the
+resources whose update was refused are recreated, each listed in the report.
+It asks for confirmation first, or takes --yes where there's no terminal.
 
 Safety features:
 - Each cluster deployment is independent - failure in one doesn't stop others
-- Detailed error reporting shows exactly what went wrong where
-- Dry-run capability (planned) to preview changes before applying them
-- Rollback capability (planned) to quickly revert problematic deployments
+- Change freezes are honored: clusters marked 'frozen: true' in the config, or
+  whose kube-system or target namespace carries the annotation
+  mcm.io/deploy-frozen=true, are skipped unless --ignore-freeze is given
+- Detailed error reporting shows exactly what went wrong where; a deploy an
+  admission webhook, ValidatingAdmissionPolicy or API validation refuses is
+  reported with just its reason, e.g. "prod-eu: rejected by webhook
+  'pod-policy': privileged containers not allowed". With --wait, pods refused
+  the same way fail the rollout right away instead of waiting for the timeout
+- With --managed-only, deployments get the config's managedByLabel, and existing
+  deployments without it (owned by other tools) are refused rather than overwritten
+- With --wait, success means the rollout finished (same rules as
+  'kubectl rollout status'), and a rollout Kubernetes has marked
+  ProgressDeadlineExceeded fails immediately with "rollout stuck: <reason>" instead
+  of running out the clock; --progress-deadline shortens that deadline
+- Clusters a deploy fails on are remembered per manifest file; --retry-failed
+  redeploys the file to just those clusters (and same namespace) after a fix
+- With --create-namespace, a missing target namespace is created and labeled
+  mcm.io/created-by=mcm (with creation time and user annotations), so
+  'mcm namespaces cleanup' can find it once it's empty again
+- --explain prints what will happen before it happens, worked out from each
+  cluster's live state, e.g. "This will update Deployment 'web' (replicas 3→5)
+  in namespace 'prod' on clusters: prod-us, prod-eu"; --dry-run prints the
+  same explanation and stops without changing anything. --diff adds a diff of
+  the live object against the manifest per cluster (clusters with the same
+  diff share one), tuned with --context-lines and --diff-format=side-by-side
+- --check-apis checks every object's apiVersion and kind against each target
+  cluster's discovery data and reports which clusters can't serve which kinds
+  (e.g. a batch/v1beta1 CronJob on a newer cluster), then stops; it exits
+  non-zero if any cluster can't take the whole manifest
+- --plan is the terraform plan of a deploy: every object in the manifest is
+  sent to every target cluster as a server-side apply with dry run and
+  compared with the live object, and a table shows each resource and cluster
+  with its action - create, update (with the fields that change), unchanged,
+  recreate (with --force-recreate), or refuse (and why, e.g. a missing
+  namespace or an admission webhook saying no). Nothing is changed, and the
+  command exits non-zero if anything would be refused or a cluster couldn't
+  be planned. --output=json or yaml gives the plan to scripts
+- --values fills in {{ .Values.x }} placeholders in the manifest from a YAML
+  file, with --set overriding single values (image.tag=1.2). It's plain
+  substitution, not Helm: a placeholder with no value is an error
+- --values-for=ENVIRONMENT=FILE adds values for clusters whose environment
+  matches, merged over --values, so one manifest renders differently per
+  cluster in a single --all-clusters deploy; --set still wins everywhere
+- Hooks: --pre-deploy and --post-deploy (or hooks.preDeploy and
+  hooks.postDeploy in the config) run shell commands around the deploy, e.g.
+  to post to chat. They see MCM_HOOK, MCM_MANIFEST, MCM_NAMESPACE,
+  MCM_CLUSTERS (comma-separated) and MCM_USER; the post-deploy hook also gets
+  MCM_RESULT (success or failure) and MCM_FAILED_CLUSTERS. A failing
+  pre-deploy hook stops the deploy, a failing post-deploy hook fails the
+  command; --ignore-hook-errors (hooks.ignoreErrors) makes both warnings.
+  Hooks don't run for --dry-run, --plan or --check-apis. Hooks and notify set
+  in a config found in the working directory (.mcm.yaml or ./mcm-config.yaml)
+  are refused unless --allow-hooks is given on the command line
+- --notify-webhook (notify.webhook in the config) POSTs a JSON summary of the
+  results - manifest, namespace, user, timestamp, and each cluster's status -
+  to a URL such as a Slack or Teams incoming webhook; --notify-on=failure
+  (notify.when) posts only when some cluster failed. The call gives up after
+  10s, and a failed notification is a warning, never a failed deploy
+- A bad release is reverted with 'mcm deployments rollback', which takes
+  each cluster back to the deployment's previous revision
 
 Examples:
   mcm deploy app.yaml                                    # Deploy to default cluster
   mcm deploy app.yaml --clusters=prod-us,prod-eu        # Deploy to specific clusters  
   mcm deploy app.yaml --clusters=prod-us,prod-eu --namespace=production
+  mcm deploy app.yaml --clusters=@prod-all              # Every cluster in the prod-all config group
   mcm deploy app.yaml --all-clusters                    # Deploy to all configured clusters
-  mcm deploy app.yaml --exclude=dev-cluster             # Deploy to all except specified`,
+  mcm deploy app.yaml --exclude=dev-cluster             # Deploy to all except specified
+  mcm deploy app.yaml --if-not-exists --fail-on-warning # Create-only, fail if anything existed
+  mcm deploy app.yaml --all-clusters --wait --timeout=10m # Wait for every rollout to finish
+  mcm deploy app.yaml -n preview-123 --create-namespace  # Create the namespace if missing
+  mcm deploy app.yaml --retry-failed                    # Redo only the clusters that failed last time
+  mcm deploy app.yaml --all-clusters --dry-run          # What would this change, and where?
+  mcm deploy app.yaml --all-clusters --check-apis       # Can every cluster take these kinds?
+  mcm deploy app.yaml --clusters=@prod-all --plan        # Per resource and cluster: create, update or unchanged?
+  mcm deploy app.yaml --all-clusters --dry-run --diff --diff-format=side-by-side
+  mcm deploy app.yaml --values=prod-values.yaml --set image.tag=1.4.2
+  mcm deploy app.yaml --all-clusters --values=values.yaml --values-for=production=prod-values.yaml
+  mcm deploy app.yaml --post-deploy='./notify.sh "$MCM_RESULT" "$MCM_CLUSTERS"'
+  mcm deploy app.yaml --all-clusters --notify-webhook=https://hooks.slack.com/services/... --notify-on=failure`,
 
 		Args: cobra.ExactArgs(1), // Require exactly one argument (the YAML file)
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -69,44 +169,456 @@ Examples:
 				return fmt.Errorf("failed to read YAML file %s: %w", yamlFile, err)
 			}
 
-			// Parse command flags to determine target clusters
-			clusters, err := parseDeploymentTargets(cmd)
+			// With --values, --values-for or --set, the manifest's {{ .Values.x }}
+			// placeholders are filled in for each cluster from its environment's values.
+			// The fingerprint covers the values too, so --retry-failed notices when
+			// either changed since the failed deploy
+			values, err := deployValues(cmd, yamlFile)
+			if err != nil {
+				return err
+			}
+			fingerprint, err := deployFingerprint(yamlContent, values)
 			if err != nil {
 				return err
 			}
 
 			// Get the target namespace
 			namespace := cmd.Flag("namespace").Value.String()
+
+			// Parse command flags to determine target clusters; --retry-failed
+			// instead picks up the clusters the last deploy of this file failed on
+			var clusters []string
+			if retryFailed, _ := cmd.Flags().GetBool("retry-failed"); retryFailed {
+				if cmd.Flags().Changed("clusters") || cmd.Flags().Changed("all-clusters") || cmd.Flags().Changed("exclude") {
+					return fmt.Errorf("--retry-failed picks its own clusters; it can't be combined with --clusters, --all-clusters or --exclude")
+				}
+				state, err := loadDeployState(yamlFile)
+				if err != nil {
+					return fmt.Errorf("failed to read the last deploy's state: %w", err)
+				}
+				clusters, err = retryTargets(state, yamlFile, fingerprint, clusterManager.HasCluster)
+				if err != nil {
+					return err
+				}
+				if namespace == "" {
+					namespace = state.Namespace
+				}
+			} else {
+				clusters, err = parseDeploymentTargets(cmd)
+				if err != nil {
+					return err
+				}
+			}
+
 			if namespace == "" {
 				namespace = appConfig.DefaultNamespace
 			}
 
+			// Respect change-freeze windows unless explicitly overridden
+			ignoreFreeze, _ := cmd.Flags().GetBool("ignore-freeze")
+			clusters, err = filterFrozenClusters(clusters, namespace, ignoreFreeze)
+			if err != nil {
+				return err
+			}
+
+			// Render for every target environment up front, so a value missing in
+			// one environment stops the deploy before any cluster is changed
+			if values != nil {
+				for _, environment := range clusterEnvironments(clusters) {
+					if _, err := values.Render(string(yamlContent), environment); err != nil {
+						return err
+					}
+				}
+			}
+
+			// Check the manifest's kinds against what each cluster serves, then stop
+			if checkAPIs, _ := cmd.Flags().GetBool("check-apis"); checkAPIs {
+				checks := workloadManager.CheckAPIs(clusters, string(yamlContent), workload.DeployOptions{Values: values})
+				if err := reportAPIChecks(os.Stdout, yamlFile, checks); err != nil {
+					cmd.SilenceUsage = true
+					return err
+				}
+				return nil
+			}
+
+			ifNotExists, _ := cmd.Flags().GetBool("if-not-exists")
+			failOnWarning, _ := cmd.Flags().GetBool("fail-on-warning")
+
+			wait, _ := cmd.Flags().GetBool("wait")
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+			progressDeadline, _ := cmd.Flags().GetDuration("progress-deadline")
+			if progressDeadline < 0 || (progressDeadline > 0 && progressDeadline < time.Second) {
+				return fmt.Errorf("--progress-deadline must be at least 1s, got %s", progressDeadline)
+			}
+
+			opts := workload.DeployOptions{
+				CreateOnly:       ifNotExists,
+				Values:           values,
+				Wait:             wait,
+				WaitTimeout:      timeout,
+				ProgressDeadline: progressDeadline,
+			}
+			opts.ForceConflicts, _ = cmd.Flags().GetBool("force-conflicts")
+			if createNamespace, _ := cmd.Flags().GetBool("create-namespace"); createNamespace {
+				opts.CreateNamespace = true
+				opts.CreatedBy = deployingUser()
+			}
+
+			// In shared namespaces, only create or update what mcm owns
+			if managedOnly, _ := cmd.Flags().GetBool("managed-only"); managedOnly {
+				key, value, err := config.ParseManagedByLabel(appConfig.ManagedByLabel)
+				if err != nil {
+					return err
+				}
+				opts.ManagedByKey, opts.ManagedByValue = key, value
+			}
+
+			// Plan every resource in every cluster with server-side dry runs, then stop
+			if planOnly, _ := cmd.Flags().GetBool("plan"); planOnly {
+				opts.ForceRecreate, _ = cmd.Flags().GetBool("force-recreate")
+				result := workloadManager.PlanManifest(clusters, namespace, string(yamlContent), opts)
+				return reportPlan(cmd, result)
+			}
+
+			// Say in plain words what is about to happen, worked out from the
+			// live state of every target; --dry-run stops right there
+			explain, _ := cmd.Flags().GetBool("explain")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			showDiff, _ := cmd.Flags().GetBool("diff")
+			diffOpts, err := deployDiffOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if explain || dryRun || showDiff {
+				plan, err := workloadManager.PlanDeploy(clusters, namespace, string(yamlContent), opts)
+				if err != nil {
+					return err
+				}
+				explainDeploy(os.Stdout, plan, opts)
+				if showDiff {
+					if err := writePlanDiffs(os.Stdout, plan, diffOpts); err != nil {
+						return err
+					}
+				}
+				if dryRun {
+					fmt.Println("\nDry run: nothing was changed.")
+					return nil
+				}
+				fmt.Println()
+			}
+
+			// Hooks from a repository's .mcm.yaml run only when asked for
+			if err := checkProjectHooks(cmd, appConfig); err != nil {
+				return err
+			}
+
+			// Production-like environments may demand --yes or a typed confirmation
+			if err := confirmChanges(cmd, "deploy "+yamlFile, clusters); err != nil {
+				return err
+			}
+
+			// Recreating takes resources down, so it is confirmed on its own, and
+			// every recreated resource is collected for the report
+			var recreatedMutex sync.Mutex
+			var recreated []workload.RecreatedResource
+			if forceRecreate, _ := cmd.Flags().GetBool("force-recreate"); forceRecreate {
+				if err := confirmRecreate(cmd, clusters); err != nil {
+					return err
+				}
+				opts.ForceRecreate = true
+				opts.OnRecreate = func(resource workload.RecreatedResource) {
+					recreatedMutex.Lock()
+					defer recreatedMutex.Unlock()
+					recreated = append(recreated, resource)
+				}
+			}
+
+			notify, err := deployNotify(cmd, appConfig.Notify)
+			if err != nil {
+				return err
+			}
+
+			// The pre-deploy hook gets the last word before anything changes
+			hooks := deployHooks(cmd, appConfig.Hooks)
+			hookContext := deployHookContext{
+				Manifest:  yamlFile,
+				Namespace: namespace,
+				Clusters:  clusters,
+				User:      deployingUser(),
+			}
+			if hooks.PreDeploy != "" {
+				err := runHook(hookPreDeploy, hooks.PreDeploy, hookContext)
+				if err := hookFailure(hooks, err); err != nil {
+					return fmt.Errorf("%w; nothing was deployed", err)
+				}
+			}
+
 			fmt.Printf("Deploying %s to %d clusters...\n", yamlFile, len(clusters))
 			fmt.Printf("Target clusters: %s\n", strings.Join(clusters, ", "))
 			fmt.Printf("Target namespace: %s\n\n", namespace)
 
+			// On an interactive terminal, show a live line per cluster while deploying
+			// Pipes and CI logs keep getting the plain batch report below
+			var progressDone chan struct{}
+			var progressEvents chan workload.DeployEvent
+			if isTerminal() && viper.GetString("output") == "table" {
+				progressEvents = make(chan workload.DeployEvent)
+				progressDone = make(chan struct{})
+				opts.Progress = progressEvents
+
+				go func() {
+					defer close(progressDone)
+					newDeployProgress(os.Stdout, clusters).run(progressEvents)
+				}()
+			}
+
 			// Execute the deployment across all target clusters
 			// This happens in parallel, so even deploying to many clusters is fast
-			results := workloadManager.DeployToMultipleClusters(clusters, namespace, string(yamlContent))
+			results := workloadManager.DeployToMultipleClusters(clusters, namespace, string(yamlContent), opts)
+
+			if progressEvents != nil {
+				close(progressEvents)
+				<-progressDone
+				fmt.Println()
+			}
+
+			// Remember what failed so --retry-failed can pick up just the stragglers
+			state := &deployState{
+				Manifest:     yamlFile,
+				ManifestHash: manifestHash(fingerprint),
+				Namespace:    namespace,
+				Failed:       failedDeployClusters(results, failOnWarning),
+				DeployedAt:   time.Now(),
+			}
+			if err := saveDeployState(yamlFile, state); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not save deploy state for --retry-failed: %v\n", err)
+			}
 
 			// Analyze and report the results
-			return reportDeploymentResults(results, yamlFile)
+			reportErr := reportDeploymentResults(results, recreated, yamlFile, failOnWarning)
+
+			// The post-deploy hook hears how it went
+			var hookErr error
+			if hooks.PostDeploy != "" {
+				hookContext.Succeeded = len(state.Failed) == 0
+				hookContext.Failed = state.Failed
+				hookErr = hookFailure(hooks, runHook(hookPostDeploy, hooks.PostDeploy, hookContext))
+			}
+
+			// A notification that can't be delivered never fails a finished deploy
+			notification := newDeployNotification(hookContext, results, failOnWarning, time.Now())
+			if shouldNotify(notify, notification) {
+				if err := postNotification(context.Background(), notify.Webhook, notification); err != nil {
+					fmt.Fprintf(os.Stderr, "⚠️  Warning: %v\n", err)
+				}
+			}
+
+			// When the deploy itself failed, that stays the command's error and the
+			// hook's failure is only reported
+			if hookErr != nil {
+				if reportErr != nil {
+					fmt.Fprintf(os.Stderr, "❌ %v\n", hookErr)
+					return reportErr
+				}
+				return fmt.Errorf("deployed, but %w", hookErr)
+			}
+			return reportErr
 		},
 	}
 
 	// Add flags that control deployment targeting and behavior
-	cmd.Flags().String("clusters", "", "comma-separated list of cluster names to deploy to")
+	cmd.Flags().String("clusters", "", "comma-separated list of cluster names or @groups to deploy to")
 	cmd.Flags().Bool("all-clusters", false, "deploy to all configured clusters")
-	cmd.Flags().String("exclude", "", "comma-separated list of clusters to exclude (used with --all-clusters)")
+	cmd.Flags().String("exclude", "", "comma-separated list of clusters or @groups to exclude (used with --all-clusters)")
 	cmd.Flags().StringP("namespace", "n", "", "target namespace (default: from config)")
-	// Future flags that would make this production-ready:
-	// cmd.Flags().Bool("dry-run", false, "preview the deployment without applying changes")
-	// cmd.Flags().Int("timeout", 300, "deployment timeout in seconds")
-	// cmd.Flags().Bool("wait", false, "wait for deployment to complete before returning")
+	cmd.Flags().Bool("if-not-exists", false, "only create resources; leave existing ones untouched and report them as warnings")
+	cmd.Flags().Bool("fail-on-warning", false, "treat warnings (e.g. resource already exists) as failures and exit non-zero")
+	cmd.Flags().Bool("force-conflicts", false, "take ownership of fields another field manager (kubectl, a controller) set, instead of failing with a conflict")
+	cmd.Flags().Bool("force-recreate", false, "delete and recreate resources whose update is refused because an immutable field changed (causes downtime; asks first)")
+	cmd.Flags().Bool("ignore-freeze", false, "deploy even to clusters or namespaces under a change freeze")
+	addYesFlag(cmd)
+	cmd.Flags().Bool("retry-failed", false, "deploy only to the clusters the last deploy of this file failed on")
+	cmd.Flags().Bool("create-namespace", false, "create the target namespace if it doesn't exist, labeled mcm.io/created-by=mcm for later 'namespaces cleanup'")
+	cmd.Flags().Bool("wait", false, "wait for each deployment to finish rolling out before returning")
+	cmd.Flags().Duration("timeout", workload.DefaultRolloutTimeout, "how long --wait follows a rollout in each cluster")
+	cmd.Flags().Duration("progress-deadline", 0, "set spec.progressDeadlineSeconds on deployments so Kubernetes marks stuck rollouts sooner (0 = keep the manifest's value)")
+	cmd.Flags().Bool("explain", false, "describe in plain words what the deploy will change on each cluster before doing it")
+	cmd.Flags().Bool("dry-run", false, "explain what the deploy would change, then stop without changing anything")
+	cmd.Flags().Bool("plan", false, "dry-run every resource in every target cluster and print a table of what would be created, updated or left unchanged, then stop without deploying")
+	cmd.Flags().Bool("check-apis", false, "check that every target cluster serves the apiVersion and kind of each object in the manifest, then stop without deploying")
+	cmd.Flags().Bool("diff", false, "with the explanation, show a diff of each cluster's live object against the manifest")
+	cmd.Flags().Int("context-lines", output.DefaultContextLines, "unchanged lines shown around each change in --diff output")
+	cmd.Flags().String("diff-format", output.DiffUnified, "--diff layout: unified, or side-by-side (live | manifest)")
+	cmd.Flags().String("values", "", "YAML file whose values fill in {{ .Values.x }} placeholders in the manifest")
+	cmd.Flags().StringArray("values-for", nil, "values for clusters in one environment, merged over --values, e.g. --values-for=production=prod.yaml (repeatable)")
+	cmd.Flags().StringArray("set", nil, "set a template value on top of --values and --values-for, e.g. --set image.tag=1.2 (repeatable)")
+	addHookFlags(cmd)
+	addNotifyFlags(cmd)
+	addManagedOnlyFlag(cmd)
 
 	return cmd
 }
 
+// reportAPIChecks prints which clusters can't serve which objects of the manifest,
+// returning an error when any cluster can't take all of them or couldn't be checked
+func reportAPIChecks(out io.Writer, yamlFile string, checks []workload.APICheck) error {
+	fmt.Fprintf(out, "Checking the API versions in %s against %d clusters...\n", yamlFile, len(checks))
+
+	var unserved, unchecked []string
+	for _, check := range checks {
+		switch {
+		case check.Err != nil:
+			unchecked = append(unchecked, check.ClusterName)
+			fmt.Fprintf(out, "⚠️  %s: could not check - %v\n", check.ClusterName, check.Err)
+		case len(check.Unserved) > 0:
+			unserved = append(unserved, check.ClusterName)
+			fmt.Fprintf(out, "❌ %s: %d of %d objects use kinds this cluster doesn't serve\n",
+				check.ClusterName, len(check.Unserved), check.Objects)
+			for _, kind := range check.Unserved {
+				fmt.Fprintf(out, "   - %s %s '%s'\n", kind.APIVersion, kind.Kind, kind.Name)
+			}
+		default:
+			fmt.Fprintf(out, "✅ %s: all %d objects served\n", check.ClusterName, check.Objects)
+		}
+	}
+	fmt.Fprintln(out)
+
+	if len(unserved) == 0 && len(unchecked) == 0 {
+		fmt.Fprintln(out, "Every cluster serves every kind in the manifest. Nothing was deployed.")
+		return nil
+	}
+	var problems []string
+	if len(unserved) > 0 {
+		problems = append(problems, fmt.Sprintf("%s can't serve every kind", strings.Join(unserved, ", ")))
+	}
+	if len(unchecked) > 0 {
+		problems = append(problems, fmt.Sprintf("%s couldn't be checked", strings.Join(unchecked, ", ")))
+	}
+	return fmt.Errorf("API check failed: %s", strings.Join(problems, "; "))
+}
+
+// reportPlan prints a deploy --plan as a table, or JSON/YAML for scripts, and
+// fails when a resource would be refused or a cluster couldn't be planned
+func reportPlan(cmd *cobra.Command, result workload.FleetResult[workload.ResourcePlan]) error {
+	switch viper.GetString("output") {
+	case "json":
+		if err := output.ResourcePlanJSON(os.Stdout, result.Items, result.ErrorMessages()); err != nil {
+			return err
+		}
+	case "yaml":
+		if err := output.ResourcePlanYAML(os.Stdout, result.Items, result.ErrorMessages()); err != nil {
+			return err
+		}
+	default:
+		if err := output.ResourcePlanTable(os.Stdout, result.Items); err != nil {
+			return err
+		}
+		printFleetFailures(os.Stdout, result)
+		fmt.Println("\nPlan only: nothing was changed.")
+	}
+
+	var refused []string
+	for _, plan := range result.Items {
+		if plan.Action == workload.PlanRefuse {
+			refused = append(refused, plan.Resource()+" on "+plan.Cluster)
+		}
+	}
+	if len(refused) > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("deploy would be refused for %s", strings.Join(refused, ", "))
+	}
+	return fleetError(cmd, result)
+}
+
+// deployValues gathers the --values file, the --values-for overlays and the
+// --set overrides; without any of them it returns nil, and the manifest is
+// used exactly as written
+func deployValues(cmd *cobra.Command, yamlFile string) (*workload.ManifestValues, error) {
+	valuesFile, _ := cmd.Flags().GetString("values")
+	overlays, _ := cmd.Flags().GetStringArray("values-for")
+	sets, _ := cmd.Flags().GetStringArray("set")
+	if valuesFile == "" && len(overlays) == 0 && len(sets) == 0 {
+		return nil, nil
+	}
+
+	values := &workload.ManifestValues{Name: yamlFile, Base: map[string]interface{}{}, Overrides: sets}
+	if valuesFile != "" {
+		base, err := readValuesFile(valuesFile)
+		if err != nil {
+			return nil, err
+		}
+		values.Base = base
+	}
+
+	for _, overlay := range overlays {
+		environment, file, ok := strings.Cut(overlay, "=")
+		if !ok || strings.TrimSpace(environment) == "" || file == "" {
+			return nil, fmt.Errorf("invalid --values-for %q: expected ENVIRONMENT=FILE", overlay)
+		}
+		if values.Environments == nil {
+			values.Environments = make(map[string]map[string]interface{})
+		}
+		if _, exists := values.Environments[environment]; exists {
+			return nil, fmt.Errorf("--values-for: environment '%s' is given more than once", environment)
+		}
+		environmentValues, err := readValuesFile(file)
+		if err != nil {
+			return nil, err
+		}
+		values.Environments[environment] = environmentValues
+	}
+
+	// Catch malformed --set values before looking at any cluster
+	if _, err := values.For(""); err != nil {
+		return nil, fmt.Errorf("--set: %w", err)
+	}
+	return values, nil
+}
+
+// readValuesFile reads and parses one values file
+func readValuesFile(path string) (map[string]interface{}, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read values file %s: %w", path, err)
+	}
+	values, err := workload.ParseValues(content)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return values, nil
+}
+
+// deployFingerprint is what a deploy's state hash covers: the manifest and,
+// when it is a template, the values it is rendered with
+func deployFingerprint(yamlContent []byte, values *workload.ManifestValues) ([]byte, error) {
+	if values == nil {
+		return yamlContent, nil
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fingerprint values: %w", err)
+	}
+	return append(append([]byte{}, yamlContent...), data...), nil
+}
+
+// clusterEnvironments lists the distinct environments of the named clusters, in order
+func clusterEnvironments(clusterNames []string) []string {
+	targets := make(map[string]bool, len(clusterNames))
+	for _, name := range clusterNames {
+		targets[name] = true
+	}
+
+	seen := make(map[string]bool)
+	var environments []string
+	for _, cluster := range appConfig.Clusters {
+		if targets[cluster.Name] && !seen[cluster.Environment] {
+			seen[cluster.Environment] = true
+			environments = append(environments, cluster.Environment)
+		}
+	}
+	return environments
+}
+
 // parseDeploymentTargets determines which clusters to deploy to based on command flags
 // This function handles the logic for --clusters, --all-clusters, and --exclude flags
 func parseDeploymentTargets(cmd *cobra.Command) ([]string, error) {
@@ -156,6 +668,9 @@ func parseDeploymentTargets(cmd *cobra.Command) ([]string, error) {
 		// Validate that all specified clusters are available and connected
 		for _, clusterName := range targetClusters {
 			client, err := clusterManager.GetClient(clusterName)
+			if cluster.IsUnknownCluster(err) {
+				return nil, err
+			}
 			if err != nil {
 				return nil, fmt.Errorf("cluster '%s' is not available: %w", clusterName, err)
 			}
@@ -180,10 +695,58 @@ func parseDeploymentTargets(cmd *cobra.Command) ([]string, error) {
 	return targetClusters, nil
 }
 
+// filterFrozenClusters drops clusters that are under a change freeze
+// Frozen clusters are skipped with a clear reason rather than failing the whole
+// rollout, so the unfrozen part of the fleet still gets the change
+func filterFrozenClusters(clusters []string, namespace string, ignoreFreeze bool) ([]string, error) {
+	var allowed []string
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	statuses := make(map[string]workload.FreezeStatus)
+
+	// Freeze checks are independent API calls, so run them in parallel like everything else
+	for _, clusterName := range clusters {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			status := workloadManager.CheckFreeze(name, namespace)
+
+			mutex.Lock()
+			statuses[name] = status
+			mutex.Unlock()
+		}(clusterName)
+	}
+	wg.Wait()
+
+	for _, clusterName := range clusters {
+		status := statuses[clusterName]
+		switch {
+		case !status.Frozen:
+			allowed = append(allowed, clusterName)
+		case ignoreFreeze:
+			fmt.Printf("⚠️  Deploying to frozen cluster %s (--ignore-freeze): %s\n", clusterName, status.Reason)
+			allowed = append(allowed, clusterName)
+		default:
+			fmt.Printf("⛔ Skipping frozen cluster %s: %s\n", clusterName, status.Reason)
+		}
+	}
+
+	if len(allowed) == 0 {
+		return nil, fmt.Errorf("all target clusters are under a change freeze; use --ignore-freeze to override")
+	}
+
+	return allowed, nil
+}
+
 // reportDeploymentResults analyzes deployment results and provides detailed feedback
 // This function is crucial for understanding what happened during a multi-cluster deployment
-func reportDeploymentResults(results map[string]error, yamlFile string) error {
+// With failOnWarning set, warnings are promoted to failures so CI pipelines exit non-zero
+// Resources --force-recreate deleted and created again are listed one by one, since
+// each of them was down for a while even on clusters that otherwise succeeded
+func reportDeploymentResults(results map[string]error, recreated []workload.RecreatedResource, yamlFile string, failOnWarning bool) error {
 	successCount := 0
+	rejected := 0
+	immutable := 0
 	var failures []string
 	var warnings []string
 
@@ -197,11 +760,18 @@ func reportDeploymentResults(results map[string]error, yamlFile string) error {
 			fmt.Printf("✅ %s: SUCCESS\n", clusterName)
 		} else {
 			// Categorize different types of errors for better user understanding
-			errorMsg := err.Error()
 			fmt.Printf("❌ %s: FAILED - %v\n", clusterName, err)
 
+			var rejection *workload.AdmissionError
+			if errors.As(err, &rejection) {
+				rejected++
+			}
+			if workload.IsImmutableFieldError(err) {
+				immutable++
+			}
+
 			// Determine if this is a warning (recoverable) or a failure (needs intervention)
-			if strings.Contains(errorMsg, "already exists") || strings.Contains(errorMsg, "no changes") {
+			if isDeployWarning(err) {
 				warnings = append(warnings, fmt.Sprintf("%s: %v", clusterName, err))
 			} else {
 				failures = append(failures, fmt.Sprintf("%s: %v", clusterName, err))
@@ -211,6 +781,30 @@ func reportDeploymentResults(results map[string]error, yamlFile string) error {
 
 	fmt.Println()
 
+	if len(recreated) > 0 {
+		sort.Slice(recreated, func(i, j int) bool {
+			if recreated[i].Cluster != recreated[j].Cluster {
+				return recreated[i].Cluster < recreated[j].Cluster
+			}
+			return recreated[i].Name < recreated[j].Name
+		})
+		fmt.Printf("🔁 Recreated (%d resources were deleted and created again because an immutable field changed):\n", len(recreated))
+		for _, resource := range recreated {
+			where := resource.Name
+			if resource.Namespace != "" {
+				where = resource.Namespace + "/" + resource.Name
+			}
+			fmt.Printf("   %s: %s %s\n", resource.Cluster, strings.ToLower(resource.Kind), where)
+		}
+		fmt.Println()
+	}
+
+	// Strict pipelines want create-only semantics, where "already exists" is an error
+	if failOnWarning && len(warnings) > 0 {
+		failures = append(failures, warnings...)
+		warnings = nil
+	}
+
 	// Provide a comprehensive summary that helps users understand what to do next
 	totalClusters := len(results)
 	if successCount == totalClusters {
@@ -242,10 +836,19 @@ func reportDeploymentResults(results map[string]error, yamlFile string) error {
 
 		// Provide actionable guidance for common failure scenarios
 		fmt.Println("Troubleshooting Tips:")
+		if rejected > 0 {
+			fmt.Println("- Rejected by a webhook, admission policy or validation: retrying won't help -")
+			fmt.Println("  change the manifest, or talk to whoever owns the policy")
+		}
+		if immutable > 0 {
+			fmt.Println("- An immutable field changed: --force-recreate deletes and recreates those")
+			fmt.Println("  resources instead (they are down in between)")
+		}
 		fmt.Println("- Check cluster connectivity: mcm clusters test")
 		fmt.Println("- Verify namespace exists: kubectl get namespaces")
 		fmt.Println("- Check YAML syntax: kubectl apply --dry-run=client -f", yamlFile)
 		fmt.Println("- Review cluster-specific differences in configuration")
+		fmt.Println("- Once fixed, retry only the failed clusters: mcm deploy", yamlFile, "--retry-failed")
 
 		return fmt.Errorf("deployment failed on %d/%d clusters", len(failures), totalClusters)
 	}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"sigs.k8s.io/yaml"
+
+	"github.com/celikgo/autoz-control-tower/internal/output"
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// newDeploymentsDiffCmd creates the 'deployments diff' subcommand
+// This is kubectl diff for the whole fleet: what would a deploy change, and where?
+func newDeploymentsDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <manifest.yaml>",
+		Short: "Show what applying a Deployment manifest would change in each cluster",
+		Long: `Compare a Deployment manifest with the live deployment in each target cluster
+and print the difference as a unified diff, like kubectl diff across clusters.
+
+The manifest is sent to each cluster as a server-side apply with dry run, so the
+right-hand side is exactly what the API server would store - defaults, mutating
+webhooks and fields other managers own included - and nothing is changed.
+The bookkeeping the server rewrites on every change (resourceVersion,
+generation, managedFields, status) is left out of both sides.
+
+- A deployment that doesn't exist yet shows as all additions
+- A cluster where the deploy would change nothing prints "no changes"
+- Clusters with identical diffs share one, so a fleet on the same version
+  prints it once
+
+Clusters are picked as for deploy: the default cluster, --clusters, or
+--all-clusters with --exclude. On a terminal the diff is colored; set NO_COLOR
+to turn that off.
+
+Examples:
+  mcm deployments diff app.yaml
+  mcm deployments diff app.yaml --all-clusters -n production
+  mcm deployments diff app.yaml --clusters=@prod-all --diff-format=side-by-side
+  mcm deployments diff app.yaml --all-clusters --values=values.yaml --set image.tag=1.4.2
+  mcm deployments diff app.yaml --all-clusters --output=json | jq '.diffs[] | select(.live != .desired) | .clusterName'`,
+
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			yamlFile := args[0]
+			yamlContent, err := os.ReadFile(yamlFile)
+			if err != nil {
+				return fmt.Errorf("failed to read YAML file %s: %w", yamlFile, err)
+			}
+			values, err := deployValues(cmd, yamlFile)
+			if err != nil {
+				return err
+			}
+			diffOpts, err := deployDiffOptions(cmd)
+			if err != nil {
+				return err
+			}
+
+			clusters, err := parseDeploymentTargets(cmd)
+			if err != nil {
+				return err
+			}
+			namespace := cmd.Flag("namespace").Value.String()
+			if namespace == "" {
+				namespace = appConfig.DefaultNamespace
+			}
+
+			opts := workload.DeployOptions{Values: values}
+			opts.ForceConflicts, _ = cmd.Flags().GetBool("force-conflicts")
+			result := workloadManager.DiffDeployment(clusters, namespace, string(yamlContent), opts)
+
+			switch viper.GetString("output") {
+			case "json", "yaml":
+				if err := outputDeploymentDiffsData(result.Items, result.ErrorMessages(), viper.GetString("output")); err != nil {
+					return err
+				}
+			default:
+				if err := writeDeploymentDiffs(os.Stdout, result.Items, diffOpts); err != nil {
+					return err
+				}
+				printFleetFailures(os.Stdout, result)
+			}
+			return fleetError(cmd, result)
+		},
+	}
+
+	cmd.Flags().String("clusters", "", "comma-separated list of cluster names or @groups to diff against")
+	cmd.Flags().Bool("all-clusters", false, "diff against all configured clusters")
+	cmd.Flags().String("exclude", "", "comma-separated list of clusters or @groups to exclude (used with --all-clusters)")
+	cmd.Flags().StringP("namespace", "n", "", "namespace for manifests that don't set one (default: from config)")
+	cmd.Flags().Bool("force-conflicts", false, "diff as if deploying with --force-conflicts, instead of failing on fields other managers own")
+	cmd.Flags().Int("context-lines", output.DefaultContextLines, "unchanged lines shown around each change")
+	cmd.Flags().String("diff-format", output.DiffUnified, "diff layout: unified, or side-by-side (live | after apply)")
+	cmd.Flags().String("values", "", "YAML file whose values fill in {{ .Values.x }} placeholders in the manifest")
+	cmd.Flags().StringArray("values-for", nil, "values for clusters in one environment, merged over --values, e.g. --values-for=production=prod.yaml (repeatable)")
+	cmd.Flags().StringArray("set", nil, "set a template value on top of --values and --values-for, e.g. --set image.tag=1.2 (repeatable)")
+
+	return cmd
+}
+
+// writeDeploymentDiffs prints each cluster's diff, or that it has none
+// Clusters whose diffs are identical share one, as in deploy --diff
+func writeDeploymentDiffs(out io.Writer, diffs []workload.DeploymentDiff, opts output.DiffOptions) error {
+	type pair struct{ live, desired string }
+	var order []pair
+	clustersByPair := make(map[pair][]string)
+	firstByPair := make(map[pair]workload.DeploymentDiff) // Names the object; it is the same across a group
+	for _, diff := range diffs {
+		key := pair{diff.Live, diff.Desired}
+		if _, seen := clustersByPair[key]; !seen {
+			order = append(order, key)
+			firstByPair[key] = diff
+		}
+		clustersByPair[key] = append(clustersByPair[key], diff.ClusterName)
+	}
+
+	for i, key := range order {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		clusters := strings.Join(clustersByPair[key], ", ")
+		diff := firstByPair[key]
+		if !diff.Changed() {
+			fmt.Fprintf(out, "No changes for %s\n", clusters)
+			continue
+		}
+
+		fmt.Fprintf(out, "Diff for %s:\n", clusters)
+		path := diff.Namespace + "/" + diff.Name
+		liveName := path + " (live)"
+		if !diff.Exists {
+			liveName = "(does not exist)"
+		}
+		if err := output.TextDiff(out, liveName, path+" (after apply)", key.live, key.desired, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// outputDeploymentDiffsData renders the diffs as JSON or YAML for scripts
+func outputDeploymentDiffsData(diffs []workload.DeploymentDiff, failures map[string]string, outputFormat string) error {
+	document := struct {
+		Diffs  []workload.DeploymentDiff `json:"diffs"`
+		Errors map[string]string         `json:"errors,omitempty"`
+	}{Diffs: diffs, Errors: failures}
+
+	if outputFormat == "yaml" {
+		yamlData, err := yaml.Marshal(document)
+		if err != nil {
+			return fmt.Errorf("failed to marshal diffs to YAML: %w", err)
+		}
+		fmt.Print(string(yamlData))
+		return nil
+	}
+	jsonData, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal diffs to JSON: %w", err)
+	}
+	fmt.Println(string(jsonData))
+	return nil
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"strings"
@@ -12,6 +13,8 @@ import (
 	"github.com/spf13/viper"
 	"sigs.k8s.io/yaml"
 
+	"github.com/celikgo/autoz-control-tower/internal/config"
+	"github.com/celikgo/autoz-control-tower/internal/output"
 	"github.com/celikgo/autoz-control-tower/internal/workload"
 )
 
@@ -41,12 +44,30 @@ This command is particularly powerful for:
 Examples:
   mcm deployments list                              # All deployments, all clusters
   mcm deployments list --clusters=prod-us,prod-eu  # Only production clusters
+  mcm deployments list --environment=production    # Every cluster tagged production
   mcm deployments list --namespace=kube-system     # System deployments only
-  mcm deployments list --output=json               # Machine-readable output`,
+  mcm deployments list --exclude-namespace=kube-system  # Every namespace but kube-system
+  mcm deployments list --output=json               # Machine-readable output
+  mcm deployments list --compact                   # One summary row per cluster
+  mcm deployments list --output=name               # Just cluster/namespace/name, for scripting
+  mcm deployments list -n web --output=object-yaml # The deployment objects themselves, as manifests
+  mcm deployments list --only-unhealthy            # Only deployments that need attention
+  mcm deployments list --sort-by=unready --limit=5 # The 5 deployments missing the most replicas
+  mcm deployments list --timeout-per-cluster=5s --timeout=10s  # Don't wait on slow clusters
+  mcm deployments list -w --until=all-ready --watch-timeout=5m  # Block until everything is Ready
+  mcm deployments list -w --interval=10s -n production  # Follow a rollout, refreshing every 10s
+  mcm deployments verify                           # Cross-check deployments against their pods
+  mcm deployments env web --all-clusters LOG_LEVEL=debug  # Set an env var everywhere
+  mcm deployments diff app.yaml --all-clusters     # What would deploying app.yaml change, per cluster?
+  mcm deployments rollback web --all-clusters      # Back to the previous revision everywhere`,
 	}
 
 	// Add the list subcommand - this is the primary operation most users will use
 	deploymentsCmd.AddCommand(newDeploymentsListCmd())
+	deploymentsCmd.AddCommand(newDeploymentsVerifyCmd())
+	deploymentsCmd.AddCommand(newDeploymentsEnvCmd())
+	deploymentsCmd.AddCommand(newDeploymentsDiffCmd())
+	deploymentsCmd.AddCommand(newDeploymentsRollbackCmd())
 
 	return deploymentsCmd
 }
@@ -64,7 +85,8 @@ of your deployment landscape.
 The output includes critical information for operations:
 - Deployment name and namespace for identification
 - Current replica count vs desired replica count (health indicator)
-- Container image version (crucial for version tracking)
+- Container image version (crucial for version tracking; long images are
+  shortened in the table - use --full-image to see every tag and digest)
 - Overall status (Ready, Partial, NotReady)
 - Age of the deployment (useful for change tracking)
 - Which cluster the deployment is running in
@@ -74,188 +96,389 @@ Understanding the status indicators:
 - Partial: Some replicas are running, but not all desired replicas are ready
 - NotReady: No replicas are currently ready (likely a problem)
 
+The summary line adds up the fleet's health: ready replicas out of desired,
+the readiness percentage, and how many deployments are Partial or NotReady,
+e.g. "41/45 replicas ready (91.1%), 2 partial, 1 not ready". JSON and YAML
+output carry the same numbers under "health".
+
 This unified view is incredibly valuable because it answers questions like:
 "Are all my production applications healthy?" or "Did my deployment succeed in all regions?"
-without requiring you to manually check each cluster individually.`,
+without requiring you to manually check each cluster individually.
+
+--environment and --region pick the clusters by the environment and region they
+are tagged with in the config, so "all of production" is just --environment=production.
+
+For a big fleet, --group-by=cluster (or namespace) splits the table into one
+sub-table per group, each with its own ready/not-ready summary, so you can
+review the fleet one cluster at a time. The --sort-by order holds within each group.
+
+--output=object-yaml prints the deployment objects themselves instead of mcm's
+summary, as multi-document YAML annotated with mcm.io/cluster, ready to pipe
+into kubectl or mcm deploy. Flags that work on the summary (--sort-by,
+--only-unhealthy, --compact...) can't be combined with it.
+
+If any cluster can't be queried, the deployments from the others are still
+shown, and the command exits non-zero so scripts and CI notice the gap.
+
+Watch mode (-w) re-lists every 2 seconds (or every --interval) until interrupted,
+redrawing the table in place. Deployments whose status changed since the last
+refresh are flagged with what they were, e.g. "Ready (was Partial)", and ones
+that just appeared with "(new)", so convergence is easy to follow. With --until=all-ready
+it becomes a convergence gate for CI: it exits 0 as soon as every listed
+deployment is Ready and every cluster answered, or non-zero once --watch-timeout
+passes without getting there:
+
+  mcm deployments list -w --until=all-ready --watch-timeout=5m`,
 
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Parse command-line flags to determine what to show
-			clusters := parseClusterList(cmd.Flag("clusters").Value.String())
+			clusters, err := targetClusters(cmd)
+			if err != nil {
+				return err
+			}
 			namespace := cmd.Flag("namespace").Value.String()
-			outputFormat := viper.GetString("output")
 
-			// Query all specified clusters for deployment information
-			// This happens in parallel, so even querying 10+ clusters is fast
-			deployments, err := workloadManager.ListDeployments(clusters, namespace)
+			if err := applyListTimeouts(cmd); err != nil {
+				return err
+			}
+
+			watch, watchOpts, err := parseWatchOptions(cmd)
 			if err != nil {
-				return fmt.Errorf("failed to list deployments: %w", err)
+				return err
+			}
+			if _, err := parseGroupBy(cmd); err != nil {
+				return err
 			}
 
-			// Sort deployments for consistent output
-			// We sort by cluster name first, then by namespace, then by deployment name
-			// This makes it easy to scan the output and find specific deployments
-			sort.Slice(deployments, func(i, j int) bool {
-				if deployments[i].ClusterName != deployments[j].ClusterName {
-					return deployments[i].ClusterName < deployments[j].ClusterName
+			if viper.GetString("output") == "object-yaml" {
+				if err := rejectObjectYAMLFlags(cmd, "compact", "only-unhealthy", "sort-by", "limit", "group-by", "watch"); err != nil {
+					return err
 				}
-				if deployments[i].Namespace != deployments[j].Namespace {
-					return deployments[i].Namespace < deployments[j].Namespace
-				}
-				return deployments[i].Name < deployments[j].Name
-			})
+				return listObjectsYAML(cmd, clusters, "deployments",
+					workload.GetOptions{Namespace: namespace, LabelSelector: managedOnlySelector(cmd, "")},
+					excludedNamespaces(cmd, namespace == ""))
+			}
 
-			// Output in the requested format
-			switch outputFormat {
-			case "json":
-				return outputDeploymentsJSON(deployments)
-			case "yaml":
-				return outputDeploymentsYAML(deployments)
-			default:
-				return outputDeploymentsTable(deployments)
+			// Query all specified clusters for deployment information
+			// This happens in parallel, so even querying 10+ clusters is fast
+			// Clusters that fail are collected in result.Errors and reported after the data
+			list := func() workload.FleetResult[workload.DeploymentInfo] {
+				return workloadManager.ListDeployments(clusters, namespace, managedOnlySelector(cmd, ""))
+			}
+			if watch {
+				return watchDeployments(cmd, watchOpts, list)
+			}
+			result := list()
+			if err := renderDeploymentList(cmd, os.Stdout, os.Stderr, result, nil); err != nil {
+				return err
 			}
+			return fleetError(cmd, result)
 		},
 	}
 
 	// Add flags specific to the deployments list command
 	// These give users fine-grained control over what they want to see
-	cmd.Flags().String("clusters", "", "comma-separated list of cluster names (default: all clusters)")
+	cmd.Flags().String("clusters", "", "comma-separated list of cluster names or @groups (default: all clusters)")
 	cmd.Flags().StringP("namespace", "n", "", "namespace to list deployments from (default: all namespaces)")
+	cmd.Flags().Bool("compact", false, "show one summary row per cluster (total, ready, not ready)")
+	cmd.Flags().Bool("only-unhealthy", false, "only show deployments that are not Ready")
+	cmd.Flags().String("sort-by", "name", "sort order: name (cluster/namespace/name), replicas (most first), unready (most missing replicas first)")
+	cmd.Flags().Int("limit", 0, "show at most N deployments across the whole fleet, after sorting (0 = no limit)")
+	cmd.Flags().Bool("full-image", false, "never truncate the IMAGE column, so tags and digests stay visible (other columns stay compact)")
+	addLocationFlags(cmd)
+	addGroupByFlag(cmd)
+	addManagedOnlyFlag(cmd)
+	addExcludeNamespaceFlag(cmd)
+	addListTimeoutFlags(cmd)
+	addWatchFlags(cmd)
 
 	return cmd
 }
 
-// outputDeploymentsTable displays deployment information in a human-readable table
-// This is the most common output format - designed for quick visual scanning
-func outputDeploymentsTable(deployments []workload.DeploymentInfo) error {
-	if len(deployments) == 0 {
-		fmt.Println("No deployments found in the specified clusters and namespaces.")
-		return nil
+// renderDeploymentList prints one listing of the fleet's deployments, applying
+// the list command's filtering, sorting and output flags
+// Notices meant for a human (a truncated list, failures in name output) go to errOut.
+// In watch mode, previous holds the statuses from the last listing so the table
+// can flag what changed
+func renderDeploymentList(cmd *cobra.Command, out, errOut io.Writer, result workload.FleetResult[workload.DeploymentInfo], previous map[string]string) error {
+	outputFormat := viper.GetString("output")
+	deployments := withoutNamespaces(result.Items, excludedNamespaces(cmd, cmd.Flag("namespace").Value.String() == ""),
+		func(deployment workload.DeploymentInfo) string { return deployment.Namespace })
+
+	// During incidents only the broken deployments matter
+	if onlyUnhealthy, _ := cmd.Flags().GetBool("only-unhealthy"); onlyUnhealthy {
+		deployments = filterUnhealthyDeployments(deployments)
 	}
 
-	// Create a tab-aligned table writer for professional-looking output
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	defer w.Flush()
+	// Sort deployments for consistent output
+	if err := sortDeployments(deployments, cmd.Flag("sort-by").Value.String()); err != nil {
+		return err
+	}
 
-	// Print table headers - these provide context for each column
-	fmt.Fprintln(w, "CLUSTER\tNAMESPACE\tNAME\tREPLICAS\tSTATUS\tIMAGE\tAGE")
-	fmt.Fprintln(w, "-------\t---------\t----\t--------\t------\t-----\t---")
+	// Compact mode collapses everything into one row per cluster
+	if compact, _ := cmd.Flags().GetBool("compact"); compact {
+		return outputClusterSummaries(out, summarizeDeploymentsByCluster(deployments, result.Errors), outputFormat)
+	}
 
-	for _, deployment := range deployments {
-		// Handle error cases gracefully - show what we can, indicate what failed
-		if deployment.Error != "" {
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-				deployment.ClusterName,
-				"-",
-				"ERROR",
-				"-",
-				"❌ "+deployment.Error,
-				"-",
-				"-",
-			)
-			continue
-		}
+	// Cap the fleet-wide total after sorting, so the result is a deterministic top-N
+	limit, _ := cmd.Flags().GetInt("limit")
+	if limit < 0 {
+		return fmt.Errorf("--limit must not be negative, got %d", limit)
+	}
+	if limit > 0 && len(deployments) > limit {
+		fmt.Fprintf(errOut, "Showing %d of %d deployments (--limit=%d)\n", limit, len(deployments), limit)
+		deployments = deployments[:limit]
+	}
 
-		// Format the replica information to show current vs desired
-		// This is crucial for understanding deployment health at a glance
-		replicas := fmt.Sprintf("%d/%d", deployment.ReadyReplicas, deployment.Replicas)
-
-		// Add visual indicators for deployment status
-		// These make it easy to quickly spot problems in a long list
-		var statusIcon string
-		switch deployment.Status {
-		case "Ready":
-			statusIcon = "✅ " + deployment.Status
-		case "Partial":
-			statusIcon = "⚠️  " + deployment.Status
-		case "NotReady":
-			statusIcon = "❌ " + deployment.Status
-		default:
-			statusIcon = "❓ " + deployment.Status
+	// Output in the requested format
+	switch outputFormat {
+	case "json":
+		return output.DeploymentsJSON(out, deployments, result.ErrorMessages())
+	case "yaml":
+		return output.DeploymentsYAML(out, deployments, result.ErrorMessages())
+	case "name":
+		// Keep failures out of the pipe
+		printFleetFailures(errOut, result)
+		return output.Names(out, deploymentNames(deployments))
+	default:
+		fullImage, _ := cmd.Flags().GetBool("full-image")
+		groupBy, _ := cmd.Flags().GetString("group-by")
+		opts := output.DeploymentsTableOptions{FullImage: fullImage, GroupBy: groupBy, PreviousStatus: previous}
+		if err := output.DeploymentsTable(out, deployments, opts); err != nil {
+			return err
 		}
+		printFleetFailures(out, result)
+		return nil
+	}
+}
 
-		// Truncate long image names to keep the table readable
-		// Full image names can be very long with registry URLs and SHA digests
-		image := deployment.Image
-		if len(image) > 40 {
-			// Keep the image name but truncate the middle part
-			// This preserves the most important parts (registry and tag)
-			parts := strings.Split(image, "/")
-			if len(parts) > 1 {
-				image = parts[0] + "/..." + parts[len(parts)-1]
+// newDeploymentsVerifyCmd creates the 'deployments verify' subcommand
+// This catches deployments whose status looks healthy but whose pods tell a different story
+func newDeploymentsVerifyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Cross-check deployment status against the pods it selects",
+		Long: `Verify that every deployment's reported readiness is backed by real pods.
+For each deployment this lists the pods matching its selector and compares
+them with the ready replica count the deployment reports.
+
+A deployment showing 3/3 ready while its selector matches no pods points to
+a label/selector mismatch - traffic-routing services built on the same labels
+will be silently broken even though 'deployments list' looks green.
+
+Verification statuses:
+- OK: ready replicas are backed by ready pods matching the selector
+- Orphaned: the deployment reports ready replicas but its selector matches no pods
+- Mismatch: fewer ready pods match the selector than the deployment reports
+
+The command exits non-zero when any deployment is flagged, so it can run in CI.
+
+Examples:
+  mcm deployments verify
+  mcm deployments verify --clusters=prod-us,prod-eu --namespace=production
+  mcm deployments verify --output=json | jq '.deployments[] | select(.status!="OK")'`,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusters := parseClusterList(cmd.Flag("clusters").Value.String())
+			namespace := cmd.Flag("namespace").Value.String()
+
+			result := workloadManager.VerifyDeployments(clusters, namespace)
+			verifications := result.Items
+			sort.Slice(verifications, func(i, j int) bool {
+				if verifications[i].ClusterName != verifications[j].ClusterName {
+					return verifications[i].ClusterName < verifications[j].ClusterName
+				}
+				if verifications[i].Namespace != verifications[j].Namespace {
+					return verifications[i].Namespace < verifications[j].Namespace
+				}
+				return verifications[i].Name < verifications[j].Name
+			})
+
+			if err := outputDeploymentVerifications(verifications, result.ErrorMessages(), viper.GetString("output")); err != nil {
+				return err
 			}
-			if len(image) > 40 {
-				image = image[:37] + "..."
+			if viper.GetString("output") != "json" && viper.GetString("output") != "yaml" {
+				printFleetFailures(os.Stdout, result)
 			}
-		}
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-			deployment.ClusterName,
-			deployment.Namespace,
-			deployment.Name,
-			replicas,
-			statusIcon,
-			image,
-			deployment.Age,
-		)
+			flagged := 0
+			for _, verification := range verifications {
+				if verification.Flagged() {
+					flagged++
+				}
+			}
+			if flagged > 0 {
+				return fmt.Errorf("%d deployments failed verification", flagged)
+			}
+			// A cluster we couldn't check can't be called verified
+			if len(result.Errors) > 0 {
+				return fmt.Errorf("%d clusters could not be verified", len(result.Errors))
+			}
+			return nil
+		},
 	}
 
-	// Print a summary line to give context about what was shown
-	fmt.Printf("\nFound %d deployments across %d clusters\n",
-		len(deployments), countUniqueClusters(deployments))
+	cmd.Flags().String("clusters", "", "comma-separated list of cluster names or @groups (default: all clusters)")
+	cmd.Flags().StringP("namespace", "n", "", "namespace to verify (default: all namespaces)")
 
-	return nil
+	return cmd
 }
 
-// outputDeploymentsJSON formats deployment information as JSON
-// This is useful for automation, scripting, or integration with other tools
-func outputDeploymentsJSON(deployments []workload.DeploymentInfo) error {
-	// Wrap the deployments in a structure that provides metadata
-	// This makes the JSON output more useful for programmatic consumption
-	output := struct {
-		Deployments []workload.DeploymentInfo `json:"deployments"`
-		Count       int                       `json:"count"`
-		Clusters    []string                  `json:"clusters"`
-	}{
-		Deployments: deployments,
-		Count:       len(deployments),
-		Clusters:    getUniqueClusters(deployments),
+// outputDeploymentVerifications renders verification results in the requested format
+func outputDeploymentVerifications(verifications []workload.DeploymentVerification, failures map[string]string, outputFormat string) error {
+	switch outputFormat {
+	case "json":
+		jsonData, err := json.MarshalIndent(struct {
+			Deployments []workload.DeploymentVerification `json:"deployments"`
+			Errors      map[string]string                 `json:"errors,omitempty"`
+		}{Deployments: verifications, Errors: failures}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal verification results to JSON: %w", err)
+		}
+		fmt.Println(string(jsonData))
+		return nil
+	case "yaml":
+		yamlData, err := yaml.Marshal(struct {
+			Deployments []workload.DeploymentVerification `json:"deployments"`
+			Errors      map[string]string                 `json:"errors,omitempty"`
+		}{Deployments: verifications, Errors: failures})
+		if err != nil {
+			return fmt.Errorf("failed to marshal verification results to YAML: %w", err)
+		}
+		fmt.Print(string(yamlData))
+		return nil
 	}
 
-	// Use indented JSON for readability when humans are viewing it
-	jsonData, err := json.MarshalIndent(output, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal deployments to JSON: %w", err)
+	if len(verifications) == 0 {
+		fmt.Println("No deployments found in the specified clusters and namespaces.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "CLUSTER\tNAMESPACE\tNAME\tREADY REPLICAS\tMATCHING PODS\tREADY PODS\tSTATUS")
+	fmt.Fprintln(w, "-------\t---------\t----\t--------------\t-------------\t----------\t------")
+
+	for _, verification := range verifications {
+		if verification.Error != "" {
+			fmt.Fprintf(w, "%s\t%s\t%s\t-\t-\t-\t❌ %s\n",
+				verification.ClusterName,
+				getValueOrDefault(verification.Namespace, "-"),
+				getValueOrDefault(verification.Name, "ERROR"),
+				verification.Error,
+			)
+			continue
+		}
+
+		status := "✅ " + verification.Status
+		if verification.Flagged() {
+			status = "❌ " + verification.Status
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%d\t%s\n",
+			verification.ClusterName,
+			verification.Namespace,
+			verification.Name,
+			verification.ReadyReplicas,
+			verification.MatchingPods,
+			verification.ReadyPods,
+			status,
+		)
+	}
+	w.Flush()
+
+	// Spell out the selectors of flagged deployments - that's where the fix usually is
+	fmt.Println()
+	for _, verification := range verifications {
+		if verification.Flagged() && verification.Error == "" {
+			fmt.Printf("⚠️  %s/%s/%s selector %q\n",
+				verification.ClusterName, verification.Namespace, verification.Name, verification.Selector)
+		}
 	}
 
-	fmt.Println(string(jsonData))
 	return nil
 }
 
-// outputDeploymentsYAML formats deployment information as YAML
-// Some users prefer YAML for its readability and comments support
-func outputDeploymentsYAML(deployments []workload.DeploymentInfo) error {
-	output := struct {
-		Deployments []workload.DeploymentInfo `yaml:"deployments"`
-		Count       int                       `yaml:"count"`
-		Clusters    []string                  `yaml:"clusters"`
-	}{
-		Deployments: deployments,
-		Count:       len(deployments),
-		Clusters:    getUniqueClusters(deployments),
-	}
+// addListTimeoutFlags registers the fan-out timeout flags shared by list commands
+func addListTimeoutFlags(cmd *cobra.Command) {
+	cmd.Flags().Duration("timeout-per-cluster", workload.DefaultPerClusterTimeout, "maximum time to wait for any single cluster")
+	cmd.Flags().Duration("timeout", 0, "maximum time for the whole query; clusters that haven't answered are reported as timed out (0 = no limit)")
+}
 
-	yamlData, err := yaml.Marshal(output)
-	if err != nil {
-		return fmt.Errorf("failed to marshal deployments to YAML: %w", err)
+// applyListTimeouts passes the fan-out timeout flags on to the workload manager
+func applyListTimeouts(cmd *cobra.Command) error {
+	perCluster, _ := cmd.Flags().GetDuration("timeout-per-cluster")
+	total, _ := cmd.Flags().GetDuration("timeout")
+	if perCluster <= 0 {
+		return fmt.Errorf("--timeout-per-cluster must be positive, got %s", perCluster)
+	}
+	if total < 0 {
+		return fmt.Errorf("--timeout must not be negative, got %s", total)
 	}
 
-	fmt.Print(string(yamlData))
+	workloadManager.SetTimeouts(workload.Timeouts{PerCluster: perCluster, Total: total})
 	return nil
 }
 
+// addManagedOnlyFlag registers --managed-only, which limits a command to resources mcm owns
+func addManagedOnlyFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("managed-only", false, "only act on resources carrying the managedByLabel from the config (default app.kubernetes.io/managed-by=mcm)")
+}
+
+// addGroupByFlag registers --group-by, which splits a list table into one sub-table per group
+func addGroupByFlag(cmd *cobra.Command) {
+	cmd.Flags().String("group-by", "", "split the table into one sub-table per cluster or namespace, each with its own summary (cluster, namespace)")
+}
+
+// parseGroupBy reads and checks --group-by
+// Grouping only shapes the table: JSON and YAML carry the fields to group on
+// already, and compact mode is one row per cluster to begin with
+func parseGroupBy(cmd *cobra.Command) (string, error) {
+	groupBy, _ := cmd.Flags().GetString("group-by")
+	if groupBy == "" {
+		return "", nil
+	}
+	if err := output.ValidateGroupBy(groupBy); err != nil {
+		return "", err
+	}
+	if compact, _ := cmd.Flags().GetBool("compact"); compact {
+		return "", fmt.Errorf("--group-by cannot be combined with --compact")
+	}
+	if format := viper.GetString("output"); format != "table" {
+		return "", fmt.Errorf("--group-by only applies to table output, not --output=%s", format)
+	}
+	return groupBy, nil
+}
+
+// managedOnlySelector adds the ownership label to a label selector when --managed-only is set
+// The label was validated when the config was loaded, so it can be used as-is
+func managedOnlySelector(cmd *cobra.Command, selector string) string {
+	if managedOnly, _ := cmd.Flags().GetBool("managed-only"); !managedOnly {
+		return selector
+	}
+	if selector == "" {
+		return appConfig.ManagedByLabel
+	}
+	return selector + "," + appConfig.ManagedByLabel
+}
+
 // parseClusterList converts a comma-separated string into a slice of cluster names
 // This handles user input like "prod-us,prod-eu,staging" and cleans it up
+// An @group entry stands for every cluster in that config group
 func parseClusterList(clusterString string) []string {
+	result := splitClusterList(clusterString)
+
+	// loadAppConfig has already refused unknown groups in the flags, so an
+	// expansion error only leaves the entry as it was, to fail as an unknown cluster
+	if appConfig != nil {
+		if expanded, err := appConfig.ExpandClusterGroups(result); err == nil {
+			result = expanded
+		}
+	}
+	return result
+}
+
+// splitClusterList splits a comma-separated list of clusters without expanding groups
+func splitClusterList(clusterString string) []string {
 	if clusterString == "" {
 		return nil // Return nil to indicate "all clusters"
 	}
@@ -273,29 +496,69 @@ func parseClusterList(clusterString string) []string {
 	return result
 }
 
-// countUniqueClusters counts how many different clusters are represented in the results
-// This is useful for summary information
-func countUniqueClusters(deployments []workload.DeploymentInfo) int {
-	clusters := make(map[string]bool)
-	for _, deployment := range deployments {
-		clusters[deployment.ClusterName] = true
+// checkClusterGroups refuses an unknown @group in --clusters, --exclude or
+// copy's --to up front, before any cluster is dialed, naming the groups that do exist
+func checkClusterGroups(cmd *cobra.Command, cfg *config.MultiClusterConfig) error {
+	for _, name := range []string{"clusters", "exclude", "to"} {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			continue
+		}
+		if _, err := cfg.ExpandClusterGroups(splitClusterList(flag.Value.String())); err != nil {
+			return fmt.Errorf("--%s: %w", name, err)
+		}
 	}
-	return len(clusters)
+	return nil
 }
 
-// getUniqueClusters returns a sorted list of unique cluster names from the deployments
-// This is useful for metadata in JSON/YAML output
-func getUniqueClusters(deployments []workload.DeploymentInfo) []string {
-	clusterSet := make(map[string]bool)
-	for _, deployment := range deployments {
-		clusterSet[deployment.ClusterName] = true
+// sortDeployments orders deployments by the requested key
+// Ties always fall back to cluster, namespace and name so output is deterministic
+func sortDeployments(deployments []workload.DeploymentInfo, sortBy string) error {
+	byName := func(i, j int) bool {
+		// We sort by cluster name first, then by namespace, then by deployment name
+		// This makes it easy to scan the output and find specific deployments
+		if deployments[i].ClusterName != deployments[j].ClusterName {
+			return deployments[i].ClusterName < deployments[j].ClusterName
+		}
+		if deployments[i].Namespace != deployments[j].Namespace {
+			return deployments[i].Namespace < deployments[j].Namespace
+		}
+		return deployments[i].Name < deployments[j].Name
 	}
 
-	var clusters []string
-	for cluster := range clusterSet {
-		clusters = append(clusters, cluster)
+	switch sortBy {
+	case "", "name":
+		sort.Slice(deployments, byName)
+	case "replicas":
+		sort.Slice(deployments, func(i, j int) bool {
+			if deployments[i].Replicas != deployments[j].Replicas {
+				return deployments[i].Replicas > deployments[j].Replicas
+			}
+			return byName(i, j)
+		})
+	case "unready":
+		sort.Slice(deployments, func(i, j int) bool {
+			missingI := deployments[i].Replicas - deployments[i].ReadyReplicas
+			missingJ := deployments[j].Replicas - deployments[j].ReadyReplicas
+			if missingI != missingJ {
+				return missingI > missingJ
+			}
+			return byName(i, j)
+		})
+	default:
+		return fmt.Errorf("unknown --sort-by value %q (supported: name, replicas, unready)", sortBy)
 	}
+	return nil
+}
 
-	sort.Strings(clusters)
-	return clusters
+// filterUnhealthyDeployments keeps deployments that are not fully Ready
+// Unreachable clusters don't need keeping here - they're reported in the failure footer
+func filterUnhealthyDeployments(deployments []workload.DeploymentInfo) []workload.DeploymentInfo {
+	var unhealthy []workload.DeploymentInfo
+	for _, deployment := range deployments {
+		if deployment.Status != "Ready" {
+			unhealthy = append(unhealthy, deployment)
+		}
+	}
+	return unhealthy
 }
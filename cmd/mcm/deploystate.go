@@ -0,0 +1,138 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+// deployState remembers which clusters the last deploy of a manifest failed on
+// This is the note on the fridge after a partial rollout: "these ones still
+// need doing", so --retry-failed can finish the job without touching the rest
+type deployState struct {
+	Manifest     string    `json:"manifest"`
+	ManifestHash string    `json:"manifestHash"`
+	Namespace    string    `json:"namespace"`
+	Failed       []string  `json:"failed"`
+	DeployedAt   time.Time `json:"deployedAt"`
+}
+
+// deployStatePath returns where the state for a manifest is stored
+// The file is keyed by the manifest's absolute path, so each manifest has its own
+func deployStatePath(manifest string) (string, error) {
+	dir, err := config.CacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	abs, err := filepath.Abs(manifest)
+	if err != nil {
+		abs = manifest
+	}
+	sum := sha256.Sum256([]byte(abs))
+	return filepath.Join(dir, "deploy-"+hex.EncodeToString(sum[:8])+".json"), nil
+}
+
+// loadDeployState reads the state for a manifest, returning nil if none exists
+func loadDeployState(manifest string) (*deployState, error) {
+	path, err := deployStatePath(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	state := &deployState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// saveDeployState records the clusters a deploy failed on, or forgets the
+// manifest entirely once nothing is left to retry
+func saveDeployState(manifest string, state *deployState) error {
+	path, err := deployStatePath(manifest)
+	if err != nil {
+		return err
+	}
+
+	if len(state.Failed) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// manifestHash fingerprints manifest content so a retry can tell if it was edited
+func manifestHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// isDeployWarning reports whether a deploy error is benign, like the resource already existing
+func isDeployWarning(err error) bool {
+	message := err.Error()
+	return strings.Contains(message, "already exists") || strings.Contains(message, "no changes")
+}
+
+// failedDeployClusters lists the clusters a deploy should be retried on, sorted
+// Warnings only count when the deploy treated them as failures
+func failedDeployClusters(results map[string]error, failOnWarning bool) []string {
+	var failed []string
+	for name, err := range results {
+		if err == nil || (isDeployWarning(err) && !failOnWarning) {
+			continue
+		}
+		failed = append(failed, name)
+	}
+	sort.Strings(failed)
+	return failed
+}
+
+// retryTargets picks the clusters for --retry-failed from the last deploy of a manifest
+// Clusters removed from the configuration since then are dropped with a note
+func retryTargets(state *deployState, yamlFile string, content []byte, known func(name string) bool) ([]string, error) {
+	if state == nil || len(state.Failed) == 0 {
+		return nil, fmt.Errorf("no failed clusters recorded for %s; nothing to retry", yamlFile)
+	}
+
+	if state.ManifestHash != manifestHash(content) {
+		fmt.Fprintf(os.Stderr, "Note: %s has changed since the failed deploy at %s; retrying with the current content\n",
+			yamlFile, state.DeployedAt.Format(time.RFC3339))
+	}
+
+	var targets []string
+	for _, name := range state.Failed {
+		if !known(name) {
+			fmt.Fprintf(os.Stderr, "Skipping cluster '%s': it is no longer in configuration\n", name)
+			continue
+		}
+		targets = append(targets, name)
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("none of the clusters that failed are configured any more")
+	}
+	return targets, nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestFailedDeployClusters(t *testing.T) {
+	results := map[string]error{
+		"prod-us": nil,
+		"prod-eu": errors.New("failed to create deployment: connection refused"),
+		"staging": errors.New("deployment default/web already exists"),
+	}
+
+	if got := failedDeployClusters(results, false); !reflect.DeepEqual(got, []string{"prod-eu"}) {
+		t.Errorf("Expected only the hard failure, got %v", got)
+	}
+	if got := failedDeployClusters(results, true); !reflect.DeepEqual(got, []string{"prod-eu", "staging"}) {
+		t.Errorf("Expected warnings to count with --fail-on-warning, got %v", got)
+	}
+}
+
+func TestDeployStateRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	content := []byte("kind: Deployment\n")
+	state := &deployState{Manifest: "app.yaml", ManifestHash: manifestHash(content), Namespace: "web", Failed: []string{"old", "prod-eu"}}
+	if err := saveDeployState("app.yaml", state); err != nil {
+		t.Fatalf("saveDeployState failed: %v", err)
+	}
+
+	loaded, err := loadDeployState("app.yaml")
+	if err != nil || loaded == nil {
+		t.Fatalf("loadDeployState failed: %v", err)
+	}
+	targets, err := retryTargets(loaded, "app.yaml", content, func(name string) bool { return name != "old" })
+	if err != nil || !reflect.DeepEqual(targets, []string{"prod-eu"}) {
+		t.Errorf("Expected to retry only configured failed clusters, got %v (%v)", targets, err)
+	}
+
+	// A fully successful retry clears the record
+	if err := saveDeployState("app.yaml", &deployState{}); err != nil {
+		t.Fatalf("saveDeployState failed: %v", err)
+	}
+	path, _ := deployStatePath("app.yaml")
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected state file to be removed, got %v", err)
+	}
+	if _, err := retryTargets(nil, "app.yaml", content, func(string) bool { return true }); err == nil {
+		t.Error("Expected an error when there is nothing to retry")
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"sigs.k8s.io/yaml"
+
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// newPodsDescribeCmd creates the 'pods describe' subcommand
+// This answers the question 'pods list' raises: "1/2 ready - but why?"
+func newPodsDescribeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "describe POD_NAME",
+		Short: "Explain a pod's readiness: probes, conditions and probe failures",
+		Long: `Show why a pod is (or isn't) Ready. For each container this lists its state,
+readiness and the readiness, liveness and startup probes that decide it - the
+probe action (http-get, tcp-socket, exec or grpc) with its delay, timeout,
+period and success/failure thresholds.
+
+Below that come the pod conditions, including any readiness gates declared in
+spec.readinessGates (a gate nobody has reported on keeps the pod unready), and
+the probe failures the kubelet recently recorded as events.
+
+Every connected cluster is searched unless --clusters narrows it down.
+
+Examples:
+  mcm pods describe web-7d4b9c-x2k8p                       # Default namespace, all clusters
+  mcm pods describe web-7d4b9c-x2k8p -n production --clusters=prod-eu
+  mcm pods describe web-7d4b9c-x2k8p --output=json`,
+
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			podName := args[0]
+			clusters := parseClusterList(cmd.Flag("clusters").Value.String())
+			namespace := cmd.Flag("namespace").Value.String()
+			if namespace == "" {
+				namespace = appConfig.DefaultNamespace
+			}
+
+			result := workloadManager.DescribePod(clusters, namespace, podName)
+
+			switch viper.GetString("output") {
+			case "json":
+				jsonData, err := json.MarshalIndent(struct {
+					Pods   []workload.PodDescription `json:"pods"`
+					Errors map[string]string         `json:"errors,omitempty"`
+				}{Pods: result.Items, Errors: result.ErrorMessages()}, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal pod description to JSON: %w", err)
+				}
+				fmt.Println(string(jsonData))
+			case "yaml":
+				yamlData, err := yaml.Marshal(struct {
+					Pods   []workload.PodDescription `json:"pods"`
+					Errors map[string]string         `json:"errors,omitempty"`
+				}{Pods: result.Items, Errors: result.ErrorMessages()})
+				if err != nil {
+					return fmt.Errorf("failed to marshal pod description to YAML: %w", err)
+				}
+				fmt.Print(string(yamlData))
+			default:
+				if len(result.Items) == 0 {
+					fmt.Printf("Pod %s not found in namespace %s.\n", podName, namespace)
+				}
+				for _, description := range result.Items {
+					outputPodDescription(description)
+				}
+				printFleetFailures(os.Stdout, result)
+			}
+
+			if len(result.Items) == 0 && len(result.Errors) == 0 {
+				return fmt.Errorf("pod %s not found in namespace %s", podName, namespace)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("clusters", "", "comma-separated list of cluster names or @groups (default: all connected clusters)")
+	cmd.Flags().StringP("namespace", "n", "", "namespace of the pod (default: from config)")
+
+	return cmd
+}
+
+// outputPodDescription prints one pod's readiness explanation, section by section
+func outputPodDescription(description workload.PodDescription) {
+	fmt.Printf("Pod %s/%s in cluster %s\n", description.Namespace, description.Name, description.ClusterName)
+	fmt.Printf("  Node: %s   Phase: %s   Ready: %s   Age: %s\n\n",
+		getValueOrDefault(description.Node, "unscheduled"), description.Phase, description.Ready, description.Age)
+
+	fmt.Println("Containers:")
+	for _, container := range description.Containers {
+		ready := "✅ ready"
+		if !container.Ready {
+			ready = "❌ not ready"
+		}
+		fmt.Printf("  %s (%s)\n", container.Name, container.Image)
+		fmt.Printf("    State: %s   %s   Restarts: %d\n", container.State, ready, container.Restarts)
+		if len(container.Probes) == 0 {
+			fmt.Println("    Probes: none (ready as soon as the container runs)")
+		}
+		for _, probe := range container.Probes {
+			fmt.Printf("    %-10s %s\n", probe.Kind+":", probe)
+		}
+	}
+	fmt.Println()
+
+	fmt.Println("Conditions:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "  TYPE\tSTATUS\tREASON\tMESSAGE")
+	for _, condition := range description.Conditions {
+		conditionType := condition.Type
+		if condition.Gate {
+			conditionType += " (readiness gate)"
+		}
+		status := condition.Status
+		if status != "True" {
+			status = "❌ " + status
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n",
+			conditionType, status, getValueOrDefault(condition.Reason, "-"), getValueOrDefault(condition.Message, "-"))
+	}
+	w.Flush()
+	fmt.Println()
+
+	if len(description.ProbeFailures) == 0 {
+		fmt.Println("Probe failures: none recorded")
+		fmt.Println()
+		return
+	}
+	fmt.Println("Recent probe failures:")
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "  LAST SEEN\tCOUNT\tMESSAGE")
+	for _, event := range description.ProbeFailures {
+		fmt.Fprintf(w, "  %s\t%d\t%s\n", event.Age, event.Count, event.Message)
+	}
+	w.Flush()
+	fmt.Println()
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"sigs.k8s.io/yaml"
+
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// newDiffClustersCmd creates the diff-clusters command
+// This is the migration checklist: before moving traffic from one cluster to another,
+// prove that the destination namespace really holds the same things as the source
+func newDiffClustersCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff-clusters",
+		Short: "Compare a namespace's contents between two clusters",
+		Long: `Compare the deployments, services and configmaps of one namespace in two
+clusters and report:
+
+- resources that exist only in the first cluster
+- resources that exist only in the second cluster
+- resources present in both but different, with the differing fields
+
+Fields the API server assigns per cluster are ignored - UIDs, resource versions,
+timestamps, status, service cluster IPs and node ports - so two clusters running
+the same manifests compare as identical. The per-namespace kube-root-ca.crt
+configmap is skipped because it always differs.
+
+Examples:
+  mcm diff-clusters --clusters=prod-us,prod-eu --namespace=app
+  mcm diff-clusters --clusters=old-prod,new-prod -n payments --output=json`,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusters := parseClusterList(cmd.Flag("clusters").Value.String())
+			if len(clusters) != 2 {
+				return fmt.Errorf("--clusters must name exactly two clusters, e.g. --clusters=prod-us,prod-eu")
+			}
+			if clusters[0] == clusters[1] {
+				return fmt.Errorf("--clusters must name two different clusters")
+			}
+
+			namespace := cmd.Flag("namespace").Value.String()
+			if namespace == "" {
+				namespace = appConfig.DefaultNamespace
+			}
+
+			diff, err := workloadManager.DiffClusters(clusters[0], clusters[1], namespace)
+			if err != nil {
+				return err
+			}
+
+			switch viper.GetString("output") {
+			case "json":
+				jsonData, err := json.MarshalIndent(diff, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal diff to JSON: %w", err)
+				}
+				fmt.Println(string(jsonData))
+				return nil
+			case "yaml":
+				yamlData, err := yaml.Marshal(diff)
+				if err != nil {
+					return fmt.Errorf("failed to marshal diff to YAML: %w", err)
+				}
+				fmt.Print(string(yamlData))
+				return nil
+			default:
+				outputClusterDiff(diff)
+				return nil
+			}
+		},
+	}
+
+	cmd.Flags().String("clusters", "", "the two clusters to compare, e.g. prod-us,prod-eu")
+	cmd.Flags().StringP("namespace", "n", "", "namespace to compare (default: from config)")
+
+	return cmd
+}
+
+// outputClusterDiff prints the three-way report section by section
+func outputClusterDiff(diff *workload.ClusterDiff) {
+	fmt.Printf("Comparing namespace %s: %s (A) vs %s (B)\n\n", diff.Namespace, diff.ClusterA, diff.ClusterB)
+	outputDiffSections(diff)
+}
+
+// outputDiffSections prints what is only on either side and what differs, then a summary
+func outputDiffSections(diff *workload.ClusterDiff) {
+	sections := []struct {
+		status string
+		title  string
+	}{
+		{workload.DiffOnlyInA, "Only in " + diff.ClusterA},
+		{workload.DiffOnlyInB, "Only in " + diff.ClusterB},
+		{workload.DiffDifferent, "Different"},
+	}
+
+	for _, section := range sections {
+		count := diff.Count(section.status)
+		if count == 0 {
+			continue
+		}
+
+		fmt.Printf("%s (%d):\n", section.title, count)
+		for _, resource := range diff.Resources {
+			if resource.Status != section.status {
+				continue
+			}
+			fmt.Printf("  %s/%s\n", resource.Kind, resource.Name)
+			for _, field := range resource.Fields {
+				fmt.Printf("      %s\n        A: %s\n        B: %s\n", field.Path, field.A, field.B)
+			}
+		}
+		fmt.Println()
+	}
+
+	if len(diff.Resources) == 0 {
+		fmt.Printf("✅ No differences: %d resources identical\n", diff.Identical)
+		return
+	}
+	fmt.Printf("Summary: %d only in %s, %d only in %s, %d different, %d identical\n",
+		diff.Count(workload.DiffOnlyInA), diff.ClusterA,
+		diff.Count(workload.DiffOnlyInB), diff.ClusterB,
+		diff.Count(workload.DiffDifferent), diff.Identical)
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"sigs.k8s.io/yaml"
+
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// newDeploymentsEnvCmd creates the 'deployments env' subcommand
+// The most common small change to a running app is an environment variable,
+// so this is kubectl set env, once for the whole fleet
+func newDeploymentsEnvCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "env DEPLOYMENT [KEY=VALUE | KEY-]...",
+		Short: "View or change a deployment's environment variables across clusters",
+		Long: `Without changes, list the environment variables of a deployment's containers
+in every cluster it runs in, so drift between clusters is easy to spot. Values
+taken from a Secret or ConfigMap show the reference, never the secret itself.
+
+With changes, patch the deployment's pod template in each target cluster, like
+kubectl set env:
+- KEY=VALUE sets a variable (replacing a Secret/ConfigMap reference if there was one)
+- KEY- removes a variable
+
+Each cluster reports every variable's before and after. Where anything changed,
+Kubernetes starts the normal rolling update; where nothing changed, the
+deployment is left alone and no pods are restarted.
+
+Changes go to the clusters chosen the same way as for 'mcm deploy' (--clusters,
+--all-clusters, --exclude, otherwise the default cluster), and clusters under a
+change freeze are skipped unless --ignore-freeze is given. Viewing covers every
+connected cluster unless --clusters narrows it down.
+
+Use --container to pick one container; by default every container is shown or changed.
+
+Examples:
+  mcm deployments env web -n production                            # Compare across clusters
+  mcm deployments env web -n production --all-clusters LOG_LEVEL=debug
+  mcm deployments env web -n production --clusters=prod-eu FEATURE_X- # Remove a variable
+  mcm deployments env web --container=sidecar --all-clusters PROXY_TIMEOUT=30s`,
+
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			container := cmd.Flag("container").Value.String()
+			namespace := cmd.Flag("namespace").Value.String()
+			if namespace == "" {
+				namespace = appConfig.DefaultNamespace
+			}
+
+			if len(args) == 1 {
+				result := workloadManager.ListDeploymentEnv(parseClusterList(cmd.Flag("clusters").Value.String()), namespace, name, container)
+				return outputEnvVars(result, name, namespace)
+			}
+
+			assignments, err := workload.ParseEnvAssignments(args[1:])
+			if err != nil {
+				return err
+			}
+
+			clusters, err := parseDeploymentTargets(cmd)
+			if err != nil {
+				return fmt.Errorf("failed to determine target clusters: %w", err)
+			}
+			ignoreFreeze, _ := cmd.Flags().GetBool("ignore-freeze")
+			clusters, err = filterFrozenClusters(clusters, namespace, ignoreFreeze)
+			if err != nil {
+				return err
+			}
+
+			if err := confirmChanges(cmd, "change the environment of deployment "+name, clusters); err != nil {
+				return err
+			}
+
+			result := workloadManager.SetDeploymentEnv(clusters, namespace, name, container, assignments)
+			if err := outputEnvChanges(result); err != nil {
+				return err
+			}
+			if len(result.Errors) > 0 {
+				return fmt.Errorf("environment not updated on %d of %d clusters", len(result.Errors), len(clusters))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("clusters", "", "comma-separated list of cluster names or @groups")
+	cmd.Flags().Bool("all-clusters", false, "change the deployment in all connected clusters")
+	cmd.Flags().String("exclude", "", "comma-separated list of clusters or @groups to exclude (used with --all-clusters)")
+	cmd.Flags().StringP("namespace", "n", "", "namespace of the deployment (default from config)")
+	cmd.Flags().String("container", "", "only show or change this container (default: all containers)")
+	cmd.Flags().Bool("ignore-freeze", false, "change the deployment even in clusters or namespaces under a change freeze")
+	addYesFlag(cmd)
+
+	return cmd
+}
+
+// outputEnvVars renders a deployment's environment across clusters
+func outputEnvVars(result workload.FleetResult[workload.EnvVarInfo], name, namespace string) error {
+	vars := result.Items
+	sort.SliceStable(vars, func(i, j int) bool {
+		if vars[i].ClusterName != vars[j].ClusterName {
+			return vars[i].ClusterName < vars[j].ClusterName
+		}
+		return vars[i].Container < vars[j].Container // Keep each container's own variable order
+	})
+
+	switch viper.GetString("output") {
+	case "json":
+		jsonData, err := json.MarshalIndent(struct {
+			Env    []workload.EnvVarInfo `json:"env"`
+			Errors map[string]string     `json:"errors,omitempty"`
+		}{Env: vars, Errors: result.ErrorMessages()}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal environment to JSON: %w", err)
+		}
+		fmt.Println(string(jsonData))
+		return nil
+	case "yaml":
+		yamlData, err := yaml.Marshal(struct {
+			Env    []workload.EnvVarInfo `json:"env"`
+			Errors map[string]string     `json:"errors,omitempty"`
+		}{Env: vars, Errors: result.ErrorMessages()})
+		if err != nil {
+			return fmt.Errorf("failed to marshal environment to YAML: %w", err)
+		}
+		fmt.Print(string(yamlData))
+		return nil
+	}
+
+	if len(vars) == 0 {
+		fmt.Printf("No environment variables found for deployment %s in namespace %s.\n", name, namespace)
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "CLUSTER\tCONTAINER\tNAME\tVALUE")
+		fmt.Fprintln(w, "-------\t---------\t----\t-----")
+		for _, env := range vars {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", env.ClusterName, env.Container, env.Name, env.Value)
+		}
+		w.Flush()
+	}
+	printFleetFailures(os.Stdout, result)
+	return nil
+}
+
+// outputEnvChanges renders the per-cluster before/after of an env update
+func outputEnvChanges(result workload.FleetResult[workload.EnvVarChange]) error {
+	changes := result.Items
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].ClusterName != changes[j].ClusterName {
+			return changes[i].ClusterName < changes[j].ClusterName
+		}
+		return changes[i].Container < changes[j].Container
+	})
+
+	switch viper.GetString("output") {
+	case "json":
+		jsonData, err := json.MarshalIndent(struct {
+			Changes []workload.EnvVarChange `json:"changes"`
+			Errors  map[string]string       `json:"errors,omitempty"`
+		}{Changes: changes, Errors: result.ErrorMessages()}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal environment changes to JSON: %w", err)
+		}
+		fmt.Println(string(jsonData))
+		return nil
+	case "yaml":
+		yamlData, err := yaml.Marshal(struct {
+			Changes []workload.EnvVarChange `json:"changes"`
+			Errors  map[string]string       `json:"errors,omitempty"`
+		}{Changes: changes, Errors: result.ErrorMessages()})
+		if err != nil {
+			return fmt.Errorf("failed to marshal environment changes to YAML: %w", err)
+		}
+		fmt.Print(string(yamlData))
+		return nil
+	}
+
+	writeEnvChanges(os.Stdout, changes)
+	printFleetFailures(os.Stdout, result)
+	return nil
+}
+
+// writeEnvChanges prints the before/after table and which clusters are rolling out
+func writeEnvChanges(out io.Writer, changes []workload.EnvVarChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "CLUSTER\tCONTAINER\tNAME\tCHANGE\tBEFORE\tAFTER")
+	fmt.Fprintln(w, "-------\t---------\t----\t------\t------\t-----")
+
+	var rolling []string
+	for _, change := range changes {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", change.ClusterName, change.Container, change.Name,
+			change.Change, getValueOrDefault(change.Before, "-"), getValueOrDefault(change.After, "-"))
+
+		if change.Change != workload.EnvUnchanged &&
+			(len(rolling) == 0 || rolling[len(rolling)-1] != change.ClusterName) {
+			rolling = append(rolling, change.ClusterName)
+		}
+	}
+	w.Flush()
+
+	fmt.Fprintln(out)
+	if len(rolling) == 0 {
+		fmt.Fprintln(out, "Nothing changed; no rollout was started.")
+		return
+	}
+	fmt.Fprintf(out, "Rolling update started on %d cluster(s): %s\n", len(rolling), strings.Join(rolling, ", "))
+}
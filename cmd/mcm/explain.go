@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/celikgo/autoz-control-tower/internal/output"
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// explainDeploy prints what a deploy is about to do, in plain words
+// Clusters where the same thing will happen share one sentence, e.g.
+// "This will update Deployment 'web' (replicas 3→5) in namespace 'prod' on clusters: prod-us, prod-eu"
+func explainDeploy(out io.Writer, plan *workload.DeployPlan, opts workload.DeployOptions) {
+	var sentences []string
+	clustersBySentence := make(map[string][]string)
+	for _, clusterPlan := range plan.Clusters {
+		sentence := planSentence(plan, clusterPlan)
+		if _, seen := clustersBySentence[sentence]; !seen {
+			sentences = append(sentences, sentence)
+		}
+		clustersBySentence[sentence] = append(clustersBySentence[sentence], clusterPlan.Cluster)
+	}
+
+	lines := make([]string, 0, len(sentences))
+	for _, sentence := range sentences {
+		clusters := clustersBySentence[sentence]
+		noun := "clusters"
+		if len(clusters) == 1 {
+			noun = "cluster"
+		}
+		lines = append(lines, fmt.Sprintf("%s on %s: %s", sentence, noun, strings.Join(clusters, ", ")))
+	}
+
+	if len(lines) == 1 {
+		fmt.Fprintf(out, "This will %s\n", lines[0])
+	} else {
+		fmt.Fprintln(out, "This will:")
+		for _, line := range lines {
+			fmt.Fprintf(out, "  - %s\n", line)
+		}
+	}
+
+	if opts.Wait {
+		timeout := opts.WaitTimeout
+		if timeout <= 0 {
+			timeout = workload.DefaultRolloutTimeout
+		}
+		fmt.Fprintf(out, "It will then wait up to %s in each cluster for the rollout to finish.\n", timeout)
+	}
+}
+
+// planSentence phrases one cluster's planned action, without the cluster name
+func planSentence(plan *workload.DeployPlan, clusterPlan workload.ClusterPlan) string {
+	subject := fmt.Sprintf("%s '%s'", plan.Kind, plan.Name)
+	where := fmt.Sprintf("in namespace '%s'", plan.Namespace)
+
+	switch clusterPlan.Action {
+	case workload.PlanCreate:
+		if clusterPlan.CreateNamespace {
+			return fmt.Sprintf("create namespace '%s' and %s in it", plan.Namespace, subject)
+		}
+		return fmt.Sprintf("create %s %s", subject, where)
+	case workload.PlanUpdate:
+		return fmt.Sprintf("update %s (%s) %s", subject, strings.Join(clusterPlan.Changes, ", "), where)
+	case workload.PlanUnchanged:
+		return fmt.Sprintf("leave %s %s unchanged (it already matches)", subject, where)
+	case workload.PlanRefuse:
+		return fmt.Sprintf("fail to deploy %s %s because %s", subject, where, clusterPlan.Reason)
+	default:
+		return fmt.Sprintf("try to deploy %s %s, but its current state couldn't be read (%s)", subject, where, clusterPlan.Reason)
+	}
+}
+
+// writePlanDiffs prints, after the explanation, how each cluster's live object
+// differs from the manifest. Clusters whose diffs are identical - the usual case
+// for a fleet running the same version - share one diff instead of repeating it
+func writePlanDiffs(out io.Writer, plan *workload.DeployPlan, opts output.DiffOptions) error {
+	type pair struct{ live, desired string }
+	var order []pair
+	clustersByPair := make(map[pair][]string)
+	for _, clusterPlan := range plan.Clusters {
+		if clusterPlan.Desired == "" {
+			continue // Nothing will be written there
+		}
+		key := pair{clusterPlan.Live, clusterPlan.Desired}
+		if _, seen := clustersByPair[key]; !seen {
+			order = append(order, key)
+		}
+		clustersByPair[key] = append(clustersByPair[key], clusterPlan.Cluster)
+	}
+
+	for _, key := range order {
+		clusters := strings.Join(clustersByPair[key], ", ")
+		fmt.Fprintf(out, "\nDiff for %s:\n", clusters)
+
+		liveName := fmt.Sprintf("%s/%s (live)", plan.Namespace, plan.Name)
+		if key.live == "" {
+			liveName = "(does not exist)"
+		}
+		if err := output.TextDiff(out, liveName, "manifest", key.live, key.desired, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deployDiffOptions reads and checks the --diff tuning flags
+// On a terminal, side-by-side columns are fitted to its width and the diff is
+// colored, unless NO_COLOR is set
+func deployDiffOptions(cmd *cobra.Command) (output.DiffOptions, error) {
+	contextLines, _ := cmd.Flags().GetInt("context-lines")
+	if contextLines < 0 {
+		return output.DiffOptions{}, fmt.Errorf("--context-lines must not be negative, got %d", contextLines)
+	}
+
+	format := cmd.Flag("diff-format").Value.String()
+	if format != output.DiffUnified && format != output.DiffSideBySide {
+		return output.DiffOptions{}, fmt.Errorf("unknown --diff-format %q (supported: %s, %s)", format, output.DiffUnified, output.DiffSideBySide)
+	}
+
+	opts := output.DiffOptions{Format: format, ContextLines: contextLines}
+	if isTerminal() {
+		if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+			opts.Width = width
+		}
+		opts.Color = os.Getenv("NO_COLOR") == ""
+	}
+	return opts, nil
+}
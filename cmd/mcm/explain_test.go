@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+func TestExplainDeployGroupsClustersBySentence(t *testing.T) {
+	plan := &workload.DeployPlan{Kind: "Deployment", Name: "web", Namespace: "prod", Clusters: []workload.ClusterPlan{
+		{Cluster: "prod-eu", Action: workload.PlanUpdate, Changes: []string{"replicas 3→5"}},
+		{Cluster: "prod-us", Action: workload.PlanUpdate, Changes: []string{"replicas 3→5"}},
+	}}
+
+	var out bytes.Buffer
+	explainDeploy(&out, plan, workload.DeployOptions{})
+
+	want := "This will update Deployment 'web' (replicas 3→5) in namespace 'prod' on clusters: prod-eu, prod-us\n"
+	if out.String() != want {
+		t.Errorf("Expected %q, got %q", want, out.String())
+	}
+}
+
+func TestExplainDeployListsDifferingClusters(t *testing.T) {
+	plan := &workload.DeployPlan{Kind: "Deployment", Name: "web", Namespace: "prod", Clusters: []workload.ClusterPlan{
+		{Cluster: "prod-eu", Action: workload.PlanCreate, CreateNamespace: true},
+		{Cluster: "prod-us", Action: workload.PlanUnchanged},
+	}}
+
+	var out bytes.Buffer
+	explainDeploy(&out, plan, workload.DeployOptions{Wait: true})
+
+	want := "This will:\n" +
+		"  - create namespace 'prod' and Deployment 'web' in it on cluster: prod-eu\n" +
+		"  - leave Deployment 'web' in namespace 'prod' unchanged (it already matches) on cluster: prod-us\n" +
+		"It will then wait up to " + workload.DefaultRolloutTimeout.String() + " in each cluster for the rollout to finish.\n"
+	if out.String() != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, out.String())
+	}
+}
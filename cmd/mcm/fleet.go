@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/celikgo/autoz-control-tower/internal/output"
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// printFleetFailures prints the footer naming clusters that failed a fleet-wide
+// query, with each full error underneath when --verbose is set
+func printFleetFailures[T any](out io.Writer, result workload.FleetResult[T]) {
+	output.FleetFailures(out, result, viper.GetBool("verbose"))
+}
+
+// fleetError fails a listing command when any cluster couldn't be queried, so
+// scripts and CI pipelines see partial results in the exit code. The data and
+// the failure footer are already printed by then, so usage help would be noise
+func fleetError[T any](cmd *cobra.Command, result workload.FleetResult[T]) error {
+	err := result.Err()
+	if err != nil {
+		cmd.SilenceUsage = true
+	}
+	return err
+}
+
+// addLocationFlags registers --environment and --region, which target clusters
+// by the environment and region the config tags them with
+func addLocationFlags(cmd *cobra.Command) {
+	cmd.Flags().String("environment", "", "only clusters in this environment, e.g. production (case-insensitive)")
+	cmd.Flags().String("region", "", "only clusters in this region, e.g. us-east-1 (case-insensitive)")
+}
+
+// addExcludeNamespaceFlag registers --exclude-namespace for listings that can span all namespaces
+func addExcludeNamespaceFlag(cmd *cobra.Command) {
+	cmd.Flags().StringArray("exclude-namespace", nil,
+		"leave this namespace out when listing all namespaces, e.g. kube-system (repeatable; replaces the config's excludeNamespaces, --exclude-namespace= shows them all)")
+}
+
+// excludedNamespaces is what an all-namespace listing leaves out: the
+// --exclude-namespace flags when given, otherwise the config's excludeNamespaces
+// A listing of one namespace asked for it by name, so it excludes nothing
+func excludedNamespaces(cmd *cobra.Command, allNamespaces bool) map[string]bool {
+	if !allNamespaces {
+		return nil
+	}
+	names := appConfig.ExcludeNamespaces
+	if cmd.Flags().Changed("exclude-namespace") {
+		names, _ = cmd.Flags().GetStringArray("exclude-namespace")
+	}
+
+	excluded := make(map[string]bool, len(names))
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			excluded[name] = true
+		}
+	}
+	return excluded
+}
+
+// withoutNamespaces drops the items in excluded namespaces, keeping the order
+func withoutNamespaces[T any](items []T, excluded map[string]bool, namespaceOf func(T) string) []T {
+	if len(excluded) == 0 {
+		return items
+	}
+	kept := items[:0:0]
+	for _, item := range items {
+		if !excluded[namespaceOf(item)] {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// targetClusters resolves --clusters, --environment and --region to the clusters
+// a command should query; nil still means every cluster
+func targetClusters(cmd *cobra.Command) ([]string, error) {
+	environment, _ := cmd.Flags().GetString("environment")
+	region, _ := cmd.Flags().GetString("region")
+	named := parseClusterList(cmd.Flag("clusters").Value.String())
+	if environment == "" && region == "" {
+		return named, nil
+	}
+	return selectClusters(named, environment, region, clusterManager.ClustersByEnvironment)
+}
+
+// selectClusters narrows the fleet to the clusters in an environment and region,
+// and, when clusters were also named, to the named ones among them. Matching
+// nothing is an error rather than quietly falling back to the whole fleet
+func selectClusters(named []string, environment, region string, byEnvironment func(environment, region string) []string) ([]string, error) {
+	matching := byEnvironment(environment, region)
+	if len(named) > 0 {
+		inLocation := make(map[string]bool, len(matching))
+		for _, name := range matching {
+			inLocation[name] = true
+		}
+		matching = nil
+		for _, name := range named {
+			if inLocation[name] {
+				matching = append(matching, name)
+			}
+		}
+	}
+
+	if len(matching) == 0 {
+		var filters []string
+		if environment != "" {
+			filters = append(filters, "--environment="+environment)
+		}
+		if region != "" {
+			filters = append(filters, "--region="+region)
+		}
+		if len(named) > 0 {
+			filters = append(filters, "--clusters="+strings.Join(named, ","))
+		}
+		return nil, fmt.Errorf("no configured clusters match %s", strings.Join(filters, " "))
+	}
+	return matching, nil
+}
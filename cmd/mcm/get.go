@@ -0,0 +1,230 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/celikgo/autoz-control-tower/internal/output"
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// newGetCmd creates the get command
+// deployments and pods have hand-made tables; this is the escape hatch for
+// everything else - ingresses, jobs, CRDs - printed the way each cluster's API
+// server prints it for kubectl
+func newGetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get RESOURCE [NAME]",
+		Short: "Show any resource across clusters, as kubectl get would",
+		Long: `Show any kind of resource from every connected cluster (or the ones given
+with --clusters) in one table, with a CLUSTER column in front.
+
+RESOURCE is anything kubectl get accepts: a plural (ingresses), a singular
+(ingress), a short name (ing), or a group-qualified name
+(certificates.cert-manager.io). It is resolved against each cluster's own
+API, so custom resources work wherever their CRD is installed.
+
+The columns are the ones the API server prints for kubectl, CRD printer
+columns included; --wide adds the extra columns kubectl shows with -o wide.
+Clusters that print the same columns share one table; a resource whose
+columns differ between clusters (a CRD at two versions, say) gets a table per
+set of columns. A few aggregated APIs can't print tables at all - for those
+clusters only NAME and AGE are shown, and a note says which.
+
+With -A, namespaces given with --exclude-namespace (or the config's
+excludeNamespaces) are left out.
+
+--output=json and --output=yaml print the full objects, each with its
+cluster; --output=object-yaml prints them as plain multi-document YAML, like
+kubectl get -o yaml, annotated with mcm.io/cluster; --output=name prints
+cluster/namespace/name, one per line.
+
+Examples:
+  mcm get ingresses -n production
+  mcm get cronjobs -A --clusters=prod-us,prod-eu
+  mcm get certificates.cert-manager.io -A --wide
+  mcm get jobs -A --exclude-namespace=kube-system --exclude-namespace=monitoring
+  mcm get nodes -l node-role.kubernetes.io/control-plane
+  mcm get configmap app-settings -n production --output=yaml`,
+		Args: cobra.RangeArgs(1, 2),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resource := args[0]
+			name := ""
+			if len(args) == 2 {
+				name = args[1]
+			}
+
+			clusters := parseClusterList(cmd.Flag("clusters").Value.String())
+			allNamespaces, _ := cmd.Flags().GetBool("all-namespaces")
+			wide, _ := cmd.Flags().GetBool("wide")
+			opts := workload.GetOptions{
+				Namespace:     cmd.Flag("namespace").Value.String(),
+				LabelSelector: cmd.Flag("selector").Value.String(),
+				FieldSelector: cmd.Flag("field-selector").Value.String(),
+				Wide:          wide,
+			}
+
+			if allNamespaces {
+				if name != "" {
+					return fmt.Errorf("--all-namespaces can't be used with a NAME; give its namespace with --namespace")
+				}
+				if cmd.Flags().Changed("namespace") {
+					return fmt.Errorf("--all-namespaces and --namespace can't be used together")
+				}
+				opts.Namespace = ""
+			} else if opts.Namespace == "" {
+				opts.Namespace = appConfig.DefaultNamespace
+			}
+
+			// Catch typos locally instead of getting the same error from every cluster
+			if opts.LabelSelector != "" {
+				if _, err := labels.Parse(opts.LabelSelector); err != nil {
+					return fmt.Errorf("invalid label selector %q: %w", opts.LabelSelector, err)
+				}
+			}
+			if opts.FieldSelector != "" {
+				if _, err := fields.ParseSelector(opts.FieldSelector); err != nil {
+					return fmt.Errorf("invalid field selector %q: %w", opts.FieldSelector, err)
+				}
+			}
+
+			if err := applyListTimeouts(cmd); err != nil {
+				return err
+			}
+
+			excluded := excludedNamespaces(cmd, allNamespaces)
+
+			switch outputFormat := viper.GetString("output"); outputFormat {
+			case "object-yaml":
+				return writeObjectsYAML(workloadManager.GetObjects(clusters, resource, name, opts), excluded)
+			case "json", "yaml":
+				result := workloadManager.GetObjects(clusters, resource, name, opts)
+				objects := sortedObjects(result.Items, excluded)
+				if outputFormat == "yaml" {
+					return output.ResourcesYAML(os.Stdout, objects, result.ErrorMessages())
+				}
+				return output.ResourcesJSON(os.Stdout, objects, result.ErrorMessages())
+			case "name":
+				result := workloadManager.GetTables(clusters, resource, name, opts)
+				// Keep failures out of the pipe
+				printFleetFailures(os.Stderr, result)
+				return output.Names(os.Stdout, resourceNames(tablesWithoutNamespaces(sortedTables(result.Items), excluded)))
+			default:
+				result := workloadManager.GetTables(clusters, resource, name, opts)
+				tables := tablesWithoutNamespaces(sortedTables(result.Items), excluded)
+				if err := output.ResourcesTable(os.Stdout, tables, output.ResourcesTableOptions{AllNamespaces: allNamespaces}); err != nil {
+					return err
+				}
+				printUnprintedNote(tables)
+				printFleetFailures(os.Stdout, result)
+				return nil
+			}
+		},
+	}
+
+	cmd.Flags().String("clusters", "", "comma-separated list of cluster names or @groups (default: all connected clusters)")
+	cmd.Flags().StringP("namespace", "n", "", "namespace to get from (default: the configured default namespace)")
+	cmd.Flags().BoolP("all-namespaces", "A", false, "get from all namespaces")
+	addExcludeNamespaceFlag(cmd)
+	cmd.Flags().StringP("selector", "l", "", "label selector to filter by (e.g., 'app=nginx,tier=frontend')")
+	cmd.Flags().String("field-selector", "", "field selector to filter by server-side (e.g., 'metadata.name=web')")
+	cmd.Flags().Bool("wide", false, "also show the columns kubectl only shows with -o wide")
+	addListTimeoutFlags(cmd)
+
+	return cmd
+}
+
+// sortedObjects drops the objects in excluded namespaces and orders the rest
+// by cluster, keeping each cluster's own order
+func sortedObjects(objects []workload.ClusterObject, excluded map[string]bool) []workload.ClusterObject {
+	objects = withoutNamespaces(objects, excluded, func(object workload.ClusterObject) string {
+		namespace, _, _ := unstructured.NestedString(object.Object, "metadata", "namespace")
+		return namespace
+	})
+	sort.SliceStable(objects, func(i, j int) bool { return objects[i].ClusterName < objects[j].ClusterName })
+	return objects
+}
+
+// writeObjectsYAML prints fetched objects for --output=object-yaml
+// Failures go to stderr, so stdout stays a stream of manifests
+func writeObjectsYAML(result workload.FleetResult[workload.ClusterObject], excluded map[string]bool) error {
+	printFleetFailures(os.Stderr, result)
+	return output.ObjectsYAML(os.Stdout, sortedObjects(result.Items, excluded))
+}
+
+// listObjectsYAML is --output=object-yaml for the list commands: it fetches
+// the full objects of resource with the same clusters and filters the list
+// would use, prints them, and fails like the list when a cluster couldn't be queried
+func listObjectsYAML(cmd *cobra.Command, clusters []string, resource string, opts workload.GetOptions, excluded map[string]bool) error {
+	result := workloadManager.GetObjects(clusters, resource, "", opts)
+	if err := writeObjectsYAML(result, excluded); err != nil {
+		return err
+	}
+	return fleetError(cmd, result)
+}
+
+// rejectObjectYAMLFlags refuses the list flags that work on mcm's summary of
+// the objects - sorting, limiting, health filters - since --output=object-yaml
+// prints the objects themselves
+func rejectObjectYAMLFlags(cmd *cobra.Command, flags ...string) error {
+	for _, flag := range flags {
+		if cmd.Flags().Changed(flag) {
+			return fmt.Errorf("--%s can't be used with --output=object-yaml, which prints the objects as they are", flag)
+		}
+	}
+	return nil
+}
+
+// sortedTables orders the per-cluster tables by cluster name, so output is stable
+// across runs despite the clusters answering in any order
+func sortedTables(tables []workload.ResourceTable) []workload.ResourceTable {
+	sort.SliceStable(tables, func(i, j int) bool { return tables[i].ClusterName < tables[j].ClusterName })
+	return tables
+}
+
+// tablesWithoutNamespaces drops the rows of objects in excluded namespaces
+func tablesWithoutNamespaces(tables []workload.ResourceTable, excluded map[string]bool) []workload.ResourceTable {
+	for i := range tables {
+		tables[i].Rows = withoutNamespaces(tables[i].Rows, excluded, func(row workload.ResourceRow) string { return row.Namespace })
+	}
+	return tables
+}
+
+// resourceNames lists every row as cluster/namespace/name, or cluster/name
+// for cluster-scoped resources
+func resourceNames(tables []workload.ResourceTable) []string {
+	var names []string
+	for _, table := range tables {
+		for _, row := range table.Rows {
+			if row.Namespace == "" {
+				names = append(names, table.ClusterName+"/"+row.Name)
+				continue
+			}
+			names = append(names, table.ClusterName+"/"+row.Namespace+"/"+row.Name)
+		}
+	}
+	return names
+}
+
+// printUnprintedNote says which clusters only got NAME and AGE, so missing
+// columns aren't mistaken for empty ones
+func printUnprintedNote(tables []workload.ResourceTable) {
+	var unprinted []string
+	for _, table := range tables {
+		if !table.ServerPrinted {
+			unprinted = append(unprinted, table.ClusterName)
+		}
+	}
+	if len(unprinted) > 0 {
+		fmt.Fprintf(os.Stderr, "Note: %s did not print a table for this resource; only NAME and AGE are shown\n",
+			summarizeNames(unprinted, 5))
+	}
+}
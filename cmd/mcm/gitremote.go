@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bufio"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// gitSource locates dir within a git checkout: the origin remote, normalized
+// so every clone of the repository agrees on it, and dir's path from the
+// checkout root, with slashes. ok is false outside a checkout, or in one
+// without an origin remote
+// The files are read directly rather than through git, which may not be
+// installed, and in CI often refuses a checkout another user owns
+func gitSource(dir string) (remote, relative string, ok bool) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", "", false
+	}
+
+	for root := abs; ; root = filepath.Dir(root) {
+		if gitDir := resolveGitDir(filepath.Join(root, ".git")); gitDir != "" {
+			remote = normalizeGitRemote(readOriginURL(filepath.Join(gitDir, "config")))
+			if remote == "" {
+				return "", "", false
+			}
+			relative, err = filepath.Rel(root, abs)
+			if err != nil {
+				return "", "", false
+			}
+			return remote, filepath.ToSlash(relative), true
+		}
+		if filepath.Dir(root) == root {
+			return "", "", false
+		}
+	}
+}
+
+// resolveGitDir returns the directory holding a checkout's config, given its
+// .git entry: the directory itself, or for a worktree or submodule, where its
+// "gitdir:" file points (and that repository's common directory, if it has one)
+func resolveGitDir(dotGit string) string {
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		return ""
+	}
+	if info.IsDir() {
+		return dotGit
+	}
+
+	data, err := os.ReadFile(dotGit)
+	if err != nil {
+		return ""
+	}
+	gitDir, found := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !found {
+		return ""
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(filepath.Dir(dotGit), gitDir)
+	}
+	if common, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		commonDir := strings.TrimSpace(string(common))
+		if !filepath.IsAbs(commonDir) {
+			commonDir = filepath.Join(gitDir, commonDir)
+		}
+		return commonDir
+	}
+	return gitDir
+}
+
+// readOriginURL returns remote.origin.url from a git config file, or ""
+func readOriginURL(configPath string) string {
+	file, err := os.Open(configPath)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	inOrigin := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			inOrigin = strings.Join(strings.Fields(line), " ") == `[remote "origin"]`
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if inOrigin && found && strings.TrimSpace(key) == "url" {
+			return strings.Trim(strings.TrimSpace(value), `"`)
+		}
+	}
+	return ""
+}
+
+// normalizeGitRemote reduces a remote URL to host/path, so the SSH clone on a
+// laptop and the HTTPS clone in CI - with a per-job token in it - agree:
+// git@github.com:org/repo.git and https://token@github.com/org/repo both
+// become github.com/org/repo
+func normalizeGitRemote(remote string) string {
+	if remote == "" {
+		return ""
+	}
+
+	var host, path string
+	if parsed, err := url.Parse(remote); err == nil && parsed.Host != "" {
+		host, path = parsed.Hostname(), parsed.Path
+	} else if at, rest, found := strings.Cut(remote, ":"); found && len(at) > 1 && !strings.Contains(at, "/") {
+		// scp-like syntax: [user@]host:path
+		host, path = at[strings.LastIndex(at, "@")+1:], rest
+	} else {
+		// A local path: as good as it gets
+		return filepath.ToSlash(remote)
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	return strings.ToLower(host) + "/" + path
+}
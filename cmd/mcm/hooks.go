@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+// Hook names, passed to every hook as MCM_HOOK
+const (
+	hookPreDeploy  = "pre-deploy"
+	hookPostDeploy = "post-deploy"
+)
+
+// deployHookContext is what the hooks are told about a deploy
+type deployHookContext struct {
+	Manifest  string
+	Namespace string
+	Clusters  []string
+	User      string
+
+	// Set for the post-deploy hook only
+	Succeeded bool
+	Failed    []string // Clusters the deploy failed on, sorted
+}
+
+// addHookFlags registers the flags that set or override the config's deploy hooks
+func addHookFlags(cmd *cobra.Command) {
+	cmd.Flags().String("pre-deploy", "", "shell command to run before deploying; if it fails, nothing is deployed (overrides hooks.preDeploy)")
+	cmd.Flags().String("post-deploy", "", "shell command to run after deploying, told the outcome in MCM_RESULT (overrides hooks.postDeploy)")
+	cmd.Flags().Bool("ignore-hook-errors", false, "report failing hooks as warnings instead of failing the command (like hooks.ignoreErrors)")
+	cmd.Flags().Bool("allow-hooks", false, "run hooks and notifications set in a project config (.mcm.yaml or ./mcm-config.yaml), which are refused otherwise")
+}
+
+// checkProjectHooks refuses hooks and notifications that would come from a
+// config found in the working directory (a project config, or
+// ./mcm-config.yaml) unless --allow-hooks is given. A repository's config may
+// pick clusters once trusted, but running its shell commands, or sending
+// results to its webhook, takes a separate opt-in. Settings the flags
+// override aren't used, so they don't count, and only --allow-hooks on the
+// command line does: a default for it would come from the very config it
+// vouches for
+func checkProjectHooks(cmd *cobra.Command, cfg *config.MultiClusterConfig) error {
+	if cfg.ProjectConfig == "" {
+		return nil
+	}
+	if allow, _ := cmd.Flags().GetBool("allow-hooks"); allow && cmd.Flags().Changed("allow-hooks") {
+		return nil
+	}
+
+	var fields []string
+	if cfg.Hooks.PreDeploy != "" && !cmd.Flags().Changed("pre-deploy") {
+		fields = append(fields, "hooks.preDeploy")
+	}
+	if cfg.Hooks.PostDeploy != "" && !cmd.Flags().Changed("post-deploy") {
+		fields = append(fields, "hooks.postDeploy")
+	}
+	if cfg.Notify.Webhook != "" && !cmd.Flags().Changed("notify-webhook") {
+		fields = append(fields, "notify.webhook")
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s sets %s; these don't run from a config found in the working directory unless you pass --allow-hooks",
+		cfg.ProjectConfig, strings.Join(fields, ", "))
+}
+
+// deployHooks combines the config's hooks with the flags, which win
+func deployHooks(cmd *cobra.Command, configured config.DeployHooks) config.DeployHooks {
+	hooks := configured
+	if cmd.Flags().Changed("pre-deploy") {
+		hooks.PreDeploy, _ = cmd.Flags().GetString("pre-deploy")
+	}
+	if cmd.Flags().Changed("post-deploy") {
+		hooks.PostDeploy, _ = cmd.Flags().GetString("post-deploy")
+	}
+	if ignore, _ := cmd.Flags().GetBool("ignore-hook-errors"); ignore {
+		hooks.IgnoreErrors = true
+	}
+	return hooks
+}
+
+// env describes the deploy to a hook through MCM_* variables
+func (c deployHookContext) env(hook string) []string {
+	env := []string{
+		"MCM_HOOK=" + hook,
+		"MCM_MANIFEST=" + c.Manifest,
+		"MCM_NAMESPACE=" + c.Namespace,
+		"MCM_CLUSTERS=" + strings.Join(c.Clusters, ","),
+		"MCM_USER=" + c.User,
+	}
+	if hook == hookPostDeploy {
+		result := "failure"
+		if c.Succeeded {
+			result = "success"
+		}
+		env = append(env, "MCM_RESULT="+result, "MCM_FAILED_CLUSTERS="+strings.Join(c.Failed, ","))
+	}
+	return env
+}
+
+// runHook runs one hook command through the shell, with its output going
+// straight to the terminal so whatever it prints reads as part of the deploy
+func runHook(hook, command string, hookContext deployHookContext) error {
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+
+	fmt.Printf("Running %s hook: %s\n", hook, command)
+	run := exec.Command(shell, flag, command)
+	run.Env = append(os.Environ(), hookContext.env(hook)...)
+	run.Stdout = os.Stdout
+	run.Stderr = os.Stderr
+	if err := run.Run(); err != nil {
+		return fmt.Errorf("%s hook failed: %w", hook, err)
+	}
+	return nil
+}
+
+// hookFailure decides what a failed hook means for the command: with
+// IgnoreErrors it's only a warning, otherwise it's the command's error
+func hookFailure(hooks config.DeployHooks, err error) error {
+	if err == nil {
+		return nil
+	}
+	if hooks.IgnoreErrors {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: %v (ignored)\n", err)
+		return nil
+	}
+	return err
+}
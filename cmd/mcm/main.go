@@ -1,11 +1,14 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"sigs.k8s.io/yaml"
 
 	"github.com/celikgo/autoz-control-tower/internal/cluster"
 	"github.com/celikgo/autoz-control-tower/internal/config"
@@ -43,9 +46,11 @@ Examples:
 
 Configuration:
   MCM looks for configuration in these locations (in order):
-  1. ./mcm-config.yaml (current directory)
-  2. ~/.mcm/config.yaml (user home directory)
-  3. $XDG_CONFIG_HOME/mcm/config.yaml (XDG config directory)
+  1. .mcm.yaml in the current directory or any parent (project config), when
+     its directory is in the user config's trustedProjects or with --trust-project-config
+  2. ./mcm-config.yaml (current directory)
+  3. ~/.mcm/config.yaml (user home directory)
+  4. $XDG_CONFIG_HOME/mcm/config.yaml (XDG config directory)
 
   Use 'mcm config init' to create a sample configuration file.`,
 
@@ -54,16 +59,59 @@ Configuration:
 	// connections upfront so individual commands execute quickly
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		// Initialize configuration
-		configPath := viper.GetString("config")
-		cfg, err := config.LoadConfig(configPath)
+		if err := loadAppConfig(cmd); err != nil {
+			return err
+		}
+		cfg := appConfig
+
+		// Initialize cluster manager (this establishes all cluster connections,
+		// or in lazy mode leaves each one until a command first needs it)
+		connectMode := cfg.ConnectMode
+		if cmd.Flags().Changed("connect-mode") {
+			connectMode, _ = cmd.Flags().GetString("connect-mode")
+			if err := config.ValidateConnectMode(connectMode); err != nil {
+				return fmt.Errorf("--connect-mode: %w", err)
+			}
+		}
+		opts := cluster.Options{
+			ImpersonateUser:   viper.GetString("as"),
+			ImpersonateGroups: viper.GetStringSlice("as-group"),
+			ContextSwitchSafe: viper.GetBool("context-switch-safe") || cfg.ContextSwitchSafe,
+			Verbose:           viper.GetBool("verbose"),
+			ExecWrapper:       execWrapper,
+			Lazy:              connectMode == config.ConnectLazy,
+		}
+		if !opts.Lazy {
+			fmt.Fprintf(os.Stderr, "Connecting to clusters...\n")
+		}
+		if opts.ImpersonateUser != "" {
+			fmt.Fprintf(os.Stderr, "Impersonating %s on all clusters\n", opts.ImpersonateUser)
+		}
+
+		// Don't let a cluster that's been down all morning cost every command the full
+		// connect timeout: skip clusters that failed moments ago until they cool down
+		breaker, err := loadConnectBreaker()
 		if err != nil {
-			return fmt.Errorf("failed to load configuration: %w", err)
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		if force, _ := cmd.Flags().GetBool("force-reconnect"); !force {
+			opts.Skip = breaker.skips(cfg.Clusters, time.Now())
+		}
+		opts.OnConnect = func(client *cluster.ClusterClient) {
+			breaker.record(client, time.Now())
+			// Lazy connections happen while the command runs, long after the save below
+			if opts.Lazy {
+				if err := breaker.save(); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to save connection state: %v\n", err)
+				}
+			}
 		}
-		appConfig = cfg
 
-		// Initialize cluster manager (this establishes all cluster connections)
-		fmt.Printf("Connecting to clusters...\n")
-		mgr, err := cluster.NewManager(cfg)
+		mgr, err := cluster.NewManagerWithOptions(cfg, opts)
+		breaker.prune(cfg.Clusters)
+		if saveErr := breaker.save(); saveErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save connection state: %v\n", saveErr)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to initialize cluster manager: %w", err)
 		}
@@ -71,12 +119,64 @@ Configuration:
 
 		// Initialize workload manager
 		workloadManager = workload.NewManager(clusterManager)
+		workloadManager.SetListPageSize(appConfig.ListPageSize)
 
 		return nil
 	},
 }
 
+// loadAppConfig loads the configuration into appConfig and applies the
+// per-command defaults from it, without connecting to any cluster
+func loadAppConfig(cmd *cobra.Command) error {
+	configPath := viper.GetString("config")
+	cfg, err := config.LoadConfigWithOptions(configPath, config.LoadOptions{
+		SkipInvalidClusters: viper.GetBool("skip-invalid-clusters"),
+		Strict:              viper.GetBool("strict-config"),
+		TrustProjectConfig:  viper.GetBool("trust-project-config"),
+	})
+	if err != nil {
+		// A single broken entry is worth working around until it's fixed
+		var invalid *config.InvalidClusterError
+		if errors.As(err, &invalid) {
+			return fmt.Errorf("failed to load configuration: %w (use --skip-invalid-clusters to load the other clusters)", err)
+		}
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	appConfig = cfg
+
+	// Per-command flag defaults from the config, e.g. json for 'deployments list'
+	if err := applyCommandDefaults(cmd, cfg.Defaults); err != nil {
+		return err
+	}
+	if err := checkClusterGroups(cmd, cfg); err != nil {
+		return err
+	}
+
+	// Show exactly what configuration would be used, then stop before connecting
+	if dump, _ := cmd.Flags().GetBool("dump-config"); dump {
+		data, err := yaml.Marshal(cfg.Redacted())
+		if err != nil {
+			return fmt.Errorf("failed to marshal effective configuration: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "# Effective configuration (after defaults and overrides)\n%s", data)
+		os.Exit(0)
+	}
+
+	return nil
+}
+
+// execWrapper is this binary, which credential plugins are run through (see
+// cluster.ServeExecWrapper); main sets it, so tests run plugins directly
+var execWrapper string
+
 func main() {
+	if code, served := cluster.ServeExecWrapper(os.Args); served {
+		os.Exit(code)
+	}
+	if self, err := os.Executable(); err == nil {
+		execWrapper = self
+	}
+
 	// Execute the root command - this starts the entire CLI application
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -91,7 +191,16 @@ func init() {
 	// Global flags that apply to all commands
 	rootCmd.PersistentFlags().String("config", "", "config file path (default: auto-detect)")
 	rootCmd.PersistentFlags().Bool("verbose", false, "enable verbose output")
-	rootCmd.PersistentFlags().String("output", "table", "output format (table, json, yaml)")
+	rootCmd.PersistentFlags().String("output", "table", "output format (table, json, yaml, name; object-yaml for the full objects, on get and the list commands)")
+	rootCmd.PersistentFlags().Bool("dump-config", false, "print the fully-resolved configuration to stderr and exit")
+	rootCmd.PersistentFlags().String("as", "", "username to impersonate on every cluster, e.g. system:serviceaccount:ns:name")
+	rootCmd.PersistentFlags().StringArray("as-group", nil, "group to impersonate on every cluster (repeatable; requires --as)")
+	rootCmd.PersistentFlags().String("connect-mode", "", "eager: connect to every cluster before running; lazy: connect to each cluster when the command first needs it (overrides connectMode; default eager)")
+	rootCmd.PersistentFlags().Bool("force-reconnect", false, "dial every cluster, including ones skipped because they failed to connect moments ago")
+	rootCmd.PersistentFlags().Bool("skip-invalid-clusters", false, "load the valid clusters when some config entries are broken (e.g. a missing kubeconfig), warning about the rest")
+	rootCmd.PersistentFlags().Bool("strict-config", false, "refuse an ambiguous configuration, such as several default clusters, instead of warning and picking one")
+	rootCmd.PersistentFlags().Bool("trust-project-config", false, "use a .mcm.yaml found in this directory or a parent instead of the user config, even if its directory isn't in trustedProjects")
+	rootCmd.PersistentFlags().Bool("context-switch-safe", false, "refuse clusters whose context resolves to a server other than their configured server/serverPattern")
 
 	// Bind flags to viper for configuration management
 	// We check these errors because flag binding can fail if flag names don't match
@@ -107,14 +216,42 @@ func init() {
 	if err := viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output")); err != nil {
 		panic(fmt.Sprintf("failed to bind output flag: %v", err))
 	}
+	if err := viper.BindPFlag("as", rootCmd.PersistentFlags().Lookup("as")); err != nil {
+		panic(fmt.Sprintf("failed to bind as flag: %v", err))
+	}
+	if err := viper.BindPFlag("as-group", rootCmd.PersistentFlags().Lookup("as-group")); err != nil {
+		panic(fmt.Sprintf("failed to bind as-group flag: %v", err))
+	}
+	if err := viper.BindPFlag("context-switch-safe", rootCmd.PersistentFlags().Lookup("context-switch-safe")); err != nil {
+		panic(fmt.Sprintf("failed to bind context-switch-safe flag: %v", err))
+	}
+	if err := viper.BindPFlag("skip-invalid-clusters", rootCmd.PersistentFlags().Lookup("skip-invalid-clusters")); err != nil {
+		panic(fmt.Sprintf("failed to bind skip-invalid-clusters flag: %v", err))
+	}
+	if err := viper.BindPFlag("strict-config", rootCmd.PersistentFlags().Lookup("strict-config")); err != nil {
+		panic(fmt.Sprintf("failed to bind strict-config flag: %v", err))
+	}
+	if err := viper.BindPFlag("trust-project-config", rootCmd.PersistentFlags().Lookup("trust-project-config")); err != nil {
+		panic(fmt.Sprintf("failed to bind trust-project-config flag: %v", err))
+	}
 
 	// Add all our subcommands to the root command
 	// This builds the complete command tree that users will interact with
 	rootCmd.AddCommand(newClustersCmd())
 	rootCmd.AddCommand(newDeploymentsCmd())
 	rootCmd.AddCommand(newPodsCmd())
+	rootCmd.AddCommand(newServicesCmd())
+	rootCmd.AddCommand(newCopyCmd())
+	rootCmd.AddCommand(newTopCmd())
+	rootCmd.AddCommand(newLogsCmd())
+	rootCmd.AddCommand(newGetCmd())
+	rootCmd.AddCommand(newNamespacesCmd())
 	rootCmd.AddCommand(newDeployCmd())
+	rootCmd.AddCommand(newDiffClustersCmd())
+	rootCmd.AddCommand(newSnapshotCmd())
 	rootCmd.AddCommand(newConfigCmd())
+	rootCmd.AddCommand(newSyncCmd())
+	rootCmd.AddCommand(newServeCmd())
 }
 
 // initConfig reads in config file and ENV variables if set
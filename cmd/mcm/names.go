@@ -0,0 +1,52 @@
+package main
+
+import (
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+	"github.com/celikgo/autoz-control-tower/internal/output"
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// clusterNames identifies clusters by name alone
+func clusterNames(clusters []cluster.ClusterStatus) []string {
+	names := make([]string, 0, len(clusters))
+	for _, status := range clusters {
+		names = append(names, status.Name)
+	}
+	return names
+}
+
+// deploymentNames identifies deployments as cluster/namespace/name
+func deploymentNames(deployments []workload.DeploymentInfo) []string {
+	names := make([]string, 0, len(deployments))
+	for _, deployment := range deployments {
+		names = append(names, output.DeploymentKey(deployment))
+	}
+	return names
+}
+
+// podNames identifies pods as cluster/namespace/name
+func podNames(pods []workload.PodInfo) []string {
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, pod.ClusterName+"/"+pod.Namespace+"/"+pod.Name)
+	}
+	return names
+}
+
+// serviceNames identifies services as cluster/namespace/name
+func serviceNames(services []workload.ServiceInfo) []string {
+	names := make([]string, 0, len(services))
+	for _, service := range services {
+		names = append(names, service.ClusterName+"/"+service.Namespace+"/"+service.Name)
+	}
+	return names
+}
+
+// namespaceNames identifies namespaces as cluster/name
+func namespaceNames(namespaces []workload.NamespaceInfo) []string {
+	names := make([]string, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		names = append(names, namespace.ClusterName+"/"+namespace.Name)
+	}
+	return names
+}
@@ -0,0 +1,332 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"sigs.k8s.io/yaml"
+
+	"github.com/celikgo/autoz-control-tower/internal/output"
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// newNamespacesCmd creates the namespaces command group
+func newNamespacesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "namespaces",
+		Aliases: []string{"ns"},
+		Short:   "List namespaces across clusters and tidy up the ones mcm created",
+		Long: `Commands for namespaces across the fleet. 'list' shows every namespace in each
+cluster, to audit which clusters have which tenants provisioned.
+
+'cleanup' handles the namespaces that 'mcm deploy --create-namespace' creates.
+Those namespaces are labeled mcm.io/created-by=mcm and annotated with when and
+by whom they were created, so they can be found and tidied up later.`,
+	}
+
+	cmd.AddCommand(newNamespacesListCmd())
+	cmd.AddCommand(newNamespacesCleanupCmd())
+
+	return cmd
+}
+
+// newNamespacesListCmd creates the 'namespaces list' subcommand
+// Tenancy questions - is team X provisioned everywhere it should be? - start here
+func newNamespacesListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List namespaces across clusters",
+		Long: `List the namespaces in each cluster with their status (Active, or Terminating
+while being deleted), age, and the value of chosen labels as extra columns -
+by default the team label. JSON and YAML output carry every label.
+
+Examples:
+  mcm namespaces list
+  mcm namespaces list -l team=payments             # Where is the payments team provisioned?
+  mcm namespaces list -L team -L cost-center       # Show two labels as columns
+  mcm namespaces list --output=name                # Just cluster/name, for scripting
+  mcm namespaces list --environment=production --output=json`,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusters, err := targetClusters(cmd)
+			if err != nil {
+				return err
+			}
+			labelSelector := cmd.Flag("selector").Value.String()
+			labelColumns, _ := cmd.Flags().GetStringArray("label-columns")
+			if err := applyListTimeouts(cmd); err != nil {
+				return err
+			}
+
+			if viper.GetString("output") == "object-yaml" {
+				return listObjectsYAML(cmd, clusters, "namespaces", workload.GetOptions{LabelSelector: labelSelector}, nil)
+			}
+
+			result := workloadManager.ListNamespaces(clusters, labelSelector)
+			namespaces := result.Items
+			sort.Slice(namespaces, func(i, j int) bool {
+				if namespaces[i].ClusterName != namespaces[j].ClusterName {
+					return namespaces[i].ClusterName < namespaces[j].ClusterName
+				}
+				return namespaces[i].Name < namespaces[j].Name
+			})
+
+			switch viper.GetString("output") {
+			case "json":
+				jsonData, err := json.MarshalIndent(struct {
+					Namespaces []workload.NamespaceInfo `json:"namespaces"`
+					Errors     map[string]string        `json:"errors,omitempty"`
+				}{Namespaces: namespaces, Errors: result.ErrorMessages()}, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal namespaces to JSON: %w", err)
+				}
+				fmt.Println(string(jsonData))
+			case "yaml":
+				yamlData, err := yaml.Marshal(struct {
+					Namespaces []workload.NamespaceInfo `json:"namespaces"`
+					Errors     map[string]string        `json:"errors,omitempty"`
+				}{Namespaces: namespaces, Errors: result.ErrorMessages()})
+				if err != nil {
+					return fmt.Errorf("failed to marshal namespaces to YAML: %w", err)
+				}
+				fmt.Print(string(yamlData))
+			case "name":
+				// Keep failures out of the pipe
+				printFleetFailures(os.Stderr, result)
+				if err := output.Names(os.Stdout, namespaceNames(namespaces)); err != nil {
+					return err
+				}
+			default:
+				writeNamespaces(os.Stdout, namespaces, labelColumns)
+				printFleetFailures(os.Stdout, result)
+			}
+			return fleetError(cmd, result)
+		},
+	}
+
+	cmd.Flags().String("clusters", "", "comma-separated list of cluster names or @groups")
+	cmd.Flags().StringP("selector", "l", "", "label selector to filter namespaces (e.g., 'team=payments')")
+	cmd.Flags().StringArrayP("label-columns", "L", []string{"team"}, "label to show as a column (repeatable)")
+	addLocationFlags(cmd)
+	addListTimeoutFlags(cmd)
+
+	return cmd
+}
+
+// writeNamespaces prints the namespaces table, one column per chosen label
+func writeNamespaces(out io.Writer, namespaces []workload.NamespaceInfo, labelColumns []string) {
+	if len(namespaces) == 0 {
+		fmt.Fprintln(out, "No namespaces found in the specified clusters.")
+		return
+	}
+
+	header := []string{"CLUSTER", "NAME", "STATUS", "AGE"}
+	for _, label := range labelColumns {
+		header = append(header, strings.ToUpper(label))
+	}
+	underline := make([]string, len(header))
+	for i, column := range header {
+		underline[i] = strings.Repeat("-", len(column))
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	fmt.Fprintln(w, strings.Join(underline, "\t"))
+	clusters := make(map[string]bool)
+	for _, namespace := range namespaces {
+		clusters[namespace.ClusterName] = true
+		row := []string{namespace.ClusterName, namespace.Name, namespace.Status, namespace.Age}
+		for _, label := range labelColumns {
+			row = append(row, getValueOrDefault(namespace.Labels[label], "-"))
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+
+	fmt.Fprintf(out, "\nFound %d namespaces across %d clusters\n", len(namespaces), len(clusters))
+}
+
+// newNamespacesCleanupCmd creates the 'namespaces cleanup' subcommand
+// Repeated --create-namespace deploys (preview environments, experiments) leave
+// namespaces behind; this is the broom that sweeps up the empty ones
+func newNamespacesCleanupCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cleanup",
+		Short: "List, and optionally delete, empty namespaces mcm created",
+		Long: `List the namespaces mcm created with --create-namespace in each cluster and
+show whether they are empty. A namespace counts as empty when it holds no
+deployments, statefulsets, daemonsets, pods, services, persistent volume
+claims, secrets or configmaps (other than the kube-root-ca.crt configmap and
+service account tokens Kubernetes adds itself).
+
+Nothing is deleted unless --delete is given. Each namespace is checked again
+right before deletion, so one that received a deploy in the meantime is kept.
+Namespaces without the mcm.io/created-by=mcm label are never touched.
+
+Examples:
+  mcm namespaces cleanup                          # Report mcm-created namespaces
+  mcm namespaces cleanup --clusters=dev,staging --delete
+  mcm namespaces cleanup --output=json | jq '.namespaces[] | select(.empty)'`,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusters := parseClusterList(cmd.Flag("clusters").Value.String())
+			deleteEmpty, _ := cmd.Flags().GetBool("delete")
+
+			result := workloadManager.ListCreatedNamespaces(clusters)
+			namespaces := result.Items
+			sort.Slice(namespaces, func(i, j int) bool {
+				if namespaces[i].ClusterName != namespaces[j].ClusterName {
+					return namespaces[i].ClusterName < namespaces[j].ClusterName
+				}
+				return namespaces[i].Name < namespaces[j].Name
+			})
+
+			outputFormat := viper.GetString("output")
+			switch outputFormat {
+			case "json", "yaml":
+				if err := outputCreatedNamespaces(namespaces, result.ErrorMessages(), outputFormat); err != nil {
+					return err
+				}
+			default:
+				outputCreatedNamespacesTable(namespaces, deleteEmpty)
+				printFleetFailures(os.Stdout, result)
+			}
+
+			if !deleteEmpty {
+				return nil
+			}
+			if err := confirmChanges(cmd, "delete empty namespaces", emptyNamespaceClusters(namespaces)); err != nil {
+				return err
+			}
+			return deleteEmptyNamespaces(namespaces)
+		},
+	}
+
+	cmd.Flags().String("clusters", "", "comma-separated list of cluster names or @groups (default: all connected clusters)")
+	cmd.Flags().Bool("delete", false, "delete the empty namespaces instead of only listing them")
+	addYesFlag(cmd)
+
+	return cmd
+}
+
+// emptyNamespaceClusters lists the clusters a cleanup would delete namespaces in
+func emptyNamespaceClusters(namespaces []workload.CreatedNamespace) []string {
+	seen := make(map[string]bool)
+	var clusters []string
+	for _, namespace := range namespaces {
+		if namespace.Empty && !seen[namespace.ClusterName] {
+			seen[namespace.ClusterName] = true
+			clusters = append(clusters, namespace.ClusterName)
+		}
+	}
+	return clusters
+}
+
+// deleteEmptyNamespaces deletes every empty namespace in the list, reporting on stderr
+// so JSON/YAML output on stdout stays parseable
+func deleteEmptyNamespaces(namespaces []workload.CreatedNamespace) error {
+	deleted, failed := 0, 0
+	for _, namespace := range namespaces {
+		if !namespace.Empty {
+			continue
+		}
+		if err := workloadManager.DeleteCreatedNamespace(namespace.ClusterName, namespace.Name); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %s/%s: %v\n", namespace.ClusterName, namespace.Name, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "🗑️  Deleted namespace %s in cluster %s\n", namespace.Name, namespace.ClusterName)
+		deleted++
+	}
+
+	fmt.Fprintf(os.Stderr, "\nDeleted %d empty namespaces\n", deleted)
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d namespaces", failed)
+	}
+	return nil
+}
+
+// outputCreatedNamespaces renders the namespace report as JSON or YAML
+func outputCreatedNamespaces(namespaces []workload.CreatedNamespace, failures map[string]string, outputFormat string) error {
+	output := struct {
+		Namespaces []workload.CreatedNamespace `json:"namespaces"`
+		Errors     map[string]string           `json:"errors,omitempty"`
+	}{Namespaces: namespaces, Errors: failures}
+
+	if outputFormat == "yaml" {
+		yamlData, err := yaml.Marshal(output)
+		if err != nil {
+			return fmt.Errorf("failed to marshal namespaces to YAML: %w", err)
+		}
+		fmt.Print(string(yamlData))
+		return nil
+	}
+
+	jsonData, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal namespaces to JSON: %w", err)
+	}
+	fmt.Println(string(jsonData))
+	return nil
+}
+
+// outputCreatedNamespacesTable shows each mcm-created namespace and whether it can go
+func outputCreatedNamespacesTable(namespaces []workload.CreatedNamespace, deleting bool) {
+	if len(namespaces) == 0 {
+		fmt.Println("No namespaces created by mcm were found.")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "CLUSTER\tNAMESPACE\tCREATED BY\tAGE\tCONTENTS")
+	fmt.Fprintln(w, "-------\t---------\t----------\t---\t--------")
+
+	empty := 0
+	for _, namespace := range namespaces {
+		contents := "🧹 empty"
+		if namespace.Empty {
+			empty++
+		} else {
+			contents = namespace.Contents + ", ..."
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			namespace.ClusterName,
+			namespace.Name,
+			getValueOrDefault(namespace.CreatedBy, "-"),
+			namespace.Age,
+			contents,
+		)
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d of %d namespaces are empty", empty, len(namespaces))
+	if empty > 0 && !deleting {
+		fmt.Print(" - run with --delete to remove them")
+	}
+	fmt.Println()
+}
+
+// deployingUser names the person running mcm, for the namespace creator annotation
+// An impersonated identity is noted too, since that's who the cluster saw
+func deployingUser() string {
+	name := os.Getenv("USER")
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		name = current.Username
+	}
+	if as := viper.GetString("as"); as != "" {
+		if name == "" {
+			return as
+		}
+		return fmt.Sprintf("%s (as %s)", name, as)
+	}
+	return name
+}
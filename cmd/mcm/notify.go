@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+// notifyTimeout bounds the whole webhook call, so a slow or unreachable chat
+// service can't keep a finished deploy from returning
+const notifyTimeout = 10 * time.Second
+
+// Per-cluster outcomes in a deploy notification
+const (
+	notifySucceeded = "succeeded"
+	notifyWarning   = "warning" // e.g. already exists with --if-not-exists
+	notifyFailed    = "failed"
+)
+
+// deployNotification is the JSON body POSTed to the notification webhook
+// Text is a one-line summary, which is what Slack and Teams incoming webhooks
+// display; everything else is there for bots and dashboards
+type deployNotification struct {
+	Text      string                `json:"text"`
+	Manifest  string                `json:"manifest"`
+	Namespace string                `json:"namespace"`
+	User      string                `json:"user"`
+	Timestamp time.Time             `json:"timestamp"`
+	Result    string                `json:"result"` // success or failure, as MCM_RESULT for hooks
+	Clusters  []clusterNotification `json:"clusters"`
+}
+
+// clusterNotification is one cluster's outcome
+type clusterNotification struct {
+	Cluster string `json:"cluster"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+// addNotifyFlags registers the flags that set or override the config's notify section
+func addNotifyFlags(cmd *cobra.Command) {
+	cmd.Flags().String("notify-webhook", "", "POST a JSON summary of the results to this URL, e.g. a Slack incoming webhook (overrides notify.webhook)")
+	cmd.Flags().String("notify-on", "", "when to notify: always or failure (overrides notify.when; default always)")
+}
+
+// deployNotify combines the config's notify section with the flags, which win
+func deployNotify(cmd *cobra.Command, configured config.DeployNotify) (config.DeployNotify, error) {
+	notify := configured
+	if cmd.Flags().Changed("notify-webhook") {
+		notify.Webhook, _ = cmd.Flags().GetString("notify-webhook")
+		if notify.Webhook != "" {
+			if err := config.ValidateWebhookURL(notify.Webhook); err != nil {
+				return notify, fmt.Errorf("--notify-webhook: %w", err)
+			}
+		}
+	}
+	if cmd.Flags().Changed("notify-on") {
+		notify.When, _ = cmd.Flags().GetString("notify-on")
+		if err := config.ValidateNotifyWhen(notify.When); err != nil {
+			return notify, fmt.Errorf("--notify-on: %w", err)
+		}
+	}
+	return notify, nil
+}
+
+// newDeployNotification summarizes a deploy's results
+// Warnings count as failures only when the deploy treated them so (--fail-on-warning)
+func newDeployNotification(hookContext deployHookContext, results map[string]error, failOnWarning bool, now time.Time) deployNotification {
+	notification := deployNotification{
+		Manifest:  hookContext.Manifest,
+		Namespace: hookContext.Namespace,
+		User:      hookContext.User,
+		Timestamp: now.UTC(),
+		Result:    "success",
+	}
+
+	failed := 0
+	for cluster, err := range results {
+		outcome := clusterNotification{Cluster: cluster, Status: notifySucceeded}
+		if err != nil {
+			outcome.Error = err.Error()
+			outcome.Status = notifyFailed
+			if isDeployWarning(err) && !failOnWarning {
+				outcome.Status = notifyWarning
+			}
+		}
+		if outcome.Status == notifyFailed {
+			failed++
+		}
+		notification.Clusters = append(notification.Clusters, outcome)
+	}
+	sort.Slice(notification.Clusters, func(i, j int) bool {
+		return notification.Clusters[i].Cluster < notification.Clusters[j].Cluster
+	})
+
+	if failed > 0 {
+		notification.Result = "failure"
+		var names []string
+		for _, outcome := range notification.Clusters {
+			if outcome.Status == notifyFailed {
+				names = append(names, outcome.Cluster)
+			}
+		}
+		notification.Text = fmt.Sprintf("❌ %s deployed %s to namespace %s: failed on %d of %d clusters (%s)",
+			notification.User, notification.Manifest, notification.Namespace, failed, len(results), strings.Join(names, ", "))
+	} else {
+		notification.Text = fmt.Sprintf("✅ %s deployed %s to namespace %s on %d clusters",
+			notification.User, notification.Manifest, notification.Namespace, len(results))
+	}
+	return notification
+}
+
+// shouldNotify applies notify.when to a deploy's outcome
+func shouldNotify(notify config.DeployNotify, notification deployNotification) bool {
+	if notify.Webhook == "" {
+		return false
+	}
+	return notify.When != config.NotifyOnFailure || notification.Result == "failure"
+}
+
+// postNotification POSTs the notification as JSON and fails on any non-2xx answer
+func postNotification(ctx context.Context, webhook string, notification deployNotification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to encode the notification: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, notifyTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build the notification request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		// The transport's error repeats the URL, and with it the webhook's secret
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("failed to post the notification: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		detail, _ := io.ReadAll(io.LimitReader(response.Body, 200))
+		return fmt.Errorf("notification webhook answered %s: %s", response.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
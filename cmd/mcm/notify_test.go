@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+func TestNewDeployNotification(t *testing.T) {
+	hookContext := deployHookContext{Manifest: "app.yaml", Namespace: "shop", User: "alice"}
+	results := map[string]error{
+		"prod-us": nil,
+		"prod-eu": errors.New("failed to apply deployment: connection refused"),
+		"staging": errors.New("deployment shop/web already exists"),
+	}
+	now := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+
+	notification := newDeployNotification(hookContext, results, false, now)
+	if notification.Result != "failure" || !notification.Timestamp.Equal(now) || notification.User != "alice" {
+		t.Errorf("Unexpected notification %+v", notification)
+	}
+	var statuses []string
+	for _, outcome := range notification.Clusters {
+		statuses = append(statuses, outcome.Cluster+"="+outcome.Status)
+	}
+	if got := strings.Join(statuses, " "); got != "prod-eu=failed prod-us=succeeded staging=warning" {
+		t.Errorf("Unexpected cluster statuses %s", got)
+	}
+	if !strings.Contains(notification.Text, "failed on 1 of 3 clusters (prod-eu)") {
+		t.Errorf("Unexpected text %q", notification.Text)
+	}
+
+	if strict := newDeployNotification(hookContext, results, true, now); !strings.Contains(strict.Text, "(prod-eu, staging)") {
+		t.Errorf("Expected the warning to count with --fail-on-warning, got %q", strict.Text)
+	}
+
+	success := newDeployNotification(hookContext, map[string]error{"prod-us": nil}, false, now)
+	if success.Result != "success" {
+		t.Errorf("Expected success, got %+v", success)
+	}
+	if shouldNotify(config.DeployNotify{Webhook: "https://example.com", When: config.NotifyOnFailure}, success) {
+		t.Error("Expected no notification for a success with --notify-on=failure")
+	}
+	if !shouldNotify(config.DeployNotify{Webhook: "https://example.com"}, success) {
+		t.Error("Expected a notification after every deploy by default")
+	}
+}
+
+func TestPostNotification(t *testing.T) {
+	var received deployNotification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected a JSON POST, got %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode the notification: %v", err)
+		}
+		if received.Manifest == "broken.yaml" {
+			http.Error(w, "invalid_payload", http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	sent := deployNotification{Text: "✅ done", Manifest: "app.yaml", Result: "success"}
+	if err := postNotification(context.Background(), server.URL, sent); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if received.Text != sent.Text || received.Manifest != "app.yaml" {
+		t.Errorf("Expected the notification to arrive intact, got %+v", received)
+	}
+
+	err := postNotification(context.Background(), server.URL, deployNotification{Manifest: "broken.yaml"})
+	if err == nil || !strings.Contains(err.Error(), "400") || !strings.Contains(err.Error(), "invalid_payload") {
+		t.Errorf("Expected the webhook's refusal to be reported, got %v", err)
+	}
+}
@@ -5,13 +5,14 @@ import (
 	"fmt"
 	"os"
 	"sort"
-	"strings"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/fields"
 	"sigs.k8s.io/yaml"
 
+	"github.com/celikgo/autoz-control-tower/internal/output"
 	"github.com/celikgo/autoz-control-tower/internal/workload"
 )
 
@@ -47,11 +48,25 @@ Examples:
   mcm pods list                                    # All pods, all clusters
   mcm pods list --clusters=prod-us                # Only specific cluster
   mcm pods list --namespace=default               # Only default namespace
+  mcm pods list --exclude-namespace=kube-system   # All namespaces but kube-system
   mcm pods list --selector="app=nginx"            # Filter by label selector
-  mcm pods list --output=json | jq '.pods[] | select(.status=="Failed")'  # Find failed pods`,
+  mcm pods list --field-selector=status.phase=Pending  # Filter by field selector
+  mcm pods list --compact                         # One summary row per cluster
+  mcm pods list --output=name                     # Just cluster/namespace/name, one per line
+  mcm pods list -l app=web --output=object-yaml   # The full pod objects, as kubectl get -o yaml prints them
+  mcm pods list --only-unhealthy                  # Only pods that need attention
+  mcm pods list --flag-on-bad-nodes               # Mark pods whose node is NotReady
+  mcm pods list --sort-by=restarts --limit=10     # The 10 most-restarted pods in the fleet
+  mcm pods list --output=json | jq '.pods[] | select(.status=="Failed")'  # Find failed pods
+  mcm pods events web-7d4b9c-x2k8p -n production  # Why won't this pod start?
+  mcm pods describe web-7d4b9c-x2k8p -n production # 1/2 ready - but why? Probes and conditions
+  mcm pods logs -l app=web --dump-dir=./logs      # Save every web pod's logs, one file per pod`,
 	}
 
 	podsCmd.AddCommand(newPodsListCmd())
+	podsCmd.AddCommand(newPodsEventsCmd())
+	podsCmd.AddCommand(newPodsDescribeCmd())
+	podsCmd.AddCommand(newPodsLogsCmd())
 	return podsCmd
 }
 
@@ -73,7 +88,8 @@ Understanding pod status is crucial for operations:
 
 The Ready column shows container readiness in "ready/total" format:
 - "2/2" means both containers in the pod are ready
-- "1/2" means only one of two containers is ready (potential problem)
+- "1/2" means only one of two containers is ready (potential problem);
+  'mcm pods describe POD' shows the probes and conditions behind it
 - "0/1" means the single container is not yet ready
 
 Restart counts indicate stability:
@@ -82,268 +98,425 @@ Restart counts indicate stability:
 - High restart count (10+): Indicates a problem that needs investigation
 
 This information helps answer critical operational questions like "Are there any
-unhealthy pods in production?" or "Did the deployment succeed in all regions?"`,
+unhealthy pods in production?" or "Did the deployment succeed in all regions?"
+
+With --group-by=cluster (or namespace) the table is split into one sub-table
+per group, each with its own running count; --sort-by still orders each group.
+
+--environment and --region pick the clusters by the environment and region they
+are tagged with in the config, e.g. --environment=production, so there's no need
+to list every production cluster by name.
+
+--output=object-yaml prints the pod objects themselves, as multi-document YAML
+annotated with mcm.io/cluster; flags that work on mcm's summary (--sort-by,
+--only-unhealthy, --compact...) can't be combined with it.
+
+--flag-on-bad-nodes also lists each cluster's nodes and marks the pods whose
+node is NotReady or unreachable. Such a pod can still say Running - that's the
+last thing its kubelet reported - while nothing is serving. With
+--only-unhealthy the flagged pods are shown along with the failing ones.
+
+If any cluster can't be queried, the pods from the others are still shown, and
+the command exits non-zero so scripts and CI notice the gap.`,
 
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Parse command flags to determine query parameters
-			clusters := parseClusterList(cmd.Flag("clusters").Value.String())
+			clusters, err := targetClusters(cmd)
+			if err != nil {
+				return err
+			}
 			namespace := cmd.Flag("namespace").Value.String()
-			labelSelector := cmd.Flag("selector").Value.String()
+			labelSelector := managedOnlySelector(cmd, cmd.Flag("selector").Value.String())
+			fieldSelector := cmd.Flag("field-selector").Value.String()
 			outputFormat := viper.GetString("output")
 
-			// Query all clusters for pod information in parallel
-			pods, err := workloadManager.ListPods(clusters, namespace, labelSelector)
+			// Catch typos locally instead of getting the same error from every cluster
+			if fieldSelector != "" {
+				if _, err := fields.ParseSelector(fieldSelector); err != nil {
+					return fmt.Errorf("invalid field selector %q: %w", fieldSelector, err)
+				}
+			}
+
+			if err := applyListTimeouts(cmd); err != nil {
+				return err
+			}
+			groupBy, err := parseGroupBy(cmd)
 			if err != nil {
-				return fmt.Errorf("failed to list pods: %w", err)
+				return err
 			}
 
-			// Sort pods for consistent, scannable output
-			// Primary sort: cluster name (group by infrastructure)
-			// Secondary sort: namespace (group by application boundary)
-			// Tertiary sort: pod name (alphabetical within namespace)
-			sort.Slice(pods, func(i, j int) bool {
-				if pods[i].ClusterName != pods[j].ClusterName {
-					return pods[i].ClusterName < pods[j].ClusterName
+			if outputFormat == "object-yaml" {
+				if err := rejectObjectYAMLFlags(cmd, "compact", "only-unhealthy", "sort-by", "limit", "group-by", "flag-on-bad-nodes"); err != nil {
+					return err
 				}
-				if pods[i].Namespace != pods[j].Namespace {
-					return pods[i].Namespace < pods[j].Namespace
+				return listObjectsYAML(cmd, clusters, "pods",
+					workload.GetOptions{Namespace: namespace, LabelSelector: labelSelector, FieldSelector: fieldSelector},
+					excludedNamespaces(cmd, namespace == ""))
+			}
+
+			// Query all clusters for pod information in parallel
+			// Clusters that fail are collected in result.Errors and reported after the data
+			result := workloadManager.ListPods(clusters, namespace, labelSelector, fieldSelector)
+			if flagBadNodes, _ := cmd.Flags().GetBool("flag-on-bad-nodes"); flagBadNodes {
+				flagPodsOnBadNodes(result, clusters)
+			}
+			pods := withoutNamespaces(result.Items, excludedNamespaces(cmd, namespace == ""),
+				func(pod workload.PodInfo) string { return pod.Namespace })
+
+			// During incidents only the broken pods matter
+			if onlyUnhealthy, _ := cmd.Flags().GetBool("only-unhealthy"); onlyUnhealthy {
+				pods = filterUnhealthyPods(pods)
+			}
+
+			// Sort pods for consistent, scannable output
+			if err := sortPods(pods, cmd.Flag("sort-by").Value.String()); err != nil {
+				return err
+			}
+
+			// Compact mode collapses everything into one row per cluster
+			if compact, _ := cmd.Flags().GetBool("compact"); compact {
+				if err := outputClusterSummaries(os.Stdout, summarizePodsByCluster(pods, result.Errors), outputFormat); err != nil {
+					return err
 				}
-				return pods[i].Name < pods[j].Name
-			})
+				return fleetError(cmd, result)
+			}
+
+			// Cap the fleet-wide total after sorting, so the result is a deterministic top-N
+			limit, _ := cmd.Flags().GetInt("limit")
+			if limit < 0 {
+				return fmt.Errorf("--limit must not be negative, got %d", limit)
+			}
+			if limit > 0 && len(pods) > limit {
+				fmt.Fprintf(os.Stderr, "Showing %d of %d pods (--limit=%d)\n", limit, len(pods), limit)
+				pods = pods[:limit]
+			}
 
 			// Output in requested format
 			switch outputFormat {
 			case "json":
-				return outputPodsJSON(pods)
+				err = output.PodsJSON(os.Stdout, pods, result.ErrorMessages())
 			case "yaml":
-				return outputPodsYAML(pods)
+				err = output.PodsYAML(os.Stdout, pods, result.ErrorMessages())
+			case "name":
+				// Keep failures out of the pipe
+				printFleetFailures(os.Stderr, result)
+				err = output.Names(os.Stdout, podNames(pods))
 			default:
-				return outputPodsTable(pods)
+				err = output.PodsTable(os.Stdout, pods, output.PodsTableOptions{GroupBy: groupBy})
+				if err == nil {
+					printFleetFailures(os.Stdout, result)
+				}
+			}
+			if err != nil {
+				return err
 			}
+
+			// Any cluster that couldn't be queried makes the exit code non-zero
+			return fleetError(cmd, result)
 		},
 	}
 
 	// Add flags for filtering and targeting specific pods
-	cmd.Flags().String("clusters", "", "comma-separated list of cluster names")
-	cmd.Flags().StringP("namespace", "n", "", "namespace to list pods from")
+	cmd.Flags().String("clusters", "", "comma-separated list of cluster names or @groups")
+	cmd.Flags().StringP("namespace", "n", "", "namespace to list pods from (default: all namespaces)")
 	cmd.Flags().StringP("selector", "l", "", "label selector to filter pods (e.g., 'app=nginx,tier=frontend')")
+	cmd.Flags().String("field-selector", "", "field selector to filter pods server-side (e.g., 'status.phase=Pending,spec.nodeName=node-1')")
+	cmd.Flags().Bool("compact", false, "show one summary row per cluster (total, ready, not ready)")
+	cmd.Flags().Bool("only-unhealthy", false, "only show pods that are not Running or Succeeded")
+	cmd.Flags().Bool("flag-on-bad-nodes", false, "mark pods whose node is NotReady or unreachable (also lists each cluster's nodes)")
+	cmd.Flags().String("sort-by", "name", "sort order: name (cluster/namespace/name), restarts (most first), age (oldest first)")
+	cmd.Flags().Int("limit", 0, "show at most N pods across the whole fleet, after sorting (0 = no limit)")
+	addLocationFlags(cmd)
+	addGroupByFlag(cmd)
+	addManagedOnlyFlag(cmd)
+	addExcludeNamespaceFlag(cmd)
+	addListTimeoutFlags(cmd)
 
 	return cmd
 }
 
-// outputPodsTable displays pod information in a readable table format
-// This is optimized for quick visual scanning to spot problems
-func outputPodsTable(pods []workload.PodInfo) error {
-	if len(pods) == 0 {
-		fmt.Println("No pods found in the specified clusters and namespaces.")
-		return nil
+// newPodsEventsCmd creates the 'pods events' subcommand
+// This is the first thing to check when a pod won't start
+func newPodsEventsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "events POD_NAME",
+		Short: "Show the events for a single pod",
+		Long: `Show the Kubernetes events recorded for one pod, oldest first.
+Events explain most "why won't my pod start?" problems - failed scheduling,
+image pull errors, crash loop back-offs, failing probes - without the noise
+of a full describe.
+
+Events are fetched server-side with an involvedObject field selector, so the
+command stays fast even in namespaces with thousands of events. Every
+connected cluster is searched unless --clusters narrows it down, which is
+handy when the same pod name could exist in several clusters.
+
+Note that Kubernetes only keeps events for a limited time (one hour by default).
+
+Examples:
+  mcm pods events web-7d4b9c-x2k8p                       # Default namespace, all clusters
+  mcm pods events web-7d4b9c-x2k8p -n production --clusters=prod-eu
+  mcm pods events web-7d4b9c-x2k8p --output=json`,
+
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			podName := args[0]
+			clusters := parseClusterList(cmd.Flag("clusters").Value.String())
+			namespace := cmd.Flag("namespace").Value.String()
+			if namespace == "" {
+				namespace = appConfig.DefaultNamespace
+			}
+
+			result := workloadManager.PodEvents(clusters, namespace, podName)
+			events := result.Items
+
+			switch viper.GetString("output") {
+			case "json":
+				jsonData, err := json.MarshalIndent(struct {
+					Pod    string               `json:"pod"`
+					Events []workload.EventInfo `json:"events"`
+					Errors map[string]string    `json:"errors,omitempty"`
+				}{Pod: podName, Events: events, Errors: result.ErrorMessages()}, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal events to JSON: %w", err)
+				}
+				fmt.Println(string(jsonData))
+				return nil
+			case "yaml":
+				yamlData, err := yaml.Marshal(struct {
+					Pod    string               `json:"pod"`
+					Events []workload.EventInfo `json:"events"`
+					Errors map[string]string    `json:"errors,omitempty"`
+				}{Pod: podName, Events: events, Errors: result.ErrorMessages()})
+				if err != nil {
+					return fmt.Errorf("failed to marshal events to YAML: %w", err)
+				}
+				fmt.Print(string(yamlData))
+				return nil
+			default:
+				if err := outputEventsTable(podName, namespace, events); err != nil {
+					return err
+				}
+				printFleetFailures(os.Stdout, result)
+				return nil
+			}
+		},
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	defer w.Flush()
+	cmd.Flags().String("clusters", "", "comma-separated list of cluster names or @groups (default: all connected clusters)")
+	cmd.Flags().StringP("namespace", "n", "", "namespace of the pod (default: from config)")
 
-	// Headers that provide the most critical pod information at a glance
-	fmt.Fprintln(w, "CLUSTER\tNAMESPACE\tNAME\tREADY\tSTATUS\tRESTARTS\tAGE\tNODE")
-	fmt.Fprintln(w, "-------\t---------\t----\t-----\t------\t--------\t---\t----")
+	return cmd
+}
 
-	for _, pod := range pods {
-		// Handle error cases where we couldn't retrieve pod information
-		if strings.Contains(pod.Status, "Failed to") || strings.Contains(pod.Name, "error") {
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-				pod.ClusterName,
-				"-",
-				"ERROR",
-				"-",
-				"❌ "+pod.Status,
-				"-",
-				"-",
-				"-",
-			)
-			continue
-		}
+// newPodsLogsCmd creates the 'pods logs' subcommand
+// This is the evidence-collection step of an incident: grab everything before pods get replaced
+func newPodsLogsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Save logs of matching pods across clusters to files",
+		Long: `Download the logs of every pod matching a label selector, across clusters,
+into one file per pod laid out as <dump-dir>/<cluster>/<namespace>/<pod>.log.
 
-		// Add visual indicators for pod status to make problems immediately visible
-		var statusIcon string
-		switch pod.Status {
-		case "Running":
-			statusIcon = "✅ " + pod.Status
-		case "Pending":
-			statusIcon = "⏳ " + pod.Status
-		case "Failed":
-			statusIcon = "❌ " + pod.Status
-		case "Succeeded":
-			statusIcon = "✅ " + pod.Status
-		case "Unknown":
-			statusIcon = "❓ " + pod.Status
-		default:
-			statusIcon = pod.Status
-		}
+Pods with several containers get all of them in the same file, each section
+introduced by a "==> container NAME <==" header. Downloads run in parallel,
+capped fleet-wide by the 'concurrency' setting in the configuration.
 
-		// Highlight high restart counts as they indicate instability
-		restarts := fmt.Sprintf("%d", pod.Restarts)
-		if pod.Restarts > 5 {
-			restarts = "⚠️ " + restarts // Warning for moderate restart counts
-		}
-		if pod.Restarts > 20 {
-			restarts = "🚨 " + restarts // Alert for high restart counts
-		}
+Use --previous after a crash: the current container has only just restarted,
+so the interesting output is in the previous instance's logs.
 
-		// Truncate long pod names to keep table readable while preserving key info
-		// Pod names often include deployment names and random suffixes
-		podName := pod.Name
-		if len(podName) > 35 {
-			// Try to preserve the meaningful prefix and show it's truncated
-			podName = podName[:32] + "..."
-		}
+Examples:
+  mcm pods logs -l app=web --dump-dir=./logs                    # All clusters, default namespace
+  mcm pods logs -l app=web -n production --since=1h --dump-dir=./incident-42
+  mcm pods logs -l app=web --previous --clusters=prod-eu --dump-dir=./crash`,
 
-		// Truncate node names since they're often very long in cloud environments
-		nodeName := pod.Node
-		if len(nodeName) > 20 {
-			nodeName = nodeName[:17] + "..."
-		}
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusters := parseClusterList(cmd.Flag("clusters").Value.String())
+			labelSelector := cmd.Flag("selector").Value.String()
+			dumpDir := cmd.Flag("dump-dir").Value.String()
+			namespace := cmd.Flag("namespace").Value.String()
+			if namespace == "" {
+				namespace = appConfig.DefaultNamespace
+			}
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-			pod.ClusterName,
-			pod.Namespace,
-			podName,
-			pod.Ready,
-			statusIcon,
-			restarts,
-			pod.Age,
-			nodeName,
-		)
-	}
+			// Dumping every pod in the fleet by accident is expensive, so insist on a selector
+			if labelSelector == "" {
+				return fmt.Errorf("--selector is required")
+			}
+			if dumpDir == "" {
+				return fmt.Errorf("--dump-dir is required")
+			}
 
-	// Provide summary statistics to give context
-	runningCount := countPodsByStatus(pods, "Running")
-	totalCount := len(pods)
-	clusterCount := countUniquePodClusters(pods)
+			since, _ := cmd.Flags().GetDuration("since")
+			previous, _ := cmd.Flags().GetBool("previous")
+
+			results := workloadManager.DumpPodLogs(clusters, namespace, labelSelector, workload.LogDumpOptions{
+				Dir:         dumpDir,
+				Since:       since,
+				Previous:    previous,
+				Concurrency: appConfig.Concurrency,
+			})
 
-	fmt.Printf("\nFound %d pods (%d running) across %d clusters\n",
-		totalCount, runningCount, clusterCount)
+			if err := outputLogDumpResults(results, dumpDir); err != nil {
+				return err
+			}
 
-	// Highlight if there are any non-running pods as this might need attention
-	if runningCount < totalCount {
-		nonRunning := totalCount - runningCount
-		fmt.Printf("⚠️  Note: %d pods are not in Running state - this may require investigation\n", nonRunning)
+			failed := 0
+			for _, result := range results {
+				failed += result.Failed
+				if result.Failed == 0 && len(result.Errors) > 0 {
+					failed++ // The cluster itself couldn't be queried
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("failed to capture logs in %d place(s); see errors above", failed)
+			}
+			return nil
+		},
 	}
 
-	return nil
+	cmd.Flags().String("clusters", "", "comma-separated list of cluster names or @groups (default: all connected clusters)")
+	cmd.Flags().StringP("namespace", "n", "", "namespace to search for pods (default: from config)")
+	cmd.Flags().StringP("selector", "l", "", "label selector choosing the pods (e.g., 'app=web')")
+	cmd.Flags().String("dump-dir", "", "directory to write <cluster>/<namespace>/<pod>.log files into")
+	cmd.Flags().Duration("since", 0, "only return logs newer than this duration, e.g. 30m or 2h")
+	cmd.Flags().Bool("previous", false, "fetch logs of the previous container instance (after a crash)")
+
+	return cmd
 }
 
-// outputPodsJSON formats pod information as JSON for programmatic use
-func outputPodsJSON(pods []workload.PodInfo) error {
-	output := struct {
-		Pods     []workload.PodInfo `json:"pods"`
-		Count    int                `json:"count"`
-		Clusters []string           `json:"clusters"`
-		Summary  PodSummary         `json:"summary"`
-	}{
-		Pods:     pods,
-		Count:    len(pods),
-		Clusters: getUniquePodClusters(pods),
-		Summary:  generatePodSummary(pods),
+// outputLogDumpResults reports how many pods' logs were captured in each cluster
+func outputLogDumpResults(results []workload.LogDumpResult, dumpDir string) error {
+	switch viper.GetString("output") {
+	case "json":
+		jsonData, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal log capture results to JSON: %w", err)
+		}
+		fmt.Println(string(jsonData))
+		return nil
+	case "yaml":
+		yamlData, err := yaml.Marshal(results)
+		if err != nil {
+			return fmt.Errorf("failed to marshal log capture results to YAML: %w", err)
+		}
+		fmt.Print(string(yamlData))
+		return nil
 	}
 
-	jsonData, err := json.MarshalIndent(output, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal pods to JSON: %w", err)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CLUSTER\tCAPTURED\tFAILED")
+	for _, result := range results {
+		fmt.Fprintf(w, "%s\t%d\t%d\n", result.ClusterName, result.Captured, result.Failed)
 	}
-
-	fmt.Println(string(jsonData))
-	return nil
-}
-
-// outputPodsYAML formats pod information as YAML
-func outputPodsYAML(pods []workload.PodInfo) error {
-	output := struct {
-		Pods     []workload.PodInfo `yaml:"pods"`
-		Count    int                `yaml:"count"`
-		Clusters []string           `yaml:"clusters"`
-		Summary  PodSummary         `yaml:"summary"`
-	}{
-		Pods:     pods,
-		Count:    len(pods),
-		Clusters: getUniquePodClusters(pods),
-		Summary:  generatePodSummary(pods),
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to flush output: %w", err)
 	}
 
-	yamlData, err := yaml.Marshal(output)
-	if err != nil {
-		return fmt.Errorf("failed to marshal pods to YAML: %w", err)
+	for _, result := range results {
+		for _, message := range result.Errors {
+			fmt.Fprintf(os.Stderr, "❌ %s: %s\n", result.ClusterName, message)
+		}
 	}
 
-	fmt.Print(string(yamlData))
+	fmt.Printf("\nLogs written to %s\n", dumpDir)
 	return nil
 }
 
-// PodSummary provides aggregate statistics about the pod collection
-// This is useful for understanding the overall health of your infrastructure
-type PodSummary struct {
-	Running   int `json:"running" yaml:"running"`
-	Pending   int `json:"pending" yaml:"pending"`
-	Failed    int `json:"failed" yaml:"failed"`
-	Succeeded int `json:"succeeded" yaml:"succeeded"`
-	Unknown   int `json:"unknown" yaml:"unknown"`
-	Other     int `json:"other" yaml:"other"`
-}
+// outputEventsTable displays events in the same layout operators know from kubectl describe
+func outputEventsTable(podName, namespace string, events []workload.EventInfo) error {
+	if len(events) == 0 {
+		fmt.Printf("No events found for pod %s in namespace %s.\n", podName, namespace)
+		fmt.Println("Check the pod name and namespace, or the events may have expired.")
+		return nil
+	}
 
-// generatePodSummary calculates summary statistics from the pod list
-func generatePodSummary(pods []workload.PodInfo) PodSummary {
-	summary := PodSummary{}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	defer w.Flush()
 
-	for _, pod := range pods {
-		switch pod.Status {
-		case "Running":
-			summary.Running++
-		case "Pending":
-			summary.Pending++
-		case "Failed":
-			summary.Failed++
-		case "Succeeded":
-			summary.Succeeded++
-		case "Unknown":
-			summary.Unknown++
-		default:
-			summary.Other++
+	fmt.Fprintln(w, "CLUSTER\tLAST SEEN\tTYPE\tREASON\tCOUNT\tMESSAGE")
+	fmt.Fprintln(w, "-------\t---------\t----\t------\t-----\t-------")
+
+	for _, event := range events {
+		// Warnings are what usually explain a stuck pod, so make them stand out
+		eventType := event.Type
+		if eventType == "Warning" {
+			eventType = "⚠️  " + eventType
 		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n",
+			event.ClusterName,
+			event.Age,
+			eventType,
+			event.Reason,
+			event.Count,
+			event.Message,
+		)
 	}
 
-	return summary
+	return nil
 }
 
-// countPodsByStatus counts pods in a specific status
-func countPodsByStatus(pods []workload.PodInfo, status string) int {
-	count := 0
-	for _, pod := range pods {
-		if pod.Status == status {
-			count++
+// sortPods orders pods by the requested key
+// Ties always fall back to cluster, namespace and name so output is deterministic
+func sortPods(pods []workload.PodInfo, sortBy string) error {
+	byName := func(i, j int) bool {
+		// Primary sort: cluster name (group by infrastructure)
+		// Secondary sort: namespace (group by application boundary)
+		// Tertiary sort: pod name (alphabetical within namespace)
+		if pods[i].ClusterName != pods[j].ClusterName {
+			return pods[i].ClusterName < pods[j].ClusterName
 		}
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
 	}
-	return count
-}
 
-// countUniquePodClusters counts unique clusters in the pod list
-func countUniquePodClusters(pods []workload.PodInfo) int {
-	clusters := make(map[string]bool)
-	for _, pod := range pods {
-		clusters[pod.ClusterName] = true
+	switch sortBy {
+	case "", "name":
+		sort.Slice(pods, byName)
+	case "restarts":
+		sort.Slice(pods, func(i, j int) bool {
+			if pods[i].Restarts != pods[j].Restarts {
+				return pods[i].Restarts > pods[j].Restarts
+			}
+			return byName(i, j)
+		})
+	case "age":
+		sort.Slice(pods, func(i, j int) bool {
+			if !pods[i].CreatedAt.Equal(pods[j].CreatedAt) {
+				return pods[i].CreatedAt.Before(pods[j].CreatedAt)
+			}
+			return byName(i, j)
+		})
+	default:
+		return fmt.Errorf("unknown --sort-by value %q (supported: name, restarts, age)", sortBy)
 	}
-	return len(clusters)
+	return nil
 }
 
-// getUniquePodClusters returns sorted list of unique cluster names
-func getUniquePodClusters(pods []workload.PodInfo) []string {
-	clusterSet := make(map[string]bool)
-	for _, pod := range pods {
-		clusterSet[pod.ClusterName] = true
+// flagPodsOnBadNodes lists the nodes of the clusters the pods came from and
+// marks the pods on NotReady or unreachable ones. A cluster whose nodes can't
+// be listed is reported as failed, since its pods couldn't be checked
+func flagPodsOnBadNodes(result workload.FleetResult[workload.PodInfo], clusters []string) {
+	nodes := workloadManager.ListNodes(clusters)
+	for name, err := range nodes.Errors {
+		if _, failed := result.Errors[name]; !failed {
+			result.Errors[name] = fmt.Errorf("failed to check nodes: %w", err)
+		}
 	}
+	workload.FlagPodsOnBadNodes(result.Items, nodes.Items)
+}
 
-	var clusters []string
-	for cluster := range clusterSet {
-		clusters = append(clusters, cluster)
+// filterUnhealthyPods keeps pods that are not Running or Succeeded
+// Per-cluster error entries are kept too - an unreachable cluster is unhealthy by definition
+func filterUnhealthyPods(pods []workload.PodInfo) []workload.PodInfo {
+	var unhealthy []workload.PodInfo
+	for _, pod := range pods {
+		if (pod.Status != "Running" && pod.Status != "Succeeded") || pod.NodeStatus != "" {
+			unhealthy = append(unhealthy, pod)
+		}
 	}
-
-	sort.Strings(clusters)
-	return clusters
+	return unhealthy
 }
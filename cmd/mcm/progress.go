@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// spinnerFrames animate clusters that are still deploying
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// isTerminal reports whether stdout is an interactive terminal
+// Live redrawing only makes sense there - in pipes and CI logs it turns into garbage
+func isTerminal() bool {
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// deployProgress renders one live-updating line per cluster during a deploy
+// This is like a departures board: every cluster keeps its row, only the status changes
+type deployProgress struct {
+	out      io.Writer
+	clusters []string
+	events   map[string]workload.DeployEvent
+	started  map[string]time.Time
+	elapsed  map[string]time.Duration
+	frame    int
+	drawn    bool
+}
+
+// newDeployProgress creates a renderer for the given clusters, all initially waiting
+func newDeployProgress(out io.Writer, clusters []string) *deployProgress {
+	return &deployProgress{
+		out:      out,
+		clusters: clusters,
+		events:   make(map[string]workload.DeployEvent),
+		started:  make(map[string]time.Time),
+		elapsed:  make(map[string]time.Duration),
+	}
+}
+
+// run consumes events until the channel is closed, redrawing on every event and spinner tick
+// Only this goroutine touches the renderer state, so no locking is needed
+func (p *deployProgress) run(events <-chan workload.DeployEvent) {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	p.draw()
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				p.draw()
+				return
+			}
+			p.record(event)
+		case <-ticker.C:
+			p.frame++
+		}
+		p.draw()
+	}
+}
+
+// record stores the latest phase for a cluster and tracks how long it took
+func (p *deployProgress) record(event workload.DeployEvent) {
+	switch event.Phase {
+	case workload.DeployStarted:
+		p.started[event.Cluster] = time.Now()
+	case workload.DeployDone, workload.DeployFailed:
+		p.elapsed[event.Cluster] = time.Since(p.started[event.Cluster]).Round(100 * time.Millisecond)
+	}
+	p.events[event.Cluster] = event
+}
+
+// draw repaints every cluster line in place using ANSI cursor movement
+func (p *deployProgress) draw() {
+	if p.drawn {
+		// Move back up to the first cluster line before repainting
+		fmt.Fprintf(p.out, "\033[%dA", len(p.clusters))
+	}
+	p.drawn = true
+
+	for _, cluster := range p.clusters {
+		fmt.Fprint(p.out, "\r\033[K")
+		fmt.Fprintln(p.out, p.line(cluster))
+	}
+}
+
+// line renders the status of a single cluster
+func (p *deployProgress) line(cluster string) string {
+	event, seen := p.events[cluster]
+	if !seen {
+		return fmt.Sprintf("   %s: waiting", cluster)
+	}
+
+	switch event.Phase {
+	case workload.DeployDone:
+		return fmt.Sprintf("✅ %s: done (%s)", cluster, p.elapsed[cluster])
+	case workload.DeployFailed:
+		return fmt.Sprintf("❌ %s: failed (%s)", cluster, p.elapsed[cluster])
+	default:
+		frame := spinnerFrames[p.frame%len(spinnerFrames)]
+		return fmt.Sprintf("%s  %s: %s", frame, cluster, event.Phase)
+	}
+}
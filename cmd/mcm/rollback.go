@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"sigs.k8s.io/yaml"
+
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// newDeploymentsRollbackCmd creates the 'deployments rollback' subcommand
+// When a release goes wrong the fastest fix is usually the last version that
+// worked, on every cluster the release reached
+func newDeploymentsRollbackCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback DEPLOYMENT",
+		Short: "Roll a deployment back to an earlier revision across clusters",
+		Long: `Roll a deployment back to its previous revision in each target cluster, like
+kubectl rollout undo. Kubernetes keeps the pod template of every recent rollout
+in a ReplicaSet numbered by the deployment.kubernetes.io/revision annotation;
+the template of the chosen revision is copied back into the deployment, which
+then rolls out as usual.
+
+- Without --to-revision, each cluster goes back to the revision before its
+  current one, so clusters on different versions each step back once
+- --to-revision=N goes back to revision N; a cluster that no longer keeps it
+  fails (see spec.revisionHistoryLimit)
+- A cluster with no earlier revision, or with a paused deployment, fails on
+  its own; the other clusters are still rolled back
+- A cluster already running the requested revision is left alone
+
+The deployment controller records the rollback as a new revision, as it does
+for kubectl. Rollbacks go to the clusters chosen the same way as for 'mcm
+deploy' (--clusters, --all-clusters, --exclude, otherwise the default cluster),
+and clusters under a change freeze are skipped unless --ignore-freeze is given.
+
+Examples:
+  mcm deployments rollback web -n production --all-clusters
+  mcm deployments rollback web -n production --clusters=prod-eu --to-revision=4
+  mcm deployments rollback web --clusters=@prod-all --yes`,
+
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			namespace := cmd.Flag("namespace").Value.String()
+			if namespace == "" {
+				namespace = appConfig.DefaultNamespace
+			}
+			toRevision, _ := cmd.Flags().GetInt64("to-revision")
+			if toRevision < 0 {
+				return fmt.Errorf("--to-revision must be a positive revision number, got %d", toRevision)
+			}
+
+			clusters, err := parseDeploymentTargets(cmd)
+			if err != nil {
+				return fmt.Errorf("failed to determine target clusters: %w", err)
+			}
+			ignoreFreeze, _ := cmd.Flags().GetBool("ignore-freeze")
+			clusters, err = filterFrozenClusters(clusters, namespace, ignoreFreeze)
+			if err != nil {
+				return err
+			}
+
+			if err := confirmChanges(cmd, "roll back deployment "+name, clusters); err != nil {
+				return err
+			}
+
+			result := workloadManager.RollbackDeployment(clusters, namespace, name, toRevision)
+			if err := outputRollbacks(result); err != nil {
+				return err
+			}
+			if len(result.Errors) > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("deployment not rolled back on %d of %d clusters", len(result.Errors), len(clusters))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("clusters", "", "comma-separated list of cluster names or @groups")
+	cmd.Flags().Bool("all-clusters", false, "roll back the deployment in all connected clusters")
+	cmd.Flags().String("exclude", "", "comma-separated list of clusters or @groups to exclude (used with --all-clusters)")
+	cmd.Flags().StringP("namespace", "n", "", "namespace of the deployment (default from config)")
+	cmd.Flags().Int64("to-revision", 0, "revision to roll back to (default: the one before the current revision)")
+	cmd.Flags().Bool("ignore-freeze", false, "roll back even in clusters or namespaces under a change freeze")
+	addYesFlag(cmd)
+
+	return cmd
+}
+
+// outputRollbacks renders which revision each cluster was rolled back to
+func outputRollbacks(result workload.FleetResult[workload.RollbackResult]) error {
+	rollbacks := result.Items
+	sort.Slice(rollbacks, func(i, j int) bool {
+		return rollbacks[i].ClusterName < rollbacks[j].ClusterName
+	})
+
+	switch viper.GetString("output") {
+	case "json":
+		jsonData, err := json.MarshalIndent(struct {
+			Rollbacks []workload.RollbackResult `json:"rollbacks"`
+			Errors    map[string]string         `json:"errors,omitempty"`
+		}{Rollbacks: rollbacks, Errors: result.ErrorMessages()}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal rollbacks to JSON: %w", err)
+		}
+		fmt.Println(string(jsonData))
+		return nil
+	case "yaml":
+		yamlData, err := yaml.Marshal(struct {
+			Rollbacks []workload.RollbackResult `json:"rollbacks"`
+			Errors    map[string]string         `json:"errors,omitempty"`
+		}{Rollbacks: rollbacks, Errors: result.ErrorMessages()})
+		if err != nil {
+			return fmt.Errorf("failed to marshal rollbacks to YAML: %w", err)
+		}
+		fmt.Print(string(yamlData))
+		return nil
+	}
+
+	writeRollbacks(os.Stdout, rollbacks)
+	printFleetFailures(os.Stdout, result)
+	return nil
+}
+
+// writeRollbacks prints the from/to revision table
+func writeRollbacks(out io.Writer, rollbacks []workload.RollbackResult) {
+	if len(rollbacks) == 0 {
+		return
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "CLUSTER\tNAMESPACE\tDEPLOYMENT\tFROM\tTO\tRESULT")
+	fmt.Fprintln(w, "-------\t---------\t----------\t----\t--\t------")
+	for _, rollback := range rollbacks {
+		outcome := "rolled back"
+		if !rollback.RolledBack() {
+			outcome = "already at revision"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", rollback.ClusterName, rollback.Namespace, rollback.Deployment,
+			strconv.FormatInt(rollback.FromRevision, 10), strconv.FormatInt(rollback.ToRevision, 10), outcome)
+	}
+	w.Flush()
+}
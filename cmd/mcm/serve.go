@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/celikgo/autoz-control-tower/internal/server"
+)
+
+// newServeCmd creates the serve command for running mcm as a daemon
+// Instead of answering one question and exiting, mcm keeps watching the fleet
+// and serves the latest status to anyone who asks over HTTP
+func newServeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve fleet status as a read-only HTTP API",
+		Long: `Run mcm continuously and expose cluster, deployment and pod status over HTTP.
+Status is refreshed in the background on a fixed interval and cached, so any
+number of dashboards can poll the API without adding load to your clusters.
+
+Endpoints:
+  /healthz       200 when at least one cluster is reachable, 503 otherwise
+  /clusters      Cluster connection status (same data as 'mcm clusters list')
+  /deployments   Deployments across all clusters and namespaces
+  /pods          Pods across all clusters and namespaces
+  /metrics       Fleet status in Prometheus text format
+  /events        Server-Sent Events stream of deployment/pod status changes
+
+Every JSON response includes a lastUpdated timestamp showing how fresh the
+cached data is. Add ?refresh=true to an endpoint to force a synchronous
+refresh of that resource type before responding (use sparingly on big fleets).
+
+The /events stream emits one JSON event per status transition detected by the
+background refresher, e.g. {"cluster":"prod-us","kind":"Deployment",
+"name":"web","oldStatus":"Partial","newStatus":"Ready",...}, so dashboards can
+follow rollouts live without polling the whole fleet.
+
+The server shuts down cleanly on Ctrl-C or SIGTERM,
+which makes it suitable for running as a container or systemd service.
+
+Examples:
+  mcm serve                                   # Listen on :8080, refresh every 30s
+  mcm serve --addr=127.0.0.1:9090             # Listen on a specific address
+  mcm serve --refresh-interval=1m             # Refresh less often on large fleets`,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, _ := cmd.Flags().GetString("addr")
+			interval, _ := cmd.Flags().GetDuration("refresh-interval")
+			if interval <= 0 {
+				return fmt.Errorf("--refresh-interval must be positive, got %s", interval)
+			}
+
+			// Stop serving cleanly when the process is asked to terminate
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			fmt.Printf("Serving fleet status on %s (refreshing every %s)\n", addr, interval)
+
+			srv := server.New(clusterManager, workloadManager, interval)
+			return srv.Run(ctx, addr)
+		},
+	}
+
+	cmd.Flags().String("addr", ":8080", "address to listen on")
+	cmd.Flags().Duration("refresh-interval", 30*time.Second, "how often to refresh fleet status")
+
+	return cmd
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/celikgo/autoz-control-tower/internal/output"
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// newServicesCmd creates the services command with its subcommands
+// When something can't reach an application, the service is the next thing to look at after its pods
+func newServicesCmd() *cobra.Command {
+	servicesCmd := &cobra.Command{
+		Use:     "services",
+		Aliases: []string{"svc"},
+		Short:   "View services across clusters",
+		Long: `The services command shows how applications are exposed in each cluster: the
+service type, its cluster IP, the ports, and - for LoadBalancer services - the
+address the cloud provisioned, which tells at a glance which regions have their
+external endpoints up.
+
+Examples:
+  mcm services list                                 # All services, all clusters
+  mcm services list -n production -l app=web        # One application's services
+  mcm services list --output=name                   # Just cluster/namespace/name, for scripting
+  mcm services list --environment=production --output=json | jq '.services[] | select(.pending)'`,
+	}
+
+	servicesCmd.AddCommand(newServicesListCmd())
+	return servicesCmd
+}
+
+// newServicesListCmd creates the 'services list' subcommand
+func newServicesListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List services across multiple clusters",
+		Long: `List services from all configured clusters or a subset, in parallel.
+
+EXTERNAL-ADDRESS shows where a service is reachable from outside its cluster:
+- LoadBalancer: the IPs or hostnames of the provisioned load balancer, or
+  <pending> while the cloud hasn't provisioned one (yet)
+- externalIPs set on the service
+- ExternalName: the DNS name it points at
+
+PORTS lists port/protocol, with the node port as port:nodePort/protocol.
+
+If any cluster can't be queried, the services from the others are still shown,
+and the command exits non-zero so scripts and CI notice the gap.`,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusters, err := targetClusters(cmd)
+			if err != nil {
+				return err
+			}
+			namespace := cmd.Flag("namespace").Value.String()
+			labelSelector := managedOnlySelector(cmd, cmd.Flag("selector").Value.String())
+			if err := applyListTimeouts(cmd); err != nil {
+				return err
+			}
+
+			if viper.GetString("output") == "object-yaml" {
+				return listObjectsYAML(cmd, clusters, "services",
+					workload.GetOptions{Namespace: namespace, LabelSelector: labelSelector},
+					excludedNamespaces(cmd, namespace == ""))
+			}
+
+			result := workloadManager.ListServices(clusters, namespace, labelSelector)
+			services := withoutNamespaces(result.Items, excludedNamespaces(cmd, namespace == ""),
+				func(service workload.ServiceInfo) string { return service.Namespace })
+			sort.Slice(services, func(i, j int) bool {
+				if services[i].ClusterName != services[j].ClusterName {
+					return services[i].ClusterName < services[j].ClusterName
+				}
+				if services[i].Namespace != services[j].Namespace {
+					return services[i].Namespace < services[j].Namespace
+				}
+				return services[i].Name < services[j].Name
+			})
+
+			switch viper.GetString("output") {
+			case "json":
+				err = output.ServicesJSON(os.Stdout, services, result.ErrorMessages())
+			case "yaml":
+				err = output.ServicesYAML(os.Stdout, services, result.ErrorMessages())
+			case "name":
+				// Keep failures out of the pipe
+				printFleetFailures(os.Stderr, result)
+				err = output.Names(os.Stdout, serviceNames(services))
+			default:
+				err = output.ServicesTable(os.Stdout, services)
+				if err == nil {
+					printFleetFailures(os.Stdout, result)
+				}
+			}
+			if err != nil {
+				return err
+			}
+			return fleetError(cmd, result)
+		},
+	}
+
+	cmd.Flags().String("clusters", "", "comma-separated list of cluster names or @groups")
+	cmd.Flags().StringP("namespace", "n", "", "namespace to list services from (default: all namespaces)")
+	cmd.Flags().StringP("selector", "l", "", "label selector to filter services (e.g., 'app=nginx,tier=frontend')")
+	addLocationFlags(cmd)
+	addManagedOnlyFlag(cmd)
+	addExcludeNamespaceFlag(cmd)
+	addListTimeoutFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"sigs.k8s.io/yaml"
+
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// newSnapshotCmd creates the snapshot command group
+// A snapshot is the fleet's answer to "what was running where, last Tuesday?" -
+// taken before risky changes, kept for audits, and restorable after a disaster
+func newSnapshotCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Save the fleet's deployed state and compare against it later",
+		Long: `Record what is deployed where at a point in time, and compare the fleet
+against such a record later.
+
+A snapshot holds the deployments, services and configmaps of every cluster
+(or the ones given with --clusters) as plain manifests: everything the API
+server assigns - UIDs, resource versions, timestamps, status, cluster IPs,
+node ports - is left out. The per-namespace kube-root-ca.crt configmap is
+skipped, as in diff-clusters.
+
+The snapshot file is a tar archive:
+
+  snapshot.yaml                    when it was taken, which clusters it covers,
+                                   and which clusters could not be read
+  clusters/<name>/resources.yaml   that cluster's resources, one YAML document each
+
+Each cluster directory is an ordinary manifest directory, so restoring a
+cluster is extracting the archive and syncing that directory:
+
+  tar -xf fleet.tar -C restore
+  mcm sync restore/clusters/prod-us --clusters=prod-us
+
+Unlike 'mcm sync', which makes clusters match a directory you maintain, a
+snapshot records whatever the clusters actually run - including changes no
+one committed anywhere.`,
+	}
+
+	cmd.AddCommand(newSnapshotSaveCmd())
+	cmd.AddCommand(newSnapshotDiffCmd())
+
+	return cmd
+}
+
+// newSnapshotSaveCmd creates the 'snapshot save' subcommand
+func newSnapshotSaveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "save FILE",
+		Short: "Save the deployments, services and configmaps of the fleet to a file",
+		Long: `Save the deployments, services and configmaps of every connected cluster
+to a snapshot archive. All namespaces are included unless --namespace is given.
+
+Clusters that cannot be read are recorded as such in the snapshot, and the
+command exits non-zero after writing it, so a scheduled backup can't quietly
+miss a cluster. The file may contain configmap data, so it is only readable
+by its owner.
+
+Examples:
+  mcm snapshot save fleet-$(date +%F).tar
+  mcm snapshot save payments.tar --namespace=payments --clusters=prod-us,prod-eu`,
+		Args: cobra.ExactArgs(1),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusters := parseClusterList(cmd.Flag("clusters").Value.String())
+			namespace := cmd.Flag("namespace").Value.String()
+
+			if err := applyListTimeouts(cmd); err != nil {
+				return err
+			}
+
+			snapshot := workloadManager.SnapshotFleet(clusters, namespace)
+
+			// Render the whole archive first, so a failure never leaves half a snapshot behind
+			var archive bytes.Buffer
+			if err := workload.WriteSnapshotArchive(&archive, snapshot); err != nil {
+				return err
+			}
+			if err := os.WriteFile(args[0], archive.Bytes(), 0600); err != nil {
+				return fmt.Errorf("failed to write snapshot: %w", err)
+			}
+
+			objects := 0
+			for _, cluster := range snapshot.Snapshots {
+				objects += len(cluster.Objects)
+			}
+			fmt.Printf("📸 Saved %d resources from %d clusters to %s\n", objects, len(snapshot.Clusters), args[0])
+
+			if len(snapshot.Errors) > 0 {
+				names := make([]string, 0, len(snapshot.Errors))
+				for name, message := range snapshot.Errors {
+					names = append(names, name)
+					fmt.Fprintf(os.Stderr, "❌ %s: %s\n", name, message)
+				}
+				sort.Strings(names)
+				return fmt.Errorf("snapshot is incomplete: %d clusters could not be read (%s)",
+					len(names), summarizeNames(names, 5))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("clusters", "", "comma-separated list of cluster names or @groups (default: all connected clusters)")
+	cmd.Flags().StringP("namespace", "n", "", "only save this namespace (default: all namespaces)")
+	addListTimeoutFlags(cmd)
+
+	return cmd
+}
+
+// newSnapshotDiffCmd creates the 'snapshot diff' subcommand
+func newSnapshotDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff FILE",
+		Short: "Compare the fleet with a saved snapshot",
+		Long: `Compare each cluster in a snapshot with what it runs now and report, per
+cluster, the resources that were removed since the snapshot, the ones added
+since, and the ones changed, with the differing fields.
+
+Only the clusters and namespace the snapshot covers are compared. Fields the
+API server assigns are ignored, as in diff-clusters.
+
+Examples:
+  mcm snapshot diff fleet-2026-10-01.tar
+  mcm snapshot diff fleet-2026-10-01.tar --clusters=prod-us --output=json`,
+		Args: cobra.ExactArgs(1),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusters := parseClusterList(cmd.Flag("clusters").Value.String())
+
+			if err := applyListTimeouts(cmd); err != nil {
+				return err
+			}
+
+			file, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to open snapshot: %w", err)
+			}
+			snapshot, err := workload.ReadSnapshotArchive(file)
+			file.Close()
+			if err != nil {
+				return fmt.Errorf("failed to read snapshot %s: %w", args[0], err)
+			}
+
+			result := workloadManager.DiffSnapshot(snapshot, clusters)
+			diffs := result.Items
+			sort.Slice(diffs, func(i, j int) bool { return diffs[i].Cluster < diffs[j].Cluster })
+
+			switch outputFormat := viper.GetString("output"); outputFormat {
+			case "json", "yaml":
+				return outputSnapshotDiffs(snapshot, diffs, result.ErrorMessages(), outputFormat)
+			default:
+				outputSnapshotDiffsText(snapshot, diffs)
+				printFleetFailures(os.Stdout, result)
+				return nil
+			}
+		},
+	}
+
+	cmd.Flags().String("clusters", "", "comma-separated list of cluster names or @groups to compare (default: every cluster in the snapshot)")
+	addListTimeoutFlags(cmd)
+
+	return cmd
+}
+
+// outputSnapshotDiffsText prints one diff report per cluster
+func outputSnapshotDiffsText(snapshot *workload.FleetSnapshot, diffs []workload.SnapshotDiff) {
+	scope := "all namespaces"
+	if snapshot.Namespace != "" {
+		scope = "namespace " + snapshot.Namespace
+	}
+	fmt.Printf("Comparing %s with the snapshot taken %s\n",
+		scope, snapshot.TakenAt.Local().Format(time.DateTime))
+	if len(snapshot.Errors) > 0 {
+		fmt.Printf("⚠️  The snapshot is incomplete: %d clusters could not be read when it was taken\n", len(snapshot.Errors))
+	}
+
+	for _, diff := range diffs {
+		fmt.Printf("\n=== %s ===\n", diff.Cluster)
+		outputDiffSections(diff.ClusterDiff)
+	}
+}
+
+// outputSnapshotDiffs renders the per-cluster diffs as JSON or YAML
+func outputSnapshotDiffs(snapshot *workload.FleetSnapshot, diffs []workload.SnapshotDiff, failures map[string]string, outputFormat string) error {
+	output := struct {
+		TakenAt   time.Time               `json:"takenAt"`
+		Namespace string                  `json:"namespace,omitempty"`
+		Clusters  []workload.SnapshotDiff `json:"clusters"`
+		Errors    map[string]string       `json:"errors,omitempty"`
+	}{TakenAt: snapshot.TakenAt, Namespace: snapshot.Namespace, Clusters: diffs, Errors: failures}
+
+	if outputFormat == "yaml" {
+		yamlData, err := yaml.Marshal(output)
+		if err != nil {
+			return fmt.Errorf("failed to marshal snapshot diff to YAML: %w", err)
+		}
+		fmt.Print(string(yamlData))
+		return nil
+	}
+
+	jsonData, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot diff to JSON: %w", err)
+	}
+	fmt.Println(string(jsonData))
+	return nil
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/celikgo/autoz-control-tower/internal/output"
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// ClusterSummary condenses one cluster's resources into a single row
+// This is the "wall display" view - one glance per cluster instead of per resource
+type ClusterSummary struct {
+	Cluster  string `json:"cluster" yaml:"cluster"`
+	Total    int    `json:"total" yaml:"total"`
+	Ready    int    `json:"ready" yaml:"ready"`
+	NotReady int    `json:"notReady" yaml:"notReady"`
+	Error    string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// summarizeDeploymentsByCluster aggregates deployments into per-cluster counts
+// Only fully Ready deployments count as ready; Partial ones need attention too
+func summarizeDeploymentsByCluster(deployments []workload.DeploymentInfo, failures map[string]error) []ClusterSummary {
+	summaries := failedClusterSummaries(failures)
+	for _, deployment := range deployments {
+		summary := clusterSummaryFor(summaries, deployment.ClusterName)
+		summary.Total++
+		if deployment.Status == "Ready" {
+			summary.Ready++
+		} else {
+			summary.NotReady++
+		}
+	}
+	return sortedClusterSummaries(summaries)
+}
+
+// summarizePodsByCluster aggregates pods into per-cluster counts
+// Running and Succeeded pods are healthy; everything else is counted as not ready
+func summarizePodsByCluster(pods []workload.PodInfo, failures map[string]error) []ClusterSummary {
+	summaries := failedClusterSummaries(failures)
+	for _, pod := range pods {
+		summary := clusterSummaryFor(summaries, pod.ClusterName)
+		summary.Total++
+		if pod.Status == "Running" || pod.Status == "Succeeded" {
+			summary.Ready++
+		} else {
+			summary.NotReady++
+		}
+	}
+	return sortedClusterSummaries(summaries)
+}
+
+// failedClusterSummaries starts the summary map with a row for every cluster that
+// failed, so unreachable clusters still get their line on the wall display
+func failedClusterSummaries(failures map[string]error) map[string]*ClusterSummary {
+	summaries := make(map[string]*ClusterSummary, len(failures))
+	for name, err := range failures {
+		summaries[name] = &ClusterSummary{Cluster: name, Error: err.Error()}
+	}
+	return summaries
+}
+
+// clusterSummaryFor returns the summary row for a cluster, creating it on first use
+func clusterSummaryFor(summaries map[string]*ClusterSummary, cluster string) *ClusterSummary {
+	summary, ok := summaries[cluster]
+	if !ok {
+		summary = &ClusterSummary{Cluster: cluster}
+		summaries[cluster] = summary
+	}
+	return summary
+}
+
+// sortedClusterSummaries flattens the summary map into a list ordered by cluster name
+func sortedClusterSummaries(summaries map[string]*ClusterSummary) []ClusterSummary {
+	result := make([]ClusterSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		result = append(result, *summary)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Cluster < result[j].Cluster
+	})
+	return result
+}
+
+// outputClusterSummaries renders compact per-cluster rows in the requested format
+func outputClusterSummaries(out io.Writer, summaries []ClusterSummary, outputFormat string) error {
+	switch outputFormat {
+	case "json":
+		jsonData, err := json.MarshalIndent(struct {
+			Clusters []ClusterSummary `json:"clusters"`
+		}{Clusters: summaries}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal summary to JSON: %w", err)
+		}
+		fmt.Fprintln(out, string(jsonData))
+		return nil
+	case "yaml":
+		yamlData, err := yaml.Marshal(struct {
+			Clusters []ClusterSummary `yaml:"clusters"`
+		}{Clusters: summaries})
+		if err != nil {
+			return fmt.Errorf("failed to marshal summary to YAML: %w", err)
+		}
+		fmt.Fprint(out, string(yamlData))
+		return nil
+	case "name":
+		names := make([]string, 0, len(summaries))
+		for _, summary := range summaries {
+			names = append(names, summary.Cluster)
+		}
+		return output.Names(out, names)
+	}
+
+	if len(summaries) == 0 {
+		fmt.Fprintln(out, "No clusters returned any results.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	defer w.Flush()
+
+	fmt.Fprintln(w, "CLUSTER\tTOTAL\tREADY\tNOT READY\tSTATUS")
+	fmt.Fprintln(w, "-------\t-----\t-----\t---------\t------")
+
+	for _, summary := range summaries {
+		// One icon per cluster is all a wall display needs to draw the eye
+		status := "✅"
+		switch {
+		case summary.Error != "":
+			status = "❌ " + summary.Error
+		case summary.NotReady > 0:
+			status = "⚠️"
+		}
+
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\n",
+			summary.Cluster,
+			summary.Total,
+			summary.Ready,
+			summary.NotReady,
+			status,
+		)
+	}
+
+	return nil
+}
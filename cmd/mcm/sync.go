@@ -0,0 +1,444 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+
+	"github.com/celikgo/autoz-control-tower/internal/config"
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// newSyncCmd creates the sync command for GitOps-style fleet reconciliation
+// Where deploy pushes a single manifest, sync treats a whole directory as the
+// source of truth and converges every target cluster towards it
+func newSyncCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync [DIRECTORY]",
+		Short: "Reconcile clusters against a directory of manifests",
+		Long: `Reconcile one or more clusters against a directory of Kubernetes manifests.
+This is the building block for running mcm as a GitOps loop: point it at a
+checked-out git directory and every target cluster is brought in line with it.
+
+For each cluster, sync works out what needs to happen to every object:
+- create:    the object does not exist in the cluster yet
+- update:    the object exists but differs from the manifest; for ConfigMaps
+             and Secrets the changed keys are listed too (Secret values are
+             never shown, only which keys were added, removed or changed)
+- unchanged: the live object already matches the manifest
+- prune:     the object was created by this sync source but is no longer
+             in the directory (only with --prune)
+
+Objects are applied with server-side apply and labelled with
+app.kubernetes.io/managed-by=mcm and mcm.io/sync-id=<id> so pruning never
+touches resources owned by other tools. Pruning only inspects kinds that are
+still present in the directory.
+
+The id defaults to the directory name plus a hash of where the directory
+lives, so two directories with the same name never prune each other's objects.
+In a git checkout with an origin remote that is the remote and the path within
+the repository, so every clone - a laptop's, each CI job's - gets the same id.
+Anywhere else it is the absolute path, which changes when the directory moves:
+there, and in CI without a git checkout, pass --sync-id, or --prune never
+removes what earlier runs applied.
+
+Safety features:
+- --diff-only reports what would change across the fleet without applying
+- --rate limits API write operations per cluster so large syncs are gentle
+- --resume skips clusters already synced to the same revision, so an
+  interrupted fleet-wide sync picks up where it left off
+
+Examples:
+  mcm sync ./manifests --all-clusters --diff-only     # Preview fleet-wide changes
+  mcm sync ./manifests --clusters=prod-us,prod-eu     # Sync specific clusters
+  mcm sync ./manifests --all-clusters --prune         # Also delete removed objects
+  mcm sync ./manifests --all-clusters --resume        # Continue an interrupted sync`,
+
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := args[0]
+
+			info, err := os.Stat(dir)
+			if err != nil {
+				return fmt.Errorf("manifest directory not found: %s", dir)
+			}
+			if !info.IsDir() {
+				return fmt.Errorf("%s is not a directory", dir)
+			}
+
+			desired, err := workload.LoadManifestDir(dir)
+			if err != nil {
+				return err
+			}
+			if len(desired) == 0 {
+				return fmt.Errorf("no manifests found in %s", dir)
+			}
+
+			clusters, err := parseDeploymentTargets(cmd)
+			if err != nil {
+				return err
+			}
+
+			namespace := cmd.Flag("namespace").Value.String()
+			if namespace == "" {
+				namespace = appConfig.DefaultNamespace
+			}
+
+			syncID := cmd.Flag("sync-id").Value.String()
+			if syncID == "" {
+				syncID = defaultSyncID(dir)
+			} else if err := validateSyncID(syncID); err != nil {
+				return err
+			}
+
+			diffOnly, _ := cmd.Flags().GetBool("diff-only")
+			prune, _ := cmd.Flags().GetBool("prune")
+			rate, _ := cmd.Flags().GetFloat32("rate")
+			resume, _ := cmd.Flags().GetBool("resume")
+
+			revision := workload.Revision(desired)
+
+			// Load progress from previous runs so we can resume interrupted syncs
+			state, err := loadSyncState(syncID)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: ignoring unreadable sync state: %v\n", err)
+				state = &syncState{Clusters: make(map[string]syncClusterState)}
+			}
+
+			// Forget clusters that were removed from the configuration since the last run
+			for _, name := range state.pruneClusters(clusterManager.HasCluster) {
+				fmt.Fprintf(os.Stderr, "Forgetting sync state for cluster '%s': it is no longer in configuration\n", name)
+			}
+
+			if resume && !diffOnly {
+				var remaining []string
+				for _, name := range clusters {
+					if state.Clusters[name].Revision == revision {
+						fmt.Printf("Skipping %s: already synced to revision %s\n", name, revision)
+						continue
+					}
+					remaining = append(remaining, name)
+				}
+				clusters = remaining
+
+				if len(clusters) == 0 {
+					fmt.Printf("All target clusters are already synced to revision %s\n", revision)
+					return nil
+				}
+			}
+
+			if !diffOnly {
+				if err := confirmChanges(cmd, "sync "+dir, clusters); err != nil {
+					return err
+				}
+			}
+
+			mode := "Syncing"
+			if diffOnly {
+				mode = "Computing changes for"
+			}
+			fmt.Printf("%s %d objects from %s (revision %s) to %d clusters...\n",
+				mode, len(desired), dir, revision, len(clusters))
+			fmt.Printf("Target clusters: %s\n\n", strings.Join(clusters, ", "))
+
+			// Record each cluster as soon as it finishes, so interrupting a long
+			// fleet-wide sync keeps the clusters already done for --resume.
+			// A diff-only run changes nothing, so it must not count as progress
+			var record workload.SyncCallback
+			if !diffOnly {
+				var mu sync.Mutex
+				record = func(result workload.SyncResult) {
+					mu.Lock()
+					defer mu.Unlock()
+					state.record(result, revision, time.Now())
+					if err := saveSyncState(syncID, state); err != nil {
+						fmt.Fprintf(os.Stderr, "Warning: could not save sync state for %s: %v\n", result.ClusterName, err)
+					}
+				}
+			}
+
+			results := workloadManager.SyncClustersWithCallback(clusters, desired, workload.SyncOptions{
+				Namespace: namespace,
+				SyncID:    syncID,
+				DiffOnly:  diffOnly,
+				Prune:     prune,
+				RateLimit: rate,
+			}, record)
+
+			return reportSyncResults(results, viper.GetString("output"))
+		},
+	}
+
+	cmd.Flags().String("clusters", "", "comma-separated list of cluster names or @groups to sync")
+	cmd.Flags().Bool("all-clusters", false, "sync all configured clusters")
+	cmd.Flags().String("exclude", "", "comma-separated list of clusters or @groups to exclude (used with --all-clusters)")
+	cmd.Flags().StringP("namespace", "n", "", "namespace for objects that don't declare one (default: from config)")
+	cmd.Flags().Bool("diff-only", false, "report what would change without applying anything")
+	cmd.Flags().Bool("prune", false, "delete objects created by this sync source that are no longer in the directory (outside a git checkout, set --sync-id so the source is recognised from run to run)")
+	cmd.Flags().Float32("rate", 5, "maximum API write operations per second per cluster (0 for unlimited)")
+	cmd.Flags().String("sync-id", "", "identifier for objects owned by this sync source (default: directory name and a hash of its git remote and path in the repository, or of its absolute path)")
+	cmd.Flags().Bool("resume", false, "skip clusters already synced to the current revision")
+	addYesFlag(cmd)
+
+	return cmd
+}
+
+// reportSyncResults prints the per-cluster reconcile outcome
+// and returns an error if any cluster failed so loops and CI can react
+func reportSyncResults(results []workload.SyncResult, outputFormat string) error {
+	switch outputFormat {
+	case "json":
+		jsonData, err := json.MarshalIndent(struct {
+			Results []workload.SyncResult `json:"results"`
+		}{Results: results}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal sync results to JSON: %w", err)
+		}
+		fmt.Println(string(jsonData))
+	case "yaml":
+		yamlData, err := yaml.Marshal(struct {
+			Results []workload.SyncResult `json:"results"`
+		}{Results: results})
+		if err != nil {
+			return fmt.Errorf("failed to marshal sync results to YAML: %w", err)
+		}
+		fmt.Print(string(yamlData))
+	default:
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "CLUSTER\tKIND\tNAMESPACE\tNAME\tACTION\tERROR")
+		fmt.Fprintln(w, "-------\t----\t---------\t----\t------\t-----")
+		for _, result := range results {
+			if result.Error != "" {
+				fmt.Fprintf(w, "%s\t-\t-\t-\t-\t❌ %s\n", result.ClusterName, result.Error)
+				continue
+			}
+			for _, action := range result.Actions {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+					result.ClusterName,
+					action.Kind,
+					getValueOrDefault(action.Namespace, "-"),
+					action.Name,
+					getValueOrDefault(action.Action, "-"),
+					getValueOrDefault(action.Error, "-"),
+				)
+			}
+		}
+		w.Flush()
+		outputDataChanges(results)
+	}
+
+	var failed []string
+	for _, result := range results {
+		if result.Failed() {
+			failed = append(failed, result.ClusterName)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("sync failed on %d/%d clusters: %s", len(failed), len(results), strings.Join(failed, ", "))
+	}
+
+	if outputFormat != "json" && outputFormat != "yaml" {
+		fmt.Printf("\n✅ %d clusters reconciled\n", len(results))
+	}
+	return nil
+}
+
+// outputDataChanges lists, under the sync table, which ConfigMap and Secret keys
+// each update touches. Secret values are never shown, only that a key changed
+func outputDataChanges(results []workload.SyncResult) {
+	header := false
+	for _, result := range results {
+		for _, action := range result.Actions {
+			if len(action.Changes) == 0 {
+				continue
+			}
+			if !header {
+				fmt.Println("\nData changes:")
+				header = true
+			}
+
+			fmt.Printf("  %s: %s %s/%s\n", result.ClusterName, action.Kind, getValueOrDefault(action.Namespace, "-"), action.Name)
+			for _, change := range action.Changes {
+				fmt.Printf("    %s\n", formatDataChange(change))
+			}
+		}
+	}
+}
+
+// formatDataChange renders one key change, e.g. `~ LOG_LEVEL: "info" -> "debug"`
+func formatDataChange(change workload.DataChange) string {
+	switch change.Change {
+	case workload.DataKeyAdded:
+		if change.ValueHidden {
+			return fmt.Sprintf("+ %s (value hidden)", change.Key)
+		}
+		return fmt.Sprintf("+ %s: %s", change.Key, displayDataValue(change.To))
+	case workload.DataKeyRemoved:
+		return fmt.Sprintf("- %s", change.Key)
+	default:
+		if change.ValueHidden {
+			return fmt.Sprintf("~ %s (value hidden)", change.Key)
+		}
+		return fmt.Sprintf("~ %s: %s -> %s", change.Key, displayDataValue(change.From), displayDataValue(change.To))
+	}
+}
+
+// displayDataValue keeps ConfigMap values to one short line
+// Whole config files stored under a key are summarized by their line count
+func displayDataValue(value string) string {
+	if lines := strings.Count(strings.TrimSuffix(value, "\n"), "\n") + 1; lines > 1 {
+		return fmt.Sprintf("<%d lines>", lines)
+	}
+	if len(value) > 60 {
+		value = value[:57] + "..."
+	}
+	return fmt.Sprintf("%q", value)
+}
+
+// syncState remembers which revision each cluster was last synced to
+type syncState struct {
+	Clusters map[string]syncClusterState `json:"clusters"`
+}
+
+type syncClusterState struct {
+	Revision string    `json:"revision"`
+	SyncedAt time.Time `json:"syncedAt"`
+}
+
+// record notes a cluster's sync outcome: a success is synced to revision,
+// a failure has to be synced again
+func (s *syncState) record(result workload.SyncResult, revision string, now time.Time) {
+	if result.Failed() {
+		delete(s.Clusters, result.ClusterName)
+		return
+	}
+	s.Clusters[result.ClusterName] = syncClusterState{Revision: revision, SyncedAt: now}
+}
+
+// pruneClusters drops entries for clusters that no longer exist and returns their names
+func (s *syncState) pruneClusters(known func(name string) bool) []string {
+	var removed []string
+	for name := range s.Clusters {
+		if !known(name) {
+			removed = append(removed, name)
+			delete(s.Clusters, name)
+		}
+	}
+	sort.Strings(removed)
+	return removed
+}
+
+// syncStatePath returns where the state for a sync source is stored
+func syncStatePath(syncID string) (string, error) {
+	dir, err := config.CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "sync-"+syncID+".json"), nil
+}
+
+// loadSyncState reads the state for a sync source, returning empty state if none exists
+func loadSyncState(syncID string) (*syncState, error) {
+	state := &syncState{Clusters: make(map[string]syncClusterState)}
+
+	path, err := syncStatePath(syncID)
+	if err != nil {
+		return state, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return state, err
+	}
+	if state.Clusters == nil {
+		state.Clusters = make(map[string]syncClusterState)
+	}
+
+	return state, nil
+}
+
+// saveSyncState persists the state for a sync source
+// It is saved as each cluster finishes, so it is written to a temporary file and
+// renamed into place: an interrupted sync never leaves a half-written state behind
+func saveSyncState(syncID string, state *syncState) error {
+	path, err := syncStatePath(syncID)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// invalidLabelChars matches everything that is not allowed in a label value
+var invalidLabelChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// validateSyncID checks a --sync-id before it becomes a label value and part of
+// the state file's name, so "../x" can't write outside the cache directory
+func validateSyncID(syncID string) error {
+	if errs := validation.IsValidLabelValue(syncID); len(errs) > 0 {
+		return fmt.Errorf("invalid --sync-id %q: %s", syncID, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// defaultSyncID derives a label-safe sync identifier from the directory
+// Two directories both called "manifests" must not share an identifier - each
+// would prune the other's objects - so the directory name is followed by a
+// hash of where it lives. In a git checkout that is the origin remote and the
+// path within the repository, which stay the same wherever it is checked out,
+// CI workspaces included; elsewhere it is the absolute path
+func defaultSyncID(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		abs = dir
+	}
+	source := abs
+	if remote, relative, ok := gitSource(abs); ok {
+		source = remote + "//" + relative
+	}
+	sum := sha256.Sum256([]byte(source))
+	hash := hex.EncodeToString(sum[:4])
+
+	// Leave room for "-" and the hash within the 63 characters of a label value
+	name := invalidLabelChars.ReplaceAllString(filepath.Base(abs), "-")
+	name = strings.Trim(name, "-_.")
+	if len(name) > 63-len(hash)-1 {
+		name = strings.TrimRight(name[:63-len(hash)-1], "-_.")
+	}
+	if name == "" {
+		return hash
+	}
+
+	return name + "-" + hash
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/celikgo/autoz-control-tower/internal/output"
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// newTopCmd creates the top command with its nodes and pods subcommands
+// It answers "where is the fleet busy?" from the same metrics kubectl top reads
+func newTopCmd() *cobra.Command {
+	topCmd := &cobra.Command{
+		Use:   "top",
+		Short: "Show CPU and memory usage of nodes or pods across clusters",
+		Long: `Show the current CPU and memory usage of nodes or pods in every cluster, like
+kubectl top, from the resource metrics API (metrics.k8s.io) that metrics-server
+serves. CPU is shown in millicores (1000m = one core) and memory in Mi, busiest
+first.
+
+A cluster without metrics-server is named under the table rather than failing
+the command; any other failure to query a cluster still exits non-zero.
+
+Examples:
+  mcm top nodes                                # Every node in the default clusters
+  mcm top nodes --environment=production       # Production nodes, by CPU
+  mcm top pods -n production --sort-by=memory  # The hungriest pods in production
+  mcm top pods -l app=web --clusters=@prod-all`,
+	}
+
+	topCmd.AddCommand(newTopNodesCmd())
+	topCmd.AddCommand(newTopPodsCmd())
+	return topCmd
+}
+
+// newTopNodesCmd creates the 'top nodes' subcommand
+func newTopNodesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "nodes",
+		Short: "Show CPU and memory usage of nodes across clusters",
+		Long: `Show each node's current CPU and memory usage, busiest first. CPU% and
+MEMORY% are of the node's allocatable capacity, as in kubectl top nodes.`,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusters, err := targetClusters(cmd)
+			if err != nil {
+				return err
+			}
+			sortBy := cmd.Flag("sort-by").Value.String()
+			if err := validateUsageSort(sortBy); err != nil {
+				return err
+			}
+			if err := applyListTimeouts(cmd); err != nil {
+				return err
+			}
+
+			result, unavailable := withoutMetricsUnavailable(workloadManager.TopNodes(clusters))
+			nodes := result.Items
+			sortUsage(nodes, sortBy,
+				func(n workload.NodeUsage) (int64, int64) { return n.CPUMillicores, n.MemoryBytes },
+				func(n workload.NodeUsage) string { return n.ClusterName + "/" + n.Name })
+
+			switch viper.GetString("output") {
+			case "json":
+				err = output.NodeUsageJSON(os.Stdout, nodes, unavailable, result.ErrorMessages())
+			case "yaml":
+				err = output.NodeUsageYAML(os.Stdout, nodes, unavailable, result.ErrorMessages())
+			default:
+				err = output.NodeUsageTable(os.Stdout, nodes, unavailable)
+				if err == nil {
+					printFleetFailures(os.Stdout, result)
+				}
+			}
+			if err != nil {
+				return err
+			}
+			return fleetError(cmd, result)
+		},
+	}
+
+	addTopFlags(cmd)
+	return cmd
+}
+
+// newTopPodsCmd creates the 'top pods' subcommand
+func newTopPodsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pods",
+		Short: "Show CPU and memory usage of pods across clusters",
+		Long: `Show each pod's current CPU and memory usage, summed over its containers,
+busiest first. Pods that have only just started may not have metrics yet.`,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusters, err := targetClusters(cmd)
+			if err != nil {
+				return err
+			}
+			namespace := cmd.Flag("namespace").Value.String()
+			labelSelector := cmd.Flag("selector").Value.String()
+			sortBy := cmd.Flag("sort-by").Value.String()
+			if err := validateUsageSort(sortBy); err != nil {
+				return err
+			}
+			if err := applyListTimeouts(cmd); err != nil {
+				return err
+			}
+
+			result, unavailable := withoutMetricsUnavailable(workloadManager.TopPods(clusters, namespace, labelSelector))
+			pods := withoutNamespaces(result.Items, excludedNamespaces(cmd, namespace == ""),
+				func(pod workload.PodUsage) string { return pod.Namespace })
+			sortUsage(pods, sortBy,
+				func(p workload.PodUsage) (int64, int64) { return p.CPUMillicores, p.MemoryBytes },
+				func(p workload.PodUsage) string { return p.ClusterName + "/" + p.Namespace + "/" + p.Name })
+
+			switch viper.GetString("output") {
+			case "json":
+				err = output.PodUsageJSON(os.Stdout, pods, unavailable, result.ErrorMessages())
+			case "yaml":
+				err = output.PodUsageYAML(os.Stdout, pods, unavailable, result.ErrorMessages())
+			default:
+				err = output.PodUsageTable(os.Stdout, pods, unavailable)
+				if err == nil {
+					printFleetFailures(os.Stdout, result)
+				}
+			}
+			if err != nil {
+				return err
+			}
+			return fleetError(cmd, result)
+		},
+	}
+
+	cmd.Flags().StringP("namespace", "n", "", "namespace to show pods from (default: all namespaces)")
+	cmd.Flags().StringP("selector", "l", "", "label selector to filter pods (e.g., 'app=nginx')")
+	addExcludeNamespaceFlag(cmd)
+	addTopFlags(cmd)
+	return cmd
+}
+
+// addTopFlags registers the flags both top subcommands share
+func addTopFlags(cmd *cobra.Command) {
+	cmd.Flags().String("clusters", "", "comma-separated list of cluster names or @groups")
+	cmd.Flags().String("sort-by", "cpu", "sort order, highest first: cpu or memory")
+	addLocationFlags(cmd)
+	addListTimeoutFlags(cmd)
+}
+
+// validateUsageSort rejects a --sort-by top doesn't know
+func validateUsageSort(sortBy string) error {
+	if sortBy != "cpu" && sortBy != "memory" {
+		return fmt.Errorf("invalid --sort-by %q: must be cpu or memory", sortBy)
+	}
+	return nil
+}
+
+// sortUsage orders items by CPU or memory use, highest first, then by name so
+// equal usage still sorts the same way every run
+func sortUsage[T any](items []T, sortBy string, usage func(T) (cpu, memory int64), name func(T) string) {
+	sort.Slice(items, func(i, j int) bool {
+		firstI, secondI := usage(items[i])
+		firstJ, secondJ := usage(items[j])
+		if sortBy == "memory" {
+			firstI, secondI, firstJ, secondJ = secondI, firstI, secondJ, firstJ
+		}
+		if firstI != firstJ {
+			return firstI > firstJ
+		}
+		if secondI != secondJ {
+			return secondI > secondJ
+		}
+		return name(items[i]) < name(items[j])
+	})
+}
+
+// withoutMetricsUnavailable takes the clusters without metrics-server out of
+// the result's failures, so they're reported as a note instead of failing the
+// command, and returns their names sorted
+func withoutMetricsUnavailable[T any](result workload.FleetResult[T]) (workload.FleetResult[T], []string) {
+	var unavailable []string
+	failures := make(map[string]error, len(result.Errors))
+	for name, err := range result.Errors {
+		if errors.Is(err, workload.ErrMetricsUnavailable) {
+			unavailable = append(unavailable, name)
+			continue
+		}
+		failures[name] = err
+	}
+	sort.Strings(unavailable)
+
+	result.Errors = failures
+	return result, unavailable
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+func TestWithoutMetricsUnavailable(t *testing.T) {
+	result, unavailable := withoutMetricsUnavailable(workload.FleetResult[workload.NodeUsage]{
+		Errors: map[string]error{
+			"staging": fmt.Errorf("%w: the server could not find the requested resource", workload.ErrMetricsUnavailable),
+			"dev":     workload.ErrMetricsUnavailable,
+			"prod-ap": workload.ErrClusterTimeout,
+		},
+	})
+
+	if !reflect.DeepEqual(unavailable, []string{"dev", "staging"}) {
+		t.Errorf("Expected dev and staging without metrics-server, got %v", unavailable)
+	}
+	if len(result.Errors) != 1 || !errors.Is(result.Errors["prod-ap"], workload.ErrClusterTimeout) {
+		t.Errorf("Expected only the timeout to remain a failure, got %v", result.Errors)
+	}
+}
+
+func TestSortUsage(t *testing.T) {
+	pods := []workload.PodUsage{
+		{Name: "a", CPUMillicores: 100, MemoryBytes: 300},
+		{Name: "b", CPUMillicores: 500, MemoryBytes: 100},
+		{Name: "c", CPUMillicores: 100, MemoryBytes: 300},
+	}
+	usage := func(p workload.PodUsage) (int64, int64) { return p.CPUMillicores, p.MemoryBytes }
+	name := func(p workload.PodUsage) string { return p.Name }
+	names := func() []string { return []string{pods[0].Name, pods[1].Name, pods[2].Name} }
+
+	sortUsage(pods, "cpu", usage, name)
+	if got := names(); !reflect.DeepEqual(got, []string{"b", "a", "c"}) {
+		t.Errorf("By CPU: got %v", got)
+	}
+	sortUsage(pods, "memory", usage, name)
+	if got := names(); !reflect.DeepEqual(got, []string{"a", "c", "b"}) {
+		t.Errorf("By memory: got %v", got)
+	}
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/celikgo/autoz-control-tower/internal/output"
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// watchInterval is how often watch mode lists the fleet again, unless --interval says otherwise
+const watchInterval = 2 * time.Second
+
+// untilAllReady is the --until condition met once every deployment is Ready
+const untilAllReady = "all-ready"
+
+// watchOptions controls how often watch mode lists and when it stops on its own
+type watchOptions struct {
+	interval time.Duration // How long to wait between listings
+	until    string        // Condition that ends the watch successfully; empty watches until interrupted
+	timeout  time.Duration // Give up after this long; 0 means never
+}
+
+// addWatchFlags adds -w, its --interval, and the flags that let a watch end by itself
+func addWatchFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("watch", "w", false, "keep listing, every --interval, until interrupted")
+	cmd.Flags().Duration("interval", watchInterval, "with --watch, how long to wait between listings")
+	cmd.Flags().String("until", "", "with --watch, exit 0 once the condition holds: all-ready (every deployment Ready, every cluster answering)")
+	cmd.Flags().Duration("watch-timeout", 0, "with --watch, stop after this long; with --until, not getting there in time is an error (0 = no limit)")
+}
+
+// parseWatchOptions reads and checks the watch flags
+func parseWatchOptions(cmd *cobra.Command) (bool, watchOptions, error) {
+	watch, _ := cmd.Flags().GetBool("watch")
+	until, _ := cmd.Flags().GetString("until")
+	timeout, _ := cmd.Flags().GetDuration("watch-timeout")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	opts := watchOptions{interval: interval, until: until, timeout: timeout}
+
+	if until != "" && until != untilAllReady {
+		return false, opts, fmt.Errorf("unknown --until condition %q (supported: %s)", until, untilAllReady)
+	}
+	if timeout < 0 {
+		return false, opts, fmt.Errorf("--watch-timeout must not be negative, got %s", timeout)
+	}
+	if interval <= 0 {
+		return false, opts, fmt.Errorf("--interval must be positive, got %s", interval)
+	}
+	if !watch && (until != "" || timeout > 0 || cmd.Flags().Changed("interval")) {
+		return false, opts, fmt.Errorf("--until, --watch-timeout and --interval only apply with --watch")
+	}
+	return watch, opts, nil
+}
+
+// watchDeployments lists the fleet's deployments over and over, like watch(1)
+// On a terminal each listing replaces the last; in a CI log a listing is only
+// printed when it differs from the previous one, so the log stays readable.
+// Each listing flags the deployments whose status changed since the one before
+func watchDeployments(cmd *cobra.Command, opts watchOptions, list func() workload.FleetResult[workload.DeploymentInfo]) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	interactive := isTerminal()
+	var previous, status string
+	var statuses map[string]string // Nil for the first listing, which has nothing to compare with
+	err := watchLoop(ctx, opts.interval, opts.timeout, func() (bool, error) {
+		result := list()
+
+		var frame bytes.Buffer
+		if err := renderDeploymentList(cmd, &frame, &frame, result, statuses); err != nil {
+			return false, err
+		}
+		if interactive || frame.String() != previous {
+			writeWatchFrame(os.Stdout, cmd.CommandPath(), frame.String(), opts.interval, interactive, time.Now())
+		}
+		previous = frame.String()
+		statuses = deploymentStatuses(result, statuses)
+
+		if opts.until == "" {
+			return false, nil
+		}
+		var done bool
+		done, status = allDeploymentsReady(result)
+		return done, nil
+	})
+
+	switch {
+	case err == nil:
+		fmt.Fprintf(os.Stderr, "✅ %s\n", status)
+		return nil
+	case errors.Is(err, context.DeadlineExceeded):
+		if opts.until == "" {
+			return nil // The time limit was the whole point
+		}
+		return fmt.Errorf("gave up after %s waiting for %s: %s", opts.timeout, opts.until, status)
+	case errors.Is(err, context.Canceled):
+		if opts.until == "" {
+			return nil
+		}
+		return fmt.Errorf("interrupted while waiting for %s: %s", opts.until, status)
+	default:
+		return err
+	}
+}
+
+// watchLoop calls refresh right away and then every interval, until refresh
+// reports it is done or fails, ctx ends, or the timeout (if any) passes
+func watchLoop(ctx context.Context, interval, timeout time.Duration, refresh func() (bool, error)) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		done, err := refresh()
+		if err != nil || done {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// writeWatchFrame prints one listing under a header saying when it was taken
+// On a terminal the screen is cleared first, so the listing updates in place;
+// elsewhere listings follow each other, separated by a blank line
+func writeWatchFrame(out io.Writer, command, frame string, interval time.Duration, interactive bool, now time.Time) {
+	if interactive {
+		fmt.Fprint(out, "\033[H\033[2J")
+	}
+	fmt.Fprintf(out, "Every %s: %s  %s\n\n", interval, command, now.Format(time.TimeOnly))
+	fmt.Fprint(out, frame)
+	if !interactive {
+		fmt.Fprintln(out)
+	}
+}
+
+// deploymentStatuses records each listed deployment's status for the next
+// listing to compare with. A cluster that didn't answer this time keeps its
+// statuses from before, so its deployments aren't flagged as new when it's back
+func deploymentStatuses(result workload.FleetResult[workload.DeploymentInfo], previous map[string]string) map[string]string {
+	statuses := make(map[string]string, len(result.Items))
+	for _, deployment := range result.Items {
+		statuses[output.DeploymentKey(deployment)] = deployment.Status
+	}
+	for key, status := range previous {
+		clusterName, _, _ := strings.Cut(key, "/")
+		if _, failed := result.Errors[clusterName]; failed {
+			statuses[key] = status
+		}
+	}
+	return statuses
+}
+
+// allDeploymentsReady reports whether the --until=all-ready condition holds,
+// with a summary of what is still missing (or of success) for the user
+// A cluster that didn't answer could be hiding an unready deployment, and an
+// empty result more likely means nothing has been deployed yet than success,
+// so neither counts as ready
+func allDeploymentsReady(result workload.FleetResult[workload.DeploymentInfo]) (bool, string) {
+	var notReady []string
+	for _, deployment := range result.Items {
+		if deployment.Status != "Ready" {
+			notReady = append(notReady, fmt.Sprintf("%s/%s/%s %s",
+				deployment.ClusterName, deployment.Namespace, deployment.Name, deployment.Status))
+		}
+	}
+
+	var missing []string
+	if len(notReady) > 0 {
+		missing = append(missing, fmt.Sprintf("%d of %d deployments not ready (%s)",
+			len(notReady), len(result.Items), summarizeNames(notReady, 5)))
+	}
+	if len(result.Errors) > 0 {
+		clusters := make([]string, 0, len(result.Errors))
+		for name := range result.Errors {
+			clusters = append(clusters, name)
+		}
+		sort.Strings(clusters)
+		missing = append(missing, fmt.Sprintf("%d clusters did not answer (%s)", len(clusters), summarizeNames(clusters, 5)))
+	}
+	if len(result.Items) == 0 && len(result.Errors) == 0 {
+		missing = append(missing, "no deployments found")
+	}
+
+	if len(missing) > 0 {
+		return false, strings.Join(missing, "; ")
+	}
+	return true, fmt.Sprintf("all %d deployments are ready", len(result.Items))
+}
+
+// summarizeNames joins up to limit names, counting the rest
+func summarizeNames(names []string, limit int) string {
+	if len(names) <= limit {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(names[:limit], ", "), len(names)-limit)
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+func TestAllDeploymentsReady(t *testing.T) {
+	ready := workload.DeploymentInfo{ClusterName: "prod-us", Namespace: "web", Name: "api", Status: "Ready"}
+	partial := workload.DeploymentInfo{ClusterName: "prod-eu", Namespace: "web", Name: "api", Status: "Partial"}
+
+	tests := []struct {
+		name   string
+		result workload.FleetResult[workload.DeploymentInfo]
+		done   bool
+		status string
+	}{
+		{"all ready", workload.FleetResult[workload.DeploymentInfo]{Items: []workload.DeploymentInfo{ready}}, true, "all 1 deployments are ready"},
+		{"one partial", workload.FleetResult[workload.DeploymentInfo]{Items: []workload.DeploymentInfo{ready, partial}}, false, "1 of 2 deployments not ready (prod-eu/web/api Partial)"},
+		{"cluster down", workload.FleetResult[workload.DeploymentInfo]{
+			Items:  []workload.DeploymentInfo{ready},
+			Errors: map[string]error{"prod-ap": errors.New("connection refused")},
+		}, false, "1 clusters did not answer (prod-ap)"},
+		{"nothing yet", workload.FleetResult[workload.DeploymentInfo]{}, false, "no deployments found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done, status := allDeploymentsReady(tt.result)
+			if done != tt.done || status != tt.status {
+				t.Errorf("got (%v, %q), want (%v, %q)", done, status, tt.done, tt.status)
+			}
+		})
+	}
+}
+
+func TestWatchLoopStopsWhenDone(t *testing.T) {
+	calls := 0
+	err := watchLoop(context.Background(), time.Millisecond, time.Minute, func() (bool, error) {
+		calls++
+		return calls == 3, nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("got err=%v after %d refreshes, want success after 3", err, calls)
+	}
+}
+
+func TestWatchLoopTimesOut(t *testing.T) {
+	err := watchLoop(context.Background(), time.Millisecond, 20*time.Millisecond, func() (bool, error) {
+		return false, nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want the timeout to end the watch", err)
+	}
+}
+
+func TestParseWatchOptionsRequiresWatch(t *testing.T) {
+	cmd := newDeploymentsListCmd()
+	if err := cmd.Flags().Parse([]string{"--until=all-ready"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := parseWatchOptions(cmd); err == nil || !strings.Contains(err.Error(), "--watch") {
+		t.Errorf("got %v, want --until without --watch to be rejected", err)
+	}
+
+	cmd = newDeploymentsListCmd()
+	if err := cmd.Flags().Parse([]string{"-w", "--until=all-healthy"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := parseWatchOptions(cmd); err == nil || !strings.Contains(err.Error(), "all-healthy") {
+		t.Errorf("got %v, want an unknown condition to be rejected", err)
+	}
+}
+
+func TestParseWatchOptionsInterval(t *testing.T) {
+	cmd := newDeploymentsListCmd()
+	if err := cmd.Flags().Parse([]string{"-w", "--interval=10s"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, opts, err := parseWatchOptions(cmd); err != nil || opts.interval != 10*time.Second {
+		t.Errorf("got (%v, %v), want a 10s interval", opts.interval, err)
+	}
+
+	cmd = newDeploymentsListCmd()
+	if err := cmd.Flags().Parse([]string{"-w", "--interval=0s"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := parseWatchOptions(cmd); err == nil || !strings.Contains(err.Error(), "--interval") {
+		t.Errorf("got %v, want a zero interval to be rejected", err)
+	}
+
+	cmd = newDeploymentsListCmd()
+	if err := cmd.Flags().Parse([]string{"--interval=10s"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := parseWatchOptions(cmd); err == nil || !strings.Contains(err.Error(), "--watch") {
+		t.Errorf("got %v, want --interval without --watch to be rejected", err)
+	}
+}
+
+func TestDeploymentStatusesKeepFailedClusters(t *testing.T) {
+	previous := map[string]string{
+		"prod-us/web/api": "Partial",
+		"prod-eu/web/api": "Ready",
+	}
+	result := workload.FleetResult[workload.DeploymentInfo]{
+		Items:  []workload.DeploymentInfo{{ClusterName: "prod-us", Namespace: "web", Name: "api", Status: "Ready"}},
+		Errors: map[string]error{"prod-eu": workload.ErrClusterTimeout},
+	}
+
+	statuses := deploymentStatuses(result, previous)
+	if statuses["prod-us/web/api"] != "Ready" {
+		t.Errorf("Expected the fresh status to replace the old one, got %q", statuses["prod-us/web/api"])
+	}
+	if statuses["prod-eu/web/api"] != "Ready" {
+		t.Errorf("Expected a cluster that timed out to keep its statuses, got %v", statuses)
+	}
+}
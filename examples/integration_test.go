@@ -72,19 +72,19 @@ func TestFullWorkflow(t *testing.T) {
 
 	// Test listing deployments
 	t.Log("Testing deployment listing...")
-	deployments, err := workloadMgr.ListDeployments(nil, "")
-	if err != nil {
-		t.Fatalf("Failed to list deployments: %v", err)
+	deployments := workloadMgr.ListDeployments(nil, "", "")
+	for name, err := range deployments.Errors {
+		t.Fatalf("Failed to list deployments in %s: %v", name, err)
 	}
-	t.Logf("Found %d deployments in cluster", len(deployments))
+	t.Logf("Found %d deployments in cluster", len(deployments.Items))
 
 	// Test listing pods
 	t.Log("Testing pod listing...")
-	pods, err := workloadMgr.ListPods(nil, "", "")
-	if err != nil {
-		t.Fatalf("Failed to list pods: %v", err)
+	pods := workloadMgr.ListPods(nil, "", "", "")
+	for name, err := range pods.Errors {
+		t.Fatalf("Failed to list pods in %s: %v", name, err)
 	}
-	t.Logf("Found %d pods in cluster", len(pods))
+	t.Logf("Found %d pods in cluster", len(pods.Items))
 
 	// Step 7: Test deployment operation (if we have a test manifest)
 	testManifestPath := "nginx-deployment.yaml"
@@ -0,0 +1,76 @@
+// Package audit appends a JSON-lines record of fleet changes to a log file
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Writer appends one JSON record per line to an audit log
+// Parallel deploys share a Writer across goroutines, and several mcm processes
+// may point at the same file, so every record goes out as a single write under
+// both a mutex and an exclusive file lock. A reader never sees half a line.
+type Writer struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// Open opens path for appending, creating it and its directory if needed
+func Open(path string) (*Writer, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return &Writer{file: file}, nil
+}
+
+// Write appends record as one line of JSON
+func (w *Writer) Write(record any) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+	line = append(line, '\n')
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return fmt.Errorf("audit log is closed")
+	}
+
+	// The mutex covers our goroutines; the lock covers other processes
+	if err := lockFile(w.file); err != nil {
+		return fmt.Errorf("failed to lock audit log: %w", err)
+	}
+	defer unlockFile(w.file)
+
+	n, err := w.file.Write(line)
+	if err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+	if n != len(line) {
+		return fmt.Errorf("short write to audit log: wrote %d of %d bytes", n, len(line))
+	}
+	return nil
+}
+
+// Close closes the log; later writes return an error
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
@@ -0,0 +1,91 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type testRecord struct {
+	Writer  int    `json:"writer"`
+	Seq     int    `json:"seq"`
+	Payload string `json:"payload"`
+}
+
+func TestConcurrentWritersKeepLinesIntact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "audit.jsonl")
+
+	// Separate Writers have separate file handles, standing in for separate
+	// mcm processes; the goroutines on each stand in for parallel deploys
+	const writers, goroutines, records = 3, 8, 50
+	payload := strings.Repeat("x", 8192)
+
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		w, err := Open(path)
+		if err != nil {
+			t.Fatalf("Open() error: %v", err)
+		}
+		defer w.Close()
+
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func(id int) {
+				defer wg.Done()
+				for seq := 0; seq < records; seq++ {
+					if err := w.Write(testRecord{Writer: id, Seq: seq, Payload: payload}); err != nil {
+						t.Errorf("Write() error: %v", err)
+						return
+					}
+				}
+			}(i*goroutines + g)
+		}
+	}
+	wg.Wait()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	seen := map[int]int{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024)
+	lines := 0
+	for scanner.Scan() {
+		lines++
+		var rec testRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			t.Fatalf("line %d is not valid JSON: %v", lines, err)
+		}
+		if rec.Seq != seen[rec.Writer] {
+			t.Errorf("writer %d: got seq %d, want %d", rec.Writer, rec.Seq, seen[rec.Writer])
+		}
+		seen[rec.Writer]++
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := writers * goroutines * records; lines != want {
+		t.Errorf("got %d lines, want %d", lines, want)
+	}
+}
+
+func TestWriteAfterClose(t *testing.T) {
+	w, err := Open(filepath.Join(t.TempDir(), "audit.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(testRecord{}); err == nil {
+		t.Error("Write() after Close() should fail")
+	}
+}
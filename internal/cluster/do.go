@@ -0,0 +1,123 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+)
+
+// DefaultCallTimeout bounds one operation against a cluster, retries included,
+// when the configuration doesn't set callTimeout
+const DefaultCallTimeout = 60 * time.Second
+
+// ErrCallTimeout marks an operation that ran out of its call timeout
+var ErrCallTimeout = errors.New("call timed out")
+
+// RetryPolicy bounds how often and how patiently transient API errors are retried
+// This is like redialing a busy phone line - a few times, waiting longer each time
+type RetryPolicy struct {
+	Attempts     int           // Total attempts including the first one
+	InitialDelay time.Duration // Delay before the first retry, doubled after each failure
+	MaxDelay     time.Duration // Upper bound for any single delay, including Retry-After
+}
+
+// DefaultRetryPolicy rides out short API server hiccups (restarts, throttling)
+// without holding a whole fleet-wide operation hostage to one unhealthy cluster
+var DefaultRetryPolicy = RetryPolicy{
+	Attempts:     4,
+	InitialDelay: 500 * time.Millisecond,
+	MaxDelay:     8 * time.Second,
+}
+
+// Do runs one operation against the cluster with the client's reliability rules
+// applied in one place: the call timeout bounds the whole operation, transient
+// errors are retried with backoff, and anything else fails fast.
+//
+// fn must be safe to run more than once - make it read before it writes, so
+// a retry after a write that did land finds it done. Operations that aren't,
+// like creating from a generateName, belong in DoOnce
+func (c *ClusterClient) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	policy := c.Retry
+	if policy.Attempts <= 0 {
+		policy = DefaultRetryPolicy
+	}
+	return c.do(ctx, policy, fn)
+}
+
+// DoOnce is Do without retries, for operations that must not be repeated
+func (c *ClusterClient) DoOnce(ctx context.Context, fn func(ctx context.Context) error) error {
+	return c.do(ctx, RetryPolicy{Attempts: 1}, fn)
+}
+
+// do applies the call timeout around a retried operation
+func (c *ClusterClient) do(ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) error) error {
+	timeout := c.CallTimeout
+	if timeout <= 0 {
+		timeout = DefaultCallTimeout
+	}
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := withRetry(callCtx, policy, IsRetryable, func() error { return fn(callCtx) })
+
+	// Only our own deadline is reported as a call timeout; a caller's deadline
+	// or cancellation is theirs to explain
+	if err != nil && ctx.Err() == nil && errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s on cluster %s: %w", ErrCallTimeout, timeout, c.Config.Name, err)
+	}
+	return err
+}
+
+// withRetry runs fn until it succeeds, fails with an error retryable doesn't
+// accept, runs out of attempts, or the context is done
+func withRetry(ctx context.Context, policy RetryPolicy, retryable func(error) bool, fn func() error) error {
+	delay := policy.InitialDelay
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn()
+		if err == nil || !retryable(err) || attempt >= policy.Attempts {
+			return err
+		}
+
+		// Honor the server's Retry-After hint when it gives one
+		wait := delay
+		if seconds, ok := apierrors.SuggestsClientDelay(err); ok {
+			wait = time.Duration(seconds) * time.Second
+		}
+		if wait > policy.MaxDelay {
+			wait = policy.MaxDelay
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+
+		delay *= 2
+		if delay > policy.MaxDelay {
+			delay = policy.MaxDelay
+		}
+	}
+}
+
+// IsRetryable reports whether an API error is likely to go away on its own
+// Authorization and not-found errors fail fast - retrying them only adds latency
+func IsRetryable(err error) bool {
+	switch {
+	case apierrors.IsTooManyRequests(err),
+		apierrors.IsServerTimeout(err),
+		apierrors.IsTimeout(err),
+		apierrors.IsInternalError(err),
+		apierrors.IsServiceUnavailable(err):
+		return true
+	case utilnet.IsConnectionReset(err), utilnet.IsProbableEOF(err):
+		return true
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+// fastRetryPolicy keeps tests quick while exercising the same code paths
+var fastRetryPolicy = RetryPolicy{Attempts: 3, InitialDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+func TestDoRecoversFromThrottling(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+
+	calls := 0
+	clientset.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		calls++
+		if calls == 1 {
+			return true, nil, apierrors.NewTooManyRequests("slow down", 0)
+		}
+		return false, nil, nil
+	})
+
+	client := &ClusterClient{Clientset: clientset, Retry: fastRetryPolicy}
+	err := client.Do(context.Background(), func(ctx context.Context) error {
+		_, listErr := client.Clientset.CoreV1().Pods("default").List(ctx, metav1.ListOptions{})
+		return listErr
+	})
+
+	if err != nil {
+		t.Fatalf("Expected retry to succeed, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 list calls, got %d", calls)
+	}
+}
+
+func TestDoFailsFastOnForbidden(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+
+	calls := 0
+	clientset.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		calls++
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", nil)
+	})
+
+	client := &ClusterClient{Clientset: clientset, Retry: fastRetryPolicy}
+	err := client.Do(context.Background(), func(ctx context.Context) error {
+		_, listErr := client.Clientset.CoreV1().Pods("default").List(ctx, metav1.ListOptions{})
+		return listErr
+	})
+
+	if !apierrors.IsForbidden(err) {
+		t.Fatalf("Expected forbidden error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected a single list call, got %d", calls)
+	}
+}
+
+func TestDoGivesUpAfterAttempts(t *testing.T) {
+	calls := 0
+	client := &ClusterClient{Retry: fastRetryPolicy}
+	err := client.Do(context.Background(), func(ctx context.Context) error {
+		calls++
+		return apierrors.NewServiceUnavailable("apiserver is shutting down")
+	})
+
+	if err == nil {
+		t.Fatal("Expected an error after exhausting attempts")
+	}
+	if calls != fastRetryPolicy.Attempts {
+		t.Errorf("Expected %d attempts, got %d", fastRetryPolicy.Attempts, calls)
+	}
+}
+
+func TestDoOnceNeverRetries(t *testing.T) {
+	calls := 0
+	client := &ClusterClient{Retry: fastRetryPolicy}
+	err := client.DoOnce(context.Background(), func(ctx context.Context) error {
+		calls++
+		return apierrors.NewServiceUnavailable("apiserver is shutting down")
+	})
+
+	if err == nil || calls != 1 {
+		t.Errorf("Expected one failed attempt, got %d attempts and err=%v", calls, err)
+	}
+}
+
+func TestDoReportsItsOwnTimeout(t *testing.T) {
+	client := &ClusterClient{Config: config.ClusterConfig{Name: "prod"}, CallTimeout: 10 * time.Millisecond}
+	err := client.Do(context.Background(), func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	if !errors.Is(err, ErrCallTimeout) || !strings.Contains(err.Error(), "prod") {
+		t.Errorf("Expected a call timeout naming the cluster, got %v", err)
+	}
+
+	// A deadline the caller set is the caller's to explain
+	client.CallTimeout = time.Minute
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = client.Do(ctx, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if errors.Is(err, ErrCallTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the caller's deadline to pass through, got %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// DefaultCredentialTimeout is how long an exec credential plugin may take when
+// the configuration doesn't set credentialTimeout
+const DefaultCredentialTimeout = 60 * time.Second
+
+// maxPluginStderr caps how much of a failed plugin's stderr ends up in an error
+const maxPluginStderr = 1024
+
+// ExecPluginError is an exec credential plugin (aws eks get-token,
+// gke-gcloud-auth-plugin, kubelogin...) that failed to hand over credentials,
+// with what it printed on stderr - usually the only useful part, e.g. an
+// expired SSO session
+type ExecPluginError struct {
+	Command string
+	Stderr  string
+	Err     error
+}
+
+func (e *ExecPluginError) Error() string {
+	if e.Stderr == "" {
+		return fmt.Sprintf("credential plugin %s failed: %v", e.Command, e.Err)
+	}
+	return fmt.Sprintf("credential plugin %s failed: %v; plugin stderr: %s", e.Command, e.Err, e.Stderr)
+}
+
+func (e *ExecPluginError) Unwrap() error {
+	return e.Err
+}
+
+// isCredentialError tells a request that failed because the exec plugin
+// couldn't produce credentials, which client-go reports as "getting credentials: ..."
+func isCredentialError(err error) bool {
+	return strings.Contains(err.Error(), "getting credentials: ")
+}
+
+// execWrapperArg, as mcm's first argument, runs the rest of the command line
+// as a credential plugin on client-go's behalf; see ServeExecWrapper
+const execWrapperArg = "__exec-credential-plugin"
+
+// ServeExecWrapper runs a credential plugin when mcm was started as its
+// wrapper, and reports whether it was; args are the process's arguments
+// client-go passes a plugin's stderr straight to ours and keeps only the exit
+// code, so Options.ExecWrapper puts mcm in between: the plugin's stdin, stdout
+// and exit code pass through, and its stderr goes to the terminal as before
+// and to a file the connecting mcm reads if the plugin fails
+func ServeExecWrapper(args []string) (code int, served bool) {
+	if len(args) < 4 || args[1] != execWrapperArg {
+		return 0, false
+	}
+
+	stderr := io.Writer(os.Stderr)
+	// Never created here: once the connection check is over the file is gone,
+	// and later token refreshes only need the terminal
+	if file, err := os.OpenFile(args[2], os.O_WRONLY|os.O_APPEND, 0); err == nil {
+		defer file.Close()
+		stderr = io.MultiWriter(os.Stderr, ignoreErrors{file})
+	}
+
+	cmd := exec.Command(args[3], args[4:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+			return exitErr.ExitCode(), true
+		}
+		fmt.Fprintln(stderr, err)
+		return 1, true
+	}
+	return 0, true
+}
+
+// ignoreErrors writes what it can to w, but never fails: losing the copy of a
+// plugin's stderr mustn't cut off what the user sees of it
+type ignoreErrors struct {
+	w io.Writer
+}
+
+func (i ignoreErrors) Write(p []byte) (int, error) {
+	_, _ = i.w.Write(p)
+	return len(p), nil
+}
+
+// wrapExecPlugin makes the provider run its plugin through wrapper, the mcm
+// binary, so a failure's stderr can be reported. It returns the file the
+// stderr is copied to and a cleanup that removes it; with no wrapper, or a
+// plugin that can't be found (client-go explains that one best), the provider
+// is left alone and the path is empty
+func wrapExecPlugin(provider *clientcmdapi.ExecConfig, wrapper string) (stderrPath string, cleanup func()) {
+	if wrapper == "" {
+		return "", func() {}
+	}
+	if _, err := exec.LookPath(provider.Command); err != nil {
+		return "", func() {}
+	}
+	file, err := os.CreateTemp("", "mcm-credential-plugin-*.stderr")
+	if err != nil {
+		return "", func() {}
+	}
+	file.Close()
+
+	args := append([]string{execWrapperArg, file.Name(), provider.Command}, provider.Args...)
+	provider.Command, provider.Args = wrapper, args
+	return file.Name(), func() { os.Remove(file.Name()) }
+}
+
+// execPluginError explains a failed credential plugin with what it printed on
+// stderr, as copied to stderrPath by wrapExecPlugin
+func execPluginError(command, stderrPath string, err error) error {
+	return &ExecPluginError{Command: command, Stderr: execPluginStderr(stderrPath), Err: err}
+}
+
+// execPluginStderr returns the end of a plugin's captured stderr, on one line
+func execPluginStderr(stderrPath string) string {
+	if stderrPath == "" {
+		return ""
+	}
+	data, err := os.ReadFile(stderrPath)
+	if err != nil {
+		return ""
+	}
+
+	output := strings.Join(strings.Fields(string(data)), " ")
+	if len(output) > maxPluginStderr {
+		output = "..." + output[len(output)-maxPluginStderr:]
+	}
+	return output
+}
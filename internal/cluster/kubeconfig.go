@@ -0,0 +1,96 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+// ExportKubeconfig builds one kubeconfig holding a context per configured cluster,
+// for tools that only understand kubeconfigs. Each context, cluster and user entry
+// is named after the mcm cluster, so "kubectl --context=prod-eu" means the same
+// cluster as "mcm --clusters=prod-eu".
+//
+// Entries are copied from the kubeconfigs they came from rather than built from a
+// connected client: exec plugins stay exec plugins (with the cluster's execEnv
+// added), so no short-lived token is ever written to disk. Relative certificate
+// paths are made absolute so the result works from any directory. The default
+// cluster becomes the current context. Every cluster that can't be exported is
+// reported; nothing is returned unless all of them can be
+func ExportKubeconfig(clusters []config.ClusterConfig) (*clientcmdapi.Config, error) {
+	exported := clientcmdapi.NewConfig()
+	loaded := make(map[string]*clientcmdapi.Config)
+
+	var errs []error
+	for _, clusterConfig := range clusters {
+		if err := exportCluster(exported, loaded, clusterConfig); err != nil {
+			errs = append(errs, fmt.Errorf("cluster '%s': %w", clusterConfig.Name, err))
+			continue
+		}
+		if clusterConfig.IsDefault {
+			exported.CurrentContext = clusterConfig.Name
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return exported, nil
+}
+
+// exportCluster copies one cluster's context, cluster and user entries into exported
+// Each kubeconfig file is read once, however many clusters share it. A cluster with
+// several candidate contexts is exported with the first, its primary: a kubeconfig
+// has no way to fail over
+func exportCluster(exported *clientcmdapi.Config, loaded map[string]*clientcmdapi.Config, clusterConfig config.ClusterConfig) error {
+	candidates := clusterConfig.CandidateContexts()
+	if len(candidates) == 0 {
+		return fmt.Errorf("no context configured")
+	}
+	clusterConfig.Context = candidates[0]
+
+	kubeconfigPath, err := resolveKubeconfigPath(clusterConfig)
+	if err != nil {
+		return err
+	}
+	source, ok := loaded[kubeconfigPath]
+	if !ok {
+		source, err = clientcmd.LoadFromFile(kubeconfigPath)
+		if err != nil {
+			return fmt.Errorf("failed to load kubeconfig: %w", err)
+		}
+		if err := clientcmd.ResolveLocalPaths(source); err != nil {
+			return fmt.Errorf("failed to resolve paths in %s: %w", kubeconfigPath, err)
+		}
+		loaded[kubeconfigPath] = source
+	}
+
+	kubeContext, ok := source.Contexts[clusterConfig.Context]
+	if !ok {
+		return fmt.Errorf("context '%s' not found in %s", clusterConfig.Context, kubeconfigPath)
+	}
+	server, ok := source.Clusters[kubeContext.Cluster]
+	if !ok {
+		return fmt.Errorf("cluster '%s' of context '%s' not found in %s", kubeContext.Cluster, clusterConfig.Context, kubeconfigPath)
+	}
+
+	name := clusterConfig.Name
+	exported.Clusters[name] = server.DeepCopy()
+
+	exportedContext := kubeContext.DeepCopy()
+	exportedContext.Cluster = name
+	exportedContext.AuthInfo = ""
+	if authInfo, ok := source.AuthInfos[kubeContext.AuthInfo]; ok {
+		user := authInfo.DeepCopy()
+		if user.Exec != nil && len(clusterConfig.ExecEnv) > 0 {
+			applyExecEnv(user.Exec, clusterConfig.ExecEnv)
+		}
+		exported.AuthInfos[name] = user
+		exportedContext.AuthInfo = name
+	}
+	exported.Contexts[name] = exportedContext
+	return nil
+}
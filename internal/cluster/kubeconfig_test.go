@@ -0,0 +1,89 @@
+package cluster
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+func TestExportKubeconfig(t *testing.T) {
+	dir := t.TempDir()
+	kubeconfig := filepath.Join(dir, "kubeconfig")
+	content := `apiVersion: v1
+kind: Config
+clusters:
+- name: eks-prod
+  cluster:
+    server: https://prod.example.com
+    certificate-authority: certs/prod-ca.crt
+- name: kind-dev
+  cluster:
+    server: https://127.0.0.1:6443
+contexts:
+- name: prod-admin
+  context: {cluster: eks-prod, user: aws-prod, namespace: web}
+- name: dev
+  context: {cluster: kind-dev, user: dev-user}
+users:
+- name: aws-prod
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1beta1
+      command: aws
+      args: [eks, get-token, --cluster-name, prod]
+- name: dev-user
+  user:
+    token: static-dev-token
+`
+	if err := os.WriteFile(kubeconfig, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	exported, err := ExportKubeconfig([]config.ClusterConfig{
+		{Name: "prod-us", Context: "prod-admin", KubeConfig: kubeconfig, ExecEnv: map[string]string{"AWS_PROFILE": "prod"}},
+		{Name: "dev", Context: "dev", KubeConfig: kubeconfig, IsDefault: true},
+	})
+	if err != nil {
+		t.Fatalf("ExportKubeconfig: %v", err)
+	}
+
+	if exported.CurrentContext != "dev" {
+		t.Errorf("Expected the default cluster as current context, got %q", exported.CurrentContext)
+	}
+	context := exported.Contexts["prod-us"]
+	if context == nil || context.Cluster != "prod-us" || context.AuthInfo != "prod-us" || context.Namespace != "web" {
+		t.Fatalf("Expected a prod-us context pointing at prod-us entries, got %+v", context)
+	}
+	if ca := exported.Clusters["prod-us"].CertificateAuthority; ca != filepath.Join(dir, "certs", "prod-ca.crt") {
+		t.Errorf("Expected the CA path made absolute, got %q", ca)
+	}
+
+	exec := exported.AuthInfos["prod-us"].Exec
+	if exec == nil || exec.Command != "aws" || exported.AuthInfos["prod-us"].Token != "" {
+		t.Fatalf("Expected the exec plugin to be kept without a token, got %+v", exported.AuthInfos["prod-us"])
+	}
+	if len(exec.Env) != 1 || exec.Env[0].Name != "AWS_PROFILE" || exec.Env[0].Value != "prod" {
+		t.Errorf("Expected the cluster's execEnv on the exec plugin, got %+v", exec.Env)
+	}
+}
+
+func TestExportKubeconfigReportsEveryBrokenCluster(t *testing.T) {
+	kubeconfig := writeTestKubeconfig(t, "https://127.0.0.1:6443")
+
+	_, err := ExportKubeconfig([]config.ClusterConfig{
+		{Name: "ok", Context: "test", KubeConfig: kubeconfig},
+		{Name: "typo", Context: "tset", KubeConfig: kubeconfig},
+		{Name: "gone", Context: "test", KubeConfig: filepath.Join(t.TempDir(), "missing")},
+	})
+	if err == nil {
+		t.Fatal("Expected an error for the broken clusters")
+	}
+	for _, name := range []string{"typo", "gone"} {
+		if !strings.Contains(err.Error(), "cluster '"+name+"'") {
+			t.Errorf("Expected cluster %s to be reported, got %v", name, err)
+		}
+	}
+}
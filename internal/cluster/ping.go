@@ -0,0 +1,128 @@
+package cluster
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+// DefaultPingTimeout bounds each TCP dial made by PingClusters
+const DefaultPingTimeout = 2 * time.Second
+
+// PingResult is whether one cluster's API server accepts TCP connections
+// It says nothing about credentials: a reachable cluster can still refuse us,
+// which is exactly the difference between a network and an auth problem
+type PingResult struct {
+	Name      string `json:"name"`
+	Server    string `json:"server,omitempty"`  // API server URL, credentials stripped
+	Address   string `json:"address,omitempty"` // The host:port that was dialed
+	Reachable bool   `json:"reachable"`
+	Error     string `json:"error,omitempty"`
+
+	// Latency is the time to establish the TCP connection; LatencyMs is the
+	// same in milliseconds, for scripts
+	Latency   time.Duration `json:"-"`
+	LatencyMs float64       `json:"latencyMs,omitempty"`
+}
+
+// PingClusters dials every cluster's API server in parallel, without TLS or
+// authentication, and reports which ones answered and how fast
+// Only the kubeconfig is read, so this works even when no cluster can be
+// connected to properly. Proxy settings are not applied: the dial goes straight
+// to the API server, as a plain network check should
+func PingClusters(clusters []config.ClusterConfig, timeout time.Duration) []PingResult {
+	if timeout <= 0 {
+		timeout = DefaultPingTimeout
+	}
+
+	results := make([]PingResult, len(clusters))
+	var wg sync.WaitGroup
+	for i, clusterConfig := range clusters {
+		wg.Add(1)
+		go func(i int, cc config.ClusterConfig) {
+			defer wg.Done()
+			results[i] = pingCluster(cc, timeout)
+		}(i, clusterConfig)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// pingCluster resolves one cluster's API server and opens a TCP connection to it
+// With several candidate contexts, each is pinged in turn until one answers
+func pingCluster(clusterConfig config.ClusterConfig, timeout time.Duration) PingResult {
+	candidates := clusterConfig.CandidateContexts()
+	if len(candidates) <= 1 {
+		return pingContext(clusterConfig, timeout)
+	}
+
+	var result PingResult
+	for _, candidate := range candidates {
+		attempt := clusterConfig
+		attempt.Context = candidate
+		if result = pingContext(attempt, timeout); result.Reachable {
+			break
+		}
+	}
+	return result
+}
+
+// pingContext pings the API server a cluster's Context resolves to
+func pingContext(clusterConfig config.ClusterConfig, timeout time.Duration) PingResult {
+	result := PingResult{Name: clusterConfig.Name}
+
+	restConfig, err := loadRestConfig(clusterConfig)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Server = DisplayServer(restConfig.Host)
+
+	address, err := dialAddress(restConfig.Host)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Address = address
+
+	start := time.Now()
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Latency = time.Since(start)
+	result.LatencyMs = float64(result.Latency.Microseconds()) / 1000
+	conn.Close()
+
+	result.Reachable = true
+	return result
+}
+
+// dialAddress turns an API server URL into the host:port to dial, filling in
+// the scheme's default port, e.g. https://api.example.com -> api.example.com:443
+func dialAddress(server string) (string, error) {
+	// rest.Config allows a bare host:port, which is served over HTTPS
+	if !strings.Contains(server, "://") {
+		server = "https://" + server
+	}
+	u, err := url.Parse(server)
+	if err != nil || u.Hostname() == "" {
+		return "", fmt.Errorf("cannot parse API server address %q", DisplayServer(server))
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = "443"
+		if u.Scheme == "http" {
+			port = "80"
+		}
+	}
+	return net.JoinHostPort(u.Hostname(), port), nil
+}
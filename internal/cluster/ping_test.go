@@ -0,0 +1,61 @@
+package cluster
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+func TestPingClusters(t *testing.T) {
+	// Anything that accepts TCP connections will do; ping never speaks HTTP
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	// A port that was just freed is as good as a cluster behind a broken VPN
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedAddress := closed.Addr().String()
+	closed.Close()
+
+	results := PingClusters([]config.ClusterConfig{
+		{Name: "up", Context: "test", KubeConfig: writeTestKubeconfig(t, "https://"+listener.Addr().String())},
+		{Name: "down", Context: "test", KubeConfig: writeTestKubeconfig(t, "https://"+closedAddress)},
+		{Name: "no-context", KubeConfig: writeTestKubeconfig(t, "https://"+listener.Addr().String())},
+	}, time.Second)
+
+	if up := results[0]; !up.Reachable || up.Address != listener.Addr().String() || up.Latency <= 0 {
+		t.Errorf("Expected 'up' to be reachable, got %+v", up)
+	}
+	if down := results[1]; down.Reachable || down.Error == "" || down.Address != closedAddress {
+		t.Errorf("Expected 'down' to be unreachable with a reason, got %+v", down)
+	}
+	if broken := results[2]; broken.Reachable || broken.Address != "" || broken.Error == "" {
+		t.Errorf("Expected a cluster without a context not to be dialed, got %+v", broken)
+	}
+}
+
+func TestDialAddress(t *testing.T) {
+	tests := map[string]string{
+		"https://prod.example.com:6443":   "prod.example.com:6443",
+		"https://prod.example.com":        "prod.example.com:443",
+		"http://localhost":                "localhost:80",
+		"10.0.0.1:6443":                   "10.0.0.1:6443",
+		"https://[2001:db8::1]:6443/path": "[2001:db8::1]:6443",
+	}
+	for server, want := range tests {
+		if got, err := dialAddress(server); err != nil || got != want {
+			t.Errorf("dialAddress(%q) = %q, %v; want %q", server, got, err, want)
+		}
+	}
+
+	if _, err := dialAddress("https://"); err == nil {
+		t.Error("Expected an error for a server without a host")
+	}
+}
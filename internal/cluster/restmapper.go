@@ -0,0 +1,54 @@
+package cluster
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/restmapper"
+)
+
+// RESTMapper returns the cluster's kind-to-resource mapper, running discovery only
+// the first time it's asked for. Discovery walks every API group on the server, which
+// is far too slow to repeat for every apply - this is like printing the building
+// directory once instead of asking reception for each room number.
+//
+// Call InvalidateRESTMapper when a kind can't be resolved, so CRDs installed since
+// the mapper was built are picked up on the next call.
+func (m *Manager) RESTMapper(clusterName string) (meta.RESTMapper, error) {
+	client, err := m.GetClient(clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mutex.RLock()
+	mapper := client.restMapper
+	m.mutex.RUnlock()
+	if mapper != nil {
+		return mapper, nil
+	}
+
+	// Discover without holding the lock - it's a network round trip per API group,
+	// and other clusters' lookups shouldn't wait on it. Two callers racing here
+	// both discover and the last one wins, which is harmless
+	groupResources, err := restmapper.GetAPIGroupResources(client.Clientset.Discovery())
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover API resources: %w", err)
+	}
+	mapper = restmapper.NewDiscoveryRESTMapper(groupResources)
+
+	m.mutex.Lock()
+	client.restMapper = mapper
+	m.mutex.Unlock()
+
+	return mapper, nil
+}
+
+// InvalidateRESTMapper drops a cluster's cached mapper so the next RESTMapper call rediscovers
+func (m *Manager) InvalidateRESTMapper(clusterName string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if client, exists := m.clients[clusterName]; exists {
+		client.restMapper = nil
+	}
+}
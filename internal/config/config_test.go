@@ -1,9 +1,16 @@
 package config
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+
+	"sigs.k8s.io/yaml"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -18,6 +25,8 @@ clusters:
   - name: "test-cluster"
     context: "test-context"
     environment: "test"
+    provider: "aws"
+    account: "012345678901"
     default: true
 `
 
@@ -44,6 +53,11 @@ clusters:
 	if config.Clusters[0].Name != "test-cluster" {
 		t.Errorf("Expected cluster name 'test-cluster', got '%s'", config.Clusters[0].Name)
 	}
+
+	// Quoted account IDs keep their leading zeros
+	if config.Clusters[0].Provider != "aws" || config.Clusters[0].Account != "012345678901" {
+		t.Errorf("Expected provider aws and account 012345678901, got %q and %q", config.Clusters[0].Provider, config.Clusters[0].Account)
+	}
 }
 
 func TestValidateConfig(t *testing.T) {
@@ -68,6 +82,27 @@ func TestValidateConfig(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "candidate contexts",
+			config: &MultiClusterConfig{
+				Clusters: []ClusterConfig{{Name: "test", Contexts: []string{"primary", "standby"}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "both context and contexts",
+			config: &MultiClusterConfig{
+				Clusters: []ClusterConfig{{Name: "test", Context: "primary", Contexts: []string{"primary", "standby"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "repeated candidate context",
+			config: &MultiClusterConfig{
+				Clusters: []ClusterConfig{{Name: "test", Contexts: []string{"primary", "primary"}}},
+			},
+			wantErr: true,
+		},
 		{
 			name: "duplicate cluster names",
 			config: &MultiClusterConfig{
@@ -78,6 +113,79 @@ func TestValidateConfig(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid server pattern",
+			config: &MultiClusterConfig{
+				Clusters: []ClusterConfig{
+					{Name: "test", Context: "test-context", ServerPattern: "https://(prod"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative timeout",
+			config: &MultiClusterConfig{
+				Clusters: []ClusterConfig{{Name: "test", Context: "test-context"}},
+				Timeout:  -5,
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative concurrency",
+			config: &MultiClusterConfig{
+				Clusters:    []ClusterConfig{{Name: "test", Context: "test-context"}},
+				Concurrency: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative connect retries",
+			config: &MultiClusterConfig{
+				Clusters:       []ClusterConfig{{Name: "test", Context: "test-context"}},
+				ConnectRetries: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative credential timeout",
+			config: &MultiClusterConfig{
+				Clusters:          []ClusterConfig{{Name: "test", Context: "test-context"}},
+				CredentialTimeout: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative list page size",
+			config: &MultiClusterConfig{
+				Clusters:     []ClusterConfig{{Name: "test", Context: "test-context"}},
+				ListPageSize: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid excluded namespace",
+			config: &MultiClusterConfig{
+				Clusters:          []ClusterConfig{{Name: "test", Context: "test-context"}},
+				ExcludeNamespaces: []string{"kube-system", "Kube_Public"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown connect mode",
+			config: &MultiClusterConfig{
+				Clusters:    []ClusterConfig{{Name: "test", Context: "test-context"}},
+				ConnectMode: "on-demand",
+			},
+			wantErr: true,
+		},
+		{
+			name: "large timeout is only a warning",
+			config: &MultiClusterConfig{
+				Clusters: []ClusterConfig{{Name: "test", Context: "test-context"}},
+				Timeout:  30000,
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -89,3 +197,636 @@ func TestValidateConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestSettingWarnings(t *testing.T) {
+	sane := &MultiClusterConfig{Timeout: 30, CallTimeout: 60, Concurrency: 10, ConnectRetries: 3}
+	if warnings := settingWarnings(sane); len(warnings) != 0 {
+		t.Errorf("Expected no warnings for sane settings, got %v", warnings)
+	}
+
+	absurd := &MultiClusterConfig{Timeout: 30000, CallTimeout: 60000, Concurrency: 500, ConnectRetries: 30, CredentialTimeout: 3600}
+	if warnings := settingWarnings(absurd); len(warnings) != 5 {
+		t.Errorf("Expected a warning per absurd setting, got %v", warnings)
+	}
+}
+
+func TestWriteConfigFileKeepsBackup(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+
+	if err := WriteConfigFile(configPath, []byte("version: 1\n")); err != nil {
+		t.Fatalf("First write failed: %v", err)
+	}
+	if _, err := os.Stat(BackupPath(configPath)); !os.IsNotExist(err) {
+		t.Error("Expected no backup after the first write")
+	}
+
+	if err := WriteConfigFile(configPath, []byte("version: 2\n")); err != nil {
+		t.Fatalf("Second write failed: %v", err)
+	}
+
+	backup, err := os.ReadFile(BackupPath(configPath))
+	if err != nil {
+		t.Fatalf("Expected backup to exist: %v", err)
+	}
+	if string(backup) != "version: 1\n" {
+		t.Errorf("Expected backup to hold the previous version, got %q", backup)
+	}
+
+	// Restoring swaps the versions so the restore itself can be undone
+	if err := RestoreConfigFile(configPath); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	current, _ := os.ReadFile(configPath)
+	backup, _ = os.ReadFile(BackupPath(configPath))
+	if string(current) != "version: 1\n" || string(backup) != "version: 2\n" {
+		t.Errorf("Expected versions to be swapped, got current %q backup %q", current, backup)
+	}
+
+	// No temp files should be left behind
+	entries, _ := os.ReadDir(filepath.Dir(configPath))
+	if len(entries) != 2 {
+		t.Errorf("Expected only config and backup in directory, got %d entries", len(entries))
+	}
+}
+
+func TestFindProjectConfig(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "services", "web")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if path := FindProjectConfig(nested); path != "" && strings.HasPrefix(path, root) {
+		t.Errorf("Expected no project config yet, got %s", path)
+	}
+
+	rootConfig := filepath.Join(root, ProjectConfigName)
+	if err := os.WriteFile(rootConfig, []byte("clusters: []\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if path := FindProjectConfig(nested); path != rootConfig {
+		t.Errorf("Expected %s from a subdirectory, got %s", rootConfig, path)
+	}
+
+	// The nearest one wins, like a nested .gitignore
+	servicesConfig := filepath.Join(root, "services", ProjectConfigName)
+	if err := os.WriteFile(servicesConfig, []byte("clusters: []\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if path := FindProjectConfig(nested); path != servicesConfig {
+		t.Errorf("Expected nearer %s, got %s", servicesConfig, path)
+	}
+}
+
+func TestProjectConfigTakesPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	for _, name := range []string{ProjectConfigName, "mcm-config.yaml"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("clusters: []\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if path, _ := findDefaultConfigPath(true); filepath.Base(path) != ProjectConfigName {
+		t.Errorf("Expected a trusted %s to win over mcm-config.yaml, got %s", ProjectConfigName, path)
+	}
+}
+
+func TestWorkingDirConfigIsMarkedAsProject(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	content := "clusters:\n  - name: \"dev\"\n    context: \"dev\"\n"
+	if err := os.WriteFile("mcm-config.yaml", []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Found in the working directory, so it may be a checked-out repository's
+	found, err := LoadConfigWithOptions("", LoadOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.ProjectConfig != workingDirConfig {
+		t.Errorf("Expected ProjectConfig %s, got %q", workingDirConfig, found.ProjectConfig)
+	}
+
+	// Named with --config, it's the user's own
+	named, err := LoadConfigWithOptions("mcm-config.yaml", LoadOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if named.ProjectConfig != "" {
+		t.Errorf("Expected a config given explicitly not to count as a project config, got %q", named.ProjectConfig)
+	}
+}
+
+func TestProjectConfigRequiresTrust(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	userConfig := filepath.Join(home, ".mcm", "config.yaml")
+	if err := os.MkdirAll(filepath.Dir(userConfig), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(userConfig, []byte("clusters: []\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := filepath.Join(t.TempDir(), "repo")
+	nested := filepath.Join(repo, "services")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+	project := filepath.Join(repo, ProjectConfigName)
+	if err := os.WriteFile(project, []byte("clusters: []\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(nested)
+
+	// Found, but not trusted: the user config stays in charge, and says why
+	path, note := FindConfigPath(false)
+	if path != userConfig {
+		t.Errorf("Expected the untrusted project config to be passed over for %s, got %s", userConfig, path)
+	}
+	if !strings.Contains(note, "Ignoring project config "+project) || !strings.Contains(note, "--trust-project-config") {
+		t.Errorf("Expected a note explaining how to trust %s, got %q", project, note)
+	}
+
+	// Trusted for this run with --trust-project-config
+	if path, note := FindConfigPath(true); path != project || !strings.Contains(note, "Using project config "+project) {
+		t.Errorf("Expected %s with a note, got %s (%q)", project, path, note)
+	}
+
+	// Trusted for good from the user config
+	trusted := fmt.Sprintf("clusters: []\ntrustedProjects: [%q]\n", repo)
+	if err := os.WriteFile(userConfig, []byte(trusted), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if path, _ := FindConfigPath(false); path != project {
+		t.Errorf("Expected %s once listed in trustedProjects, got %s", project, path)
+	}
+}
+
+func TestDefaultsAcceptNaturalYAMLValues(t *testing.T) {
+	data := []byte(`
+defaults:
+  deployments list:
+    output: json
+    full-image: true
+    limit: 5
+    clusters: [prod-us, prod-eu]
+`)
+	var cfg MultiClusterConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]FlagValue{"output": "json", "full-image": "true", "limit": "5", "clusters": "prod-us,prod-eu"}
+	for name, value := range want {
+		if got := cfg.Defaults["deployments list"][name]; got != value {
+			t.Errorf("Expected %s to be %q, got %q", name, value, got)
+		}
+	}
+
+	if err := yaml.Unmarshal([]byte("defaults: {pods list: {output: {format: json}}}"), &cfg); err == nil {
+		t.Error("Expected a nested map to be rejected as a flag default")
+	}
+}
+
+func TestPermissionWarning(t *testing.T) {
+	plain := &MultiClusterConfig{Clusters: []ClusterConfig{{Name: "dev", ExecEnv: map[string]string{"AWS_PROFILE": "dev"}}}}
+	secret := &MultiClusterConfig{Clusters: []ClusterConfig{{Name: "prod", ExecEnv: map[string]string{"AWS_SECRET_ACCESS_KEY": "x"}}}}
+	webhook := &MultiClusterConfig{Notify: DeployNotify{Webhook: "https://hooks.slack.com/services/T000/B000/secret"}}
+
+	tests := []struct {
+		name          string
+		mode          os.FileMode
+		config        *MultiClusterConfig
+		wantWarning   bool
+		wantEscalated bool
+	}{
+		{name: "private file", mode: 0600, config: secret, wantWarning: false},
+		{name: "world readable", mode: 0644, config: plain, wantWarning: true, wantEscalated: false},
+		{name: "world readable with credentials", mode: 0644, config: secret, wantWarning: true, wantEscalated: true},
+		{name: "world readable with a webhook", mode: 0644, config: webhook, wantWarning: true, wantEscalated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(configPath, []byte("clusters: []\n"), tt.mode); err != nil {
+				t.Fatalf("Failed to write test config: %v", err)
+			}
+			// Undo any umask so the mode under test is the one on disk
+			if err := os.Chmod(configPath, tt.mode); err != nil {
+				t.Fatalf("Failed to chmod test config: %v", err)
+			}
+
+			warning := permissionWarning(configPath, tt.config)
+			if (warning != "") != tt.wantWarning {
+				t.Fatalf("Expected warning=%v, got %q", tt.wantWarning, warning)
+			}
+			if escalated := strings.Contains(warning, "contains credentials"); escalated != tt.wantEscalated {
+				t.Errorf("Expected escalated=%v, got %q", tt.wantEscalated, warning)
+			}
+		})
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	original := &MultiClusterConfig{Clusters: []ClusterConfig{{
+		Name:    "prod",
+		ExecEnv: map[string]string{"AWS_PROFILE": "prod", "AWS_SECRET_ACCESS_KEY": "hunter2"},
+	}}}
+
+	redacted := original.Redacted()
+
+	if got := redacted.Clusters[0].ExecEnv["AWS_SECRET_ACCESS_KEY"]; got != RedactedValue {
+		t.Errorf("Expected secret to be redacted, got %q", got)
+	}
+	if got := redacted.Clusters[0].ExecEnv["AWS_PROFILE"]; got != "prod" {
+		t.Errorf("Expected non-sensitive value to be kept, got %q", got)
+	}
+	if original.Clusters[0].ExecEnv["AWS_SECRET_ACCESS_KEY"] != "hunter2" {
+		t.Error("Redacted must not modify the original configuration")
+	}
+
+	original.Notify.Webhook = "https://hooks.slack.com/services/T000/B000/secret"
+	if got := original.Redacted().Notify.Webhook; got != "https://hooks.slack.com/"+RedactedValue {
+		t.Errorf("Expected the webhook's path to be redacted, got %q", got)
+	}
+}
+
+func TestParseManagedByLabel(t *testing.T) {
+	key, value, err := ParseManagedByLabel(DefaultManagedByLabel)
+	if err != nil || key != "app.kubernetes.io/managed-by" || value != "mcm" {
+		t.Errorf("ParseManagedByLabel(default) = %q, %q, %v", key, value, err)
+	}
+
+	for _, invalid := range []string{"mcm", "=mcm", "bad key=mcm", "team=not valid"} {
+		if _, _, err := ParseManagedByLabel(invalid); err == nil {
+			t.Errorf("Expected %q to be rejected", invalid)
+		}
+	}
+}
+
+func TestLoadConfigSkipsInvalidClusters(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := `
+clusters:
+  - name: "prod-us"
+    context: "prod-us"
+  - name: "prod-eu"
+    context: "prod-eu"
+    kubeconfig: "/nonexistent/kubeconfig"
+  - name: "prod-us"
+    context: "prod-us-2"
+  - name: "dev"
+    context: "dev"
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Strict loading refuses the file, and says which entry is at fault
+	_, err := LoadConfig(configPath)
+	var invalid *InvalidClusterError
+	if !errors.As(err, &invalid) || invalid.Name != "prod-eu" || invalid.Index != 1 {
+		t.Fatalf("Expected prod-eu's missing kubeconfig to fail the load, got %v", err)
+	}
+
+	config, err := LoadConfigWithOptions(configPath, LoadOptions{SkipInvalidClusters: true})
+	if err != nil {
+		t.Fatalf("Expected the valid clusters to load, got %v", err)
+	}
+	var names []string
+	for _, cluster := range config.Clusters {
+		names = append(names, cluster.Name+"/"+cluster.Context)
+	}
+	if strings.Join(names, ",") != "prod-us/prod-us,dev/dev" {
+		t.Errorf("clusters = %v, want the first prod-us and dev", names)
+	}
+	if len(config.Skipped) != 2 || config.Skipped[0].Name != "prod-eu" || config.Skipped[1].Index != 2 {
+		t.Errorf("Skipped = %+v, want prod-eu and the duplicate prod-us", config.Skipped)
+	}
+
+	// The config file can ask for the same, but not when nothing would be left
+	allBroken := "skipInvalidClusters: true\nclusters:\n  - name: \"prod-eu\"\n    context: \"\"\n"
+	if err := os.WriteFile(configPath, []byte(allBroken), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(configPath); err == nil || !strings.Contains(err.Error(), "all 1 cluster entries are invalid") {
+		t.Errorf("Expected an error when every entry is invalid, got %v", err)
+	}
+}
+
+func TestRequiredConfirmation(t *testing.T) {
+	config := &MultiClusterConfig{
+		Clusters: []ClusterConfig{
+			{Name: "dev-1", Environment: "development"},
+			{Name: "stage-1", Environment: "staging"},
+			{Name: "prod-eu", Environment: "Production"},
+			{Name: "prod-us", Environment: "Production"},
+		},
+		ConfirmationPolicy: map[string]string{
+			"development": ConfirmNone,
+			"staging":     ConfirmYesFlag,
+			"production":  ConfirmTyped,
+		},
+	}
+
+	if got := config.RequiredConfirmation([]string{"dev-1"}); got.Level != ConfirmNone || len(got.Clusters) != 0 {
+		t.Errorf("Expected dev-only targets to need no confirmation, got %+v", got)
+	}
+	if got := config.RequiredConfirmation([]string{"dev-1", "stage-1"}); got.Level != ConfirmYesFlag ||
+		strings.Join(got.Clusters, ",") != "stage-1" {
+		t.Errorf("Expected staging to require --yes, got %+v", got)
+	}
+
+	got := config.RequiredConfirmation([]string{"prod-us", "dev-1", "stage-1", "prod-eu"})
+	if got.Level != ConfirmTyped {
+		t.Fatalf("Expected one production cluster to require typed confirmation, got %q", got.Level)
+	}
+	if strings.Join(got.Clusters, ",") != "prod-eu,prod-us" || strings.Join(got.Environments, ",") != "Production" {
+		t.Errorf("Expected only the production clusters to be named, got %+v", got)
+	}
+}
+
+func TestValidateNotify(t *testing.T) {
+	valid := []DeployNotify{
+		{},
+		{Webhook: "https://hooks.slack.com/services/T000/B000/x"},
+		{Webhook: "http://chatops.internal:8080/deploys", When: NotifyOnFailure},
+	}
+	for _, notify := range valid {
+		if err := validateNotify(notify); err != nil {
+			t.Errorf("Expected %+v to be valid, got %v", notify, err)
+		}
+	}
+
+	invalid := []DeployNotify{
+		{Webhook: "hooks.slack.com/services/x"},
+		{Webhook: "ftp://example.com/x"},
+		{Webhook: "https://example.com/x", When: "sometimes"},
+	}
+	for _, notify := range invalid {
+		if err := validateNotify(notify); err == nil {
+			t.Errorf("Expected %+v to be rejected", notify)
+		}
+	}
+}
+
+func TestValidateConfirmationPolicy(t *testing.T) {
+	if err := validateConfirmationPolicy(map[string]string{"production": ConfirmTyped, "dev": ConfirmNone}); err != nil {
+		t.Errorf("Expected known levels to be accepted, got %v", err)
+	}
+	if err := validateConfirmationPolicy(map[string]string{"production": "always"}); err == nil {
+		t.Error("Expected an unknown level to be rejected")
+	}
+}
+
+func TestSelectDefaultCluster(t *testing.T) {
+	tests := []struct {
+		name        string
+		defaults    []bool
+		strict      bool
+		wantDefault string
+		wantWarning bool
+		wantErr     bool
+	}{
+		{name: "none marked", defaults: []bool{false, false, false}, wantDefault: "a"},
+		{name: "one marked", defaults: []bool{false, true, false}, wantDefault: "b"},
+		{name: "several marked", defaults: []bool{false, true, true}, wantDefault: "b", wantWarning: true},
+		{name: "several marked, strict", defaults: []bool{false, true, true}, strict: true, wantErr: true},
+		{name: "one marked, strict", defaults: []bool{false, false, true}, strict: true, wantDefault: "c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &MultiClusterConfig{}
+			for i, isDefault := range tt.defaults {
+				config.Clusters = append(config.Clusters, ClusterConfig{Name: string(rune('a' + i)), IsDefault: isDefault})
+			}
+
+			warning, err := selectDefaultCluster(config, tt.strict)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("selectDefaultCluster() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !strings.Contains(err.Error(), "b, c") {
+					t.Errorf("Expected the error to name the marked clusters, got %v", err)
+				}
+				return
+			}
+			if (warning != "") != tt.wantWarning {
+				t.Errorf("warning = %q, wantWarning %v", warning, tt.wantWarning)
+			}
+			if tt.wantWarning && !strings.Contains(warning, "using 'b'") {
+				t.Errorf("Expected the warning to name the chosen cluster, got %q", warning)
+			}
+
+			var marked []string
+			for _, cluster := range config.Clusters {
+				if cluster.IsDefault {
+					marked = append(marked, cluster.Name)
+				}
+			}
+			if len(marked) != 1 || marked[0] != tt.wantDefault {
+				t.Errorf("defaults after selection = %v, want only %s", marked, tt.wantDefault)
+			}
+		})
+	}
+}
+
+func TestLoadConfigStrictRefusesSeveralDefaults(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := `
+clusters:
+  - name: "prod-us"
+    context: "prod-us"
+    default: true
+  - name: "prod-eu"
+    context: "prod-eu"
+    default: true
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("Expected a lenient load to pick a default, got %v", err)
+	}
+	if !config.Clusters[0].IsDefault || config.Clusters[1].IsDefault {
+		t.Errorf("Expected only prod-us, the first in the file, to stay default")
+	}
+
+	if _, err := LoadConfigWithOptions(configPath, LoadOptions{Strict: true}); err == nil {
+		t.Error("Expected strict loading to refuse several defaults")
+	}
+	if err := os.WriteFile(configPath, []byte("strict: true\n"+configContent), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(configPath); err == nil {
+		t.Error("Expected 'strict: true' in the file to refuse several defaults")
+	}
+}
+
+func TestSchemaCoversConfigFields(t *testing.T) {
+	var schema struct {
+		Properties map[string]struct {
+			Items struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"items"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(Schema(), &schema); err != nil {
+		t.Fatalf("Schema is not valid JSON: %v", err)
+	}
+	clusterProperties := schema.Properties["clusters"].Items.Properties
+
+	// Every field that can be written in the file needs a schema property, or
+	// validate --schema would call it unknown; and the schema mustn't invent any
+	check := func(typ reflect.Type, properties map[string]bool) {
+		t.Helper()
+		for i := 0; i < typ.NumField(); i++ {
+			name, _, _ := strings.Cut(typ.Field(i).Tag.Get("yaml"), ",")
+			if name == "-" {
+				continue
+			}
+			if !properties[name] {
+				t.Errorf("%s.%s (yaml %q) is missing from the schema", typ.Name(), typ.Field(i).Name, name)
+			}
+			delete(properties, name)
+		}
+		for name := range properties {
+			t.Errorf("schema property %q has no %s field", name, typ.Name())
+		}
+	}
+
+	top := make(map[string]bool)
+	for name := range schema.Properties {
+		top[name] = true
+	}
+	check(reflect.TypeOf(MultiClusterConfig{}), top)
+
+	cluster := make(map[string]bool)
+	for name := range clusterProperties {
+		cluster[name] = true
+	}
+	check(reflect.TypeOf(ClusterConfig{}), cluster)
+}
+
+func TestValidateSchema(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want []string
+	}{
+		{"valid", `
+timeout: 30
+clusters:
+  - name: prod
+    contexts: [primary, standby]
+    default: true
+    execEnv: {AWS_PROFILE: prod}
+confirmationPolicy: {production: typed}
+defaults:
+  deployments list: {output: json, limit: 5, full-image: true, clusters: [a, b]}
+`, nil},
+		{"wrong types", `
+timeout: 30s
+clusters:
+  - name: a
+    context: a
+  - name: b
+    context: b
+  - name: c
+    context: c
+    default: "yes"
+`, []string{"clusters[2].default must be true or false", "timeout must be an integer"}},
+		{"unknown and missing fields", `
+clusters:
+  - context: a
+    defualt: true
+`, []string{"clusters[0].name is required", "clusters[0].defualt is not a known field"}},
+		{"values out of range", `
+concurrency: -1
+clusters:
+  - name: a
+    contexts: [x, x]
+confirmationPolicy: {production: ask}
+`, []string{
+			`clusters[0].contexts lists "x" more than once`,
+			"concurrency must be at least 0, got -1",
+			`confirmationPolicy.production must be one of none, yes-flag, typed, got "ask"`,
+		}},
+		{"no clusters", "clusters: []\n", []string{"clusters must not be empty"}},
+		{"empty file", "", []string{"configuration must be a map"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs, err := ValidateSchema([]byte(tt.yaml))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, e := range errs {
+				got = append(got, e.Error())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("got errors\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestExpandClusterGroups(t *testing.T) {
+	config := &MultiClusterConfig{Groups: map[string][]string{
+		"prod-all": {"prod-us", "prod-eu"},
+		"us":       {"prod-us", "staging-us"},
+	}}
+
+	got, err := config.ExpandClusterGroups([]string{"@prod-all", "staging-us", "@us"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(got, ",") != "prod-us,prod-eu,staging-us" {
+		t.Errorf("Expected groups expanded in order without repeats, got %v", got)
+	}
+
+	_, err = config.ExpandClusterGroups([]string{"@prod"})
+	if err == nil || !strings.Contains(err.Error(), "unknown cluster group 'prod'") || !strings.Contains(err.Error(), "@prod-all, @us") {
+		t.Errorf("Expected an unknown group to be refused with the defined groups listed, got %v", err)
+	}
+}
+
+func TestValidateGroups(t *testing.T) {
+	clusters := []ClusterConfig{{Name: "prod-us", Context: "a"}, {Name: "prod-eu", Context: "b"}}
+
+	tests := []struct {
+		name    string
+		groups  map[string][]string
+		wantErr string
+	}{
+		{"valid", map[string][]string{"prod": {"prod-us", "prod-eu"}}, ""},
+		{"unknown cluster", map[string][]string{"prod": {"prod-us", "prod-ap"}}, "unknown cluster 'prod-ap'"},
+		{"nested group", map[string][]string{"prod": {"prod-us"}, "all": {"@prod"}}, "groups can't contain other groups"},
+		{"empty group", map[string][]string{"prod": {}}, "has no clusters"},
+		{"prefixed name", map[string][]string{"@prod": {"prod-us"}}, "without the leading @"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConfig(&MultiClusterConfig{Clusters: clusters, Groups: tt.groups})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected valid groups, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Confirmation levels a confirmationPolicy can require, from least to most strict
+const (
+	ConfirmNone    = "none"     // Go ahead without asking
+	ConfirmYesFlag = "yes-flag" // The command must be given --yes
+	ConfirmTyped   = "typed"    // Someone at a terminal must type the environment's name
+)
+
+// confirmationRank orders the levels so the strictest one among a command's targets wins
+var confirmationRank = map[string]int{
+	ConfirmNone:    0,
+	ConfirmYesFlag: 1,
+	ConfirmTyped:   2,
+}
+
+// ConfirmationRequirement is what a destructive command must get before it
+// changes a set of clusters, and which of them are the reason
+type ConfirmationRequirement struct {
+	Level        string
+	Clusters     []string // The targets whose environment demands Level
+	Environments []string // Those clusters' environments, sorted
+}
+
+// validateConfirmationPolicy checks every level in the policy is one we know
+func validateConfirmationPolicy(policy map[string]string) error {
+	for environment, level := range policy {
+		if _, ok := confirmationRank[level]; !ok {
+			return fmt.Errorf("confirmationPolicy for '%s': unknown level %q (supported: %s, %s, %s)",
+				environment, level, ConfirmNone, ConfirmYesFlag, ConfirmTyped)
+		}
+	}
+	return nil
+}
+
+// ConfirmationLevel is the confirmation the policy requires for an environment
+// Environments are matched case-insensitively, and ones the policy doesn't
+// mention need no confirmation
+func (c *MultiClusterConfig) ConfirmationLevel(environment string) string {
+	for policyEnvironment, level := range c.ConfirmationPolicy {
+		if strings.EqualFold(policyEnvironment, environment) {
+			return level
+		}
+	}
+	return ConfirmNone
+}
+
+// RequiredConfirmation works out the strictest confirmation any of the named
+// clusters needs; one production cluster among twenty dev ones is enough to
+// require production's confirmation for the whole command
+func (c *MultiClusterConfig) RequiredConfirmation(clusterNames []string) ConfirmationRequirement {
+	targets := make(map[string]bool, len(clusterNames))
+	for _, name := range clusterNames {
+		targets[name] = true
+	}
+
+	requirement := ConfirmationRequirement{Level: ConfirmNone}
+	environments := make(map[string]bool)
+	for _, cluster := range c.Clusters {
+		if !targets[cluster.Name] {
+			continue
+		}
+
+		level := c.ConfirmationLevel(cluster.Environment)
+		switch {
+		case confirmationRank[level] > confirmationRank[requirement.Level]:
+			requirement = ConfirmationRequirement{Level: level}
+			environments = make(map[string]bool)
+		case level != requirement.Level:
+			continue
+		}
+		requirement.Clusters = append(requirement.Clusters, cluster.Name)
+		environments[cluster.Environment] = true
+	}
+
+	if requirement.Level == ConfirmNone {
+		return ConfirmationRequirement{Level: ConfirmNone}
+	}
+	for environment := range environments {
+		requirement.Environments = append(requirement.Environments, environment)
+	}
+	sort.Strings(requirement.Clusters)
+	sort.Strings(requirement.Environments)
+	return requirement
+}
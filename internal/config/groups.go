@@ -0,0 +1,93 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GroupPrefix marks a --clusters entry as a group name rather than a cluster name
+const GroupPrefix = "@"
+
+// ExpandClusterGroups replaces every @group in names with the group's clusters,
+// keeping the order given and dropping repeats, so "@prod-all,staging" becomes
+// each production cluster followed by staging. Plain cluster names pass through
+// untouched; an unknown group is an error
+func (c *MultiClusterConfig) ExpandClusterGroups(names []string) ([]string, error) {
+	var expanded []string
+	seen := make(map[string]bool, len(names))
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			expanded = append(expanded, name)
+		}
+	}
+
+	for _, name := range names {
+		group, isGroup := strings.CutPrefix(name, GroupPrefix)
+		if !isGroup {
+			add(name)
+			continue
+		}
+		members, ok := c.Groups[group]
+		if !ok {
+			return nil, fmt.Errorf("unknown cluster group '%s'%s", group, c.groupHint())
+		}
+		for _, member := range members {
+			add(member)
+		}
+	}
+	return expanded, nil
+}
+
+// groupHint lists the configured groups for an unknown-group error
+func (c *MultiClusterConfig) groupHint() string {
+	if len(c.Groups) == 0 {
+		return " (no groups are defined in the configuration)"
+	}
+	names := make([]string, 0, len(c.Groups))
+	for name := range c.Groups {
+		names = append(names, GroupPrefix+name)
+	}
+	sort.Strings(names)
+	return fmt.Sprintf(" (defined groups: %s)", strings.Join(names, ", "))
+}
+
+// validateGroups checks that every group lists at least one cluster and only
+// clusters in the configuration - including entries skipped as invalid, which
+// then show up as failures for that cluster rather than breaking the group
+// Groups of groups are refused: one level keeps what @name means easy to read
+func validateGroups(config *MultiClusterConfig) error {
+	known := make(map[string]bool, len(config.Clusters)+len(config.Skipped))
+	for _, cluster := range config.Clusters {
+		known[cluster.Name] = true
+	}
+	for _, skipped := range config.Skipped {
+		known[skipped.Name] = true
+	}
+
+	names := make([]string, 0, len(config.Groups))
+	for name := range config.Groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if strings.TrimSpace(name) == "" || strings.HasPrefix(name, GroupPrefix) || strings.Contains(name, ",") {
+			return fmt.Errorf("group name %q is invalid; write it without the leading %s and without commas", name, GroupPrefix)
+		}
+		members := config.Groups[name]
+		if len(members) == 0 {
+			return fmt.Errorf("group '%s' has no clusters", name)
+		}
+		for _, member := range members {
+			if strings.HasPrefix(member, GroupPrefix) {
+				return fmt.Errorf("group '%s' includes group '%s'; groups can't contain other groups, list the clusters instead", name, member)
+			}
+			if !known[member] {
+				return fmt.Errorf("group '%s' includes unknown cluster '%s'", name, member)
+			}
+		}
+	}
+	return nil
+}
@@ -2,18 +2,59 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
 
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/yaml"
 )
 
+// LoadOptions tweaks how LoadConfigWithOptions treats a configuration
+type LoadOptions struct {
+	// SkipInvalidClusters drops cluster entries that fail validation - a missing
+	// kubeconfig file, no context, a duplicate name - with a warning, instead of
+	// refusing the whole file. The config file's skipInvalidClusters does the same
+	SkipInvalidClusters bool
+
+	// Strict refuses a configuration that would otherwise be loaded with a warning
+	// about a guess mcm made for it, such as several clusters marked default.
+	// The config file's strict does the same
+	Strict bool
+
+	// TrustProjectConfig lets a project config (.mcm.yaml) found in the working
+	// directory or above replace the user config, like listing its directory in
+	// the user config's trustedProjects
+	TrustProjectConfig bool
+}
+
 // LoadConfig reads the multi-cluster configuration from a YAML file
 // This function is like opening your address book and reading all the contacts
 func LoadConfig(configPath string) (*MultiClusterConfig, error) {
+	return LoadConfigWithOptions(configPath, LoadOptions{})
+}
+
+// LoadConfigWithOptions is LoadConfig with control over invalid cluster entries
+// One wrong kubeconfig path among twenty clusters shouldn't lock the user out of
+// the other nineteen while they fix it
+func LoadConfigWithOptions(configPath string, opts LoadOptions) (*MultiClusterConfig, error) {
 	// If no config path provided, try to find it in common locations
+	projectConfig := ""
 	if configPath == "" {
-		configPath = findDefaultConfigPath()
+		var note string
+		configPath, note = findDefaultConfigPath(opts.TrustProjectConfig)
+		if note != "" {
+			fmt.Fprintln(os.Stderr, note)
+		}
+		// ./mcm-config.yaml comes with the working directory too, so it is just
+		// as likely to be a checked-out repository's file as .mcm.yaml is
+		if filepath.Base(configPath) == ProjectConfigName || configPath == workingDirConfig {
+			projectConfig = configPath
+		}
 	}
 
 	// Read the YAML file
@@ -28,23 +69,119 @@ func LoadConfig(configPath string) (*MultiClusterConfig, error) {
 		return nil, fmt.Errorf("failed to parse config file %s: %w", configPath, err)
 	}
 
+	// Set the broken entries aside first, so the rest can be validated as usual
+	if opts.SkipInvalidClusters || config.SkipInvalidClusters {
+		total := len(config.Clusters)
+		config.Skipped = dropInvalidClusters(&config)
+		for _, skipped := range config.Skipped {
+			fmt.Fprintf(os.Stderr, "Warning: skipping invalid cluster entry: %v\n", skipped)
+		}
+		if len(config.Skipped) > 0 && len(config.Skipped) == total {
+			return nil, fmt.Errorf("invalid configuration: all %d cluster entries are invalid", total)
+		}
+	}
+
 	// Validate the configuration before returning it
 	if err := validateConfig(&config); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	// Settle on one default cluster, so every command falls back to the same one
+	warning, err := selectDefaultCluster(&config, opts.Strict || config.Strict)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if warning != "" {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	// Like kubectl, warn (but don't fail) when others can read the file
+	if warning := permissionWarning(configPath, &config); warning != "" {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
 	// Set default values for any missing fields
 	setDefaults(&config)
+	config.ProjectConfig = projectConfig
 
 	return &config, nil
 }
 
-// findDefaultConfigPath looks for config file in standard locations
-// This follows the XDG specification and common practices
-func findDefaultConfigPath() string {
-	// Check for config in current directory first
-	if _, err := os.Stat("./mcm-config.yaml"); err == nil {
-		return "./mcm-config.yaml"
+// ProjectConfigName is the project-local config file; like .git, it applies to the
+// directory it's in and everything below, so a repo can pin the clusters it targets
+const ProjectConfigName = ".mcm.yaml"
+
+// workingDirConfig is the config mcm picks up from the working directory alone
+const workingDirConfig = "./mcm-config.yaml"
+
+// FindProjectConfig looks for ProjectConfigName in dir and then each parent
+// directory in turn, returning the nearest one found, or "" if there is none
+func FindProjectConfig(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+
+	for {
+		path := filepath.Join(dir, ProjectConfigName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "" // Reached the filesystem root
+		}
+		dir = parent
+	}
+}
+
+// FindConfigPath returns the config file mcm loads when --config isn't given,
+// or "" if there is none. A project config found in the working directory or
+// above is only used when trusted; note says which one was picked, or why it
+// was passed over, for printing on stderr
+func FindConfigPath(trustProject bool) (path, note string) {
+	userPath := findUserConfigPath()
+
+	project := FindProjectConfig(".")
+	if project == "" {
+		return userPath, ""
+	}
+
+	// A checked-out repository shouldn't get to swap the clusters (and hooks)
+	// mcm uses just by containing a file, so the user opts in
+	if trustProject || projectTrusted(userPath, filepath.Dir(project)) {
+		return project, fmt.Sprintf("Using project config %s", project)
+	}
+
+	where := "the user config"
+	if userPath != "" {
+		where = userPath
+	}
+	return userPath, fmt.Sprintf("Ignoring project config %s: pass --trust-project-config, or add %s to trustedProjects in %s",
+		project, filepath.Dir(project), where)
+}
+
+// findDefaultConfigPath is FindConfigPath, falling back to ~/.mcm/config.yaml
+// when no config file exists, so the error names where one was expected
+func findDefaultConfigPath(trustProject bool) (path, note string) {
+	path, note = FindConfigPath(trustProject)
+	if path != "" {
+		return path, note
+	}
+
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(homeDir, ".mcm", "config.yaml"), note
+	}
+	return workingDirConfig, note
+}
+
+// findUserConfigPath looks for the user's own config file in standard locations,
+// returning "" if there is none. This follows the XDG specification and common practices
+func findUserConfigPath() string {
+	// A config in the current directory first
+	if _, err := os.Stat(workingDirConfig); err == nil {
+		return workingDirConfig
 	}
 
 	// Check user's home directory
@@ -69,12 +206,59 @@ func findDefaultConfigPath() string {
 		}
 	}
 
-	// Return default path if nothing found
-	if homeDir != "" {
-		return filepath.Join(homeDir, ".mcm", "config.yaml")
+	return ""
+}
+
+// projectTrusted reports whether the user config at userPath lists dir in its
+// trustedProjects. Only the user config is consulted: a project config can't
+// vouch for itself
+func projectTrusted(userPath, dir string) bool {
+	if userPath == "" {
+		return false
+	}
+	data, err := os.ReadFile(userPath)
+	if err != nil {
+		return false
+	}
+	var user struct {
+		TrustedProjects []string `json:"trustedProjects"`
+	}
+	if err := yaml.Unmarshal(data, &user); err != nil {
+		return false
+	}
+
+	for _, trusted := range user.TrustedProjects {
+		if strings.HasPrefix(trusted, "~/") {
+			if homeDir, err := os.UserHomeDir(); err == nil {
+				trusted = filepath.Join(homeDir, trusted[2:])
+			}
+		}
+		if abs, err := filepath.Abs(trusted); err == nil && abs == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// CacheDir returns the directory where mcm keeps state between runs
+// (sync progress and similar bookkeeping). It follows the XDG cache convention
+// and is created on demand so callers can write into it straight away.
+func CacheDir() (string, error) {
+	cacheDir := os.Getenv("XDG_CACHE_HOME")
+	if cacheDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("cannot determine home directory: %w", err)
+		}
+		cacheDir = filepath.Join(homeDir, ".cache")
+	}
+
+	dir := filepath.Join(cacheDir, "mcm")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create cache directory %s: %w", dir, err)
 	}
 
-	return "./mcm-config.yaml"
+	return dir, nil
 }
 
 // validateConfig ensures the configuration makes sense
@@ -85,45 +269,277 @@ func validateConfig(config *MultiClusterConfig) error {
 	}
 
 	clusterNames := make(map[string]bool)
-	defaultCount := 0
 
 	for i, cluster := range config.Clusters {
-		// Check for required fields
-		if cluster.Name == "" {
-			return fmt.Errorf("cluster at index %d has no name", i)
+		if err := validateCluster(i, cluster, clusterNames); err != nil {
+			return err
+		}
+		clusterNames[cluster.Name] = true
+	}
+
+	// Zero means "not set" for these and gets a default; a negative value would
+	// otherwise turn into a context that has expired before the first call
+	if config.Timeout < 0 {
+		return fmt.Errorf("timeout must be a positive number of seconds, got %d", config.Timeout)
+	}
+	if config.CallTimeout < 0 {
+		return fmt.Errorf("callTimeout must not be negative, got %d", config.CallTimeout)
+	}
+	if config.Concurrency < 0 {
+		return fmt.Errorf("concurrency must not be negative, got %d", config.Concurrency)
+	}
+	if err := ValidateConnectMode(config.ConnectMode); err != nil {
+		return fmt.Errorf("connectMode: %w", err)
+	}
+	if config.ConnectRetries < 0 {
+		return fmt.Errorf("connectRetries must not be negative, got %d", config.ConnectRetries)
+	}
+	if config.CredentialTimeout < 0 {
+		return fmt.Errorf("credentialTimeout must not be negative, got %d", config.CredentialTimeout)
+	}
+	if config.ListPageSize < 0 {
+		return fmt.Errorf("listPageSize must not be negative, got %d", config.ListPageSize)
+	}
+	for _, warning := range settingWarnings(config) {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	if config.ManagedByLabel != "" {
+		if _, _, err := ParseManagedByLabel(config.ManagedByLabel); err != nil {
+			return err
 		}
+	}
+
+	if err := validateConfirmationPolicy(config.ConfirmationPolicy); err != nil {
+		return err
+	}
+
+	if err := validateGroups(config); err != nil {
+		return err
+	}
+
+	if err := validateNotify(config.Notify); err != nil {
+		return err
+	}
 
-		if cluster.Context == "" {
-			return fmt.Errorf("cluster '%s' has no context specified", cluster.Name)
+	for _, namespace := range config.ExcludeNamespaces {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return fmt.Errorf("excludeNamespaces: %q is not a valid namespace name: %s", namespace, strings.Join(errs, "; "))
 		}
+	}
 
-		// Check for duplicate names
-		if clusterNames[cluster.Name] {
-			return fmt.Errorf("duplicate cluster name: %s", cluster.Name)
+	for command, flags := range config.Defaults {
+		if strings.TrimSpace(command) == "" {
+			return fmt.Errorf("defaults: empty command path")
 		}
-		clusterNames[cluster.Name] = true
+		for flag := range flags {
+			if flag == "" || strings.HasPrefix(flag, "-") {
+				return fmt.Errorf("defaults for '%s': flag names are written without dashes, got %q", command, flag)
+			}
+		}
+	}
+
+	return nil
+}
 
-		// Count default clusters
+// selectDefaultCluster leaves exactly one cluster marked default, so everything that
+// falls back to "the default cluster" - commands, the exported kubeconfig's current
+// context - agrees on which one that is
+// With none marked, the first cluster in the file becomes the default. With several
+// marked, strict mode refuses the configuration; otherwise the first of them in file
+// order is kept, the others are unmarked, and the returned warning says which won
+func selectDefaultCluster(config *MultiClusterConfig, strict bool) (string, error) {
+	var marked []string
+	for _, cluster := range config.Clusters {
 		if cluster.IsDefault {
-			defaultCount++
+			marked = append(marked, cluster.Name)
 		}
+	}
 
-		// Validate kubeconfig path exists if specified
-		if cluster.KubeConfig != "" {
-			if _, err := os.Stat(cluster.KubeConfig); err != nil {
-				return fmt.Errorf("kubeconfig file not found for cluster '%s': %s", cluster.Name, cluster.KubeConfig)
-			}
+	switch {
+	case len(marked) == 0:
+		if len(config.Clusters) > 0 {
+			config.Clusters[0].IsDefault = true
+		}
+		return "", nil
+	case len(marked) == 1:
+		return "", nil
+	case strict:
+		return "", fmt.Errorf("%d clusters are marked default (%s); mark only one", len(marked), strings.Join(marked, ", "))
+	}
+
+	for i := range config.Clusters {
+		config.Clusters[i].IsDefault = config.Clusters[i].Name == marked[0]
+	}
+	return fmt.Sprintf("%d clusters are marked default (%s); using '%s', the first in the file",
+		len(marked), strings.Join(marked, ", "), marked[0]), nil
+}
+
+// InvalidClusterError is a cluster entry that failed validation
+// It is told apart from other configuration errors because the entry can be
+// skipped (LoadOptions.SkipInvalidClusters) while the rest of the fleet is used
+type InvalidClusterError struct {
+	Index int    // Position in the clusters list
+	Name  string // Empty when the entry has no name
+	Err   error
+}
+
+func (e *InvalidClusterError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *InvalidClusterError) Unwrap() error {
+	return e.Err
+}
+
+// validateCluster checks one cluster entry; seen holds the names of the entries before it
+func validateCluster(index int, cluster ClusterConfig, seen map[string]bool) *InvalidClusterError {
+	invalid := func(format string, args ...interface{}) *InvalidClusterError {
+		return &InvalidClusterError{Index: index, Name: cluster.Name, Err: fmt.Errorf(format, args...)}
+	}
+
+	// Check for required fields
+	if cluster.Name == "" {
+		return invalid("cluster at index %d has no name", index)
+	}
+
+	if cluster.Context == "" && len(cluster.Contexts) == 0 {
+		return invalid("cluster '%s' has no context specified", cluster.Name)
+	}
+	if cluster.Context != "" && len(cluster.Contexts) > 0 {
+		return invalid("cluster '%s' sets both context and contexts; list every context under contexts", cluster.Name)
+	}
+	candidates := make(map[string]bool, len(cluster.Contexts))
+	for _, context := range cluster.Contexts {
+		if context == "" {
+			return invalid("cluster '%s' has an empty entry in contexts", cluster.Name)
+		}
+		if candidates[context] {
+			return invalid("cluster '%s' lists context '%s' twice", cluster.Name, context)
 		}
+		candidates[context] = true
 	}
 
-	// Warn if more than one default cluster (we'll use the first one)
-	if defaultCount > 1 {
-		fmt.Fprintf(os.Stderr, "Warning: Multiple clusters marked as default. Using the first one.\n")
+	// Check for duplicate names
+	if seen[cluster.Name] {
+		return invalid("duplicate cluster name: %s", cluster.Name)
+	}
+
+	if cluster.ServerPattern != "" {
+		if _, err := regexp.Compile(cluster.ServerPattern); err != nil {
+			return invalid("cluster '%s' has an invalid serverPattern: %w", cluster.Name, err)
+		}
+	}
+
+	// Validate kubeconfig path exists if specified
+	if cluster.KubeConfig != "" {
+		if _, err := os.Stat(cluster.KubeConfig); err != nil {
+			return invalid("kubeconfig file not found for cluster '%s': %s", cluster.Name, cluster.KubeConfig)
+		}
 	}
 
 	return nil
 }
 
+// dropInvalidClusters removes the entries validateCluster rejects and returns them
+// For a duplicate name the first entry is kept, as if the later one weren't there
+func dropInvalidClusters(config *MultiClusterConfig) []InvalidClusterError {
+	var skipped []InvalidClusterError
+	valid := make([]ClusterConfig, 0, len(config.Clusters))
+	seen := make(map[string]bool)
+
+	for i, cluster := range config.Clusters {
+		if err := validateCluster(i, cluster, seen); err != nil {
+			skipped = append(skipped, *err)
+			continue
+		}
+		seen[cluster.Name] = true
+		valid = append(valid, cluster)
+	}
+
+	config.Clusters = valid
+	return skipped
+}
+
+// Settings above these limits are allowed but most likely a mistake
+const (
+	// maxSaneTimeoutSeconds catches timeouts written in milliseconds (timeout: 30000)
+	maxSaneTimeoutSeconds = 600
+
+	// maxSaneConcurrency is far beyond what one API server should get from one client
+	maxSaneConcurrency = 100
+
+	// maxSaneConnectRetries already waits over a minute on a cluster that is down
+	maxSaneConnectRetries = 5
+)
+
+// settingWarnings lists the global settings that are valid but suspiciously large
+func settingWarnings(config *MultiClusterConfig) []string {
+	var warnings []string
+	if config.Timeout > maxSaneTimeoutSeconds {
+		warnings = append(warnings, fmt.Sprintf(
+			"timeout is %d seconds - it is in seconds, not milliseconds; an unreachable cluster will hold up commands that long",
+			config.Timeout))
+	}
+	if config.CallTimeout > maxSaneTimeoutSeconds {
+		warnings = append(warnings, fmt.Sprintf(
+			"callTimeout is %d seconds - it is in seconds, not milliseconds; a hung call will hold up commands that long",
+			config.CallTimeout))
+	}
+	if config.CredentialTimeout > maxSaneTimeoutSeconds {
+		warnings = append(warnings, fmt.Sprintf(
+			"credentialTimeout is %d seconds - it is in seconds, not milliseconds; a hung credential plugin will hold up commands that long",
+			config.CredentialTimeout))
+	}
+	if config.Concurrency > maxSaneConcurrency {
+		warnings = append(warnings, fmt.Sprintf(
+			"concurrency is %d - that many parallel calls may get mcm throttled by the API servers", config.Concurrency))
+	}
+	if config.ConnectRetries > maxSaneConnectRetries {
+		warnings = append(warnings, fmt.Sprintf(
+			"connectRetries is %d - an unreachable cluster will be retried for minutes before commands go ahead without it",
+			config.ConnectRetries))
+	}
+	return warnings
+}
+
+// Connection modes for connectMode and --connect-mode
+const (
+	ConnectEager = "eager" // Connect to every cluster before the command runs
+	ConnectLazy  = "lazy"  // Connect to a cluster when the command first needs it
+)
+
+// ValidateConnectMode accepts the connection modes; empty means ConnectEager
+func ValidateConnectMode(mode string) error {
+	switch mode {
+	case "", ConnectEager, ConnectLazy:
+		return nil
+	}
+	return fmt.Errorf("unknown mode %q (supported: %s, %s)", mode, ConnectEager, ConnectLazy)
+}
+
+// DefaultConcurrency is used when the configuration doesn't set 'concurrency'
+const DefaultConcurrency = 10
+
+// DefaultManagedByLabel is used when the configuration doesn't set 'managedByLabel'
+// It matches the label mcm sync puts on everything it applies
+const DefaultManagedByLabel = "app.kubernetes.io/managed-by=mcm"
+
+// ParseManagedByLabel splits a "key=value" ownership label and checks both halves are valid label syntax
+func ParseManagedByLabel(label string) (key, value string, err error) {
+	key, value, found := strings.Cut(label, "=")
+	if !found || key == "" {
+		return "", "", fmt.Errorf("managedByLabel must have the form key=value, got %q", label)
+	}
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return "", "", fmt.Errorf("managedByLabel key %q is invalid: %s", key, strings.Join(errs, "; "))
+	}
+	if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+		return "", "", fmt.Errorf("managedByLabel value %q is invalid: %s", value, strings.Join(errs, "; "))
+	}
+	return key, value, nil
+}
+
 // setDefaults fills in reasonable default values for missing configuration
 func setDefaults(config *MultiClusterConfig) {
 	// Set default namespace if not specified
@@ -131,21 +547,103 @@ func setDefaults(config *MultiClusterConfig) {
 		config.DefaultNamespace = "default"
 	}
 
-	// Set default timeout if not specified (30 seconds)
+	// Set default timeout if not specified (30 seconds); negative values were rejected by validateConfig
 	if config.Timeout == 0 {
 		config.Timeout = 30
 	}
 
-	// If no cluster is marked as default, mark the first one
-	hasDefault := false
+	// Cap fan-out of per-object calls so big fleets don't hammer API servers
+	if config.Concurrency <= 0 {
+		config.Concurrency = DefaultConcurrency
+	}
+
+	if config.ManagedByLabel == "" {
+		config.ManagedByLabel = DefaultManagedByLabel
+	}
+}
+
+// sensitiveNamePattern matches variable names that usually hold credentials
+var sensitiveNamePattern = regexp.MustCompile(`(?i)(token|secret|password|passwd|credential|private_?key|access_?key)`)
+
+// permissionWarning checks whether the config file is readable by group or others
+// The warning is escalated when the file actually contains credential-looking values
+func permissionWarning(configPath string, config *MultiClusterConfig) string {
+	// Windows doesn't use Unix permission bits, so the check would always misfire
+	if runtime.GOOS == "windows" {
+		return ""
+	}
+
+	info, err := os.Stat(configPath)
+	if err != nil {
+		return ""
+	}
+
+	mode := info.Mode().Perm()
+	if mode&0077 == 0 {
+		return ""
+	}
+
+	if fields := sensitiveFields(config); len(fields) > 0 {
+		return fmt.Sprintf("config file %s contains credentials (%s) and is readable by other users (mode %04o). Run: chmod 600 %s",
+			configPath, strings.Join(fields, ", "), mode, configPath)
+	}
+
+	return fmt.Sprintf("config file %s is group/world-readable (mode %04o). Consider: chmod 600 %s",
+		configPath, mode, configPath)
+}
+
+// sensitiveFields lists config fields that look like they hold credentials
+func sensitiveFields(config *MultiClusterConfig) []string {
+	var fields []string
 	for _, cluster := range config.Clusters {
-		if cluster.IsDefault {
-			hasDefault = true
-			break
+		names := make([]string, 0, len(cluster.ExecEnv))
+		for name := range cluster.ExecEnv {
+			if sensitiveNamePattern.MatchString(name) {
+				names = append(names, name)
+			}
 		}
+		sort.Strings(names)
+		for _, name := range names {
+			fields = append(fields, fmt.Sprintf("clusters[%s].execEnv.%s", cluster.Name, name))
+		}
+	}
+	// Whoever can read an incoming webhook URL can post to the channel
+	if config.Notify.Webhook != "" {
+		fields = append(fields, "notify.webhook")
 	}
+	return fields
+}
 
-	if !hasDefault && len(config.Clusters) > 0 {
-		config.Clusters[0].IsDefault = true
+// RedactedValue replaces sensitive values in Redacted output
+const RedactedValue = "REDACTED"
+
+// Redacted returns a copy of the configuration that is safe to print
+// Credential-looking values are replaced so debug output can be shared in tickets
+func (c *MultiClusterConfig) Redacted() *MultiClusterConfig {
+	redacted := *c
+	redacted.Clusters = make([]ClusterConfig, len(c.Clusters))
+
+	for i, cluster := range c.Clusters {
+		if len(cluster.ExecEnv) > 0 {
+			env := make(map[string]string, len(cluster.ExecEnv))
+			for name, value := range cluster.ExecEnv {
+				if sensitiveNamePattern.MatchString(name) {
+					value = RedactedValue
+				}
+				env[name] = value
+			}
+			cluster.ExecEnv = env
+		}
+		redacted.Clusters[i] = cluster
 	}
+
+	// Incoming webhook URLs carry their secret in the path; the host is enough to tell them apart
+	if c.Notify.Webhook != "" {
+		redacted.Notify.Webhook = RedactedValue
+		if parsed, err := url.Parse(c.Notify.Webhook); err == nil && parsed.Host != "" {
+			redacted.Notify.Webhook = parsed.Scheme + "://" + parsed.Host + "/" + RedactedValue
+		}
+	}
+
+	return &redacted
 }
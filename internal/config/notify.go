@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// When deploy results are posted to the notification webhook
+const (
+	NotifyAlways    = "always"  // After every deploy; the default
+	NotifyOnFailure = "failure" // Only when the deploy failed on some cluster
+)
+
+// DeployNotify posts a JSON summary of every deploy's results to a webhook,
+// such as a Slack or Teams incoming webhook
+type DeployNotify struct {
+	Webhook string `yaml:"webhook,omitempty" json:"webhook,omitempty"` // http(s) URL the summary is POSTed to
+	When    string `yaml:"when,omitempty" json:"when,omitempty"`       // NotifyAlways (default) or NotifyOnFailure
+}
+
+// ValidateNotifyWhen checks a notify.when or --notify-on value
+func ValidateNotifyWhen(when string) error {
+	switch when {
+	case "", NotifyAlways, NotifyOnFailure:
+		return nil
+	}
+	return fmt.Errorf("unknown notification filter %q (supported: %s, %s)", when, NotifyOnFailure, NotifyAlways)
+}
+
+// ValidateWebhookURL checks a webhook is an absolute http or https URL
+func ValidateWebhookURL(webhook string) error {
+	parsed, err := url.Parse(webhook)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("webhook must be an http or https URL, got %q", webhook)
+	}
+	return nil
+}
+
+// validateNotify checks the notify section
+func validateNotify(notify DeployNotify) error {
+	if notify.Webhook != "" {
+		if err := ValidateWebhookURL(notify.Webhook); err != nil {
+			return fmt.Errorf("notify: %w", err)
+		}
+	}
+	if err := ValidateNotifyWhen(notify.When); err != nil {
+		return fmt.Errorf("notify.when: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+package config
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// schemaJSON is the JSON Schema of the config file, for editors' YAML language
+// servers and for ValidateSchema. It has to be kept in step with the yaml tags
+// of MultiClusterConfig and ClusterConfig; a test checks it is
+//
+//go:embed schema.json
+var schemaJSON []byte
+
+// Schema returns the config file's JSON Schema
+func Schema() []byte {
+	return bytes.Clone(schemaJSON)
+}
+
+// SchemaError is one place where a config file breaks the schema
+type SchemaError struct {
+	Path    string // Where in the file, e.g. clusters[2].timeout; empty for the file as a whole
+	Message string // What is wrong there, e.g. "must be an integer"
+}
+
+func (e SchemaError) Error() string {
+	if e.Path == "" {
+		return "configuration " + e.Message
+	}
+	return e.Path + " " + e.Message
+}
+
+// ValidateSchema checks a config file's YAML against Schema and returns every
+// violation it finds, such as "clusters[2].timeout must be an integer" or
+// "clusters[0].defualt is not a known field". Unlike loading the file, this
+// doesn't stop at the first problem or skip over unknown fields. An error is
+// returned only when the YAML can't be parsed at all
+func ValidateSchema(data []byte) ([]SchemaError, error) {
+	var root schemaNode
+	if err := json.Unmarshal(schemaJSON, &root); err != nil {
+		return nil, fmt.Errorf("invalid built-in schema: %w", err)
+	}
+
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	// Numbers stay json.Number so integers can be told from 1.5
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	var errs []SchemaError
+	root.validate("", document, &errs)
+	return errs, nil
+}
+
+// schemaNode is the part of JSON Schema that schema.json uses
+type schemaNode struct {
+	Type                 schemaTypes            `json:"type"`
+	Properties           map[string]*schemaNode `json:"properties"`
+	AdditionalProperties *additionalProperties  `json:"additionalProperties"`
+	Items                *schemaNode            `json:"items"`
+	Required             []string               `json:"required"`
+	Enum                 []string               `json:"enum"`
+	Minimum              *float64               `json:"minimum"`
+	MinItems             int                    `json:"minItems"`
+	MinLength            int                    `json:"minLength"`
+	UniqueItems          bool                   `json:"uniqueItems"`
+}
+
+// schemaTypes is a schema's "type", which may be one type name or a list of them
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = schemaTypes{single}
+		return nil
+	}
+	var several []string
+	if err := json.Unmarshal(data, &several); err != nil {
+		return fmt.Errorf("type must be a string or a list of strings: %w", err)
+	}
+	*t = several
+	return nil
+}
+
+// additionalProperties is either false, forbidding keys not under properties,
+// or the schema every such key's value must match
+type additionalProperties struct {
+	forbidden bool
+	schema    *schemaNode
+}
+
+func (a *additionalProperties) UnmarshalJSON(data []byte) error {
+	var allowed bool
+	if err := json.Unmarshal(data, &allowed); err == nil {
+		a.forbidden = !allowed
+		return nil
+	}
+	a.schema = &schemaNode{}
+	return json.Unmarshal(data, a.schema)
+}
+
+// typeDescriptions say what a value of each schema type looks like in YAML terms
+var typeDescriptions = map[string]string{
+	"object":  "a map",
+	"array":   "a list",
+	"string":  "a string",
+	"boolean": "true or false",
+	"integer": "an integer",
+	"number":  "a number",
+	"null":    "empty",
+}
+
+// validate checks value against the node, adding what's wrong to errs
+func (s *schemaNode) validate(path string, value interface{}, errs *[]SchemaError) {
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, SchemaError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(s.Type) > 0 && !s.matchesType(value) {
+		descriptions := make([]string, 0, len(s.Type))
+		for _, name := range s.Type {
+			descriptions = append(descriptions, typeDescriptions[name])
+		}
+		fail("must be %s", joinAlternatives(descriptions))
+		return // Nothing else about the value can be checked meaningfully
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		s.validateObject(path, v, errs)
+	case []interface{}:
+		if len(v) < s.MinItems {
+			fail("must not be empty")
+		}
+		seen := make(map[string]bool, len(v))
+		for i, item := range v {
+			if s.UniqueItems {
+				key := fmt.Sprint(item)
+				if seen[key] {
+					fail("lists %q more than once", key)
+				}
+				seen[key] = true
+			}
+			if s.Items != nil {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, errs)
+			}
+		}
+	case string:
+		if len(v) < s.MinLength {
+			fail("must not be empty")
+		}
+		if len(s.Enum) > 0 && !slices.Contains(s.Enum, v) {
+			fail("must be one of %s, got %q", strings.Join(s.Enum, ", "), v)
+		}
+	case json.Number:
+		if s.Minimum != nil {
+			if number, _ := v.Float64(); number < *s.Minimum {
+				fail("must be at least %g, got %s", *s.Minimum, v.String())
+			}
+		}
+	}
+}
+
+// validateObject checks a map's required keys and each of its values
+func (s *schemaNode) validateObject(path string, object map[string]interface{}, errs *[]SchemaError) {
+	for _, name := range s.Required {
+		if _, ok := object[name]; !ok {
+			*errs = append(*errs, SchemaError{Path: childPath(path, name), Message: "is required"})
+		}
+	}
+
+	// Go maps have no order; sorted keys keep the report the same on every run
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if property, ok := s.Properties[key]; ok {
+			property.validate(childPath(path, key), object[key], errs)
+			continue
+		}
+		switch {
+		case s.AdditionalProperties == nil:
+		case s.AdditionalProperties.forbidden:
+			*errs = append(*errs, SchemaError{Path: childPath(path, key), Message: "is not a known field"})
+		default:
+			s.AdditionalProperties.schema.validate(childPath(path, key), object[key], errs)
+		}
+	}
+}
+
+// matchesType reports whether value is of one of the node's types
+func (s *schemaNode) matchesType(value interface{}) bool {
+	for _, name := range s.Type {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if name == "object" {
+				return true
+			}
+		case []interface{}:
+			if name == "array" {
+				return true
+			}
+		case string:
+			if name == "string" {
+				return true
+			}
+		case bool:
+			if name == "boolean" {
+				return true
+			}
+		case json.Number:
+			if name == "number" {
+				return true
+			}
+			if _, err := v.Int64(); err == nil && name == "integer" {
+				return true
+			}
+		case nil:
+			if name == "null" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// childPath extends a path with a map key
+func childPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// joinAlternatives joins ["a", "b", "c"] as "a, b or c"
+func joinAlternatives(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " or " + items[len(items)-1]
+}
@@ -1,17 +1,52 @@
 package config
 
-import "k8s.io/client-go/rest"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/client-go/rest"
+)
 
 // ClusterConfig represents a single Kubernetes cluster configuration
 // Think of this as a "business card" for each cluster - it tells us
 // where the cluster is, how to connect to it, and what to call it
 type ClusterConfig struct {
-	Name        string `yaml:"name" json:"name"`                         // Human-readable name like "prod-us-east"
-	Context     string `yaml:"context" json:"context"`                   // kubectl context name
-	KubeConfig  string `yaml:"kubeconfig" json:"kubeconfig"`             // Path to kubeconfig file
-	Region      string `yaml:"region,omitempty" json:"region"`           // Optional: AWS region, Azure location, etc.
-	Environment string `yaml:"environment,omitempty" json:"environment"` // dev, staging, prod
-	IsDefault   bool   `yaml:"default,omitempty" json:"default"`         // Mark one as default cluster
+	Name        string `yaml:"name" json:"name"`                             // Human-readable name like "prod-us-east"
+	Context     string `yaml:"context" json:"context"`                       // kubectl context name
+	KubeConfig  string `yaml:"kubeconfig" json:"kubeconfig"`                 // Path to kubeconfig file
+	Region      string `yaml:"region,omitempty" json:"region"`               // Optional: AWS region, Azure location, etc.
+	Environment string `yaml:"environment,omitempty" json:"environment"`     // dev, staging, prod
+	Provider    string `yaml:"provider,omitempty" json:"provider,omitempty"` // Optional: cloud provider, e.g. aws, gcp, azure, on-prem
+	Account     string `yaml:"account,omitempty" json:"account,omitempty"`   // Optional: cloud account/project/subscription ID; quote numeric IDs
+	IsDefault   bool   `yaml:"default,omitempty" json:"default"`             // Mark one as default cluster
+	Server      string `yaml:"server,omitempty" json:"server,omitempty"`     // Optional: expected API server URL, guards against context drift
+	Frozen      bool   `yaml:"frozen,omitempty" json:"frozen,omitempty"`     // Refuse deploys during a change freeze
+
+	// ServerPattern is a regular expression the resolved API server URL must match
+	// in full, for clusters whose endpoint isn't fixed (e.g. https://.*\.prod\.example\.com:6443)
+	ServerPattern string `yaml:"serverPattern,omitempty" json:"serverPattern,omitempty"`
+
+	// ExecEnv is merged into the environment of the kubeconfig's exec credential
+	// plugin (aws, gcloud, ...), e.g. AWS_PROFILE, so each cluster can authenticate
+	// against a different cloud account from the same mcm process
+	ExecEnv map[string]string `yaml:"execEnv,omitempty" json:"execEnv,omitempty"`
+
+	// Contexts replaces Context for clusters reachable through more than one
+	// kubeconfig context, such as a primary and a standby control plane: they are
+	// tried in order and the first that connects is used
+	Contexts []string `yaml:"contexts,omitempty" json:"contexts,omitempty"`
+}
+
+// CandidateContexts returns the kubeconfig contexts to try for this cluster, in order
+func (c ClusterConfig) CandidateContexts() []string {
+	if len(c.Contexts) > 0 {
+		return c.Contexts
+	}
+	if c.Context == "" {
+		return nil
+	}
+	return []string{c.Context}
 }
 
 // MultiClusterConfig holds all our cluster configurations
@@ -20,7 +55,131 @@ type MultiClusterConfig struct {
 	Clusters []ClusterConfig `yaml:"clusters" json:"clusters"`
 	// Global settings that apply to all clusters
 	DefaultNamespace string `yaml:"defaultNamespace,omitempty" json:"defaultNamespace"`
-	Timeout          int    `yaml:"timeout,omitempty" json:"timeout"` // Connection timeout in seconds
+	Timeout          int    `yaml:"timeout,omitempty" json:"timeout"`         // Connection timeout in seconds
+	Concurrency      int    `yaml:"concurrency,omitempty" json:"concurrency"` // Max parallel per-object API calls (e.g. log fetches)
+
+	// CallTimeout bounds one operation against a cluster, retries included, in seconds
+	CallTimeout int `yaml:"callTimeout,omitempty" json:"callTimeout,omitempty"`
+
+	// ConnectMode is when clusters are connected to: ConnectEager (the default)
+	// dials every cluster before a command runs, ConnectLazy only the ones it uses
+	ConnectMode string `yaml:"connectMode,omitempty" json:"connectMode,omitempty"`
+
+	// ConnectRetries retries a connection check that failed on a timeout or an
+	// unavailable API server, backing off 1s, 2s, 4s...; auth errors aren't retried
+	ConnectRetries int `yaml:"connectRetries,omitempty" json:"connectRetries,omitempty"`
+
+	// CredentialTimeout is how long an exec credential plugin may take to hand
+	// over credentials, in seconds, on top of Timeout; 0 means 60
+	CredentialTimeout int `yaml:"credentialTimeout,omitempty" json:"credentialTimeout,omitempty"`
+
+	// ListPageSize is how many pods one list call fetches; big clusters are
+	// read a page at a time so they never sit in memory at once. 0 means 500
+	ListPageSize int `yaml:"listPageSize,omitempty" json:"listPageSize,omitempty"`
+
+	// DisableExecPlugins refuses kubeconfig contexts that authenticate through an
+	// exec credential plugin, so a kubeconfig can't make mcm run programs
+	DisableExecPlugins bool `yaml:"disableExecPlugins,omitempty" json:"disableExecPlugins,omitempty"`
+
+	// ManagedByLabel ("key=value") marks resources mcm owns; --managed-only
+	// restricts lists and deploys to resources carrying it
+	ManagedByLabel string `yaml:"managedByLabel,omitempty" json:"managedByLabel"`
+
+	// ContextSwitchSafe refuses clusters whose context resolves to a server other
+	// than the declared server/serverPattern, instead of only warning about it
+	ContextSwitchSafe bool `yaml:"contextSwitchSafe,omitempty" json:"contextSwitchSafe,omitempty"`
+
+	// SkipInvalidClusters loads the valid cluster entries when others are broken,
+	// warning about each skipped one, like --skip-invalid-clusters
+	SkipInvalidClusters bool `yaml:"skipInvalidClusters,omitempty" json:"skipInvalidClusters,omitempty"`
+
+	// Strict refuses ambiguous configuration, such as several default clusters,
+	// instead of warning and picking one, like --strict-config
+	Strict bool `yaml:"strict,omitempty" json:"strict,omitempty"`
+
+	// ExcludeNamespaces are left out of listings across all namespaces, e.g.
+	// kube-system; --exclude-namespace replaces the list for one command
+	ExcludeNamespaces []string `yaml:"excludeNamespaces,omitempty" json:"excludeNamespaces,omitempty"`
+
+	// Groups names sets of clusters, so --clusters=@prod-all targets all of them
+	// Members must be configured clusters; a group can't contain another group
+	Groups map[string][]string `yaml:"groups,omitempty" json:"groups,omitempty"`
+
+	// Hooks are shell commands run before and after every deploy
+	Hooks DeployHooks `yaml:"hooks,omitempty" json:"hooks,omitempty"`
+
+	// Notify posts every deploy's results to a webhook, for ChatOps
+	Notify DeployNotify `yaml:"notify,omitempty" json:"notify,omitempty"`
+
+	// ConfirmationPolicy maps a cluster environment (e.g. production) to the
+	// confirmation destructive commands need before changing clusters in it:
+	// none, yes-flag (--yes) or typed. Environments not listed need none
+	ConfirmationPolicy map[string]string `yaml:"confirmationPolicy,omitempty" json:"confirmationPolicy,omitempty"`
+
+	// TrustedProjects are directories whose project config (.mcm.yaml) may be
+	// used instead of this file; only honoured in the user config
+	TrustedProjects []string `yaml:"trustedProjects,omitempty" json:"trustedProjects,omitempty"`
+
+	// Skipped lists the entries SkipInvalidClusters left out; it is never read from the file
+	Skipped []InvalidClusterError `yaml:"-" json:"-"`
+
+	// ProjectConfig is the file this configuration was found as in the working
+	// directory or above - a .mcm.yaml, or ./mcm-config.yaml - rather than given
+	// with --config or found in the user's home, if it was; it is never read
+	// from the file
+	ProjectConfig string `yaml:"-" json:"-"`
+
+	// Defaults changes flag defaults per command, keyed by command path, e.g.
+	// "deployments list": {output: json}. Flags given on the command line and
+	// MCM_* environment variables still take precedence
+	Defaults map[string]map[string]FlagValue `yaml:"defaults,omitempty" json:"defaults,omitempty"`
+}
+
+// DeployHooks are shell commands mcm runs around a deploy, e.g. to post to chat
+// or bump a counter. They are told about the deploy through MCM_* environment
+// variables; see the deploy command's help for the list
+type DeployHooks struct {
+	PreDeploy  string `yaml:"preDeploy,omitempty" json:"preDeploy,omitempty"`   // Runs before anything is deployed; failing stops the deploy
+	PostDeploy string `yaml:"postDeploy,omitempty" json:"postDeploy,omitempty"` // Runs once every cluster is done, successful or not
+
+	// IgnoreErrors reports a failing hook as a warning instead of failing the
+	// command, for hooks that are nice to have, like notifications
+	IgnoreErrors bool `yaml:"ignoreErrors,omitempty" json:"ignoreErrors,omitempty"`
+}
+
+// FlagValue is a flag default as written in the config file
+// YAML lets people write output: json, full-image: true or limit: 5 as they would
+// naturally; everything is kept in the string form the flag itself would parse,
+// and lists become comma-separated like --clusters=a,b
+type FlagValue string
+
+// UnmarshalJSON accepts strings, booleans, numbers and lists of those
+func (v *FlagValue) UnmarshalJSON(data []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	switch value := raw.(type) {
+	case string:
+		*v = FlagValue(value)
+	case bool, float64:
+		*v = FlagValue(strings.TrimSpace(string(data)))
+	case []interface{}:
+		items := make([]string, 0, len(value))
+		for _, item := range value {
+			switch item.(type) {
+			case string, bool, float64:
+				items = append(items, fmt.Sprint(item))
+			default:
+				return fmt.Errorf("list items must be plain values, got %v", item)
+			}
+		}
+		*v = FlagValue(strings.Join(items, ","))
+	default:
+		return fmt.Errorf("flag defaults must be a string, boolean, number or list, got %s", data)
+	}
+	return nil
 }
 
 // ClusterClient wraps the Kubernetes client with cluster metadata
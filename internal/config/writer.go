@@ -0,0 +1,95 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// BackupSuffix is appended to the config path to name the previous version
+const BackupSuffix = ".bak"
+
+// BackupPath returns where the previous version of a config file is kept
+func BackupPath(configPath string) string {
+	return configPath + BackupSuffix
+}
+
+// WriteConfigFile atomically replaces the config file, keeping the previous version as a backup
+// This is like saving a document with "keep previous version" turned on - a crash
+// mid-write leaves either the old file or the new one, never half of each
+func WriteConfigFile(configPath string, data []byte) error {
+	perm := os.FileMode(0600)
+
+	// Preserve the existing file as the backup before replacing it
+	if existing, err := os.ReadFile(configPath); err == nil {
+		if info, statErr := os.Stat(configPath); statErr == nil {
+			perm = info.Mode().Perm()
+		}
+		if err := writeFileAtomic(BackupPath(configPath), existing, perm); err != nil {
+			return fmt.Errorf("failed to back up %s: %w", configPath, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read existing config %s: %w", configPath, err)
+	}
+
+	return writeFileAtomic(configPath, data, perm)
+}
+
+// RestoreConfigFile rolls the config file back to its backup
+// The current version becomes the new backup, so a restore can itself be undone
+func RestoreConfigFile(configPath string) error {
+	backup, err := os.ReadFile(BackupPath(configPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no backup found at %s", BackupPath(configPath))
+		}
+		return fmt.Errorf("failed to read backup %s: %w", BackupPath(configPath), err)
+	}
+
+	return WriteConfigFile(configPath, backup)
+}
+
+// writeFileAtomic writes data to a temp file in the same directory, fsyncs it,
+// and renames it over the target so readers never observe a partial file
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file in %s: %w", dir, err)
+	}
+	tmpPath := tmp.Name()
+
+	// Clean up the temp file on any failure before the rename
+	success := false
+	defer func() {
+		if !success {
+			tmp.Close()
+			os.Remove(tmpPath)
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", tmpPath, err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		return fmt.Errorf("failed to set permissions on %s: %w", tmpPath, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return fmt.Errorf("failed to sync %s: %w", tmpPath, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	success = true
+
+	// Persist the rename itself; not all platforms support syncing directories
+	if d, err := os.Open(dir); err == nil {
+		_ = d.Sync()
+		d.Close()
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+)
+
+// ClustersTableOptions tweaks which columns the clusters table shows
+type ClustersTableOptions struct {
+	// Wide adds the PROVIDER and ACCOUNT columns, for orgs that slice their
+	// fleet by cloud or billing account, and the API SERVER each context
+	// resolved to, for debugging a cluster directly
+	Wide bool
+}
+
+// ClustersTable displays cluster information in a human-readable table format
+// This is the default output format that most users will see
+func ClustersTable(w io.Writer, clusters []cluster.ClusterStatus, opts ClustersTableOptions) error {
+	table := newTable(w)
+
+	// Print table headers
+	if opts.Wide {
+		fmt.Fprintln(table, "NAME\tENVIRONMENT\tREGION\tPROVIDER\tACCOUNT\tSERVER\tSTATUS\tDEFAULT\tERROR")
+		fmt.Fprintln(table, "----\t-----------\t------\t--------\t-------\t------\t------\t-------\t-----")
+	} else {
+		fmt.Fprintln(table, "NAME\tENVIRONMENT\tREGION\tSTATUS\tDEFAULT\tERROR")
+		fmt.Fprintln(table, "----\t-----------\t------\t------\t-------\t-----")
+	}
+
+	// Print each cluster's information
+	for _, cluster := range clusters {
+		// Format the status with visual indicators
+		status := "❌ Disconnected"
+		if cluster.Connected {
+			status = "✅ Connected"
+		}
+
+		// Show if this is the default cluster
+		defaultMarker := ""
+		if cluster.IsDefault {
+			defaultMarker = "⭐ Yes"
+		}
+
+		// Format environment and region with fallbacks
+		environment := cluster.Environment
+		if environment == "" {
+			environment = "-"
+		}
+
+		region := cluster.Region
+		if region == "" {
+			region = "-"
+		}
+
+		// Truncate long error messages for table display
+		errorMsg := cluster.Error
+		if len(errorMsg) > 50 {
+			errorMsg = errorMsg[:47] + "..."
+		}
+		if errorMsg == "" {
+			errorMsg = "-"
+		}
+
+		if opts.Wide {
+			fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				cluster.Name,
+				environment,
+				region,
+				valueOrDash(cluster.Provider),
+				valueOrDash(cluster.Account),
+				valueOrDash(cluster.Server),
+				status,
+				defaultMarker,
+				errorMsg,
+			)
+			continue
+		}
+
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			cluster.Name,
+			environment,
+			region,
+			status,
+			defaultMarker,
+			errorMsg,
+		)
+	}
+
+	return table.Flush()
+}
+
+// valueOrDash fills empty optional columns so the table stays aligned
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// ClustersJSON displays cluster information in JSON format
+// This is useful for programmatic consumption or integration with other tools
+func ClustersJSON(w io.Writer, clusters []cluster.ClusterStatus) error {
+	// Create a wrapper structure for better JSON organization
+	output := struct {
+		Clusters []cluster.ClusterStatus `json:"clusters"`
+		Count    int                     `json:"count"`
+	}{
+		Clusters: clusters,
+		Count:    len(clusters),
+	}
+
+	return writeJSON(w, "clusters", output)
+}
+
+// ClustersYAML displays cluster information in YAML format
+// This is useful for configuration management or when YAML is preferred over JSON
+func ClustersYAML(w io.Writer, clusters []cluster.ClusterStatus) error {
+	output := struct {
+		Clusters []cluster.ClusterStatus `yaml:"clusters"`
+		Count    int                     `yaml:"count"`
+	}{
+		Clusters: clusters,
+		Count:    len(clusters),
+	}
+
+	return writeYAML(w, "clusters", output)
+}
@@ -0,0 +1,79 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+)
+
+// ConnectionStatusLine prints one timestamped line summing up a round of
+// connection checks, naming each unhealthy cluster and why, e.g.
+// "2026-10-16T09:30:00Z ❌ 3/4 clusters healthy; down: prod-ap (connection refused)"
+func ConnectionStatusLine(w io.Writer, at time.Time, checks []cluster.ConnectionCheck) error {
+	healthy := 0
+	var down []string
+	for _, check := range checks {
+		if check.Healthy {
+			healthy++
+			continue
+		}
+		down = append(down, fmt.Sprintf("%s (%s)", check.Name, check.Error))
+	}
+
+	icon := "✅"
+	if len(down) > 0 {
+		icon = "❌"
+	}
+	line := fmt.Sprintf("%s %s %d/%d clusters healthy", at.UTC().Format(time.RFC3339), icon, healthy, len(checks))
+	if len(down) > 0 {
+		line += "; down: " + strings.Join(down, ", ")
+	}
+	_, err := fmt.Fprintln(w, line)
+	return err
+}
+
+// connectionRound is one round of connection checks, for JSON and YAML
+type connectionRound struct {
+	Time     time.Time                 `json:"time"`
+	Healthy  int                       `json:"healthy"`
+	Total    int                       `json:"total"`
+	Clusters []cluster.ConnectionCheck `json:"clusters"`
+}
+
+func newConnectionRound(at time.Time, checks []cluster.ConnectionCheck) connectionRound {
+	round := connectionRound{Time: at.UTC(), Total: len(checks), Clusters: checks}
+	for _, check := range checks {
+		if check.Healthy {
+			round.Healthy++
+		}
+	}
+	return round
+}
+
+// ConnectionStatusJSONLine prints a round of connection checks as one line of
+// JSON, so a stream of rounds is JSON Lines that log shippers and jq -c take as is
+func ConnectionStatusJSONLine(w io.Writer, at time.Time, checks []cluster.ConnectionCheck) error {
+	jsonData, err := json.Marshal(newConnectionRound(at, checks))
+	if err != nil {
+		return fmt.Errorf("failed to marshal connection checks to JSON: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(jsonData))
+	return err
+}
+
+// ConnectionStatusYAML prints a round of connection checks as its own YAML
+// document, so a stream of rounds is a multi-document YAML stream
+func ConnectionStatusYAML(w io.Writer, at time.Time, checks []cluster.ConnectionCheck) error {
+	yamlData, err := yaml.Marshal(newConnectionRound(at, checks))
+	if err != nil {
+		return fmt.Errorf("failed to marshal connection checks to YAML: %w", err)
+	}
+	_, err = fmt.Fprintf(w, "---\n%s", yamlData)
+	return err
+}
@@ -0,0 +1,253 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// DeploymentsTableOptions tweaks individual columns of the deployments table
+type DeploymentsTableOptions struct {
+	// FullImage prints images untruncated - during version audits the tag or
+	// digest at the end is exactly the part that must not be cut off
+	FullImage bool
+
+	// GroupBy splits the table into one sub-table per cluster or namespace
+	// (GroupByCluster, GroupByNamespace), each with its own summary
+	GroupBy string
+
+	// PreviousStatus holds each deployment's status at the last watch refresh,
+	// by DeploymentKey. When set, a row whose status changed since then is
+	// flagged with what it was, and a deployment that wasn't there with "new"
+	PreviousStatus map[string]string
+}
+
+// DeploymentKey identifies a deployment across the fleet as cluster/namespace/name
+func DeploymentKey(deployment workload.DeploymentInfo) string {
+	return deployment.ClusterName + "/" + deployment.Namespace + "/" + deployment.Name
+}
+
+// deploymentColumns are the deployments table's columns, in order
+var deploymentColumns = []string{"CLUSTER", "NAMESPACE", "NAME", "REPLICAS", "STATUS", "IMAGE", "AGE"}
+
+// DeploymentsTable displays deployment information in a human-readable table
+// This is the most common output format - designed for quick visual scanning
+func DeploymentsTable(w io.Writer, deployments []workload.DeploymentInfo, opts DeploymentsTableOptions) error {
+	if len(deployments) == 0 {
+		_, err := fmt.Fprintln(w, "No deployments found in the specified clusters and namespaces.")
+		return err
+	}
+
+	if opts.GroupBy == "" {
+		if err := writeDeploymentRows(w, deployments, opts, ""); err != nil {
+			return err
+		}
+	} else {
+		// Reviewing a big fleet is easier one cluster (or namespace) at a time
+		key := func(d workload.DeploymentInfo) string { return d.ClusterName }
+		if opts.GroupBy == GroupByNamespace {
+			key = func(d workload.DeploymentInfo) string { return d.Namespace }
+		}
+		for i, g := range groupItems(deployments, key) {
+			writeGroupHeading(w, opts.GroupBy, g.name, i == 0)
+			if err := writeDeploymentRows(w, g.items, opts, groupColumn(opts.GroupBy)); err != nil {
+				return err
+			}
+			fmt.Fprintln(w, deploymentGroupSummary(g.items))
+		}
+	}
+
+	// Print a summary line to give context about what was shown, and answer
+	// "is the fleet healthy right now?" in the same breath
+	_, err := fmt.Fprintf(w, "\nFound %d deployments across %d clusters: %s\n",
+		len(deployments), len(uniqueDeploymentClusters(deployments)), newDeploymentHealth(deployments))
+	return err
+}
+
+// deploymentHealth adds up the readiness of a set of deployments
+type deploymentHealth struct {
+	DesiredReplicas int32   `json:"desiredReplicas"`
+	ReadyReplicas   int32   `json:"readyReplicas"`
+	ReadyPercent    float64 `json:"readyPercent"` // 100 when nothing is desired
+	Partial         int     `json:"partial"`
+	NotReady        int     `json:"notReady"`
+}
+
+// newDeploymentHealth sums replicas across the deployments
+// Surge replicas above a deployment's desired count don't make up for another
+// deployment's missing ones, so each deployment counts at most its desired replicas
+func newDeploymentHealth(deployments []workload.DeploymentInfo) deploymentHealth {
+	var health deploymentHealth
+	for _, deployment := range deployments {
+		health.DesiredReplicas += deployment.Replicas
+		health.ReadyReplicas += min(deployment.ReadyReplicas, deployment.Replicas)
+		switch deployment.Status {
+		case "Partial":
+			health.Partial++
+		case "NotReady":
+			health.NotReady++
+		}
+	}
+	health.ReadyPercent = 100
+	if health.DesiredReplicas > 0 {
+		health.ReadyPercent = math.Round(float64(health.ReadyReplicas)*1000/float64(health.DesiredReplicas)) / 10
+	}
+	return health
+}
+
+// String is the health part of the summary line, e.g.
+// "41/45 replicas ready (91.1%), 2 partial, 1 not ready"
+func (h deploymentHealth) String() string {
+	summary := fmt.Sprintf("%d/%d replicas ready (%.1f%%)", h.ReadyReplicas, h.DesiredReplicas, h.ReadyPercent)
+	if h.Partial > 0 {
+		summary += fmt.Sprintf(", %d partial", h.Partial)
+	}
+	if h.NotReady > 0 {
+		summary += fmt.Sprintf(", %d not ready", h.NotReady)
+	}
+	return summary
+}
+
+// writeDeploymentRows prints one table of deployments, without the omitted column
+func writeDeploymentRows(w io.Writer, deployments []workload.DeploymentInfo, opts DeploymentsTableOptions, omit string) error {
+	table := newTable(w)
+
+	// Print table headers - these provide context for each column
+	writeTableHeader(table, deploymentColumns, omit)
+
+	for _, deployment := range deployments {
+		// Format the replica information to show current vs desired
+		// This is crucial for understanding deployment health at a glance
+		replicas := fmt.Sprintf("%d/%d", deployment.ReadyReplicas, deployment.Replicas)
+
+		// Add visual indicators for deployment status
+		// These make it easy to quickly spot problems in a long list
+		var statusIcon string
+		switch deployment.Status {
+		case "Ready":
+			statusIcon = "✅ " + deployment.Status
+		case "Partial":
+			statusIcon = "⚠️  " + deployment.Status
+		case "NotReady":
+			statusIcon = "❌ " + deployment.Status
+		default:
+			statusIcon = "❓ " + deployment.Status
+		}
+
+		// In watch mode, point out what moved since the last refresh
+		if opts.PreviousStatus != nil {
+			previous, seen := opts.PreviousStatus[DeploymentKey(deployment)]
+			switch {
+			case !seen:
+				statusIcon += " (new)"
+			case previous != deployment.Status:
+				statusIcon += " (was " + previous + ")"
+			}
+		}
+
+		// Truncate long image names to keep the table readable
+		// Full image names can be very long with registry URLs and SHA digests
+		image := deployment.Image
+		if len(image) > 40 && !opts.FullImage {
+			// Keep the image name but truncate the middle part
+			// This preserves the most important parts (registry and tag)
+			parts := strings.Split(image, "/")
+			if len(parts) > 1 {
+				image = parts[0] + "/..." + parts[len(parts)-1]
+			}
+			if len(image) > 40 {
+				image = image[:37] + "..."
+			}
+		}
+
+		writeTableRow(table, deploymentColumns, []string{
+			deployment.ClusterName,
+			deployment.Namespace,
+			deployment.Name,
+			replicas,
+			statusIcon,
+			image,
+			deployment.Age,
+		}, omit)
+	}
+
+	// The table has to be flushed before the summary, or the summary lands above it
+	return table.Flush()
+}
+
+// deploymentGroupSummary counts one sub-table's deployments by readiness
+func deploymentGroupSummary(deployments []workload.DeploymentInfo) string {
+	ready := 0
+	for _, deployment := range deployments {
+		if deployment.Status == "Ready" {
+			ready++
+		}
+	}
+	if ready == len(deployments) {
+		return fmt.Sprintf("%d deployments, all ready", len(deployments))
+	}
+	return fmt.Sprintf("%d deployments, %d ready, %d not ready", len(deployments), ready, len(deployments)-ready)
+}
+
+// DeploymentsJSON formats deployment information as JSON
+// This is useful for automation, scripting, or integration with other tools
+func DeploymentsJSON(w io.Writer, deployments []workload.DeploymentInfo, failures map[string]string) error {
+	// Wrap the deployments in a structure that provides metadata
+	// This makes the JSON output more useful for programmatic consumption
+	output := struct {
+		Deployments []workload.DeploymentInfo `json:"deployments"`
+		Count       int                       `json:"count"`
+		Clusters    []string                  `json:"clusters"`
+		Health      deploymentHealth          `json:"health"`
+		Errors      map[string]string         `json:"errors,omitempty"` // Clusters that failed, with why
+	}{
+		Deployments: deployments,
+		Count:       len(deployments),
+		Clusters:    uniqueDeploymentClusters(deployments),
+		Health:      newDeploymentHealth(deployments),
+		Errors:      failures,
+	}
+
+	return writeJSON(w, "deployments", output)
+}
+
+// DeploymentsYAML formats deployment information as YAML
+// Some users prefer YAML for its readability and comments support
+func DeploymentsYAML(w io.Writer, deployments []workload.DeploymentInfo, failures map[string]string) error {
+	output := struct {
+		Deployments []workload.DeploymentInfo `yaml:"deployments"`
+		Count       int                       `yaml:"count"`
+		Clusters    []string                  `yaml:"clusters"`
+		Health      deploymentHealth          `yaml:"health"`
+		Errors      map[string]string         `json:"errors,omitempty" yaml:"errors,omitempty"`
+	}{
+		Deployments: deployments,
+		Count:       len(deployments),
+		Clusters:    uniqueDeploymentClusters(deployments),
+		Health:      newDeploymentHealth(deployments),
+		Errors:      failures,
+	}
+
+	return writeYAML(w, "deployments", output)
+}
+
+// uniqueDeploymentClusters returns a sorted list of unique cluster names from the deployments
+// This is useful for metadata in JSON/YAML output and the table summary
+func uniqueDeploymentClusters(deployments []workload.DeploymentInfo) []string {
+	clusterSet := make(map[string]bool)
+	for _, deployment := range deployments {
+		clusterSet[deployment.ClusterName] = true
+	}
+
+	var clusters []string
+	for cluster := range clusterSet {
+		clusters = append(clusters, cluster)
+	}
+
+	sort.Strings(clusters)
+	return clusters
+}
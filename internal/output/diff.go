@@ -0,0 +1,290 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Diff formats accepted by --diff-format
+const (
+	DiffUnified    = "unified"
+	DiffSideBySide = "side-by-side"
+)
+
+// DefaultContextLines is how many unchanged lines surround each change, as in diff -u
+const DefaultContextLines = 3
+
+// DiffOptions tunes how a text diff is rendered
+type DiffOptions struct {
+	Format       string // DiffUnified (default) or DiffSideBySide
+	ContextLines int    // Unchanged lines shown around each change
+	Width        int    // Total line width for side-by-side; 0 means 160
+	Color        bool   // Color removals red, additions green and hunk headers cyan, for terminals
+}
+
+// ANSI colors for diffs on a terminal
+const (
+	diffRed   = "\033[31m"
+	diffGreen = "\033[32m"
+	diffCyan  = "\033[36m"
+	diffBold  = "\033[1m"
+	diffReset = "\033[0m"
+)
+
+// painter wraps text in an ANSI color, or leaves it alone when color is off
+type painter bool
+
+func (p painter) paint(color, text string) string {
+	if !p || text == "" {
+		return text
+	}
+	return color + text + diffReset
+}
+
+// diffLine is one line of an edit script: kept (' '), removed ('-') or added ('+')
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// hunk is a run of edits plus its surrounding context, with 0-based start lines on each side
+type hunk struct {
+	fromStart, fromCount int
+	toStart, toCount     int
+	lines                []diffLine
+}
+
+// TextDiff renders the line differences between from and to, or nothing when they're equal
+// This is what diff -u prints, with an optional two-column layout for wide terminals
+func TextDiff(w io.Writer, fromName, toName, from, to string, opts DiffOptions) error {
+	hunks := diffHunks(splitLines(from), splitLines(to), opts.ContextLines)
+	if len(hunks) == 0 {
+		return nil
+	}
+
+	switch opts.Format {
+	case "", DiffUnified:
+		writeUnified(w, fromName, toName, hunks, painter(opts.Color))
+	case DiffSideBySide:
+		width := opts.Width
+		if width <= 0 {
+			width = 160
+		}
+		writeSideBySide(w, fromName, toName, hunks, width, painter(opts.Color))
+	default:
+		return fmt.Errorf("unknown diff format %q (supported: %s, %s)", opts.Format, DiffUnified, DiffSideBySide)
+	}
+	return nil
+}
+
+// writeUnified prints hunks in the unified format every review tool understands
+func writeUnified(w io.Writer, fromName, toName string, hunks []hunk, color painter) {
+	fmt.Fprintln(w, color.paint(diffBold, "--- "+fromName))
+	fmt.Fprintln(w, color.paint(diffBold, "+++ "+toName))
+	for _, h := range hunks {
+		fmt.Fprintln(w, color.paint(diffCyan, hunkHeader(h)))
+		for _, line := range h.lines {
+			text := string(line.op) + line.text
+			switch line.op {
+			case '-':
+				text = color.paint(diffRed, text)
+			case '+':
+				text = color.paint(diffGreen, text)
+			}
+			fmt.Fprintln(w, text)
+		}
+	}
+}
+
+// writeSideBySide prints hunks as two columns, marking each row like sdiff:
+// '|' changed, '<' only on the left, '>' only on the right
+func writeSideBySide(w io.Writer, fromName, toName string, hunks []hunk, width int, color painter) {
+	column := (width - 3) / 2
+	row := func(left string, marker byte, right string) {
+		left = fmt.Sprintf("%-*s", column, truncate(left, column))
+		right = truncate(right, column)
+		if marker == '|' || marker == '<' {
+			left = color.paint(diffRed, left)
+		}
+		if marker == '|' || marker == '>' {
+			right = color.paint(diffGreen, right)
+		}
+		line := fmt.Sprintf("%s %c %s", left, marker, right)
+		fmt.Fprintln(w, strings.TrimRight(line, " "))
+	}
+
+	row(fromName, ' ', toName)
+	for _, h := range hunks {
+		fmt.Fprintln(w, color.paint(diffCyan, hunkHeader(h)))
+
+		// Pair each run of removals with the additions that follow it, so a
+		// changed line sits next to what it became
+		for i := 0; i < len(h.lines); {
+			if h.lines[i].op == ' ' {
+				row(h.lines[i].text, ' ', h.lines[i].text)
+				i++
+				continue
+			}
+
+			var removed, added []string
+			for ; i < len(h.lines) && h.lines[i].op == '-'; i++ {
+				removed = append(removed, h.lines[i].text)
+			}
+			for ; i < len(h.lines) && h.lines[i].op == '+'; i++ {
+				added = append(added, h.lines[i].text)
+			}
+
+			for j := 0; j < len(removed) || j < len(added); j++ {
+				switch {
+				case j < len(removed) && j < len(added):
+					row(removed[j], '|', added[j])
+				case j < len(removed):
+					row(removed[j], '<', "")
+				default:
+					row("", '>', added[j])
+				}
+			}
+		}
+	}
+}
+
+// hunkHeader formats the "@@ -from +to @@" line with 1-based line numbers
+func hunkHeader(h hunk) string {
+	return fmt.Sprintf("@@ -%s +%s @@", hunkRange(h.fromStart, h.fromCount), hunkRange(h.toStart, h.toCount))
+}
+
+// hunkRange formats one side of a hunk header the way diff -u does
+// An empty side names the line before the hunk, so it reads as "insert after line N"
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// diffHunks computes the edit script between two line lists and groups it into hunks
+// Changes closer than twice the context share a hunk, so context is never printed twice
+func diffHunks(from, to []string, context int) []hunk {
+	if context < 0 {
+		context = 0
+	}
+	script := editScript(from, to)
+
+	var changed []int
+	for i, line := range script {
+		if line.op != ' ' {
+			changed = append(changed, i)
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	// Line numbers on each side at every position of the script
+	fromLine := make([]int, len(script)+1)
+	toLine := make([]int, len(script)+1)
+	for i, line := range script {
+		fromLine[i+1], toLine[i+1] = fromLine[i], toLine[i]
+		if line.op != '+' {
+			fromLine[i+1]++
+		}
+		if line.op != '-' {
+			toLine[i+1]++
+		}
+	}
+
+	var hunks []hunk
+	start, end := -1, -1
+	flush := func() {
+		h := hunk{
+			fromStart: fromLine[start], fromCount: fromLine[end] - fromLine[start],
+			toStart: toLine[start], toCount: toLine[end] - toLine[start],
+			lines: script[start:end],
+		}
+		hunks = append(hunks, h)
+	}
+
+	for _, index := range changed {
+		lo := max(index-context, 0)
+		hi := min(index+context+1, len(script))
+		if start >= 0 && lo <= end {
+			end = max(end, hi)
+			continue
+		}
+		if start >= 0 {
+			flush()
+		}
+		start, end = lo, hi
+	}
+	flush()
+
+	return hunks
+}
+
+// editScript finds a shortest line-level edit from one text to the other using
+// the longest common subsequence. Manifests are at most a few hundred lines,
+// so the quadratic table is cheap and the result is minimal
+func editScript(from, to []string) []diffLine {
+	// common[i][j] is the LCS length of from[i:] and to[j:]
+	common := make([][]int, len(from)+1)
+	for i := range common {
+		common[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	script := make([]diffLine, 0, len(from)+len(to))
+	i, j := 0, 0
+	for i < len(from) && j < len(to) {
+		switch {
+		case from[i] == to[j]:
+			script = append(script, diffLine{' ', from[i]})
+			i++
+			j++
+		case common[i+1][j] >= common[i][j+1]:
+			script = append(script, diffLine{'-', from[i]})
+			i++
+		default:
+			script = append(script, diffLine{'+', to[j]})
+			j++
+		}
+	}
+	for ; i < len(from); i++ {
+		script = append(script, diffLine{'-', from[i]})
+	}
+	for ; j < len(to); j++ {
+		script = append(script, diffLine{'+', to[j]})
+	}
+	return script
+}
+
+// splitLines breaks text into lines, ignoring the final newline
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// truncate shortens s to at most width characters, marking the cut with "…"
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	if width <= 1 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-1]) + "…"
+}
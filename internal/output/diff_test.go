@@ -0,0 +1,97 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const diffLive = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: prod
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - image: nginx:1.26
+        name: web
+      - image: envoy:1.30
+        name: proxy
+`
+
+const diffDesired = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: prod
+spec:
+  replicas: 5
+  template:
+    spec:
+      containers:
+      - image: nginx:1.27
+        name: web
+`
+
+func TestTextDiffGolden(t *testing.T) {
+	tests := []struct {
+		name string
+		opts DiffOptions
+	}{
+		{"diff_unified", DiffOptions{ContextLines: DefaultContextLines}},
+		{"diff_unified_no_context", DiffOptions{ContextLines: 0}},
+		{"diff_side_by_side", DiffOptions{Format: DiffSideBySide, ContextLines: 1, Width: 60}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := TextDiff(&buf, "prod/web (live)", "manifest", diffLive, diffDesired, tt.opts); err != nil {
+				t.Fatal(err)
+			}
+			assertGolden(t, tt.name, buf.Bytes())
+		})
+	}
+}
+
+func TestTextDiffOfEqualTextsIsEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := TextDiff(&buf, "a", "b", diffLive, diffLive, DiffOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output for equal texts, got:\n%s", buf.String())
+	}
+}
+
+func TestTextDiffOfCreateIsAllAdditions(t *testing.T) {
+	var buf bytes.Buffer
+	if err := TextDiff(&buf, "(does not exist)", "manifest", "", "a: 1\nb: 2\n", DiffOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	want := "--- (does not exist)\n+++ manifest\n@@ -0,0 +1,2 @@\n+a: 1\n+b: 2\n"
+	if buf.String() != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, buf.String())
+	}
+}
+
+func TestTextDiffRejectsUnknownFormat(t *testing.T) {
+	err := TextDiff(&bytes.Buffer{}, "a", "b", "x\n", "y\n", DiffOptions{Format: "html"})
+	if err == nil || !strings.Contains(err.Error(), "side-by-side") {
+		t.Errorf("Expected an error naming the supported formats, got %v", err)
+	}
+}
+
+func TestTextDiffColor(t *testing.T) {
+	var buf bytes.Buffer
+	if err := TextDiff(&buf, "live", "manifest", "a: 1\n", "a: 2\n", DiffOptions{Color: true}); err != nil {
+		t.Fatal(err)
+	}
+	want := "\033[1m--- live\033[0m\n\033[1m+++ manifest\033[0m\n\033[36m@@ -1 +1 @@\033[0m\n\033[31m-a: 1\033[0m\n\033[32m+a: 2\033[0m\n"
+	if buf.String() != want {
+		t.Errorf("Expected:\n%q\ngot:\n%q", want, buf.String())
+	}
+}
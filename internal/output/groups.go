@@ -0,0 +1,88 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Groupings accepted by --group-by
+const (
+	GroupByCluster   = "cluster"
+	GroupByNamespace = "namespace"
+)
+
+// ValidateGroupBy checks a --group-by value; empty means no grouping
+func ValidateGroupBy(groupBy string) error {
+	switch groupBy {
+	case "", GroupByCluster, GroupByNamespace:
+		return nil
+	}
+	return fmt.Errorf("unknown --group-by %q (supported: %s, %s)", groupBy, GroupByCluster, GroupByNamespace)
+}
+
+// group is the rows of one sub-table
+type group[T any] struct {
+	name  string
+	items []T
+}
+
+// groupItems splits items into groups by key, with groups in name order and
+// each group's items in the order they came in, so the caller's sort still holds
+func groupItems[T any](items []T, key func(T) string) []group[T] {
+	index := make(map[string]int)
+	var groups []group[T]
+	for _, item := range items {
+		name := key(item)
+		i, ok := index[name]
+		if !ok {
+			i = len(groups)
+			index[name] = i
+			groups = append(groups, group[T]{name: name})
+		}
+		groups[i].items = append(groups[i].items, item)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].name < groups[j].name })
+	return groups
+}
+
+// groupColumn is the table column a grouping makes redundant - every row in a
+// cluster's sub-table has the same CLUSTER, so it's left out
+func groupColumn(groupBy string) string {
+	return strings.ToUpper(groupBy)
+}
+
+// writeGroupHeading introduces one sub-table, e.g. "== cluster prod-us =="
+func writeGroupHeading(w io.Writer, groupBy, name string, first bool) {
+	if !first {
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintf(w, "== %s %s ==\n", groupBy, name)
+}
+
+// writeTableHeader prints the column names and their underlines, leaving out omit
+func writeTableHeader(w io.Writer, columns []string, omit string) {
+	var names, lines []string
+	for _, column := range columns {
+		if column == omit {
+			continue
+		}
+		names = append(names, column)
+		lines = append(lines, strings.Repeat("-", len(column)))
+	}
+	fmt.Fprintln(w, strings.Join(names, "\t"))
+	fmt.Fprintln(w, strings.Join(lines, "\t"))
+}
+
+// writeTableRow prints one row, leaving out the cell under the omitted column
+func writeTableRow(w io.Writer, columns, cells []string, omit string) {
+	kept := make([]string, 0, len(cells))
+	for i, cell := range cells {
+		if columns[i] != omit {
+			kept = append(kept, cell)
+		}
+	}
+	fmt.Fprintln(w, strings.Join(kept, "\t"))
+}
@@ -0,0 +1,74 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// inventoryPhases are the pod phases the summary table gives a column each
+var inventoryPhases = []corev1.PodPhase{
+	corev1.PodRunning, corev1.PodPending, corev1.PodSucceeded, corev1.PodFailed, corev1.PodUnknown,
+}
+
+// ResourceCountsTable shows each cluster's resource counts, one row per cluster,
+// with a TOTAL row for the fleet when more than one cluster answered
+func ResourceCountsTable(w io.Writer, counts []workload.ResourceCounts) error {
+	table := newTable(w)
+
+	fmt.Fprintln(table, "CLUSTER\tNODES\tNAMESPACES\tDEPLOYMENTS\tSERVICES\tPODS\tRUNNING\tPENDING\tSUCCEEDED\tFAILED\tUNKNOWN")
+	fmt.Fprintln(table, "-------\t-----\t----------\t-----------\t--------\t----\t-------\t-------\t---------\t------\t-------")
+
+	for _, c := range counts {
+		writeResourceCountsRow(table, c.ClusterName, c)
+	}
+	if len(counts) > 1 {
+		writeResourceCountsRow(table, "TOTAL", totalResourceCounts(counts))
+	}
+
+	return table.Flush()
+}
+
+// writeResourceCountsRow writes one line of the summary table
+func writeResourceCountsRow(w io.Writer, label string, c workload.ResourceCounts) {
+	fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d", label, c.Nodes, c.Namespaces, c.Deployments, c.Services, c.Pods)
+	for _, phase := range inventoryPhases {
+		fmt.Fprintf(w, "\t%d", c.PodPhases[phase])
+	}
+	fmt.Fprintln(w)
+}
+
+// totalResourceCounts adds up every cluster's counts
+func totalResourceCounts(counts []workload.ResourceCounts) workload.ResourceCounts {
+	var total workload.ResourceCounts
+	for _, c := range counts {
+		total.Add(c)
+	}
+	return total
+}
+
+// resourceCountsDocument is the JSON/YAML shape of a summary: per-cluster
+// counts, the fleet total, and the clusters that couldn't be counted
+type resourceCountsDocument struct {
+	Clusters []workload.ResourceCounts `json:"clusters"`
+	Total    workload.ResourceCounts   `json:"total"`
+	Errors   map[string]string         `json:"errors,omitempty"` // Clusters that failed, with why
+}
+
+// newResourceCountsDocument wraps counts for JSON/YAML output
+func newResourceCountsDocument(counts []workload.ResourceCounts, failures map[string]string) resourceCountsDocument {
+	return resourceCountsDocument{Clusters: counts, Total: totalResourceCounts(counts), Errors: failures}
+}
+
+// ResourceCountsJSON formats a summary as JSON
+func ResourceCountsJSON(w io.Writer, counts []workload.ResourceCounts, failures map[string]string) error {
+	return writeJSON(w, "summary", newResourceCountsDocument(counts, failures))
+}
+
+// ResourceCountsYAML formats a summary as YAML
+func ResourceCountsYAML(w io.Writer, counts []workload.ResourceCounts, failures map[string]string) error {
+	return writeYAML(w, "summary", newResourceCountsDocument(counts, failures))
+}
@@ -0,0 +1,81 @@
+// Package output renders mcm's query results for people and for scripts
+// Every renderer writes to an io.Writer instead of stdout, so the exact text a
+// user would see can be produced - and tested - without running a command
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// newTable creates the tab-aligned writer all tables share
+// This is like a spreadsheet that auto-adjusts column widths once every row is in
+func newTable(w io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+}
+
+// writeJSON writes a value as indented JSON followed by a newline
+func writeJSON(w io.Writer, what string, value interface{}) error {
+	jsonData, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s to JSON: %w", what, err)
+	}
+	_, err = fmt.Fprintln(w, string(jsonData))
+	return err
+}
+
+// writeYAML writes a value as YAML
+func writeYAML(w io.Writer, what string, value interface{}) error {
+	yamlData, err := yaml.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s to YAML: %w", what, err)
+	}
+	_, err = w.Write(yamlData)
+	return err
+}
+
+// Names prints one identifier per line for --output=name
+// This is the scripting format: nothing but names, ready to pipe into xargs
+func Names(w io.Writer, names []string) error {
+	for _, name := range names {
+		if _, err := fmt.Fprintln(w, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FleetFailures prints the footer naming clusters that contributed nothing
+// to a fleet-wide query, e.g. "⚠️  2 clusters failed: dev (forbidden), prod-eu (timeout)"
+// The short reasons keep the footer to one line; verbose adds each full error
+// underneath for when the reason alone isn't enough to act on
+func FleetFailures[T any](w io.Writer, result workload.FleetResult[T], verbose bool) {
+	failed := result.FailedClusters()
+	if len(failed) == 0 {
+		return
+	}
+
+	reasons := make([]string, 0, len(failed))
+	for _, name := range failed {
+		reasons = append(reasons, fmt.Sprintf("%s (%s)", name, workload.FailureReason(result.Errors[name])))
+	}
+
+	noun := "clusters"
+	if len(failed) == 1 {
+		noun = "cluster"
+	}
+	fmt.Fprintf(w, "\n⚠️  %d %s failed: %s\n", len(failed), noun, strings.Join(reasons, ", "))
+
+	if verbose {
+		for _, name := range failed {
+			fmt.Fprintf(w, "   %s: %v\n", name, result.Errors[name])
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+)
+
+// PingTable shows which API servers answered a TCP dial, and how fast
+func PingTable(w io.Writer, results []cluster.PingResult) error {
+	table := newTable(w)
+
+	fmt.Fprintln(table, "NAME\tSERVER\tSTATUS\tLATENCY\tERROR")
+	fmt.Fprintln(table, "----\t------\t------\t-------\t-----")
+
+	reachable := 0
+	for _, result := range results {
+		status := "❌ Unreachable"
+		latency := "-"
+		if result.Reachable {
+			reachable++
+			status = "✅ Reachable"
+			latency = result.Latency.Round(100 * time.Microsecond).String()
+		}
+
+		errorMsg := result.Error
+		if len(errorMsg) > 60 {
+			errorMsg = errorMsg[:57] + "..."
+		}
+
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\n",
+			result.Name, valueOrDash(result.Server), status, latency, valueOrDash(errorMsg))
+	}
+
+	if err := table.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%d/%d API servers reachable\n", reachable, len(results))
+	return err
+}
+
+// PingJSON formats ping results as JSON
+func PingJSON(w io.Writer, results []cluster.PingResult) error {
+	return writeJSON(w, "ping results", struct {
+		Results []cluster.PingResult `json:"results"`
+	}{Results: results})
+}
+
+// PingYAML formats ping results as YAML
+func PingYAML(w io.Writer, results []cluster.PingResult) error {
+	return writeYAML(w, "ping results", struct {
+		Results []cluster.PingResult `json:"results"`
+	}{Results: results})
+}
@@ -0,0 +1,91 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// planActions are the plan's actions in the order the summary line counts them
+var planActions = []struct{ action, phrase string }{
+	{workload.PlanCreate, "to create"},
+	{workload.PlanUpdate, "to update"},
+	{workload.PlanRecreate, "to recreate"},
+	{workload.PlanUnchanged, "unchanged"},
+	{workload.PlanRefuse, "refused"},
+}
+
+// ResourcePlanTable shows a deploy plan as one row per resource and cluster,
+// naming each resource only on its first row so the clusters line up under it,
+// then a summary line like "Plan: 1 to create, 2 to update, 3 unchanged"
+// Plans are expected sorted by resource, as PlanManifest returns them
+func ResourcePlanTable(w io.Writer, plans []workload.ResourcePlan) error {
+	table := newTable(w)
+
+	fmt.Fprintln(table, "RESOURCE\tCLUSTER\tACTION\tDETAILS")
+	fmt.Fprintln(table, "--------\t-------\t------\t-------")
+
+	previous := ""
+	for _, plan := range plans {
+		resource := plan.Resource()
+		if resource == previous {
+			resource = ""
+		} else {
+			previous = resource
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", resource, plan.Cluster, plan.Action, planDetails(plan))
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\n%s\n", PlanSummary(plans))
+	return err
+}
+
+// PlanSummary counts the plan's actions, e.g. "Plan: 1 to create, 2 to update, 3 unchanged"
+// Actions nothing is planned for are left out
+func PlanSummary(plans []workload.ResourcePlan) string {
+	counts := make(map[string]int)
+	for _, plan := range plans {
+		counts[plan.Action]++
+	}
+
+	var parts []string
+	for _, entry := range planActions {
+		if counts[entry.action] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[entry.action], entry.phrase))
+		}
+	}
+	if len(parts) == 0 {
+		return "Plan: nothing to do"
+	}
+	return "Plan: " + strings.Join(parts, ", ")
+}
+
+// planDetails is the DETAILS cell: the fields an update changes, or the reason
+// given for any other action
+func planDetails(plan workload.ResourcePlan) string {
+	if len(plan.Changes) > 0 {
+		return strings.Join(plan.Changes, ", ")
+	}
+	return plan.Reason
+}
+
+// resourcePlanDocument is the JSON/YAML shape of a plan
+type resourcePlanDocument struct {
+	Resources []workload.ResourcePlan `json:"resources"`
+	Errors    map[string]string       `json:"errors,omitempty"` // Clusters that couldn't be planned, with why
+}
+
+// ResourcePlanJSON formats a plan as JSON
+func ResourcePlanJSON(w io.Writer, plans []workload.ResourcePlan, failures map[string]string) error {
+	return writeJSON(w, "plan", resourcePlanDocument{Resources: plans, Errors: failures})
+}
+
+// ResourcePlanYAML formats a plan as YAML
+func ResourcePlanYAML(w io.Writer, plans []workload.ResourcePlan, failures map[string]string) error {
+	return writeYAML(w, "plan", resourcePlanDocument{Resources: plans, Errors: failures})
+}
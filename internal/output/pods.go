@@ -0,0 +1,232 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// PodSummary provides aggregate statistics about the pod collection
+// This is useful for understanding the overall health of your infrastructure
+type PodSummary struct {
+	Running   int `json:"running" yaml:"running"`
+	Pending   int `json:"pending" yaml:"pending"`
+	Failed    int `json:"failed" yaml:"failed"`
+	Succeeded int `json:"succeeded" yaml:"succeeded"`
+	Unknown   int `json:"unknown" yaml:"unknown"`
+	Other     int `json:"other" yaml:"other"`
+}
+
+// SummarizePods calculates summary statistics from the pod list
+func SummarizePods(pods []workload.PodInfo) PodSummary {
+	summary := PodSummary{}
+
+	for _, pod := range pods {
+		switch pod.Status {
+		case "Running":
+			summary.Running++
+		case "Pending":
+			summary.Pending++
+		case "Failed":
+			summary.Failed++
+		case "Succeeded":
+			summary.Succeeded++
+		case "Unknown":
+			summary.Unknown++
+		default:
+			summary.Other++
+		}
+	}
+
+	return summary
+}
+
+// PodsTableOptions tweaks the pods table
+type PodsTableOptions struct {
+	// GroupBy splits the table into one sub-table per cluster or namespace
+	// (GroupByCluster, GroupByNamespace), each with its own summary
+	GroupBy string
+}
+
+// podColumns are the pods table's columns, in order
+var podColumns = []string{"CLUSTER", "NAMESPACE", "NAME", "READY", "STATUS", "RESTARTS", "AGE", "NODE"}
+
+// PodsTable displays pod information in a readable table format
+// This is optimized for quick visual scanning to spot problems
+func PodsTable(w io.Writer, pods []workload.PodInfo, opts PodsTableOptions) error {
+	if len(pods) == 0 {
+		_, err := fmt.Fprintln(w, "No pods found in the specified clusters and namespaces.")
+		return err
+	}
+
+	if opts.GroupBy == "" {
+		if err := writePodRows(w, pods, ""); err != nil {
+			return err
+		}
+	} else {
+		key := func(p workload.PodInfo) string { return p.ClusterName }
+		if opts.GroupBy == GroupByNamespace {
+			key = func(p workload.PodInfo) string { return p.Namespace }
+		}
+		for i, g := range groupItems(pods, key) {
+			writeGroupHeading(w, opts.GroupBy, g.name, i == 0)
+			if err := writePodRows(w, g.items, groupColumn(opts.GroupBy)); err != nil {
+				return err
+			}
+			summary := SummarizePods(g.items)
+			fmt.Fprintf(w, "%d pods, %d running\n", len(g.items), summary.Running)
+		}
+	}
+
+	// Provide summary statistics to give context
+	summary := SummarizePods(pods)
+	totalCount := len(pods)
+	fmt.Fprintf(w, "\nFound %d pods (%d running) across %d clusters\n",
+		totalCount, summary.Running, len(uniquePodClusters(pods)))
+
+	// Highlight if there are any non-running pods as this might need attention
+	if summary.Running < totalCount {
+		nonRunning := totalCount - summary.Running
+		fmt.Fprintf(w, "⚠️  Note: %d pods are not in Running state - this may require investigation\n", nonRunning)
+	}
+	if onBadNodes := countPodsOnBadNodes(pods); onBadNodes > 0 {
+		fmt.Fprintf(w, "🚨 %d pods are on NotReady or unreachable nodes - they may not be serving whatever their status says\n", onBadNodes)
+	}
+
+	return nil
+}
+
+// countPodsOnBadNodes counts the pods FlagPodsOnBadNodes flagged
+func countPodsOnBadNodes(pods []workload.PodInfo) int {
+	count := 0
+	for _, pod := range pods {
+		if pod.NodeStatus != "" {
+			count++
+		}
+	}
+	return count
+}
+
+// writePodRows prints one table of pods, without the omitted column
+func writePodRows(w io.Writer, pods []workload.PodInfo, omit string) error {
+	table := newTable(w)
+
+	// Headers that provide the most critical pod information at a glance
+	writeTableHeader(table, podColumns, omit)
+
+	for _, pod := range pods {
+		// Add visual indicators for pod status to make problems immediately visible
+		var statusIcon string
+		switch pod.Status {
+		case "Running":
+			statusIcon = "✅ " + pod.Status
+		case "Pending":
+			statusIcon = "⏳ " + pod.Status
+		case "Failed":
+			statusIcon = "❌ " + pod.Status
+		case "Succeeded":
+			statusIcon = "✅ " + pod.Status
+		case "Unknown":
+			statusIcon = "❓ " + pod.Status
+		default:
+			statusIcon = pod.Status
+		}
+		// A pod on a broken node only looks healthy; its phase is stale
+		if pod.NodeStatus != "" {
+			statusIcon = fmt.Sprintf("🚨 %s (node %s)", pod.Status, pod.NodeStatus)
+		}
+
+		// Highlight high restart counts as they indicate instability
+		restarts := fmt.Sprintf("%d", pod.Restarts)
+		if pod.Restarts > 5 {
+			restarts = "⚠️ " + restarts // Warning for moderate restart counts
+		}
+		if pod.Restarts > 20 {
+			restarts = "🚨 " + restarts // Alert for high restart counts
+		}
+
+		// Truncate long pod names to keep table readable while preserving key info
+		// Pod names often include deployment names and random suffixes
+		podName := pod.Name
+		if len(podName) > 35 {
+			// Try to preserve the meaningful prefix and show it's truncated
+			podName = podName[:32] + "..."
+		}
+
+		// Truncate node names since they're often very long in cloud environments
+		nodeName := pod.Node
+		if len(nodeName) > 20 {
+			nodeName = nodeName[:17] + "..."
+		}
+
+		writeTableRow(table, podColumns, []string{
+			pod.ClusterName,
+			pod.Namespace,
+			podName,
+			pod.Ready,
+			statusIcon,
+			restarts,
+			pod.Age,
+			nodeName,
+		}, omit)
+	}
+
+	// The table has to be flushed before the summary, or the summary lands above it
+	return table.Flush()
+}
+
+// PodsJSON formats pod information as JSON for programmatic use
+func PodsJSON(w io.Writer, pods []workload.PodInfo, failures map[string]string) error {
+	output := struct {
+		Pods     []workload.PodInfo `json:"pods"`
+		Count    int                `json:"count"`
+		Clusters []string           `json:"clusters"`
+		Summary  PodSummary         `json:"summary"`
+		Errors   map[string]string  `json:"errors,omitempty"` // Clusters that failed, with why
+	}{
+		Pods:     pods,
+		Count:    len(pods),
+		Clusters: uniquePodClusters(pods),
+		Summary:  SummarizePods(pods),
+		Errors:   failures,
+	}
+
+	return writeJSON(w, "pods", output)
+}
+
+// PodsYAML formats pod information as YAML
+func PodsYAML(w io.Writer, pods []workload.PodInfo, failures map[string]string) error {
+	output := struct {
+		Pods     []workload.PodInfo `yaml:"pods"`
+		Count    int                `yaml:"count"`
+		Clusters []string           `yaml:"clusters"`
+		Summary  PodSummary         `yaml:"summary"`
+		Errors   map[string]string  `json:"errors,omitempty" yaml:"errors,omitempty"`
+	}{
+		Pods:     pods,
+		Count:    len(pods),
+		Clusters: uniquePodClusters(pods),
+		Summary:  SummarizePods(pods),
+		Errors:   failures,
+	}
+
+	return writeYAML(w, "pods", output)
+}
+
+// uniquePodClusters returns sorted list of unique cluster names
+func uniquePodClusters(pods []workload.PodInfo) []string {
+	clusterSet := make(map[string]bool)
+	for _, pod := range pods {
+		clusterSet[pod.ClusterName] = true
+	}
+
+	var clusters []string
+	for cluster := range clusterSet {
+		clusters = append(clusters, cluster)
+	}
+
+	sort.Strings(clusters)
+	return clusters
+}
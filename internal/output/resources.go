@@ -0,0 +1,155 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// ResourcesTableOptions tweaks the generic resource table
+type ResourcesTableOptions struct {
+	// AllNamespaces adds a NAMESPACE column for namespaced resources, since the
+	// server's own columns leave it out when listing a single namespace
+	AllNamespaces bool
+}
+
+// ResourcesTable prints server-printed tables from several clusters as kubectl
+// would, with a CLUSTER column in front
+// Clusters that printed the same columns share one table. A resource whose
+// columns differ between clusters - say, a CRD at two versions - gets one
+// table per set of columns, since the rows can't be lined up
+func ResourcesTable(w io.Writer, tables []workload.ResourceTable, opts ResourcesTableOptions) error {
+	rows := 0
+	for _, table := range tables {
+		rows += len(table.Rows)
+	}
+	if rows == 0 {
+		_, err := fmt.Fprintln(w, "No resources found.")
+		return err
+	}
+
+	// Group tables by their columns, keeping the order the clusters came in
+	var groups [][]workload.ResourceTable
+	index := make(map[string]int)
+	for _, table := range tables {
+		if len(table.Rows) == 0 {
+			continue
+		}
+		key := fmt.Sprintf("%t|%s", table.Namespaced, strings.Join(table.Columns, "\x00"))
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], table)
+	}
+
+	for i, group := range groups {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		if err := writeResourceRows(w, group, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeResourceRows prints one table for clusters that share the same columns
+func writeResourceRows(w io.Writer, tables []workload.ResourceTable, opts ResourcesTableOptions) error {
+	withNamespace := opts.AllNamespaces && tables[0].Namespaced
+
+	columns := []string{"CLUSTER"}
+	if withNamespace {
+		columns = append(columns, "NAMESPACE")
+	}
+	// Server column names are in title case ("Name"); kubectl upper-cases them
+	for _, column := range tables[0].Columns {
+		columns = append(columns, strings.ToUpper(column))
+	}
+
+	table := newTable(w)
+	writeTableHeader(table, columns, "")
+	for _, resources := range tables {
+		for _, row := range resources.Rows {
+			cells := []string{resources.ClusterName}
+			if withNamespace {
+				cells = append(cells, row.Namespace)
+			}
+			cells = append(cells, row.Cells...)
+			writeTableRow(table, columns, cells, "")
+		}
+	}
+	return table.Flush()
+}
+
+// ResourcesJSON prints the objects a get returned, with the cluster each came from
+func ResourcesJSON(w io.Writer, objects []workload.ClusterObject, failures map[string]string) error {
+	return writeJSON(w, "resources", resourcesOutput(objects, failures))
+}
+
+// ResourcesYAML prints the objects a get returned as YAML
+func ResourcesYAML(w io.Writer, objects []workload.ClusterObject, failures map[string]string) error {
+	return writeYAML(w, "resources", resourcesOutput(objects, failures))
+}
+
+// resourcesOutput is the document ResourcesJSON and ResourcesYAML print
+func resourcesOutput(objects []workload.ClusterObject, failures map[string]string) interface{} {
+	if objects == nil {
+		objects = []workload.ClusterObject{}
+	}
+	return struct {
+		Items  []workload.ClusterObject `json:"items"`
+		Count  int                      `json:"count"`
+		Errors map[string]string        `json:"errors,omitempty"`
+	}{Items: objects, Count: len(objects), Errors: failures}
+}
+
+// ObjectsYAML prints the objects as kubectl-style multi-document YAML - the
+// objects themselves, not mcm's summary of them - so they can be piped into
+// kubectl or mcm deploy. Each is annotated with the cluster it came from, and
+// managedFields are left out as kubectl leaves them out
+func ObjectsYAML(w io.Writer, objects []workload.ClusterObject) error {
+	for i, object := range objects {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w, "---"); err != nil {
+				return err
+			}
+		}
+		if err := writeYAML(w, "object", annotatedObject(object)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// annotatedObject copies an object's top level and metadata, so the cluster
+// annotation can be added without touching the object it came from
+func annotatedObject(object workload.ClusterObject) map[string]interface{} {
+	copied := make(map[string]interface{}, len(object.Object))
+	for key, value := range object.Object {
+		copied[key] = value
+	}
+
+	metadata := make(map[string]interface{})
+	if original, ok := object.Object["metadata"].(map[string]interface{}); ok {
+		for key, value := range original {
+			metadata[key] = value
+		}
+	}
+	delete(metadata, "managedFields")
+
+	annotations := make(map[string]interface{})
+	if original, ok := metadata["annotations"].(map[string]interface{}); ok {
+		for key, value := range original {
+			annotations[key] = value
+		}
+	}
+	annotations[workload.ClusterAnnotation] = object.ClusterName
+	metadata["annotations"] = annotations
+	copied["metadata"] = metadata
+	return copied
+}
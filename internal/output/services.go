@@ -0,0 +1,90 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// ServicesTable displays services with the addresses they're reached on
+// A LoadBalancer still waiting for its cloud load balancer shows <pending>, so
+// the regions without an external endpoint stand out
+func ServicesTable(w io.Writer, services []workload.ServiceInfo) error {
+	if len(services) == 0 {
+		_, err := fmt.Fprintln(w, "No services found in the specified clusters and namespaces.")
+		return err
+	}
+
+	table := newTable(w)
+	fmt.Fprintln(table, "CLUSTER\tNAMESPACE\tNAME\tTYPE\tCLUSTER-IP\tEXTERNAL-ADDRESS\tPORTS\tAGE")
+	fmt.Fprintln(table, "-------\t---------\t----\t----\t----------\t----------------\t-----\t---")
+
+	clusters := make(map[string]bool)
+	external, pending := 0, 0
+	for _, service := range services {
+		clusters[service.ClusterName] = true
+
+		address := valueOrDash(strings.Join(service.ExternalAddresses, ","))
+		switch {
+		case service.Pending:
+			address = "<pending>"
+			pending++
+		case service.Type == "LoadBalancer":
+			external++
+		}
+
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			service.ClusterName,
+			service.Namespace,
+			service.Name,
+			service.Type,
+			valueOrDash(service.ClusterIP),
+			address,
+			valueOrDash(strings.Join(service.Ports, ",")),
+			service.Age,
+		)
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nFound %d services across %d clusters", len(services), len(clusters))
+	if external > 0 || pending > 0 {
+		fmt.Fprintf(w, "; %d load balancers with an external address, %d pending", external, pending)
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+// ServicesJSON formats services as JSON
+func ServicesJSON(w io.Writer, services []workload.ServiceInfo, failures map[string]string) error {
+	return writeJSON(w, "services", servicesOutput(services, failures))
+}
+
+// ServicesYAML formats services as YAML
+func ServicesYAML(w io.Writer, services []workload.ServiceInfo, failures map[string]string) error {
+	return writeYAML(w, "services", servicesOutput(services, failures))
+}
+
+// servicesOutput is the shape of the services JSON and YAML output
+func servicesOutput(services []workload.ServiceInfo, failures map[string]string) interface{} {
+	clusterSet := make(map[string]bool)
+	for _, service := range services {
+		clusterSet[service.ClusterName] = true
+	}
+	clusters := make([]string, 0, len(clusterSet))
+	for name := range clusterSet {
+		clusters = append(clusters, name)
+	}
+	sort.Strings(clusters)
+
+	return struct {
+		Services []workload.ServiceInfo `json:"services"`
+		Count    int                    `json:"count"`
+		Clusters []string               `json:"clusters"`
+		Errors   map[string]string      `json:"errors,omitempty"` // Clusters that failed, with why
+	}{Services: services, Count: len(services), Clusters: clusters, Errors: failures}
+}
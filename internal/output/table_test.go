@@ -0,0 +1,356 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// Run 'go test ./internal/output -update' to rewrite the golden files after an intended change
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// assertGolden compares rendered output with testdata/<name>.golden
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read golden file (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("Output does not match %s\n--- got ---\n%s\n--- want ---\n%s", path, got, want)
+	}
+}
+
+// failedFleet is a fleet result where one cluster timed out and one was forbidden
+func failedFleet[T any](items []T) workload.FleetResult[T] {
+	return workload.FleetResult[T]{
+		Items: items,
+		Errors: map[string]error{
+			"prod-ap": workload.ErrClusterTimeout,
+			"dev":     apierrors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "deployments"}, "", errors.New("User \"ci\" cannot list resource")),
+		},
+	}
+}
+
+func TestDeploymentsTableGolden(t *testing.T) {
+	prodUS := []workload.DeploymentInfo{
+		{ClusterName: "prod-us", Namespace: "production", Name: "web", Replicas: 3, ReadyReplicas: 3, Image: "nginx:1.27", Status: "Ready", Age: "12d"},
+		{ClusterName: "prod-us", Namespace: "production", Name: "worker", Replicas: 4, ReadyReplicas: 2, Image: "registry.example.com/platform/team/worker:v2.14.3-rc1", Status: "Partial", Age: "3h"},
+	}
+	multi := append(append([]workload.DeploymentInfo{}, prodUS...),
+		workload.DeploymentInfo{ClusterName: "prod-eu", Namespace: "production", Name: "web", Replicas: 3, ReadyReplicas: 0, Image: "nginx:1.27", Status: "NotReady", Age: "5m"},
+	)
+
+	tests := []struct {
+		name   string
+		result workload.FleetResult[workload.DeploymentInfo]
+		opts   DeploymentsTableOptions
+	}{
+		{"deployments_empty", workload.FleetResult[workload.DeploymentInfo]{}, DeploymentsTableOptions{}},
+		{"deployments_single_cluster", workload.FleetResult[workload.DeploymentInfo]{Items: prodUS}, DeploymentsTableOptions{}},
+		{"deployments_multi_cluster", workload.FleetResult[workload.DeploymentInfo]{Items: multi}, DeploymentsTableOptions{}},
+		{"deployments_failed_clusters", failedFleet(prodUS), DeploymentsTableOptions{}},
+		{"deployments_full_image", workload.FleetResult[workload.DeploymentInfo]{Items: prodUS}, DeploymentsTableOptions{FullImage: true}},
+		{"deployments_grouped_cluster", workload.FleetResult[workload.DeploymentInfo]{Items: multi}, DeploymentsTableOptions{GroupBy: GroupByCluster}},
+		{"deployments_grouped_namespace", workload.FleetResult[workload.DeploymentInfo]{Items: multi}, DeploymentsTableOptions{GroupBy: GroupByNamespace}},
+		{"deployments_status_changes", workload.FleetResult[workload.DeploymentInfo]{Items: multi}, DeploymentsTableOptions{PreviousStatus: map[string]string{
+			"prod-us/production/web":    "Partial",
+			"prod-us/production/worker": "Partial",
+		}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := DeploymentsTable(&buf, tt.result.Items, tt.opts); err != nil {
+				t.Fatal(err)
+			}
+			FleetFailures(&buf, tt.result, false)
+			assertGolden(t, tt.name, buf.Bytes())
+		})
+	}
+}
+
+func TestPodsTableGolden(t *testing.T) {
+	prodUS := []workload.PodInfo{
+		{ClusterName: "prod-us", Namespace: "production", Name: "web-7d4b9c-x2k8p", Status: "Running", Ready: "1/1", Restarts: 0, Age: "2d", Node: "ip-10-0-1-12.ec2.internal"},
+		{ClusterName: "prod-us", Namespace: "production", Name: "worker-6f8d5-crashy", Status: "Running", Ready: "0/1", Restarts: 27, Age: "2d", Node: "node-a"},
+	}
+	multi := append(append([]workload.PodInfo{}, prodUS...),
+		workload.PodInfo{ClusterName: "prod-eu", Namespace: "production", Name: "web-5c9f7-pending", Status: "Pending", Ready: "0/1", Restarts: 7, Age: "1m"},
+	)
+	badNodes := append(append([]workload.PodInfo{}, prodUS...),
+		workload.PodInfo{ClusterName: "prod-us", Namespace: "production", Name: "web-7d4b9c-q9z4m", Status: "Running", Ready: "1/1", Age: "2d", Node: "node-b", NodeStatus: workload.NodeNotReady},
+	)
+
+	tests := []struct {
+		name   string
+		result workload.FleetResult[workload.PodInfo]
+		opts   PodsTableOptions
+	}{
+		{"pods_empty", workload.FleetResult[workload.PodInfo]{}, PodsTableOptions{}},
+		{"pods_single_cluster", workload.FleetResult[workload.PodInfo]{Items: prodUS}, PodsTableOptions{}},
+		{"pods_multi_cluster", workload.FleetResult[workload.PodInfo]{Items: multi}, PodsTableOptions{}},
+		{"pods_failed_clusters", failedFleet(prodUS), PodsTableOptions{}},
+		{"pods_grouped_cluster", workload.FleetResult[workload.PodInfo]{Items: multi}, PodsTableOptions{GroupBy: GroupByCluster}},
+		{"pods_bad_nodes", workload.FleetResult[workload.PodInfo]{Items: badNodes}, PodsTableOptions{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := PodsTable(&buf, tt.result.Items, tt.opts); err != nil {
+				t.Fatal(err)
+			}
+			FleetFailures(&buf, tt.result, false)
+			assertGolden(t, tt.name, buf.Bytes())
+		})
+	}
+}
+
+func TestClustersTableGolden(t *testing.T) {
+	tests := []struct {
+		name     string
+		clusters []cluster.ClusterStatus
+		opts     ClustersTableOptions
+	}{
+		{"clusters_empty", nil, ClustersTableOptions{}},
+		{"clusters_single", []cluster.ClusterStatus{
+			{Name: "prod-us", Environment: "production", Region: "us-east-1", Connected: true, IsDefault: true},
+		}, ClustersTableOptions{}},
+		{"clusters_multi", []cluster.ClusterStatus{
+			{Name: "dev", Environment: "development", Connected: true},
+			{Name: "prod-us", Environment: "production", Region: "us-east-1", Connected: true, IsDefault: true},
+		}, ClustersTableOptions{}},
+		{"clusters_error", []cluster.ClusterStatus{
+			{Name: "prod-us", Environment: "production", Region: "us-east-1", Connected: true, IsDefault: true},
+			{Name: "prod-eu", Environment: "production", Region: "eu-west-1",
+				Error: "refusing to connect: expects server https://prod-eu.example.com:6443 but context 'prod-eu' resolves to https://dev.example.com"},
+		}, ClustersTableOptions{}},
+		{"clusters_wide", []cluster.ClusterStatus{
+			{Name: "dev", Environment: "development", Connected: true},
+			{Name: "prod-us", Environment: "production", Region: "us-east-1", Provider: "aws", Account: "123456789012",
+				Server: "https://ABC123.gr7.us-east-1.eks.amazonaws.com", Connected: true, IsDefault: true},
+		}, ClustersTableOptions{Wide: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := ClustersTable(&buf, tt.clusters, tt.opts); err != nil {
+				t.Fatal(err)
+			}
+			assertGolden(t, tt.name, buf.Bytes())
+		})
+	}
+}
+
+func TestResourcesTableGolden(t *testing.T) {
+	ingresses := func(clusterName string, columns ...string) workload.ResourceTable {
+		cells := []string{"web", "nginx", "80, 443"}[:len(columns)]
+		return workload.ResourceTable{
+			ClusterName: clusterName, Namespaced: true, ServerPrinted: true, Columns: columns,
+			Rows: []workload.ResourceRow{{Namespace: "prod", Name: "web", Cells: cells}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		tables []workload.ResourceTable
+		opts   ResourcesTableOptions
+	}{
+		{"resources_empty", []workload.ResourceTable{{ClusterName: "prod-us", Columns: []string{"Name"}}}, ResourcesTableOptions{}},
+		{"resources_all_namespaces", []workload.ResourceTable{
+			ingresses("prod-eu", "Name", "Class", "Ports"),
+			ingresses("prod-us", "Name", "Class", "Ports"),
+		}, ResourcesTableOptions{AllNamespaces: true}},
+		{"resources_mixed_columns", []workload.ResourceTable{
+			ingresses("prod-eu", "Name", "Class", "Ports"),
+			ingresses("prod-us", "Name", "Class"),
+		}, ResourcesTableOptions{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := ResourcesTable(&buf, tt.tables, tt.opts); err != nil {
+				t.Fatal(err)
+			}
+			assertGolden(t, tt.name, buf.Bytes())
+		})
+	}
+}
+
+func TestPingTableGolden(t *testing.T) {
+	results := []cluster.PingResult{
+		{Name: "prod-eu", Server: "https://prod-eu.example.com:6443", Address: "prod-eu.example.com:6443",
+			Error: "dial tcp 10.1.0.5:6443: i/o timeout"},
+		{Name: "prod-us", Server: "https://prod-us.example.com:6443", Address: "prod-us.example.com:6443",
+			Reachable: true, Latency: 12345 * time.Microsecond},
+	}
+
+	var buf bytes.Buffer
+	if err := PingTable(&buf, results); err != nil {
+		t.Fatal(err)
+	}
+	assertGolden(t, "ping", buf.Bytes())
+}
+
+func TestResourceCountsTableGolden(t *testing.T) {
+	counts := []workload.ResourceCounts{
+		{ClusterName: "prod-eu", Nodes: 12, Namespaces: 31, Deployments: 140, Services: 152, Pods: 611,
+			PodPhases: map[corev1.PodPhase]int{corev1.PodRunning: 598, corev1.PodPending: 3, corev1.PodSucceeded: 10}},
+		{ClusterName: "prod-us", Nodes: 9, Namespaces: 28, Deployments: 131, Services: 140, Pods: 402,
+			PodPhases: map[corev1.PodPhase]int{corev1.PodRunning: 399, corev1.PodFailed: 2, corev1.PodUnknown: 1}},
+	}
+
+	var buf bytes.Buffer
+	if err := ResourceCountsTable(&buf, counts); err != nil {
+		t.Fatal(err)
+	}
+	assertGolden(t, "summary", buf.Bytes())
+}
+
+func TestResourcePlanTableGolden(t *testing.T) {
+	plans := []workload.ResourcePlan{
+		{Cluster: "prod-eu", Kind: "ConfigMap", Namespace: "shop", Name: "settings", Action: workload.PlanUnchanged},
+		{Cluster: "prod-us", Kind: "ConfigMap", Namespace: "shop", Name: "settings", Action: workload.PlanUpdate,
+			Changes: []string{"data.mode", `metadata.labels["app.kubernetes.io/version"]`}},
+		{Cluster: "staging", Kind: "ConfigMap", Namespace: "shop", Name: "settings", Action: workload.PlanCreate},
+		{Cluster: "prod-eu", Kind: "Deployment", Namespace: "shop", Name: "web", Action: workload.PlanRefuse,
+			Reason: "it is not managed by mcm (missing label app.kubernetes.io/managed-by=mcm)"},
+		{Cluster: "staging", Kind: "Deployment", Namespace: "shop", Name: "web", Action: workload.PlanCreate,
+			Reason: "namespace 'shop' is created first"},
+	}
+
+	var buf bytes.Buffer
+	if err := ResourcePlanTable(&buf, plans); err != nil {
+		t.Fatal(err)
+	}
+	assertGolden(t, "plan", buf.Bytes())
+}
+
+func TestDeploymentHealth(t *testing.T) {
+	health := newDeploymentHealth([]workload.DeploymentInfo{
+		{Replicas: 3, ReadyReplicas: 4, Status: "Ready"}, // Mid-rollout surge counts as 3
+		{Replicas: 4, ReadyReplicas: 2, Status: "Partial"},
+		{Replicas: 2, ReadyReplicas: 0, Status: "NotReady"},
+	})
+	if got, want := health.String(), "5/9 replicas ready (55.6%), 1 partial, 1 not ready"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	scaledDown := newDeploymentHealth([]workload.DeploymentInfo{{Replicas: 0, ReadyReplicas: 0, Status: "Ready"}})
+	if got, want := scaledDown.String(), "0/0 replicas ready (100.0%)"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestUsageTablesGolden(t *testing.T) {
+	nodes := []workload.NodeUsage{
+		{ClusterName: "prod-eu", Name: "node-a", CPUMillicores: 1500, MemoryBytes: 2 << 30, CPUPercent: 37.5, MemoryPercent: 25},
+		{ClusterName: "prod-us", Name: "node-b", CPUMillicores: 251, MemoryBytes: 512 << 20},
+	}
+	pods := []workload.PodUsage{
+		{ClusterName: "prod-eu", Namespace: "shop", Name: "web-7d4b9c-x2k8p", CPUMillicores: 120, MemoryBytes: 80 << 20},
+		{ClusterName: "prod-us", Namespace: "shop", Name: "worker-5f6c8-k9j2q", CPUMillicores: 3, MemoryBytes: 16 << 20},
+	}
+
+	var buf bytes.Buffer
+	if err := NodeUsageTable(&buf, nodes, []string{"dev", "staging"}); err != nil {
+		t.Fatal(err)
+	}
+	assertGolden(t, "top_nodes", buf.Bytes())
+
+	buf.Reset()
+	if err := PodUsageTable(&buf, pods, nil); err != nil {
+		t.Fatal(err)
+	}
+	assertGolden(t, "top_pods", buf.Bytes())
+}
+
+func TestConnectionStatusGolden(t *testing.T) {
+	at := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	checks := []cluster.ConnectionCheck{
+		{Name: "dev", Error: "not connected: dial tcp 10.0.0.1:6443: connect: connection refused"},
+		{Name: "prod-eu", Healthy: true, Latency: 42 * time.Millisecond, LatencyMs: 42},
+		{Name: "prod-us", Healthy: true, Latency: 18 * time.Millisecond, LatencyMs: 18},
+	}
+
+	var buf bytes.Buffer
+	if err := ConnectionStatusLine(&buf, at, checks); err != nil {
+		t.Fatal(err)
+	}
+	if err := ConnectionStatusLine(&buf, at.Add(30*time.Second), checks[1:]); err != nil {
+		t.Fatal(err)
+	}
+	if err := ConnectionStatusJSONLine(&buf, at, checks); err != nil {
+		t.Fatal(err)
+	}
+	assertGolden(t, "connection_status", buf.Bytes())
+}
+
+func TestServicesTableGolden(t *testing.T) {
+	services := []workload.ServiceInfo{
+		{ClusterName: "prod-eu", Namespace: "shop", Name: "web", Type: "LoadBalancer", ClusterIP: "10.0.0.10",
+			ExternalAddresses: []string{"a1b2.elb.eu-west-1.amazonaws.com"}, Ports: []string{"443:30443/TCP"}, Age: "40d"},
+		{ClusterName: "prod-us", Namespace: "shop", Name: "web", Type: "LoadBalancer", ClusterIP: "10.1.0.10",
+			Pending: true, Ports: []string{"443:31443/TCP"}, Age: "2m"},
+		{ClusterName: "prod-us", Namespace: "shop", Name: "cache", Type: "ClusterIP", ClusterIP: "None",
+			Ports: []string{"6379/TCP"}, Age: "40d"},
+	}
+
+	var buf bytes.Buffer
+	if err := ServicesTable(&buf, services); err != nil {
+		t.Fatal(err)
+	}
+	assertGolden(t, "services", buf.Bytes())
+}
+
+func TestObjectsYAMLGolden(t *testing.T) {
+	deployment := map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":          "web",
+			"namespace":     "production",
+			"annotations":   map[string]interface{}{"deployment.kubernetes.io/revision": "4"},
+			"managedFields": []interface{}{map[string]interface{}{"manager": "kubectl"}},
+		},
+		"spec": map[string]interface{}{"replicas": int64(3)},
+	}
+	objects := []workload.ClusterObject{
+		{ClusterName: "prod-eu", Object: deployment},
+		{ClusterName: "prod-us", Object: deployment},
+	}
+
+	var buf bytes.Buffer
+	if err := ObjectsYAML(&buf, objects); err != nil {
+		t.Fatal(err)
+	}
+	assertGolden(t, "objects_yaml", buf.Bytes())
+
+	if _, ok := deployment["metadata"].(map[string]interface{})["managedFields"]; !ok {
+		t.Error("ObjectsYAML modified the object it was given")
+	}
+}
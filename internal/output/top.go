@@ -0,0 +1,117 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// NodeUsageTable shows each node's CPU and memory use, like kubectl top nodes
+// Clusters without metrics-server are named underneath rather than treated as failures
+func NodeUsageTable(w io.Writer, nodes []workload.NodeUsage, metricsUnavailable []string) error {
+	if len(nodes) == 0 {
+		fmt.Fprintln(w, "No node metrics found in the specified clusters.")
+	} else {
+		table := newTable(w)
+		fmt.Fprintln(table, "CLUSTER\tNAME\tCPU(cores)\tCPU%\tMEMORY(bytes)\tMEMORY%")
+		fmt.Fprintln(table, "-------\t----\t----------\t----\t-------------\t-------")
+		for _, node := range nodes {
+			fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\n", node.ClusterName, node.Name,
+				formatMillicores(node.CPUMillicores), formatPercent(node.CPUPercent),
+				formatMebibytes(node.MemoryBytes), formatPercent(node.MemoryPercent))
+		}
+		if err := table.Flush(); err != nil {
+			return err
+		}
+	}
+
+	writeMetricsUnavailable(w, metricsUnavailable)
+	return nil
+}
+
+// PodUsageTable shows each pod's CPU and memory use, like kubectl top pods
+func PodUsageTable(w io.Writer, pods []workload.PodUsage, metricsUnavailable []string) error {
+	if len(pods) == 0 {
+		fmt.Fprintln(w, "No pod metrics found in the specified clusters and namespaces.")
+	} else {
+		table := newTable(w)
+		fmt.Fprintln(table, "CLUSTER\tNAMESPACE\tNAME\tCPU(cores)\tMEMORY(bytes)")
+		fmt.Fprintln(table, "-------\t---------\t----\t----------\t-------------")
+		for _, pod := range pods {
+			fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\n", pod.ClusterName, pod.Namespace, pod.Name,
+				formatMillicores(pod.CPUMillicores), formatMebibytes(pod.MemoryBytes))
+		}
+		if err := table.Flush(); err != nil {
+			return err
+		}
+	}
+
+	writeMetricsUnavailable(w, metricsUnavailable)
+	return nil
+}
+
+// NodeUsageJSON formats node usage as JSON
+func NodeUsageJSON(w io.Writer, nodes []workload.NodeUsage, metricsUnavailable []string, failures map[string]string) error {
+	return writeJSON(w, "node usage", nodeUsageOutput(nodes, metricsUnavailable, failures))
+}
+
+// NodeUsageYAML formats node usage as YAML
+func NodeUsageYAML(w io.Writer, nodes []workload.NodeUsage, metricsUnavailable []string, failures map[string]string) error {
+	return writeYAML(w, "node usage", nodeUsageOutput(nodes, metricsUnavailable, failures))
+}
+
+// PodUsageJSON formats pod usage as JSON
+func PodUsageJSON(w io.Writer, pods []workload.PodUsage, metricsUnavailable []string, failures map[string]string) error {
+	return writeJSON(w, "pod usage", podUsageOutput(pods, metricsUnavailable, failures))
+}
+
+// PodUsageYAML formats pod usage as YAML
+func PodUsageYAML(w io.Writer, pods []workload.PodUsage, metricsUnavailable []string, failures map[string]string) error {
+	return writeYAML(w, "pod usage", podUsageOutput(pods, metricsUnavailable, failures))
+}
+
+// nodeUsageOutput is the shape of 'top nodes' JSON and YAML output
+func nodeUsageOutput(nodes []workload.NodeUsage, metricsUnavailable []string, failures map[string]string) interface{} {
+	return struct {
+		Nodes              []workload.NodeUsage `json:"nodes"`
+		MetricsUnavailable []string             `json:"metricsUnavailable,omitempty"` // Clusters without metrics-server
+		Errors             map[string]string    `json:"errors,omitempty"`             // Clusters that failed, with why
+	}{Nodes: nodes, MetricsUnavailable: metricsUnavailable, Errors: failures}
+}
+
+// podUsageOutput is the shape of 'top pods' JSON and YAML output
+func podUsageOutput(pods []workload.PodUsage, metricsUnavailable []string, failures map[string]string) interface{} {
+	return struct {
+		Pods               []workload.PodUsage `json:"pods"`
+		MetricsUnavailable []string            `json:"metricsUnavailable,omitempty"` // Clusters without metrics-server
+		Errors             map[string]string   `json:"errors,omitempty"`             // Clusters that failed, with why
+	}{Pods: pods, MetricsUnavailable: metricsUnavailable, Errors: failures}
+}
+
+// writeMetricsUnavailable notes the clusters top had nothing to read from
+func writeMetricsUnavailable(w io.Writer, clusters []string) {
+	if len(clusters) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\nℹ️  metrics-server not installed: %s\n", strings.Join(clusters, ", "))
+}
+
+// formatMillicores renders CPU the way kubectl top does, e.g. 250m
+func formatMillicores(millicores int64) string {
+	return fmt.Sprintf("%dm", millicores)
+}
+
+// formatMebibytes renders memory in whole Mi, e.g. 512Mi
+func formatMebibytes(bytes int64) string {
+	return fmt.Sprintf("%dMi", bytes/(1<<20))
+}
+
+// formatPercent renders a share of node capacity, or a dash when the capacity isn't known
+func formatPercent(percent float64) string {
+	if percent == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", percent)
+}
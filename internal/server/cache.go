@@ -0,0 +1,126 @@
+package server
+
+import (
+	"sync"
+	"time"
+
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// resourceCache holds the latest copy of one resource type (clusters, pods, ...)
+// Readers never talk to the clusters - they only ever read what the background
+// refresher (or a forced refresh) last stored here
+type resourceCache[T any] struct {
+	load func() []T // Queries the fleet for fresh data
+
+	// onChange, when set, is called after every refresh that replaced earlier
+	// data, with the previous and current items, so changes can be detected.
+	// unavailable names the clusters that failed this refresh or the one
+	// before: their items are missing from one side, not gone from the cluster
+	onChange func(previous, current []T, unavailable map[string]bool)
+
+	refreshMutex sync.Mutex   // Serializes refreshes so concurrent forced refreshes don't stampede the API servers
+	mutex        sync.RWMutex // Protects the fields below
+	items        []T
+	failures     map[string]string // Clusters the last fleet query couldn't read, with why
+	lastUpdated  time.Time
+	lastDuration time.Duration
+	refreshes    int
+}
+
+// newResourceCache creates an empty cache backed by the given loader
+func newResourceCache[T any](load func() []T) *resourceCache[T] {
+	return &resourceCache[T]{load: load}
+}
+
+// newFleetCache creates a cache backed by a fleet-wide query
+// Clusters that failed are remembered next to the items that did arrive, so the
+// API can say "these numbers are missing prod-eu" instead of quietly undercounting
+func newFleetCache[T any](query func() workload.FleetResult[T]) *resourceCache[T] {
+	c := &resourceCache[T]{}
+	c.load = func() []T {
+		result := query()
+		c.mutex.Lock()
+		c.failures = result.ErrorMessages()
+		c.mutex.Unlock()
+		return result.Items
+	}
+	return c
+}
+
+// refresh queries the fleet and atomically swaps in the new data
+// The slow part (talking to clusters) happens outside the read lock,
+// so readers keep getting the previous data while a refresh is in progress
+func (c *resourceCache[T]) refresh() {
+	c.refreshMutex.Lock()
+	defer c.refreshMutex.Unlock()
+
+	c.mutex.RLock()
+	previousFailures := c.failures
+	c.mutex.RUnlock()
+
+	start := time.Now()
+	items := c.load()
+	duration := time.Since(start)
+
+	c.mutex.Lock()
+	previous, hadData := c.items, !c.lastUpdated.IsZero()
+	c.items = items
+	c.lastUpdated = time.Now()
+	c.lastDuration = duration
+	c.refreshes++
+	failures := c.failures
+	c.mutex.Unlock()
+
+	// Skip the very first load - everything would look "new" otherwise
+	if c.onChange != nil && hadData {
+		unavailable := make(map[string]bool, len(previousFailures)+len(failures))
+		for name := range previousFailures {
+			unavailable[name] = true
+		}
+		for name := range failures {
+			unavailable[name] = true
+		}
+		c.onChange(previous, items, unavailable)
+	}
+}
+
+// get returns the cached items and when they were last refreshed
+func (c *resourceCache[T]) get() ([]T, time.Time) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.items, c.lastUpdated
+}
+
+// failed returns the clusters the last refresh couldn't read, keyed by name
+func (c *resourceCache[T]) failed() map[string]string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.failures
+}
+
+// stats returns refresh bookkeeping for the metrics endpoint
+func (c *resourceCache[T]) stats() cacheStats {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return cacheStats{
+		LastUpdated:  c.lastUpdated,
+		LastDuration: c.lastDuration,
+		Refreshes:    c.refreshes,
+	}
+}
+
+// set replaces the cached items directly, bypassing the loader
+func (c *resourceCache[T]) set(items []T, updated time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.items = items
+	c.lastUpdated = updated
+}
+
+// cacheStats summarizes how fresh a cache is
+type cacheStats struct {
+	LastUpdated  time.Time
+	LastDuration time.Duration
+	Refreshes    int
+}
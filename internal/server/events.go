@@ -0,0 +1,177 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// StatusDeleted is reported as the new status when an object disappears
+const StatusDeleted = "Deleted"
+
+// Event describes a single status transition detected between two refreshes
+type Event struct {
+	Cluster   string    `json:"cluster"`
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	OldStatus string    `json:"oldStatus"`
+	NewStatus string    `json:"newStatus"`
+	Time      time.Time `json:"time"`
+}
+
+// eventBroker fans events out to every connected stream
+// Each subscriber gets its own buffered channel so one slow browser
+// can't hold up the refresher or the other subscribers
+type eventBroker struct {
+	mutex       sync.Mutex
+	subscribers map[chan Event]struct{}
+}
+
+func newEventBroker() *eventBroker {
+	return &eventBroker{subscribers: make(map[chan Event]struct{})}
+}
+
+// subscribe registers a new listener and returns its channel plus a cancel func
+func (b *eventBroker) subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, 64)
+
+	b.mutex.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mutex.Unlock()
+
+	return ch, func() {
+		b.mutex.Lock()
+		delete(b.subscribers, ch)
+		b.mutex.Unlock()
+	}
+}
+
+// publish delivers events to all subscribers, dropping them for any
+// subscriber whose buffer is full rather than blocking the refresher
+func (b *eventBroker) publish(events []Event) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for ch := range b.subscribers {
+		for _, event := range events {
+			select {
+			case ch <- event:
+			default:
+			}
+		}
+	}
+}
+
+// statusKey identifies an object across refreshes
+type statusKey struct {
+	cluster, namespace, name string
+}
+
+// diffStatuses compares two status maps and returns a transition event for
+// every object whose status changed, appeared, or disappeared
+func diffStatuses(kind string, previous, current map[statusKey]string, now time.Time) []Event {
+	var events []Event
+
+	for key, newStatus := range current {
+		if oldStatus, existed := previous[key]; !existed || oldStatus != newStatus {
+			events = append(events, Event{
+				Cluster:   key.cluster,
+				Kind:      kind,
+				Namespace: key.namespace,
+				Name:      key.name,
+				OldStatus: oldStatus,
+				NewStatus: newStatus,
+				Time:      now,
+			})
+		}
+	}
+
+	for key, oldStatus := range previous {
+		if _, exists := current[key]; !exists {
+			events = append(events, Event{
+				Cluster:   key.cluster,
+				Kind:      kind,
+				Namespace: key.namespace,
+				Name:      key.name,
+				OldStatus: oldStatus,
+				NewStatus: StatusDeleted,
+				Time:      now,
+			})
+		}
+	}
+
+	return events
+}
+
+// deploymentStatuses indexes deployment status by cluster, namespace and name
+func deploymentStatuses(deployments []workload.DeploymentInfo) map[statusKey]string {
+	statuses := make(map[statusKey]string, len(deployments))
+	for _, deployment := range deployments {
+		statuses[statusKey{deployment.ClusterName, deployment.Namespace, deployment.Name}] = deployment.Status
+	}
+	return statuses
+}
+
+// withoutClusters drops the statuses of the given clusters
+func withoutClusters(statuses map[statusKey]string, clusters map[string]bool) map[statusKey]string {
+	for key := range statuses {
+		if clusters[key.cluster] {
+			delete(statuses, key)
+		}
+	}
+	return statuses
+}
+
+// podStatuses indexes pod phase by cluster, namespace and name
+func podStatuses(pods []workload.PodInfo) map[statusKey]string {
+	statuses := make(map[statusKey]string, len(pods))
+	for _, pod := range pods {
+		statuses[statusKey{pod.ClusterName, pod.Namespace, pod.Name}] = pod.Status
+	}
+	return statuses
+}
+
+// handleEvents streams status transitions to the client using Server-Sent Events
+// Browsers can consume this directly with `new EventSource("/events")`
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	events, unsubscribe := s.events.subscribe()
+	defer unsubscribe()
+
+	// Periodic comments keep proxies from closing an idle connection
+	keepAlive := time.NewTicker(15 * time.Second)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		case event := <-events:
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: status\ndata: %s\n\n", data)
+			flusher.Flush()
+		}
+	}
+}
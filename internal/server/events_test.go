@@ -0,0 +1,146 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+func TestDiffStatuses(t *testing.T) {
+	now := time.Now()
+	previous := map[statusKey]string{
+		{"prod-us", "default", "web"}:    "Partial",
+		{"prod-us", "default", "worker"}: "Ready",
+		{"prod-us", "default", "old"}:    "Ready",
+	}
+	current := map[statusKey]string{
+		{"prod-us", "default", "web"}:    "Ready",
+		{"prod-us", "default", "worker"}: "Ready",
+		{"prod-us", "default", "new"}:    "NotReady",
+	}
+
+	events := diffStatuses("Deployment", previous, current, now)
+
+	got := make(map[string]Event)
+	for _, event := range events {
+		got[event.Name] = event
+	}
+
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events, got %d: %+v", len(events), events)
+	}
+	if e := got["web"]; e.OldStatus != "Partial" || e.NewStatus != "Ready" {
+		t.Errorf("Unexpected transition for web: %+v", e)
+	}
+	if e := got["new"]; e.OldStatus != "" || e.NewStatus != "NotReady" {
+		t.Errorf("Unexpected transition for new: %+v", e)
+	}
+	if e := got["old"]; e.NewStatus != StatusDeleted {
+		t.Errorf("Expected old to be reported as deleted, got %+v", e)
+	}
+	if _, ok := got["worker"]; ok {
+		t.Error("Expected no event for unchanged worker")
+	}
+}
+
+func TestFailedClusterIsNotReportedDeleted(t *testing.T) {
+	refreshes := []workload.FleetResult[workload.DeploymentInfo]{
+		{Items: []workload.DeploymentInfo{
+			{ClusterName: "a", Namespace: "default", Name: "web", Status: "Ready"},
+			{ClusterName: "b", Namespace: "default", Name: "web", Status: "Ready"},
+		}},
+		// Cluster b can't be read; a's deployment changes meanwhile
+		{Items: []workload.DeploymentInfo{
+			{ClusterName: "a", Namespace: "default", Name: "web", Status: "Partial"},
+		}, Errors: map[string]error{"b": errors.New("connection refused")}},
+		// b is back, unchanged
+		{Items: []workload.DeploymentInfo{
+			{ClusterName: "a", Namespace: "default", Name: "web", Status: "Partial"},
+			{ClusterName: "b", Namespace: "default", Name: "web", Status: "Ready"},
+		}},
+	}
+
+	s := newTestServer(nil, nil, nil)
+	calls := 0
+	s.deployments = newFleetCache(func() workload.FleetResult[workload.DeploymentInfo] {
+		result := refreshes[calls]
+		calls++
+		return result
+	})
+	s.publishChanges()
+
+	events, cancel := s.events.subscribe()
+	defer cancel()
+	for range refreshes {
+		s.deployments.refresh()
+	}
+
+	var got []Event
+	for len(events) > 0 {
+		got = append(got, <-events)
+	}
+	if len(got) != 1 {
+		t.Fatalf("Expected only a's transition, got %+v", got)
+	}
+	if e := got[0]; e.Cluster != "a" || e.OldStatus != "Ready" || e.NewStatus != "Partial" {
+		t.Errorf("Unexpected event: %+v", e)
+	}
+}
+
+func TestEventsStream(t *testing.T) {
+	s := newTestServer(nil, nil, nil)
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/events")
+	if err != nil {
+		t.Fatalf("Failed to connect to event stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Expected text/event-stream, got %q", ct)
+	}
+
+	// The subscription is registered after the headers are flushed,
+	// so keep publishing until the reader sees the event
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-time.After(10 * time.Millisecond):
+				s.events.publish([]Event{{Cluster: "prod-us", Kind: "Pod", Name: "web-1", NewStatus: "Running"}})
+			}
+		}
+	}()
+
+	reader := bufio.NewReader(resp.Body)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Failed to read event stream: %v", err)
+		}
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+
+		var event Event
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event); err != nil {
+			t.Fatalf("Failed to decode event: %v", err)
+		}
+		if event.Name != "web-1" || event.NewStatus != "Running" {
+			t.Errorf("Unexpected event: %+v", event)
+		}
+		return
+	}
+}
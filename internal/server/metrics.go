@@ -0,0 +1,128 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// handleMetrics serves the cached fleet status in the Prometheus text format
+// Everything here is derived from the caches, so scraping never touches the clusters
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	clusters, _ := s.clusters.get()
+	deployments, _ := s.deployments.get()
+	pods, _ := s.pods.get()
+
+	writeMetricHeader(w, "mcm_cluster_connected", "gauge", "Whether mcm is connected to the cluster (1) or not (0).")
+	for _, status := range clusters {
+		value := 0
+		if status.Connected {
+			value = 1
+		}
+		fmt.Fprintf(w, "mcm_cluster_connected{cluster=%q,environment=%q,region=%q} %d\n",
+			status.Name, status.Environment, status.Region, value)
+	}
+
+	deploymentCounts := make(map[[2]string]int)
+	readyReplicas := make(map[string]int32)
+	desiredReplicas := make(map[string]int32)
+	for _, deployment := range deployments {
+		deploymentCounts[[2]string{deployment.ClusterName, deployment.Status}]++
+		readyReplicas[deployment.ClusterName] += deployment.ReadyReplicas
+		desiredReplicas[deployment.ClusterName] += deployment.Replicas
+	}
+
+	writeMetricHeader(w, "mcm_deployments", "gauge", "Number of deployments by cluster and status.")
+	for _, key := range sortedPairKeys(deploymentCounts) {
+		fmt.Fprintf(w, "mcm_deployments{cluster=%q,status=%q} %d\n", key[0], key[1], deploymentCounts[key])
+	}
+
+	writeMetricHeader(w, "mcm_deployment_replicas_desired", "gauge", "Sum of desired deployment replicas by cluster.")
+	for _, name := range sortedKeys(desiredReplicas) {
+		fmt.Fprintf(w, "mcm_deployment_replicas_desired{cluster=%q} %d\n", name, desiredReplicas[name])
+	}
+
+	writeMetricHeader(w, "mcm_deployment_replicas_ready", "gauge", "Sum of ready deployment replicas by cluster.")
+	for _, name := range sortedKeys(readyReplicas) {
+		fmt.Fprintf(w, "mcm_deployment_replicas_ready{cluster=%q} %d\n", name, readyReplicas[name])
+	}
+
+	podCounts := make(map[[2]string]int)
+	podRestarts := make(map[string]int32)
+	for _, pod := range pods {
+		podCounts[[2]string{pod.ClusterName, pod.Status}]++
+		podRestarts[pod.ClusterName] += pod.Restarts
+	}
+
+	writeMetricHeader(w, "mcm_pods", "gauge", "Number of pods by cluster and phase.")
+	for _, key := range sortedPairKeys(podCounts) {
+		fmt.Fprintf(w, "mcm_pods{cluster=%q,phase=%q} %d\n", key[0], key[1], podCounts[key])
+	}
+
+	writeMetricHeader(w, "mcm_pod_restarts", "gauge", "Sum of container restarts by cluster.")
+	for _, name := range sortedKeys(podRestarts) {
+		fmt.Fprintf(w, "mcm_pod_restarts{cluster=%q} %d\n", name, podRestarts[name])
+	}
+
+	cacheStats := map[string]cacheStats{
+		"clusters":    s.clusters.stats(),
+		"deployments": s.deployments.stats(),
+		"pods":        s.pods.stats(),
+	}
+	resources := []string{"clusters", "deployments", "pods"}
+
+	writeMetricHeader(w, "mcm_cache_last_updated_timestamp_seconds", "gauge", "Unix time of the last successful cache refresh.")
+	for _, resource := range resources {
+		var ts float64
+		if updated := cacheStats[resource].LastUpdated; !updated.IsZero() {
+			ts = float64(updated.UnixNano()) / 1e9
+		}
+		fmt.Fprintf(w, "mcm_cache_last_updated_timestamp_seconds{resource=%q} %.3f\n", resource, ts)
+	}
+
+	writeMetricHeader(w, "mcm_cache_refresh_duration_seconds", "gauge", "Duration of the last cache refresh.")
+	for _, resource := range resources {
+		fmt.Fprintf(w, "mcm_cache_refresh_duration_seconds{resource=%q} %.3f\n",
+			resource, cacheStats[resource].LastDuration.Seconds())
+	}
+
+	writeMetricHeader(w, "mcm_cache_refreshes_total", "counter", "Number of cache refreshes since startup.")
+	for _, resource := range resources {
+		fmt.Fprintf(w, "mcm_cache_refreshes_total{resource=%q} %d\n", resource, cacheStats[resource].Refreshes)
+	}
+}
+
+// writeMetricHeader writes the HELP and TYPE lines that precede a metric family
+func writeMetricHeader(w io.Writer, name, metricType, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, strings.TrimSpace(help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, metricType)
+}
+
+// sortedKeys returns map keys in a stable order so scrapes are diffable
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortedPairKeys returns two-label map keys in a stable order
+func sortedPairKeys(m map[[2]string]int) [][2]string {
+	keys := make([][2]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	return keys
+}
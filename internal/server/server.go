@@ -0,0 +1,234 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// Server exposes fleet status as a small read-only JSON API
+// Think of this as a "status board" that keeps itself up to date, so dashboards
+// can read fleet state without every viewer querying every cluster
+type Server struct {
+	clusterManager  *cluster.Manager
+	workloadManager *workload.Manager
+	interval        time.Duration
+
+	// One cache per resource type so each can be refreshed independently
+	clusters    *resourceCache[cluster.ClusterStatus]
+	deployments *resourceCache[workload.DeploymentInfo]
+	pods        *resourceCache[workload.PodInfo]
+
+	events *eventBroker // Fans status transitions out to /events subscribers
+}
+
+// New creates a server that refreshes fleet status every interval
+func New(clusterManager *cluster.Manager, workloadManager *workload.Manager, interval time.Duration) *Server {
+	s := &Server{
+		clusterManager:  clusterManager,
+		workloadManager: workloadManager,
+		interval:        interval,
+		events:          newEventBroker(),
+	}
+
+	s.clusters = newResourceCache(func() []cluster.ClusterStatus {
+		return s.clusterManager.ListClusters()
+	})
+	// An empty namespace lists across all namespaces
+	s.deployments = newFleetCache(func() workload.FleetResult[workload.DeploymentInfo] {
+		return s.workloadManager.ListDeployments(nil, "", "")
+	})
+	s.pods = newFleetCache(func() workload.FleetResult[workload.PodInfo] {
+		return s.workloadManager.ListPods(nil, "", "", "")
+	})
+
+	s.publishChanges()
+
+	return s
+}
+
+// publishChanges publishes status transitions detected between refreshes to /events
+// A cluster that couldn't be read is left out of both sides of the comparison,
+// so an outage doesn't show up as every one of its objects being deleted
+func (s *Server) publishChanges() {
+	s.deployments.onChange = func(previous, current []workload.DeploymentInfo, unavailable map[string]bool) {
+		s.events.publish(diffStatuses("Deployment",
+			withoutClusters(deploymentStatuses(previous), unavailable),
+			withoutClusters(deploymentStatuses(current), unavailable), time.Now()))
+	}
+	s.pods.onChange = func(previous, current []workload.PodInfo, unavailable map[string]bool) {
+		s.events.publish(diffStatuses("Pod",
+			withoutClusters(podStatuses(previous), unavailable),
+			withoutClusters(podStatuses(current), unavailable), time.Now()))
+	}
+}
+
+// Run starts the background refresher and serves HTTP on addr until ctx is cancelled
+func (s *Server) Run(ctx context.Context, addr string) error {
+	// Take the first snapshot before accepting traffic so the endpoints never
+	// serve an empty fleet just because the server started a moment ago
+	s.refreshAll()
+	go s.refreshLoop(ctx)
+
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errChan:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return fmt.Errorf("HTTP server failed: %w", err)
+	case <-ctx.Done():
+		// Give in-flight requests a moment to finish before exiting
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return httpServer.Shutdown(shutdownCtx)
+	}
+}
+
+// Handler returns the HTTP routes served by the status API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", s.handleHealthz)
+	mux.HandleFunc("/clusters", s.handleClusters)
+	mux.HandleFunc("/deployments", s.handleDeployments)
+	mux.HandleFunc("/pods", s.handlePods)
+	mux.HandleFunc("/metrics", s.handleMetrics)
+	mux.HandleFunc("/events", s.handleEvents)
+	return mux
+}
+
+// refreshLoop re-queries the fleet on every tick until ctx is cancelled
+func (s *Server) refreshLoop(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.refreshAll()
+		}
+	}
+}
+
+// refreshAll refreshes every resource cache in parallel
+func (s *Server) refreshAll() {
+	var wg sync.WaitGroup
+	for _, refresh := range []func(){s.clusters.refresh, s.deployments.refresh, s.pods.refresh} {
+		wg.Add(1)
+		go func(refresh func()) {
+			defer wg.Done()
+			refresh()
+		}(refresh)
+	}
+	wg.Wait()
+}
+
+// wantsRefresh reports whether the caller asked for fresh data with ?refresh=true
+func wantsRefresh(r *http.Request) bool {
+	refresh, _ := strconv.ParseBool(r.URL.Query().Get("refresh"))
+	return refresh
+}
+
+// handleHealthz reports whether the server has data and can reach at least one cluster
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	if wantsRefresh(r) {
+		s.clusters.refresh()
+	}
+	clusters, lastUpdated := s.clusters.get()
+
+	connected := 0
+	for _, status := range clusters {
+		if status.Connected {
+			connected++
+		}
+	}
+
+	status := http.StatusOK
+	state := "ok"
+	if lastUpdated.IsZero() || connected == 0 {
+		status = http.StatusServiceUnavailable
+		state = "unavailable"
+	}
+
+	writeJSON(w, status, map[string]interface{}{
+		"status":            state,
+		"connectedClusters": connected,
+		"totalClusters":     len(clusters),
+		"lastUpdated":       lastUpdated,
+	})
+}
+
+func (s *Server) handleClusters(w http.ResponseWriter, r *http.Request) {
+	if wantsRefresh(r) {
+		s.clusters.refresh()
+	}
+	clusters, lastUpdated := s.clusters.get()
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"clusters":    clusters,
+		"count":       len(clusters),
+		"lastUpdated": lastUpdated,
+	})
+}
+
+func (s *Server) handleDeployments(w http.ResponseWriter, r *http.Request) {
+	if wantsRefresh(r) {
+		s.deployments.refresh()
+	}
+	deployments, lastUpdated := s.deployments.get()
+	writeJSON(w, http.StatusOK, withFailures(map[string]interface{}{
+		"deployments": deployments,
+		"count":       len(deployments),
+		"lastUpdated": lastUpdated,
+	}, s.deployments.failed()))
+}
+
+func (s *Server) handlePods(w http.ResponseWriter, r *http.Request) {
+	if wantsRefresh(r) {
+		s.pods.refresh()
+	}
+	pods, lastUpdated := s.pods.get()
+	writeJSON(w, http.StatusOK, withFailures(map[string]interface{}{
+		"pods":        pods,
+		"count":       len(pods),
+		"lastUpdated": lastUpdated,
+	}, s.pods.failed()))
+}
+
+// withFailures adds an "errors" field naming the clusters missing from a
+// response body, leaving the body untouched when every cluster answered
+func withFailures(body map[string]interface{}, failures map[string]string) map[string]interface{} {
+	if len(failures) > 0 {
+		body["errors"] = failures
+	}
+	return body
+}
+
+// writeJSON encodes a response body with the given status code
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(body)
+}
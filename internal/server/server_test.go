@@ -0,0 +1,195 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+	"github.com/celikgo/autoz-control-tower/internal/workload"
+)
+
+// newTestServer builds a server whose caches load from static data instead of clusters
+func newTestServer(clusters []cluster.ClusterStatus, deployments []workload.DeploymentInfo, pods []workload.PodInfo) *Server {
+	return &Server{
+		clusters:    newResourceCache(func() []cluster.ClusterStatus { return clusters }),
+		deployments: newResourceCache(func() []workload.DeploymentInfo { return deployments }),
+		pods:        newResourceCache(func() []workload.PodInfo { return pods }),
+		events:      newEventBroker(),
+	}
+}
+
+func TestHealthz(t *testing.T) {
+	tests := []struct {
+		name       string
+		clusters   []cluster.ClusterStatus
+		refresh    bool
+		wantStatus int
+	}{
+		{
+			name:       "no refresh yet",
+			clusters:   []cluster.ClusterStatus{{Name: "a", Connected: true}},
+			refresh:    false,
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "no connected clusters",
+			clusters:   []cluster.ClusterStatus{{Name: "a", Connected: false}},
+			refresh:    true,
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "healthy",
+			clusters:   []cluster.ClusterStatus{{Name: "a", Connected: true}},
+			refresh:    true,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(tt.clusters, nil, nil)
+			if tt.refresh {
+				s.refreshAll()
+			}
+
+			rec := httptest.NewRecorder()
+			s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestDeploymentsEndpoint(t *testing.T) {
+	s := newTestServer(nil, []workload.DeploymentInfo{
+		{ClusterName: "a", Namespace: "default", Name: "web"},
+		{ClusterName: "b", Namespace: "default", Name: "web"},
+	}, nil)
+	s.refreshAll()
+
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/deployments", nil))
+
+	var body struct {
+		Count       int       `json:"count"`
+		LastUpdated time.Time `json:"lastUpdated"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if body.Count != 2 {
+		t.Errorf("Expected count 2, got %d", body.Count)
+	}
+	if body.LastUpdated.IsZero() {
+		t.Error("Expected lastUpdated to be set")
+	}
+}
+
+func TestDeploymentsEndpointReportsFailedClusters(t *testing.T) {
+	s := newTestServer(nil, nil, nil)
+	s.deployments = newFleetCache(func() workload.FleetResult[workload.DeploymentInfo] {
+		return workload.FleetResult[workload.DeploymentInfo]{
+			Items:  []workload.DeploymentInfo{{ClusterName: "a", Namespace: "default", Name: "web"}},
+			Errors: map[string]error{"b": errors.New("failed to list deployments: forbidden")},
+		}
+	})
+	s.deployments.refresh()
+
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/deployments", nil))
+
+	var body struct {
+		Count  int               `json:"count"`
+		Errors map[string]string `json:"errors"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if body.Count != 1 {
+		t.Errorf("Expected count 1, got %d", body.Count)
+	}
+	if body.Errors["b"] != "failed to list deployments: forbidden" {
+		t.Errorf("Expected cluster b's failure in errors, got %v", body.Errors)
+	}
+}
+
+func TestForcedRefresh(t *testing.T) {
+	loads := 0
+	s := newTestServer(nil, nil, nil)
+	s.pods = newResourceCache(func() []workload.PodInfo {
+		loads++
+		return []workload.PodInfo{{ClusterName: "a", Name: "web-1", Status: "Running"}}
+	})
+
+	// Without ?refresh the handler must serve from the (empty) cache
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pods", nil))
+	if loads != 0 {
+		t.Fatalf("Expected no loads without refresh, got %d", loads)
+	}
+
+	rec = httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pods?refresh=true", nil))
+	if loads != 1 {
+		t.Fatalf("Expected one synchronous load, got %d", loads)
+	}
+	if !strings.Contains(rec.Body.String(), "web-1") {
+		t.Errorf("Expected refreshed pod in response, got %s", rec.Body.String())
+	}
+}
+
+func TestConcurrentReadsDuringRefresh(t *testing.T) {
+	s := newTestServer([]cluster.ClusterStatus{{Name: "a", Connected: true}}, nil, nil)
+	handler := s.Handler()
+
+	// Run with -race to catch unsynchronized access between readers and the refresher
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			s.refreshAll()
+		}()
+		go func() {
+			defer wg.Done()
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/clusters", nil))
+		}()
+	}
+	wg.Wait()
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	s := newTestServer(
+		[]cluster.ClusterStatus{{Name: "prod-us", Environment: "production", Connected: true}},
+		[]workload.DeploymentInfo{{ClusterName: "prod-us", Name: "web", Status: "Ready", Replicas: 3, ReadyReplicas: 3}},
+		[]workload.PodInfo{{ClusterName: "prod-us", Name: "web-1", Status: "Running", Restarts: 2}},
+	)
+	s.refreshAll()
+
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+
+	for _, want := range []string{
+		`mcm_cluster_connected{cluster="prod-us",environment="production",region=""} 1`,
+		`mcm_deployments{cluster="prod-us",status="Ready"} 1`,
+		`mcm_deployment_replicas_ready{cluster="prod-us"} 3`,
+		`mcm_pods{cluster="prod-us",phase="Running"} 1`,
+		`mcm_pod_restarts{cluster="prod-us"} 2`,
+		`mcm_cache_refreshes_total{resource="pods"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected metrics to contain %q", want)
+		}
+	}
+}
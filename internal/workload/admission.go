@@ -0,0 +1,126 @@
+package workload
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// What turned a request down, as reported in AdmissionError.Kind
+const (
+	AdmissionWebhook    = "webhook"
+	AdmissionPolicy     = "admission policy"
+	AdmissionValidation = "validation"
+)
+
+// failedCreateReason is the ReplicaFailure condition reason set when a
+// ReplicaSet can't create its pods - often because a webhook refused them
+const failedCreateReason = "FailedCreate"
+
+// The API server's wording for admission denials, e.g.
+//
+//	admission webhook "pod-policy.example.com" denied the request: privileged containers not allowed
+//	ValidatingAdmissionPolicy 'no-latest' with binding 'no-latest-prod' denied request: image tag required
+var (
+	webhookDenialPattern = regexp.MustCompile(`admission webhook "([^"]+)" denied the request: (?s)(.*)`)
+	policyDenialPattern  = regexp.MustCompile(`ValidatingAdmissionPolicy '([^']+)' with binding '[^']*' denied request: (?s)(.*)`)
+)
+
+// AdmissionError is a request the cluster turned down on policy grounds: a
+// validating or mutating webhook, a ValidatingAdmissionPolicy, or the API
+// server's own validation. The raw API error buries the one sentence that
+// matters under object names and status codes; this carries just that sentence
+// and who said it, so a fleet-wide deploy report reads like a list of reasons
+type AdmissionError struct {
+	Kind    string // AdmissionWebhook, AdmissionPolicy or AdmissionValidation
+	Name    string // The webhook or policy that refused; empty for validation
+	Message string // Why, in the rejecting party's own words
+	Err     error  // The original API error
+}
+
+func (e *AdmissionError) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("rejected by %s: %s", e.Kind, e.Message)
+	}
+	return fmt.Sprintf("rejected by %s '%s': %s", e.Kind, e.Name, e.Message)
+}
+
+func (e *AdmissionError) Unwrap() error {
+	return e.Err
+}
+
+// asAdmissionError turns an admission or validation rejection anywhere in err's
+// chain into an AdmissionError, and returns any other error unchanged
+func asAdmissionError(err error) error {
+	var status apierrors.APIStatus
+	if err == nil || !errors.As(err, &status) {
+		return err
+	}
+
+	message := status.Status().Message
+	if rejection := parseAdmissionDenial(message); rejection != nil {
+		rejection.Err = err
+		return rejection
+	}
+
+	if apierrors.IsInvalid(err) {
+		return &AdmissionError{Kind: AdmissionValidation, Message: validationMessage(status), Err: err}
+	}
+	return err
+}
+
+// parseAdmissionDenial recognizes a webhook or admission policy denial in an
+// API message, returning nil for anything else
+func parseAdmissionDenial(message string) *AdmissionError {
+	if match := webhookDenialPattern.FindStringSubmatch(message); match != nil {
+		return &AdmissionError{Kind: AdmissionWebhook, Name: match[1], Message: strings.TrimSpace(match[2])}
+	}
+	if match := policyDenialPattern.FindStringSubmatch(message); match != nil {
+		return &AdmissionError{Kind: AdmissionPolicy, Name: match[1], Message: strings.TrimSpace(match[2])}
+	}
+	return nil
+}
+
+// validationMessage lists the fields validation refused, e.g.
+// "spec.replicas: Invalid value: -1: must be greater than or equal to 0"
+func validationMessage(status apierrors.APIStatus) string {
+	details := status.Status().Details
+	if details != nil && len(details.Causes) > 0 {
+		causes := make([]string, 0, len(details.Causes))
+		for _, cause := range details.Causes {
+			if cause.Field == "" {
+				causes = append(causes, cause.Message)
+				continue
+			}
+			causes = append(causes, cause.Field+": "+cause.Message)
+		}
+		return strings.Join(causes, "; ")
+	}
+
+	// Without causes, drop the `Deployment.apps "web" is invalid:` preamble
+	message := status.Status().Message
+	if _, reason, found := strings.Cut(message, " is invalid: "); found {
+		return reason
+	}
+	return message
+}
+
+// podAdmissionFailure reports a deployment whose pods are being refused
+// The deployment itself was accepted, so the deploy looks fine; only its
+// ReplicaSet's FailedCreate condition shows that no pod will ever start
+func podAdmissionFailure(deployment *appsv1.Deployment) error {
+	cond := deploymentCondition(deployment.Status, appsv1.DeploymentReplicaFailure)
+	if cond == nil || cond.Reason != failedCreateReason {
+		return nil
+	}
+	// Other create failures, like an exhausted quota, can clear up on their own
+	rejection := parseAdmissionDenial(cond.Message)
+	if rejection == nil {
+		return nil
+	}
+	return fmt.Errorf("pods are being %w", rejection)
+}
@@ -0,0 +1,132 @@
+package workload
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+// webhookDenial is the error the API server returns when a validating webhook says no
+func webhookDenial(message string) error {
+	return &apierrors.StatusError{ErrStatus: metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    403,
+		Message: message,
+	}}
+}
+
+func TestAsAdmissionError(t *testing.T) {
+	deploymentsGK := schema.GroupKind{Group: "apps", Kind: "Deployment"}
+
+	tests := []struct {
+		name string
+		err  error
+		want string // Empty means the error must come back unchanged
+	}{
+		{
+			name: "webhook",
+			err:  webhookDenial(`admission webhook "pod-policy.example.com" denied the request: privileged containers not allowed`),
+			want: "rejected by webhook 'pod-policy.example.com': privileged containers not allowed",
+		},
+		{
+			name: "webhook, wrapped",
+			err: fmt.Errorf("failed to create deployment: %w",
+				webhookDenial(`deployments.apps "web" is forbidden: admission webhook "pod-policy" denied the request: no latest tags`)),
+			want: "rejected by webhook 'pod-policy': no latest tags",
+		},
+		{
+			name: "admission policy",
+			err:  webhookDenial(`deployments.apps "web" is forbidden: ValidatingAdmissionPolicy 'replica-limit' with binding 'replica-limit-prod' denied request: at most 5 replicas`),
+			want: "rejected by admission policy 'replica-limit': at most 5 replicas",
+		},
+		{
+			name: "validation",
+			err: apierrors.NewInvalid(deploymentsGK, "web", field.ErrorList{
+				field.Invalid(field.NewPath("spec", "replicas"), -1, "must be greater than or equal to 0"),
+			}),
+			want: "rejected by validation: spec.replicas: Invalid value: -1: must be greater than or equal to 0",
+		},
+		{
+			name: "not an admission error",
+			err:  apierrors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "deployments"}, "web", errors.New("RBAC says no")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := asAdmissionError(tt.err)
+			if tt.want == "" {
+				if got != tt.err {
+					t.Errorf("Expected the error unchanged, got %v", got)
+				}
+				return
+			}
+
+			var rejection *AdmissionError
+			if !errors.As(got, &rejection) {
+				t.Fatalf("Expected an AdmissionError, got %v", got)
+			}
+			if got.Error() != tt.want {
+				t.Errorf("got %q, want %q", got.Error(), tt.want)
+			}
+			if !errors.Is(got, tt.err) {
+				t.Error("The original API error should still be in the chain")
+			}
+		})
+	}
+}
+
+func TestDeployReportsWebhookRejection(t *testing.T) {
+	clientset := fake.NewClientset()
+	clientset.PrependReactor("patch", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, webhookDenial(`admission webhook "pod-policy" denied the request: privileged containers not allowed`)
+	})
+	provider := &fakeProvider{clients: map[string]*cluster.ClusterClient{
+		"prod-eu": {Config: config.ClusterConfig{Name: "prod-eu"}, Clientset: clientset, Connected: true},
+	}}
+
+	manifest := "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n"
+	err := NewManager(provider).DeployToClusterWithOptions("prod-eu", "default", manifest, DeployOptions{})
+	if err == nil || err.Error() != "rejected by webhook 'pod-policy': privileged containers not allowed" {
+		t.Fatalf("Expected the webhook's reason, got %v", err)
+	}
+}
+
+func TestRolloutStatusFailsOnRejectedPods(t *testing.T) {
+	failure := func(message string) *appsv1.Deployment {
+		return rolloutDeployment(2, 3, appsv1.DeploymentStatus{
+			ObservedGeneration: 2, Replicas: 0, UpdatedReplicas: 0,
+			Conditions: []appsv1.DeploymentCondition{{
+				Type: appsv1.DeploymentReplicaFailure, Status: corev1.ConditionTrue, Reason: failedCreateReason, Message: message,
+			}},
+		})
+	}
+
+	_, _, err := RolloutStatus(failure(`pods "web-abc-" is forbidden: admission webhook "pod-policy" denied the request: privileged containers not allowed`))
+	var rejection *AdmissionError
+	if !errors.As(err, &rejection) || rejection.Name != "pod-policy" {
+		t.Fatalf("Expected a webhook rejection, got %v", err)
+	}
+	if err.Error() != "pods are being rejected by webhook 'pod-policy': privileged containers not allowed" {
+		t.Errorf("Unexpected message %q", err.Error())
+	}
+
+	// A quota can free up, so it is waited out like any other slow rollout
+	_, done, err := RolloutStatus(failure(`pods "web-abc-" is forbidden: exceeded quota: compute, requested: cpu=1`))
+	if err != nil || done {
+		t.Errorf("Expected an exhausted quota to keep the rollout waiting, got done=%v err=%v", done, err)
+	}
+}
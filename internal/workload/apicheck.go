@@ -0,0 +1,97 @@
+package workload
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+// UnservedKind is a manifest object whose apiVersion and kind a cluster doesn't serve,
+// e.g. a batch/v1beta1 CronJob on a cluster that only has batch/v1
+type UnservedKind struct {
+	APIVersion string
+	Kind       string
+	Name       string
+}
+
+// APICheck is one cluster's answer to whether it serves every kind in a manifest
+type APICheck struct {
+	ClusterName string
+	Objects     int            // How many objects the manifest holds
+	Unserved    []UnservedKind // Objects the cluster can't take, in manifest order
+	Err         error          // Set when the cluster couldn't be checked at all
+}
+
+// OK reports whether the cluster was checked and serves every object's kind
+func (c APICheck) OK() bool {
+	return c.Err == nil && len(c.Unserved) == 0
+}
+
+// CheckAPIs checks, cluster by cluster, that each object in the manifest has an
+// apiVersion and kind the cluster serves, according to its discovery data. Nothing
+// is applied, so version skew across the fleet shows up before a deploy rather
+// than halfway through one. With values, each cluster checks the manifest as
+// rendered for its environment. Results are in the order the clusters were given
+func (m *Manager) CheckAPIs(clusterNames []string, yamlContent string, opts DeployOptions) []APICheck {
+	checks := make([]APICheck, len(clusterNames))
+
+	var wg sync.WaitGroup
+	for i, clusterName := range clusterNames {
+		wg.Add(1)
+		go func(index int, name string) {
+			defer wg.Done()
+			checks[index] = m.checkClusterAPIs(name, yamlContent, opts)
+		}(i, clusterName)
+	}
+	wg.Wait()
+
+	return checks
+}
+
+// checkClusterAPIs resolves every object's kind against one cluster's API resources
+func (m *Manager) checkClusterAPIs(clusterName, yamlContent string, opts DeployOptions) APICheck {
+	check := APICheck{ClusterName: clusterName}
+
+	environment := ""
+	if opts.Values != nil {
+		client, err := m.clusterManager.GetClient(clusterName)
+		if err != nil {
+			check.Err = err
+			return check
+		}
+		environment = client.Config.Environment
+	}
+	rendered, err := opts.render(yamlContent, environment)
+	if err != nil {
+		check.Err = err
+		return check
+	}
+	objects, err := DecodeManifests(rendered)
+	if err != nil {
+		check.Err = fmt.Errorf("failed to parse YAML: %w", err)
+		return check
+	}
+	check.Objects = len(objects)
+
+	resolver, err := m.newKindResolver(clusterName)
+	if err != nil {
+		check.Err = fmt.Errorf("failed to discover API resources: %w", err)
+		return check
+	}
+	for _, obj := range objects {
+		_, err := resolver.RESTMapping(obj.GroupVersionKind())
+		switch {
+		case meta.IsNoMatchError(err):
+			name := obj.GetName()
+			if name == "" {
+				name = generatedNamePlaceholder(obj.GetGenerateName())
+			}
+			check.Unserved = append(check.Unserved, UnservedKind{APIVersion: obj.GetAPIVersion(), Kind: obj.GetKind(), Name: name})
+		case err != nil:
+			check.Err = fmt.Errorf("failed to resolve %s %s: %w", obj.GetAPIVersion(), obj.GetKind(), err)
+			return check
+		}
+	}
+	return check
+}
@@ -0,0 +1,68 @@
+package workload
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+// discoveryClient returns a fake clientset whose discovery lists the given resources
+func discoveryClient(resources ...*metav1.APIResourceList) *fake.Clientset {
+	clientset := fake.NewSimpleClientset()
+	clientset.Resources = resources
+	return clientset
+}
+
+func TestCheckAPIsReportsUnservedKinds(t *testing.T) {
+	core := &metav1.APIResourceList{GroupVersion: "v1", APIResources: []metav1.APIResource{
+		{Name: "configmaps", Kind: "ConfigMap", Namespaced: true},
+	}}
+	batchV1 := &metav1.APIResourceList{GroupVersion: "batch/v1", APIResources: []metav1.APIResource{
+		{Name: "cronjobs", Kind: "CronJob", Namespaced: true},
+	}}
+	batchV1beta1 := &metav1.APIResourceList{GroupVersion: "batch/v1beta1", APIResources: []metav1.APIResource{
+		{Name: "cronjobs", Kind: "CronJob", Namespaced: true},
+	}}
+
+	provider := &fakeProvider{clients: map[string]*cluster.ClusterClient{
+		"old": {Config: config.ClusterConfig{Name: "old"}, Clientset: discoveryClient(core, batchV1, batchV1beta1), Connected: true},
+		"new": {Config: config.ClusterConfig{Name: "new"}, Clientset: discoveryClient(core, batchV1), Connected: true},
+	}}
+	manager := NewManager(provider)
+
+	manifest := `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+---
+apiVersion: batch/v1beta1
+kind: CronJob
+metadata:
+  name: cleanup
+`
+	checks := manager.CheckAPIs([]string{"old", "new", "missing"}, manifest, DeployOptions{})
+	if len(checks) != 3 {
+		t.Fatalf("Expected a result per cluster, got %d", len(checks))
+	}
+
+	if old := checks[0]; !old.OK() || old.Objects != 2 {
+		t.Errorf("Expected old to serve both objects, got %+v", old)
+	}
+
+	newer := checks[1]
+	if newer.OK() || newer.Err != nil || len(newer.Unserved) != 1 {
+		t.Fatalf("Expected new to lack exactly one kind, got %+v", newer)
+	}
+	if got := newer.Unserved[0]; got.APIVersion != "batch/v1beta1" || got.Kind != "CronJob" || got.Name != "cleanup" {
+		t.Errorf("Unserved = %+v, want batch/v1beta1 CronJob cleanup", got)
+	}
+
+	if checks[2].ClusterName != "missing" || checks[2].Err == nil {
+		t.Errorf("Expected an unknown cluster to be reported as unchecked, got %+v", checks[2])
+	}
+}
@@ -0,0 +1,316 @@
+package workload
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+)
+
+// typedObject is a typed API object such as *appsv1.Deployment
+type typedObject interface {
+	metav1.Object
+	runtime.Object
+}
+
+// typedClient is the part of a typed client-go client the apply path uses;
+// the Deployment, StatefulSet and DaemonSet clients all satisfy it
+type typedClient[T typedObject] interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (T, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (T, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+}
+
+// applyOptions are the server-side apply options a deploy sends: mcm's field
+// manager, taking over fields other managers own only with ForceConflicts
+func (opts DeployOptions) applyOptions() metav1.PatchOptions {
+	force := opts.ForceConflicts
+	return metav1.PatchOptions{FieldManager: FieldManager, Force: &force}
+}
+
+// ensureTargetNamespace creates the namespace when CreateNamespace asks for it
+func (opts DeployOptions) ensureTargetNamespace(ctx context.Context, clientset kubernetes.Interface, clusterName, namespace string) error {
+	if !opts.CreateNamespace {
+		return nil
+	}
+	created, err := ensureNamespace(ctx, clientset, namespace, opts.CreatedBy)
+	if err != nil {
+		return err
+	}
+	if created {
+		opts.logf("Created namespace %s in cluster %s\n", namespace, clusterName)
+	}
+	return nil
+}
+
+// applyPatch encodes a typed object as a server-side apply patch
+// The empty status and creationTimestamp every typed object carries are left
+// out, so mcm never claims ownership of fields it didn't set
+func applyPatch(obj runtime.Object, gvk schema.GroupVersionKind) ([]byte, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	patch := &unstructured.Unstructured{Object: content}
+	patch.SetGroupVersionKind(gvk)
+	patch.SetResourceVersion("")
+	unstructured.RemoveNestedField(patch.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(patch.Object, "status")
+	return json.Marshal(patch.Object)
+}
+
+// withConflictHint points a server-side apply conflict - another field manager,
+// such as kubectl edit or an autoscaler, owns a field the manifest sets - at
+// --force-conflicts, which takes those fields over
+func withConflictHint(err error) error {
+	if apierrors.IsConflict(err) {
+		return fmt.Errorf("%w (use --force-conflicts to take ownership of these fields)", err)
+	}
+	return err
+}
+
+// manifestKind reads the kind a manifest declares
+func manifestKind(yamlContent string) (string, error) {
+	var obj map[string]interface{}
+	if err := yaml.Unmarshal([]byte(yamlContent), &obj); err != nil {
+		return "", fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	kind, ok := obj["kind"].(string)
+	if !ok {
+		return "", fmt.Errorf("YAML must specify a 'kind' field")
+	}
+	return kind, nil
+}
+
+// applyAppsWorkload applies a StatefulSet or DaemonSet manifest, returning what it
+// did ("Created" or "Updated") and the object's name
+// These follow the same rules as deployments - namespace defaulted, create-only,
+// managed-only - but have no rollout to wait for or progress deadline to set yet
+func applyAppsWorkload(ctx context.Context, clientset kubernetes.Interface, clusterName, kind, namespace, yamlContent string, opts DeployOptions) (string, string, error) {
+	switch kind {
+	case "StatefulSet":
+		var statefulSet appsv1.StatefulSet
+		if err := yaml.Unmarshal([]byte(yamlContent), &statefulSet); err != nil {
+			return "", "", fmt.Errorf("failed to parse StatefulSet YAML: %w", err)
+		}
+		prepareObject(&statefulSet, namespace, opts)
+		return applyTyped(ctx, clientset, clusterName, kind, clientset.AppsV1().StatefulSets(statefulSet.Namespace), &statefulSet, opts)
+
+	case "DaemonSet":
+		var daemonSet appsv1.DaemonSet
+		if err := yaml.Unmarshal([]byte(yamlContent), &daemonSet); err != nil {
+			return "", "", fmt.Errorf("failed to parse DaemonSet YAML: %w", err)
+		}
+		prepareObject(&daemonSet, namespace, opts)
+		return applyTyped(ctx, clientset, clusterName, kind, clientset.AppsV1().DaemonSets(daemonSet.Namespace), &daemonSet, opts)
+
+	default:
+		return "", "", fmt.Errorf("resource kind '%s' is not supported yet", kind)
+	}
+}
+
+// prepareObject defaults the namespace and stamps the managed-by label,
+// as desiredDeployment does for deployments
+func prepareObject(obj metav1.Object, namespace string, opts DeployOptions) {
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(namespace)
+	}
+	if opts.ManagedByKey != "" {
+		labels := obj.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[opts.ManagedByKey] = opts.ManagedByValue
+		obj.SetLabels(labels)
+	}
+}
+
+// applyTyped creates or updates an apps/v1 object with server-side apply, returning
+// what it did ("Created" or "Updated") and the object's name. The existing object
+// is read first only to tell the two apart and to enforce CreateOnly and ManagedByKey;
+// the apply itself merges with fields other managers own instead of replacing them
+func applyTyped[T typedObject](ctx context.Context, clientset kubernetes.Interface, clusterName, kind string,
+	client typedClient[T], obj T, opts DeployOptions) (string, string, error) {
+
+	noun := strings.ToLower(kind)
+	if obj.GetName() == "" {
+		return "", "", fmt.Errorf("%s manifest must set metadata.name", kind)
+	}
+	if err := opts.ensureTargetNamespace(ctx, clientset, clusterName, obj.GetNamespace()); err != nil {
+		return "", "", err
+	}
+
+	action := "Updated"
+	existing, err := client.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		action = "Created"
+	case err != nil:
+		return "", "", fmt.Errorf("failed to read %s %s/%s: %w", noun, obj.GetNamespace(), obj.GetName(), err)
+	case opts.CreateOnly:
+		return "", "", fmt.Errorf("%s %s/%s already exists", noun, obj.GetNamespace(), obj.GetName())
+	case opts.ManagedByKey != "" && existing.GetLabels()[opts.ManagedByKey] != opts.ManagedByValue:
+		return "", "", fmt.Errorf("%s %s/%s exists but is not managed by mcm (missing label %s=%s); refusing to modify it",
+			noun, obj.GetNamespace(), obj.GetName(), opts.ManagedByKey, opts.ManagedByValue)
+	}
+
+	data, err := applyPatch(obj, appsv1.SchemeGroupVersion.WithKind(kind))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode %s: %w", noun, err)
+	}
+	apply := func(ctx context.Context) error {
+		_, err := client.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, opts.applyOptions())
+		return err
+	}
+
+	err = apply(ctx)
+	if err != nil && action == "Updated" && opts.ForceRecreate && IsImmutableFieldError(err) {
+		reason := err
+		err = recreate(ctx,
+			func(ctx context.Context) error {
+				_, err := client.Get(ctx, obj.GetName(), metav1.GetOptions{})
+				return err
+			},
+			func(ctx context.Context) error {
+				return client.Delete(ctx, obj.GetName(), backgroundDeletion())
+			},
+			apply)
+		if err == nil {
+			action = "Recreated"
+			opts.recreated(RecreatedResource{Cluster: clusterName, Kind: kind, Namespace: obj.GetNamespace(), Name: obj.GetName(), Reason: reason.Error()})
+		}
+	}
+	if err != nil {
+		return "", "", withConflictHint(fmt.Errorf("failed to apply %s: %w", noun, err))
+	}
+	return action, obj.GetName(), nil
+}
+
+// deployUnstructured applies a manifest of any other kind the cluster serves -
+// ConfigMaps, Services, Ingresses, custom resources - through the dynamic client
+// The kind is resolved with the cluster's own discovery data, and the object is
+// sent with server-side apply, like the typed kinds
+func (m *Manager) deployUnstructured(client *cluster.ClusterClient, clusterName, namespace, yamlContent string, opts DeployOptions) error {
+	objects, err := DecodeManifests(yamlContent)
+	if err != nil {
+		return fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if len(objects) != 1 {
+		return fmt.Errorf("deploy takes one object per manifest, found %d; use 'mcm sync' for several", len(objects))
+	}
+	obj := objects[0]
+
+	if client.Dynamic == nil {
+		return fmt.Errorf("cluster has no dynamic client available for kind '%s'", obj.GetKind())
+	}
+	resolver, err := m.newKindResolver(clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to discover API resources: %w", err)
+	}
+	mapping, err := resolver.RESTMapping(obj.GroupVersionKind())
+	if err != nil {
+		return fmt.Errorf("kind '%s' is not served by cluster %s: %w", obj.GetKind(), clusterName, err)
+	}
+
+	resource := resourceInterface(client.Dynamic, mapping, obj, namespace)
+	namespaced := mapping.Scope.Name() == meta.RESTScopeNameNamespace
+	noun := strings.ToLower(obj.GetKind())
+	prepareObject(obj, obj.GetNamespace(), opts)
+
+	if namespaced {
+		if err := opts.ensureTargetNamespace(context.Background(), client.Clientset, clusterName, obj.GetNamespace()); err != nil {
+			return err
+		}
+	}
+
+	// generateName objects get a fresh name every time; a retried create could
+	// leave two behind, so it gets one try
+	if obj.GetName() == "" {
+		var name string
+		err := client.DoOnce(context.Background(), func(ctx context.Context) error {
+			created, err := resource.Create(ctx, obj, metav1.CreateOptions{FieldManager: FieldManager})
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %w", noun, err)
+			}
+			name = created.GetName()
+			return nil
+		})
+		if err != nil {
+			return asAdmissionError(err)
+		}
+		opts.logf("Created %s %s in cluster %s\n", noun, name, clusterName)
+		return nil
+	}
+
+	// Server-side apply is idempotent, so reading and applying retry as one
+	var action string
+	err = client.Do(context.Background(), func(ctx context.Context) error {
+		existing, err := resource.Get(ctx, obj.GetName(), metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			action = "Created"
+		case err != nil:
+			return fmt.Errorf("failed to read %s %s: %w", noun, obj.GetName(), err)
+		case opts.CreateOnly:
+			return fmt.Errorf("%s %s already exists", noun, objectPath(obj.GetNamespace(), obj.GetName()))
+		case opts.ManagedByKey != "" && existing.GetLabels()[opts.ManagedByKey] != opts.ManagedByValue:
+			return fmt.Errorf("%s %s exists but is not managed by mcm (missing label %s=%s); refusing to modify it",
+				noun, objectPath(obj.GetNamespace(), obj.GetName()), opts.ManagedByKey, opts.ManagedByValue)
+		default:
+			action = "Updated"
+		}
+		err = applyObject(ctx, resource, obj, opts.ForceConflicts)
+		if err == nil || action != "Updated" || !opts.ForceRecreate || !IsImmutableFieldError(err) {
+			return withConflictHint(err)
+		}
+
+		reason := err
+		err = recreate(ctx,
+			func(ctx context.Context) error {
+				_, err := resource.Get(ctx, obj.GetName(), metav1.GetOptions{})
+				return err
+			},
+			func(ctx context.Context) error {
+				return resource.Delete(ctx, obj.GetName(), backgroundDeletion())
+			},
+			func(ctx context.Context) error {
+				return applyObject(ctx, resource, obj, opts.ForceConflicts)
+			})
+		if err != nil {
+			return err
+		}
+		action = "Recreated"
+		opts.recreated(RecreatedResource{Cluster: clusterName, Kind: obj.GetKind(), Namespace: obj.GetNamespace(), Name: obj.GetName(), Reason: reason.Error()})
+		return nil
+	})
+	if err != nil {
+		return asAdmissionError(err)
+	}
+	opts.logf("%s %s %s in cluster %s\n", action, noun, obj.GetName(), clusterName)
+
+	if opts.Wait {
+		opts.logf("Not waiting for %s %s: --wait only follows Deployment rollouts so far\n", noun, obj.GetName())
+	}
+	return nil
+}
+
+// objectPath names an object as namespace/name, or just name when it's cluster-scoped
+func objectPath(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
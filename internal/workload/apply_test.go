@@ -0,0 +1,174 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+func TestDeployStatefulSetCreatesThenUpdates(t *testing.T) {
+	clientset := fake.NewClientset()
+	provider := &fakeProvider{clients: map[string]*cluster.ClusterClient{
+		"prod": {Config: config.ClusterConfig{Name: "prod"}, Clientset: clientset, Connected: true},
+	}}
+	manager := NewManager(provider)
+
+	manifest := "apiVersion: apps/v1\nkind: StatefulSet\nmetadata:\n  name: db\nspec:\n  replicas: %d\n  serviceName: db\n"
+	if err := manager.DeployToCluster("prod", "data", fmt.Sprintf(manifest, 1)); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := clientset.AppsV1().StatefulSets("data").Get(context.Background(), "db", metav1.GetOptions{}); err != nil {
+		t.Fatalf("Expected the StatefulSet in the namespace from the argument: %v", err)
+	}
+
+	var sent metav1.PatchOptions
+	clientset.PrependReactor("patch", "statefulsets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchActionImpl)
+		if patch.GetPatchType() != types.ApplyPatchType {
+			t.Errorf("Expected a server-side apply patch, got %s", patch.GetPatchType())
+		}
+		sent = patch.PatchOptions
+		return false, nil, nil
+	})
+	if err := manager.DeployToCluster("prod", "data", fmt.Sprintf(manifest, 3)); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if sent.FieldManager != FieldManager || sent.Force == nil || *sent.Force {
+		t.Errorf("Expected an unforced apply as field manager %s, got %+v", FieldManager, sent)
+	}
+	updated, _ := clientset.AppsV1().StatefulSets("data").Get(context.Background(), "db", metav1.GetOptions{})
+	if *updated.Spec.Replicas != 3 {
+		t.Errorf("Expected 3 replicas after the update, got %d", *updated.Spec.Replicas)
+	}
+}
+
+func TestDeployDaemonSetCreatesThenUpdates(t *testing.T) {
+	clientset := fake.NewClientset()
+	provider := &fakeProvider{clients: map[string]*cluster.ClusterClient{
+		"prod": {Config: config.ClusterConfig{Name: "prod"}, Clientset: clientset, Connected: true},
+	}}
+	manager := NewManager(provider)
+
+	manifest := "apiVersion: apps/v1\nkind: DaemonSet\nmetadata:\n  name: fluent-bit\n  namespace: logging\nspec:\n  minReadySeconds: %d\n"
+	if err := manager.DeployToCluster("prod", "default", fmt.Sprintf(manifest, 0)); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := manager.DeployToCluster("prod", "default", fmt.Sprintf(manifest, 10)); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	daemonSet, err := clientset.AppsV1().DaemonSets("logging").Get(context.Background(), "fluent-bit", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected the DaemonSet in the manifest's own namespace: %v", err)
+	}
+	if daemonSet.Spec.MinReadySeconds != 10 {
+		t.Errorf("Expected the update to be applied, got minReadySeconds %d", daemonSet.Spec.MinReadySeconds)
+	}
+
+	if err := manager.DeployToClusterWithOptions("prod", "default", fmt.Sprintf(manifest, 10), DeployOptions{CreateOnly: true}); err == nil {
+		t.Error("Expected --if-not-exists to refuse an existing DaemonSet")
+	}
+}
+
+func TestDeployOtherKindsUseServerSideApply(t *testing.T) {
+	unmanaged := &unstructured.Unstructured{}
+	unmanaged.SetAPIVersion("v1")
+	unmanaged.SetKind("ConfigMap")
+	unmanaged.SetName("legacy")
+	unmanaged.SetNamespace("web")
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), unmanaged)
+
+	var applied []string
+	dynamicClient.PrependReactor("patch", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchAction)
+		if patch.GetPatchType() != types.ApplyPatchType {
+			t.Errorf("Expected a server-side apply patch, got %s", patch.GetPatchType())
+		}
+		applied = append(applied, patch.GetName())
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(patch.GetPatch()); err != nil {
+			t.Fatal(err)
+		}
+		if obj.GetLabels()[ManagedByLabel] != ManagedByValue {
+			t.Errorf("Expected the applied object to carry the managed-by label, got %v", obj.GetLabels())
+		}
+		return true, obj, nil
+	})
+
+	configMap := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	provider := &mapperProvider{
+		fakeProvider: fakeProvider{clients: map[string]*cluster.ClusterClient{
+			"prod": {Config: config.ClusterConfig{Name: "prod"}, Clientset: fake.NewSimpleClientset(), Dynamic: dynamicClient, Connected: true},
+		}},
+		mappers: []meta.RESTMapper{mapperWith(configMap)},
+	}
+	manager := NewManager(provider)
+	opts := DeployOptions{ManagedByKey: ManagedByLabel, ManagedByValue: ManagedByValue}
+
+	manifest := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: %s\ndata:\n  mode: blue\n"
+	if err := manager.DeployToClusterWithOptions("prod", "web", fmt.Sprintf(manifest, "settings"), opts); err != nil {
+		t.Fatalf("Deploy failed: %v", err)
+	}
+	if len(applied) != 1 || applied[0] != "settings" {
+		t.Errorf("Expected one apply of settings, got %v", applied)
+	}
+
+	if err := manager.DeployToClusterWithOptions("prod", "web", fmt.Sprintf(manifest, "legacy"), opts); err == nil {
+		t.Error("Expected a ConfigMap without the managed-by label to be left alone")
+	}
+	if len(applied) != 1 {
+		t.Errorf("Expected no apply for the unmanaged ConfigMap, got %v", applied)
+	}
+
+	if err := manager.DeployToClusterWithOptions("prod", "web", "apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: w\n", opts); err == nil {
+		t.Error("Expected a kind the cluster doesn't serve to be rejected")
+	}
+}
+
+func TestDeployConflictsWithOtherFieldManagers(t *testing.T) {
+	clientset := fake.NewClientset()
+	provider := &fakeProvider{clients: map[string]*cluster.ClusterClient{
+		"prod": {Config: config.ClusterConfig{Name: "prod"}, Clientset: clientset, Connected: true},
+	}}
+	manager := NewManager(provider)
+
+	manifest := "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\nspec:\n  replicas: %d\n"
+	if err := manager.DeployToCluster("prod", "default", fmt.Sprintf(manifest, 2)); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	// Someone scales the deployment by hand, taking ownership of replicas
+	force := true
+	scaled := []byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web"},"spec":{"replicas":5}}`)
+	if _, err := clientset.AppsV1().Deployments("default").Patch(context.Background(), "web", types.ApplyPatchType, scaled,
+		metav1.PatchOptions{FieldManager: "kubectl", Force: &force}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := manager.DeployToCluster("prod", "default", fmt.Sprintf(manifest, 3))
+	if err == nil || !strings.Contains(err.Error(), "--force-conflicts") {
+		t.Fatalf("Expected a conflict pointing at --force-conflicts, got %v", err)
+	}
+
+	if err := manager.DeployToClusterWithOptions("prod", "default", fmt.Sprintf(manifest, 3), DeployOptions{ForceConflicts: true}); err != nil {
+		t.Fatalf("Forced deploy failed: %v", err)
+	}
+	deployment, _ := clientset.AppsV1().Deployments("default").Get(context.Background(), "web", metav1.GetOptions{})
+	if *deployment.Spec.Replicas != 3 {
+		t.Errorf("Expected the forced deploy to set 3 replicas, got %d", *deployment.Spec.Replicas)
+	}
+}
@@ -1,8 +1,20 @@
 package workload
 
 import (
+	"fmt"
 	"testing"
 	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/restmapper"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+	"github.com/celikgo/autoz-control-tower/internal/config"
 )
 
 // BenchmarkFormatDuration tests the performance of duration formatting
@@ -200,25 +212,91 @@ func selectPodStatus(index int) string {
 	return statuses[index%len(statuses)]
 }
 
-// BenchmarkConcurrentProcessing tests performance under concurrent load
-// This simulates what happens when your tool processes multiple clusters simultaneously
-func BenchmarkConcurrentProcessing(b *testing.B) {
-	// This benchmark would test goroutine performance, but we'll keep it simple for now
-	// In a real-world tool, you'd want to benchmark the actual concurrent cluster operations
+// fakeProvider serves fake clientsets instead of real cluster connections
+type fakeProvider struct {
+	clients map[string]*cluster.ClusterClient
+}
+
+func (p *fakeProvider) GetClient(clusterName string) (*cluster.ClusterClient, error) {
+	client, ok := p.clients[clusterName]
+	if !ok {
+		return nil, &cluster.UnknownClusterError{Name: clusterName}
+	}
+	return client, nil
+}
+
+// RESTMapper builds a mapper from the fake clientset's discovery data on every call
+// Tests that care about caching substitute their own mapper source
+func (p *fakeProvider) RESTMapper(clusterName string) (meta.RESTMapper, error) {
+	client, err := p.GetClient(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	groupResources, err := restmapper.GetAPIGroupResources(client.Clientset.Discovery())
+	if err != nil {
+		return nil, err
+	}
+	return restmapper.NewDiscoveryRESTMapper(groupResources), nil
+}
+
+func (p *fakeProvider) InvalidateRESTMapper(clusterName string) {}
+
+func (p *fakeProvider) ListClusters() []cluster.ClusterStatus {
+	statuses := make([]cluster.ClusterStatus, 0, len(p.clients))
+	for name := range p.clients {
+		statuses = append(statuses, cluster.ClusterStatus{Name: name, Connected: true})
+	}
+	return statuses
+}
 
-	testData := make([]string, 1000)
-	for i := range testData {
-		testData[i] = generateDeploymentName(i)
+// newFakeFleet builds a provider with the given number of clusters,
+// each holding deploymentsPerCluster ready deployments
+func newFakeFleet(clusters, deploymentsPerCluster int) *fakeProvider {
+	provider := &fakeProvider{clients: make(map[string]*cluster.ClusterClient, clusters)}
+
+	for c := 0; c < clusters; c++ {
+		objects := make([]runtime.Object, 0, deploymentsPerCluster)
+		for d := 0; d < deploymentsPerCluster; d++ {
+			replicas := int32(3)
+			objects = append(objects, &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-%d", generateDeploymentName(d), d), Namespace: "production"},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: &replicas,
+					Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "app", Image: "nginx:1.25-alpine"}},
+					}},
+				},
+				Status: appsv1.DeploymentStatus{ReadyReplicas: 3},
+			})
+		}
+
+		name := fmt.Sprintf("cluster-%02d", c)
+		provider.clients[name] = &cluster.ClusterClient{
+			Config:    config.ClusterConfig{Name: name},
+			Clientset: fake.NewSimpleClientset(objects...),
+			Connected: true,
+		}
 	}
 
-	b.RunParallel(func(pb *testing.PB) {
-		for pb.Next() {
-			// Simulate concurrent string processing (simplified version of real operations)
-			for _, name := range testData {
-				if len(name) > 10 {
-					_ = name[:10] // Simulate string manipulation
+	return provider
+}
+
+// BenchmarkConcurrentProcessing measures the real ListDeployments fan-out and aggregation
+// across a simulated fleet, so allocation regressions in result collection show up here
+func BenchmarkConcurrentProcessing(b *testing.B) {
+	for _, size := range []struct{ clusters, deployments int }{{10, 50}, {50, 100}} {
+		b.Run(fmt.Sprintf("%dclusters-%ddeployments", size.clusters, size.deployments), func(b *testing.B) {
+			manager := NewManager(newFakeFleet(size.clusters, size.deployments))
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				deployments := manager.ListDeployments(nil, "", "").Items
+				if len(deployments) != size.clusters*size.deployments {
+					b.Fatalf("Expected %d deployments, got %d", size.clusters*size.deployments, len(deployments))
 				}
 			}
-		}
-	})
+		})
+	}
 }
@@ -0,0 +1,297 @@
+package workload
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ConnectivitySpec describes how the cross-cluster reachability probe runs
+// Each source cluster gets a short-lived pod that tries to open a TCP connection
+// to every target cluster's endpoint - the same path real service traffic takes
+type ConnectivitySpec struct {
+	Namespace      string            `json:"namespace"`                // Where probe pods run and target services live
+	Service        string            `json:"service,omitempty"`        // Service whose external address is probed in each target
+	Port           int32             `json:"port,omitempty"`           // Overrides the service's first port
+	Image          string            `json:"image,omitempty"`          // Probe container image (needs sh and nc)
+	TimeoutSeconds int               `json:"timeoutSeconds,omitempty"` // Per-connection dial timeout
+	Endpoints      map[string]string `json:"endpoints,omitempty"`      // Explicit host:port per cluster, skips service lookup
+}
+
+// ConnectivityResult is one cell of the reachability matrix
+type ConnectivityResult struct {
+	Source    string `json:"source"`
+	Target    string `json:"target"`
+	Endpoint  string `json:"endpoint,omitempty"`
+	Reachable bool   `json:"reachable"`
+	Error     string `json:"error,omitempty"`
+}
+
+const (
+	defaultProbeImage   = "busybox:1.36"
+	defaultProbeTimeout = 3
+	probeLabel          = "mcm.io/probe"
+)
+
+// safeEndpoint guards the probe script against anything but plain host:port values
+var safeEndpoint = regexp.MustCompile(`^[A-Za-z0-9._\-\[\]:]+$`)
+
+// CheckConnectivity probes reachability from every cluster to every other cluster
+// Probe pods are always cleaned up, even when a probe fails or times out
+func (m *Manager) CheckConnectivity(clusterNames []string, spec ConnectivitySpec) []ConnectivityResult {
+	if spec.Image == "" {
+		spec.Image = defaultProbeImage
+	}
+	if spec.TimeoutSeconds <= 0 {
+		spec.TimeoutSeconds = defaultProbeTimeout
+	}
+
+	// Step 1: work out which endpoint represents each target cluster
+	endpoints := make(map[string]string)
+	endpointErrors := make(map[string]string)
+	for _, name := range clusterNames {
+		endpoint, err := m.resolveProbeEndpoint(name, spec)
+		if err == nil {
+			err = checkProbeTarget(name, endpoint)
+		}
+		if err != nil {
+			endpointErrors[name] = err.Error()
+			continue
+		}
+		endpoints[name] = endpoint
+	}
+
+	// Step 2: run one probe pod per source cluster in parallel
+	var mutex sync.Mutex
+	var results []ConnectivityResult
+	var wg sync.WaitGroup
+
+	for _, source := range clusterNames {
+		wg.Add(1)
+		go func(source string) {
+			defer wg.Done()
+			reachable, err := m.runProbe(source, endpoints, spec)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			for _, target := range clusterNames {
+				result := ConnectivityResult{Source: source, Target: target, Endpoint: endpoints[target]}
+				switch {
+				case endpointErrors[target] != "":
+					result.Error = endpointErrors[target]
+				case err != nil:
+					result.Error = err.Error()
+				default:
+					result.Reachable = reachable[target]
+					if !result.Reachable {
+						result.Error = "connection failed"
+					}
+				}
+				results = append(results, result)
+			}
+		}(source)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// resolveProbeEndpoint finds the host:port a remote cluster would use to reach the target
+func (m *Manager) resolveProbeEndpoint(clusterName string, spec ConnectivitySpec) (string, error) {
+	if endpoint, ok := spec.Endpoints[clusterName]; ok {
+		if _, _, err := net.SplitHostPort(endpoint); err != nil {
+			return "", fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+		}
+		return endpoint, nil
+	}
+
+	if spec.Service == "" {
+		return "", fmt.Errorf("no probe service or endpoint configured")
+	}
+
+	client, err := m.clusterManager.GetClient(clusterName)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	service, err := client.Clientset.CoreV1().Services(spec.Namespace).Get(ctx, spec.Service, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get service %s/%s: %w", spec.Namespace, spec.Service, err)
+	}
+
+	port := spec.Port
+	if port == 0 {
+		if len(service.Spec.Ports) == 0 {
+			return "", fmt.Errorf("service %s/%s exposes no ports", spec.Namespace, spec.Service)
+		}
+		port = service.Spec.Ports[0].Port
+	}
+
+	// Cross-cluster traffic needs an address that is routable from outside
+	// the cluster, so prefer load balancer ingress, then external IPs
+	var host string
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			host = ingress.IP
+			break
+		}
+		if ingress.Hostname != "" {
+			host = ingress.Hostname
+			break
+		}
+	}
+	if host == "" && len(service.Spec.ExternalIPs) > 0 {
+		host = service.Spec.ExternalIPs[0]
+	}
+	if host == "" {
+		return "", fmt.Errorf("service %s/%s has no externally reachable address", spec.Namespace, spec.Service)
+	}
+
+	return net.JoinHostPort(host, strconv.Itoa(int(port))), nil
+}
+
+// checkProbeTarget refuses a cluster name or endpoint that isn't safe to put in
+// the probe script, so it is reported as invalid instead of as unreachable
+func checkProbeTarget(target, endpoint string) error {
+	if !safeEndpoint.MatchString(target) {
+		return fmt.Errorf("invalid target %q: cluster names in a probe may only use letters, digits, '.', '_' and '-'", target)
+	}
+	if !safeEndpoint.MatchString(endpoint) {
+		return fmt.Errorf("invalid endpoint %q: must be a plain host:port", endpoint)
+	}
+	return nil
+}
+
+// probeScript builds the shell script the probe pod runs: one nc check per
+// target, each printing a "RESULT <target> ok|fail" line
+func probeScript(endpoints map[string]string, timeoutSeconds int) (string, error) {
+	targets := make([]string, 0, len(endpoints))
+	for target := range endpoints {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	var script strings.Builder
+	for _, target := range targets {
+		endpoint := endpoints[target]
+		if err := checkProbeTarget(target, endpoint); err != nil {
+			return "", err
+		}
+		host, port, err := net.SplitHostPort(endpoint)
+		if err != nil {
+			return "", fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+		}
+		fmt.Fprintf(&script, "if nc -z -w %d %s %s; then echo 'RESULT %s ok'; else echo 'RESULT %s fail'; fi\n",
+			timeoutSeconds, host, port, target, target)
+	}
+	return script.String(), nil
+}
+
+// runProbe starts a probe pod in the source cluster, waits for it to finish,
+// and parses which endpoints it could reach from its logs
+func (m *Manager) runProbe(source string, endpoints map[string]string, spec ConnectivitySpec) (map[string]bool, error) {
+	client, err := m.clusterManager.GetClient(source)
+	if err != nil {
+		return nil, err
+	}
+
+	script, err := probeScript(endpoints, spec.TimeoutSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	pods := client.Clientset.CoreV1().Pods(spec.Namespace)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mcm-probe-" + rand.String(6),
+			Namespace: spec.Namespace,
+			Labels: map[string]string{
+				ManagedByLabel: ManagedByValue,
+				probeLabel:     "connectivity",
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{{
+				Name:    "probe",
+				Image:   spec.Image,
+				Command: []string{"sh", "-c", script},
+			}},
+		},
+	}
+
+	// Allow time for image pulls and scheduling on top of the dial timeouts
+	probeDeadline := 2*time.Minute + time.Duration(len(endpoints)*spec.TimeoutSeconds)*time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), probeDeadline)
+	defer cancel()
+
+	created, err := pods.Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create probe pod: %w", err)
+	}
+
+	// Always clean up, using a fresh context in case the probe context expired
+	defer func() {
+		cleanupCtx, cleanupCancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cleanupCancel()
+		_ = pods.Delete(cleanupCtx, created.Name, metav1.DeleteOptions{})
+	}()
+
+	if err := waitForPodCompletion(ctx, client.Clientset, spec.Namespace, created.Name); err != nil {
+		return nil, err
+	}
+
+	logs, err := pods.GetLogs(created.Name, &corev1.PodLogOptions{}).DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read probe results: %w", err)
+	}
+
+	return parseProbeOutput(string(logs)), nil
+}
+
+// waitForPodCompletion polls until the pod has finished running
+func waitForPodCompletion(ctx context.Context, clientset kubernetes.Interface, namespace, name string) error {
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err == nil && (pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("probe pod did not complete in time")
+		case <-ticker.C:
+		}
+	}
+}
+
+// parseProbeOutput turns "RESULT <cluster> ok|fail" lines into a reachability map
+func parseProbeOutput(output string) map[string]bool {
+	reachable := make(map[string]bool)
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 3 && fields[0] == "RESULT" {
+			reachable[fields[1]] = fields[2] == "ok"
+		}
+	}
+	return reachable
+}
@@ -0,0 +1,75 @@
+package workload
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseProbeOutput(t *testing.T) {
+	output := "RESULT prod-us ok\nnc: bad address 'x'\nRESULT prod-eu fail\n"
+
+	reachable := parseProbeOutput(output)
+
+	if !reachable["prod-us"] {
+		t.Error("Expected prod-us to be reachable")
+	}
+	if reachable["prod-eu"] {
+		t.Error("Expected prod-eu to be unreachable")
+	}
+	if len(reachable) != 2 {
+		t.Errorf("Expected 2 results, got %d", len(reachable))
+	}
+}
+
+func TestProbeScript(t *testing.T) {
+	script, err := probeScript(map[string]string{
+		"prod_us": "lb.us.example.com:443",
+		"prod-eu": "[2001:db8::1]:8080",
+	}, 3)
+	if err != nil {
+		t.Fatalf("probeScript() error = %v", err)
+	}
+
+	want := "if nc -z -w 3 2001:db8::1 8080; then echo 'RESULT prod-eu ok'; else echo 'RESULT prod-eu fail'; fi\n" +
+		"if nc -z -w 3 lb.us.example.com 443; then echo 'RESULT prod_us ok'; else echo 'RESULT prod_us fail'; fi\n"
+	if script != want {
+		t.Errorf("probeScript() =\n%s\nwant\n%s", script, want)
+	}
+}
+
+func TestProbeRejectsUnsafeTargets(t *testing.T) {
+	tests := []struct {
+		target, endpoint, wantErr string
+	}{
+		{"prod-us", "lb.example.com:443; reboot", "invalid endpoint"},
+		{"prod-us", "$(id):443", "invalid endpoint"},
+		{"prod us", "lb.example.com:443", "invalid target"},
+		{"prod'us", "lb.example.com:443", "invalid target"},
+	}
+
+	for _, tt := range tests {
+		if _, err := probeScript(map[string]string{tt.target: tt.endpoint}, 3); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("probeScript(%q: %q) error = %v, want %q", tt.target, tt.endpoint, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCheckConnectivityReportsInvalidEndpoints(t *testing.T) {
+	manager := NewManager(&fakeProvider{})
+	results := manager.CheckConnectivity([]string{"prod-us", "prod-eu"}, ConnectivitySpec{
+		Namespace: "default",
+		Endpoints: map[string]string{"prod-us": "10.0.0.1:80", "prod-eu": "evil`reboot`:80"},
+	})
+
+	if len(results) != 4 {
+		t.Fatalf("Expected a 2x2 matrix, got %d results", len(results))
+	}
+	for _, result := range results {
+		if result.Target != "prod-eu" {
+			continue
+		}
+		if !strings.Contains(result.Error, "invalid endpoint") {
+			t.Errorf("%s -> prod-eu: error = %q, want an invalid endpoint error", result.Source, result.Error)
+		}
+	}
+}
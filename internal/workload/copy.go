@@ -0,0 +1,26 @@
+package workload
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// FetchForCopy fetches one object from a cluster, ready to be applied to
+// another: SanitizeForApply strips what the source API server filled in and
+// what only makes sense in the source cluster, so the target fills in its own
+// resource is anything kubectl get accepts; namespace is ignored for
+// cluster-scoped resources
+func (m *Manager) FetchForCopy(clusterName, resource, name, namespace string) (*unstructured.Unstructured, error) {
+	result := m.GetObjects([]string{clusterName}, resource, name, GetOptions{Namespace: namespace})
+	if err := result.Errors[clusterName]; err != nil {
+		return nil, fmt.Errorf("failed to read %s %s from cluster %s: %w", resource, objectPath(namespace, name), clusterName, err)
+	}
+	if len(result.Items) != 1 {
+		return nil, fmt.Errorf("%s %s not found in cluster %s", resource, objectPath(namespace, name), clusterName)
+	}
+
+	obj := &unstructured.Unstructured{Object: result.Items[0].Object}
+	SanitizeForApply(obj)
+	return obj, nil
+}
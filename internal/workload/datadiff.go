@@ -0,0 +1,100 @@
+package workload
+
+import (
+	"encoding/base64"
+	"sort"
+)
+
+// Key-level changes reported for ConfigMap and Secret data
+const (
+	DataKeyAdded   = "added"
+	DataKeyRemoved = "removed"
+	DataKeyChanged = "changed"
+)
+
+// DataChange is one ConfigMap or Secret key that differs between a live object
+// and its manifest. From and To carry ConfigMap values only: Secret changes (and
+// ConfigMap binaryData) say which key changed and never what it changed to
+type DataChange struct {
+	Key         string `json:"key"`
+	Change      string `json:"change"`
+	From        string `json:"from,omitempty"`
+	To          string `json:"to,omitempty"`
+	ValueHidden bool   `json:"valueHidden,omitempty"`
+}
+
+// DiffObjectData compares the data keys of a live ConfigMap or Secret with the
+// desired one, sorted by key. Any other kind has no data to compare and yields nil.
+//
+// Secret values are compared in their base64 form and never decoded, so the
+// report is as safe to paste into a ticket as the manifest's key names are
+func DiffObjectData(kind string, live, desired map[string]interface{}) []DataChange {
+	switch kind {
+	case "ConfigMap":
+		changes := diffDataKeys(stringMap(live, "data"), stringMap(desired, "data"), true)
+		binary := diffDataKeys(stringMap(live, "binaryData"), stringMap(desired, "binaryData"), false)
+		return sortDataChanges(append(changes, binary...))
+	case "Secret":
+		return sortDataChanges(diffDataKeys(secretData(live), secretData(desired), false))
+	default:
+		return nil
+	}
+}
+
+// diffDataKeys reports every key added, removed or changed going from live to desired
+func diffDataKeys(live, desired map[string]string, showValues bool) []DataChange {
+	var changes []DataChange
+	record := func(key, change, from, to string) {
+		if !showValues {
+			changes = append(changes, DataChange{Key: key, Change: change, ValueHidden: true})
+			return
+		}
+		changes = append(changes, DataChange{Key: key, Change: change, From: from, To: to})
+	}
+
+	for key, to := range desired {
+		from, exists := live[key]
+		switch {
+		case !exists:
+			record(key, DataKeyAdded, "", to)
+		case from != to:
+			record(key, DataKeyChanged, from, to)
+		}
+	}
+	for key, from := range live {
+		if _, exists := desired[key]; !exists {
+			record(key, DataKeyRemoved, from, "")
+		}
+	}
+	return changes
+}
+
+// secretData returns a Secret's data as the API server stores it
+// stringData is write-only shorthand that the server base64-encodes into data
+// (winning over a data entry with the same key), so the manifest side is
+// normalized the same way before comparing
+func secretData(obj map[string]interface{}) map[string]string {
+	data := stringMap(obj, "data")
+	for key, value := range stringMap(obj, "stringData") {
+		data[key] = base64.StdEncoding.EncodeToString([]byte(value))
+	}
+	return data
+}
+
+// stringMap reads a map of strings from a top-level field of an unstructured object
+func stringMap(obj map[string]interface{}, field string) map[string]string {
+	values := make(map[string]string)
+	raw, _ := obj[field].(map[string]interface{})
+	for key, value := range raw {
+		if s, ok := value.(string); ok {
+			values[key] = s
+		}
+	}
+	return values
+}
+
+// sortDataChanges orders changes by key for a stable report
+func sortDataChanges(changes []DataChange) []DataChange {
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
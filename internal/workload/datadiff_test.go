@@ -0,0 +1,70 @@
+package workload
+
+import (
+	"encoding/base64"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffObjectDataConfigMapShowsValues(t *testing.T) {
+	live := map[string]interface{}{
+		"data":       map[string]interface{}{"LOG_LEVEL": "info", "OLD_KEY": "x", "SAME": "1"},
+		"binaryData": map[string]interface{}{"logo.png": "iVBORw0KGgo="},
+	}
+	desired := map[string]interface{}{
+		"data":       map[string]interface{}{"LOG_LEVEL": "debug", "FEATURE_X": "on", "SAME": "1"},
+		"binaryData": map[string]interface{}{"logo.png": "iVBORw0KGgp="},
+	}
+
+	want := []DataChange{
+		{Key: "FEATURE_X", Change: DataKeyAdded, To: "on"},
+		{Key: "LOG_LEVEL", Change: DataKeyChanged, From: "info", To: "debug"},
+		{Key: "OLD_KEY", Change: DataKeyRemoved, From: "x"},
+		{Key: "logo.png", Change: DataKeyChanged, ValueHidden: true},
+	}
+	if got := DiffObjectData("ConfigMap", live, desired); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffObjectData(ConfigMap) =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestDiffObjectDataSecretHidesValues(t *testing.T) {
+	encode := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+	live := map[string]interface{}{
+		"data": map[string]interface{}{
+			"username": encode("admin"),
+			"password": encode("hunter2"),
+			"token":    encode("abc"),
+		},
+	}
+	// stringData is what the manifest usually uses; the live object only ever has data
+	desired := map[string]interface{}{
+		"data":       map[string]interface{}{"password": encode("correct-horse")},
+		"stringData": map[string]interface{}{"username": "admin", "api-key": "s3cret"},
+	}
+
+	got := DiffObjectData("Secret", live, desired)
+	want := []DataChange{
+		{Key: "api-key", Change: DataKeyAdded, ValueHidden: true},
+		{Key: "password", Change: DataKeyChanged, ValueHidden: true},
+		{Key: "token", Change: DataKeyRemoved, ValueHidden: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffObjectData(Secret) =\n%+v\nwant\n%+v", got, want)
+	}
+
+	for _, change := range got {
+		for _, secret := range []string{"hunter2", "correct-horse", "s3cret", encode("hunter2"), encode("correct-horse")} {
+			if strings.Contains(change.From+change.To, secret) {
+				t.Errorf("Secret value leaked in change %+v", change)
+			}
+		}
+	}
+}
+
+func TestDiffObjectDataIgnoresOtherKinds(t *testing.T) {
+	obj := map[string]interface{}{"data": map[string]interface{}{"k": "v"}}
+	if got := DiffObjectData("Deployment", obj, map[string]interface{}{}); got != nil {
+		t.Errorf("Expected no data changes for a Deployment, got %+v", got)
+	}
+}
@@ -0,0 +1,140 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+// DeploymentDiff is how applying a Deployment manifest would change one cluster
+// Desired is what the API server answers to a server-side dry-run apply, so it
+// includes everything a real deploy would get: defaults, mutating webhooks, and
+// the fields other field managers keep owning
+type DeploymentDiff struct {
+	ClusterName string `json:"clusterName"`
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	Exists      bool   `json:"exists"`            // False when the deploy would create the deployment
+	Live        string `json:"live,omitempty"`    // The live object as YAML; empty when it doesn't exist
+	Desired     string `json:"desired,omitempty"` // The object as YAML after the apply
+}
+
+// Changed reports whether applying the manifest would change anything
+func (d DeploymentDiff) Changed() bool {
+	return d.Live != d.Desired
+}
+
+// DiffDeployment compares a Deployment manifest with the live object in each
+// cluster, the way kubectl diff does: the manifest is applied with DryRun=All
+// and the result is compared with what is there now. Nothing is changed. A
+// deployment that doesn't exist yet diffs as pure additions. Results are
+// sorted by cluster name
+func (m *Manager) DiffDeployment(clusterNames []string, namespace, yamlContent string, opts DeployOptions) FleetResult[DeploymentDiff] {
+	clusterNames = m.connectedClusters(clusterNames)
+
+	result := fanOut(clusterNames, m.timeouts, func(ctx context.Context, name string) ([]DeploymentDiff, error) {
+		client, err := m.clusterManager.GetClient(name)
+		if err != nil {
+			return nil, err
+		}
+
+		desired, err := desiredDeploymentFor(client.Config.Environment, namespace, yamlContent, opts)
+		if err != nil {
+			return nil, err
+		}
+		if desired.Name == "" {
+			return nil, fmt.Errorf("the manifest must set metadata.name to be diffed; a generateName deployment is new on every deploy")
+		}
+
+		var diff DeploymentDiff
+		err = client.Do(ctx, func(ctx context.Context) error {
+			var diffErr error
+			diff, diffErr = dryRunDiff(ctx, client.Clientset, desired.DeepCopy(), opts)
+			return diffErr
+		})
+		if err != nil {
+			return nil, asAdmissionError(err)
+		}
+		diff.ClusterName = name
+		return []DeploymentDiff{diff}, nil
+	})
+
+	sort.Slice(result.Items, func(i, j int) bool {
+		return result.Items[i].ClusterName < result.Items[j].ClusterName
+	})
+	return result
+}
+
+// dryRunDiff reads the live deployment and dry-run applies the desired one in one cluster
+func dryRunDiff(ctx context.Context, clientset kubernetes.Interface, desired *appsv1.Deployment, opts DeployOptions) (DeploymentDiff, error) {
+	diff := DeploymentDiff{Namespace: desired.Namespace, Name: desired.Name}
+	deployments := clientset.AppsV1().Deployments(desired.Namespace)
+	path := objectPath(desired.Namespace, desired.Name)
+
+	live, err := deployments.Get(ctx, desired.Name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		live = nil
+	case err != nil:
+		return diff, fmt.Errorf("failed to read deployment %s: %w", path, err)
+	default:
+		diff.Exists = true
+	}
+
+	data, err := applyPatch(desired, appsv1.SchemeGroupVersion.WithKind("Deployment"))
+	if err != nil {
+		return diff, fmt.Errorf("failed to encode deployment: %w", err)
+	}
+	patchOptions := opts.applyOptions()
+	patchOptions.DryRun = []string{metav1.DryRunAll}
+	applied, err := deployments.Patch(ctx, desired.Name, types.ApplyPatchType, data, patchOptions)
+	if err != nil {
+		return diff, withConflictHint(fmt.Errorf("dry-run apply of deployment %s failed: %w", path, err))
+	}
+
+	if diff.Exists {
+		if diff.Live, err = diffableYAML(live); err != nil {
+			return diff, fmt.Errorf("failed to render the live deployment: %w", err)
+		}
+	}
+	if diff.Desired, err = diffableYAML(applied); err != nil {
+		return diff, fmt.Errorf("failed to render the applied deployment: %w", err)
+	}
+	return diff, nil
+}
+
+// comparableObject copies an object without status, the bookkeeping metadata
+// the server rewrites on every change, and empty values, for comparing it with
+// another version of itself
+func comparableObject(obj map[string]interface{}) interface{} {
+	copied := runtime.DeepCopyJSON(obj)
+	kind, _ := copied["kind"].(string)
+	stripServerFields(kind, copied)
+	return pruneEmpty(copied)
+}
+
+// diffableYAML renders a deployment as YAML for a line diff, without status and
+// the bookkeeping metadata the server rewrites on every change
+func diffableYAML(deployment *appsv1.Deployment) (string, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(deployment)
+	if err != nil {
+		return "", err
+	}
+	// Typed clients drop apiVersion and kind from what they return
+	content["apiVersion"] = appsv1.SchemeGroupVersion.String()
+	content["kind"] = "Deployment"
+
+	data, err := yaml.Marshal(comparableObject(content))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,91 @@
+package workload
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+const diffManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: %REPLICAS%
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.27
+`
+
+func TestDiffDeployment(t *testing.T) {
+	manifest := func(replicas string) string {
+		return strings.ReplaceAll(diffManifest, "%REPLICAS%", replicas)
+	}
+
+	// prod already runs the manifest with 3 replicas; staging has nothing yet
+	prod := fake.NewClientset()
+	provider := &fakeProvider{clients: map[string]*cluster.ClusterClient{
+		"prod":    {Config: config.ClusterConfig{Name: "prod"}, Clientset: prod, Connected: true},
+		"staging": {Config: config.ClusterConfig{Name: "staging"}, Clientset: fake.NewClientset(), Connected: true},
+	}}
+	manager := NewManager(provider)
+	if err := manager.DeployToCluster("prod", "shop", manifest("3")); err != nil {
+		t.Fatalf("Deploy failed: %v", err)
+	}
+
+	var dryRun []string
+	prod.PrependReactor("patch", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		dryRun = action.(k8stesting.PatchActionImpl).PatchOptions.DryRun
+		return false, nil, nil
+	})
+
+	result := manager.DiffDeployment([]string{"staging", "prod"}, "shop", manifest("3"), DeployOptions{})
+	if len(result.Errors) != 0 || len(result.Items) != 2 {
+		t.Fatalf("Expected a diff per cluster, got %+v", result)
+	}
+	if len(dryRun) != 1 || dryRun[0] != metav1.DryRunAll {
+		t.Errorf("Expected the apply to be a dry run, got DryRun %v", dryRun)
+	}
+
+	unchanged, created := result.Items[0], result.Items[1]
+	if unchanged.ClusterName != "prod" || !unchanged.Exists || unchanged.Changed() {
+		t.Errorf("Expected no changes on prod, got:\n%s\nvs\n%s", unchanged.Live, unchanged.Desired)
+	}
+	if strings.Contains(unchanged.Live, "resourceVersion") || strings.Contains(unchanged.Live, "managedFields") {
+		t.Errorf("Expected server bookkeeping to be left out, got:\n%s", unchanged.Live)
+	}
+	if created.ClusterName != "staging" || created.Exists || created.Live != "" || !strings.Contains(created.Desired, "image: nginx:1.27") {
+		t.Errorf("Expected staging to diff as a create, got %+v", created)
+	}
+
+	changed := manager.DiffDeployment([]string{"prod"}, "shop", manifest("5"), DeployOptions{}).Items[0]
+	if !changed.Changed() || !strings.Contains(changed.Live, "replicas: 3") || !strings.Contains(changed.Desired, "replicas: 5") {
+		t.Errorf("Expected a replica change on prod, got:\n%s\nvs\n%s", changed.Live, changed.Desired)
+	}
+}
+
+func TestDiffDeploymentNeedsAName(t *testing.T) {
+	provider := &fakeProvider{clients: map[string]*cluster.ClusterClient{
+		"prod": {Config: config.ClusterConfig{Name: "prod"}, Clientset: fake.NewClientset(), Connected: true},
+	}}
+	manifest := "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  generateName: job-\n"
+	result := NewManager(provider).DiffDeployment([]string{"prod"}, "shop", manifest, DeployOptions{})
+	if err := result.Errors["prod"]; err == nil || !strings.Contains(err.Error(), "metadata.name") {
+		t.Errorf("Expected a generateName manifest to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,250 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+)
+
+// probeFailureReason is the event reason the kubelet uses for failed probes
+const probeFailureReason = "Unhealthy"
+
+// ProbeInfo is one container probe, rendered the way kubectl describe shows it
+type ProbeInfo struct {
+	Kind             string `json:"kind"`    // readiness, liveness or startup
+	Handler          string `json:"handler"` // e.g. "http-get http://:8080/healthz"
+	InitialDelay     int32  `json:"initialDelaySeconds"`
+	Timeout          int32  `json:"timeoutSeconds"`
+	Period           int32  `json:"periodSeconds"`
+	SuccessThreshold int32  `json:"successThreshold"`
+	FailureThreshold int32  `json:"failureThreshold"`
+}
+
+// String formats the probe like kubectl: handler followed by its timings and thresholds
+func (p ProbeInfo) String() string {
+	return fmt.Sprintf("%s delay=%ds timeout=%ds period=%ds #success=%d #failure=%d",
+		p.Handler, p.InitialDelay, p.Timeout, p.Period, p.SuccessThreshold, p.FailureThreshold)
+}
+
+// ContainerDescription is a container's readiness alongside the probes that decide it
+type ContainerDescription struct {
+	Name     string      `json:"name"`
+	Image    string      `json:"image"`
+	Ready    bool        `json:"ready"`
+	State    string      `json:"state"` // e.g. "Running", "Waiting (CrashLoopBackOff)"
+	Restarts int32       `json:"restarts"`
+	Probes   []ProbeInfo `json:"probes,omitempty"`
+}
+
+// PodConditionInfo is one pod condition, including any readiness gates
+type PodConditionInfo struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+	Gate    bool   `json:"readinessGate,omitempty"` // Declared in spec.readinessGates
+}
+
+// PodDescription answers "this pod is 1/2 ready - but why?"
+// It puts each container's probes next to its readiness, the pod conditions
+// (readiness gates included) and the probe failures the kubelet recently reported
+type PodDescription struct {
+	ClusterName   string                 `json:"clusterName"`
+	Namespace     string                 `json:"namespace"`
+	Name          string                 `json:"name"`
+	Node          string                 `json:"node"`
+	Phase         string                 `json:"phase"`
+	Ready         string                 `json:"ready"`
+	Age           string                 `json:"age"`
+	Containers    []ContainerDescription `json:"containers"`
+	Conditions    []PodConditionInfo     `json:"conditions"`
+	ProbeFailures []EventInfo            `json:"probeFailures,omitempty"`
+}
+
+// DescribePod looks a pod up in each given cluster and explains its readiness
+// Clusters where the pod doesn't exist simply contribute nothing
+func (m *Manager) DescribePod(clusterNames []string, namespace, podName string) FleetResult[PodDescription] {
+	clusterNames = m.connectedClusters(clusterNames)
+
+	return fanOut(clusterNames, m.timeouts, func(ctx context.Context, name string) ([]PodDescription, error) {
+		client, err := m.clusterManager.GetClient(name)
+		if err != nil {
+			return nil, err
+		}
+		var descriptions []PodDescription
+		err = client.Do(ctx, func(ctx context.Context) error {
+			var describeErr error
+			descriptions, describeErr = describePod(ctx, client.Clientset, name, namespace, podName)
+			return describeErr
+		})
+		return descriptions, err
+	})
+}
+
+// describePod fetches one pod and its probe failure events from a single cluster
+func describePod(ctx context.Context, clientset kubernetes.Interface, clusterName, namespace, podName string) ([]PodDescription, error) {
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod: %w", err)
+	}
+
+	description := podDescription(clusterName, pod)
+
+	// Probe failures only show up as events; the pod status just says "not ready"
+	selector := fields.Set{
+		"involvedObject.kind": "Pod",
+		"involvedObject.name": podName,
+		"reason":              probeFailureReason,
+	}.AsSelector().String()
+	events, err := clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list probe events: %w", err)
+	}
+	description.ProbeFailures = eventInfos(clusterName, events.Items)
+	sortEvents(description.ProbeFailures)
+
+	return []PodDescription{description}, nil
+}
+
+// podDescription builds the readiness explanation from the pod object alone
+func podDescription(clusterName string, pod *corev1.Pod) PodDescription {
+	statuses := make(map[string]corev1.ContainerStatus, len(pod.Status.ContainerStatuses))
+	for _, status := range pod.Status.ContainerStatuses {
+		statuses[status.Name] = status
+	}
+
+	ready := 0
+	containers := make([]ContainerDescription, 0, len(pod.Spec.Containers))
+	for _, container := range pod.Spec.Containers {
+		status := statuses[container.Name]
+		if status.Ready {
+			ready++
+		}
+
+		description := ContainerDescription{
+			Name:     container.Name,
+			Image:    container.Image,
+			Ready:    status.Ready,
+			State:    containerState(status.State),
+			Restarts: status.RestartCount,
+		}
+		for _, probe := range []struct {
+			kind  string
+			probe *corev1.Probe
+		}{
+			{"readiness", container.ReadinessProbe},
+			{"liveness", container.LivenessProbe},
+			{"startup", container.StartupProbe},
+		} {
+			if probe.probe != nil {
+				description.Probes = append(description.Probes, probeInfo(probe.kind, probe.probe))
+			}
+		}
+		containers = append(containers, description)
+	}
+
+	return PodDescription{
+		ClusterName: clusterName,
+		Namespace:   pod.Namespace,
+		Name:        pod.Name,
+		Node:        pod.Spec.NodeName,
+		Phase:       string(pod.Status.Phase),
+		Ready:       fmt.Sprintf("%d/%d", ready, len(pod.Spec.Containers)),
+		Age:         formatDuration(time.Since(pod.CreationTimestamp.Time)),
+		Containers:  containers,
+		Conditions:  podConditions(pod),
+	}
+}
+
+// podConditions lists the pod's conditions, followed by any readiness gate the
+// pod declares but nobody has reported on yet - an unreported gate keeps the pod
+// unready just as surely as a False one
+func podConditions(pod *corev1.Pod) []PodConditionInfo {
+	gates := make(map[corev1.PodConditionType]bool, len(pod.Spec.ReadinessGates))
+	for _, gate := range pod.Spec.ReadinessGates {
+		gates[gate.ConditionType] = true
+	}
+
+	conditions := make([]PodConditionInfo, 0, len(pod.Status.Conditions)+len(gates))
+	reported := make(map[corev1.PodConditionType]bool, len(pod.Status.Conditions))
+	for _, condition := range pod.Status.Conditions {
+		reported[condition.Type] = true
+		conditions = append(conditions, PodConditionInfo{
+			Type:    string(condition.Type),
+			Status:  string(condition.Status),
+			Reason:  condition.Reason,
+			Message: condition.Message,
+			Gate:    gates[condition.Type],
+		})
+	}
+
+	for _, gate := range pod.Spec.ReadinessGates {
+		if !reported[gate.ConditionType] {
+			conditions = append(conditions, PodConditionInfo{
+				Type:    string(gate.ConditionType),
+				Status:  string(corev1.ConditionFalse),
+				Reason:  "NotReported",
+				Message: "readiness gate condition has not been set on the pod",
+				Gate:    true,
+			})
+		}
+	}
+	return conditions
+}
+
+// containerState summarizes a container state, with the reason when it isn't running
+func containerState(state corev1.ContainerState) string {
+	switch {
+	case state.Running != nil:
+		return "Running"
+	case state.Waiting != nil:
+		return "Waiting (" + state.Waiting.Reason + ")"
+	case state.Terminated != nil:
+		return fmt.Sprintf("Terminated (%s, exit code %d)", state.Terminated.Reason, state.Terminated.ExitCode)
+	default:
+		return "Unknown"
+	}
+}
+
+// probeInfo describes a probe's handler and thresholds
+func probeInfo(kind string, probe *corev1.Probe) ProbeInfo {
+	return ProbeInfo{
+		Kind:             kind,
+		Handler:          probeHandler(probe.ProbeHandler),
+		InitialDelay:     probe.InitialDelaySeconds,
+		Timeout:          probe.TimeoutSeconds,
+		Period:           probe.PeriodSeconds,
+		SuccessThreshold: probe.SuccessThreshold,
+		FailureThreshold: probe.FailureThreshold,
+	}
+}
+
+// probeHandler renders the probe's action the way kubectl describe does
+func probeHandler(handler corev1.ProbeHandler) string {
+	switch {
+	case handler.HTTPGet != nil:
+		scheme := strings.ToLower(string(handler.HTTPGet.Scheme))
+		if scheme == "" {
+			scheme = "http"
+		}
+		return fmt.Sprintf("http-get %s://%s:%s%s", scheme, handler.HTTPGet.Host, handler.HTTPGet.Port.String(), handler.HTTPGet.Path)
+	case handler.TCPSocket != nil:
+		return fmt.Sprintf("tcp-socket %s:%s", handler.TCPSocket.Host, handler.TCPSocket.Port.String())
+	case handler.Exec != nil:
+		return fmt.Sprintf("exec %v", handler.Exec.Command)
+	case handler.GRPC != nil:
+		return fmt.Sprintf("grpc <pod>:%d", handler.GRPC.Port)
+	default:
+		return "unknown"
+	}
+}
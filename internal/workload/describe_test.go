@@ -0,0 +1,116 @@
+package workload
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestProbeHandlerFormatsLikeKubectl(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler corev1.ProbeHandler
+		want    string
+	}{
+		{
+			name:    "http get defaults to http scheme",
+			handler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt32(8080)}},
+			want:    "http-get http://:8080/healthz",
+		},
+		{
+			name:    "https with named port",
+			handler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/ready", Port: intstr.FromString("web"), Scheme: corev1.URISchemeHTTPS}},
+			want:    "http-get https://:web/ready",
+		},
+		{
+			name:    "tcp socket",
+			handler: corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(5432)}},
+			want:    "tcp-socket :5432",
+		},
+		{
+			name:    "exec",
+			handler: corev1.ProbeHandler{Exec: &corev1.ExecAction{Command: []string{"cat", "/tmp/ready"}}},
+			want:    "exec [cat /tmp/ready]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := probeHandler(tt.handler); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	probe := probeInfo("readiness", &corev1.Probe{
+		ProbeHandler:     corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(80)}},
+		TimeoutSeconds:   1,
+		PeriodSeconds:    10,
+		SuccessThreshold: 1,
+		FailureThreshold: 3,
+	})
+	want := "tcp-socket :80 delay=0s timeout=1s period=10s #success=1 #failure=3"
+	if got := probe.String(); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestPodDescriptionReportsUnreportedReadinessGate(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "web", ReadinessProbe: &corev1.Probe{ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/", Port: intstr.FromInt32(80)}}}},
+				{Name: "sidecar"},
+			},
+			ReadinessGates: []corev1.PodReadinessGate{
+				{ConditionType: "target-health.elbv2.k8s.aws/web"},
+			},
+		},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionFalse, Reason: "ReadinessGatesNotReady"},
+			},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "web", Ready: true, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+				{Name: "sidecar", Ready: false, State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+			},
+		},
+	}
+
+	description := podDescription("prod", pod)
+
+	if description.Ready != "1/2" {
+		t.Errorf("Expected 1/2 ready, got %s", description.Ready)
+	}
+	if len(description.Containers[0].Probes) != 1 || description.Containers[0].Probes[0].Kind != "readiness" {
+		t.Errorf("Expected the web container's readiness probe, got %+v", description.Containers[0].Probes)
+	}
+	if description.Containers[1].State != "Waiting (CrashLoopBackOff)" {
+		t.Errorf("Expected sidecar to be waiting, got %s", description.Containers[1].State)
+	}
+
+	if len(description.Conditions) != 2 {
+		t.Fatalf("Expected the Ready condition plus the unreported gate, got %+v", description.Conditions)
+	}
+	gate := description.Conditions[1]
+	if !gate.Gate || gate.Status != "False" || gate.Reason != "NotReported" {
+		t.Errorf("Expected unreported gate to show as False/NotReported, got %+v", gate)
+	}
+}
+
+func TestDescribePodNotFound(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+
+	descriptions, err := describePod(context.Background(), clientset, "prod", "default", "missing")
+	if err != nil {
+		t.Fatalf("Expected a missing pod not to be an error, got %v", err)
+	}
+	if len(descriptions) != 0 {
+		t.Errorf("Expected no descriptions, got %+v", descriptions)
+	}
+}
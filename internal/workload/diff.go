@@ -0,0 +1,346 @@
+package workload
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Resource diff statuses reported by DiffClusters
+const (
+	DiffOnlyInA   = "only-in-a"
+	DiffOnlyInB   = "only-in-b"
+	DiffDifferent = "different"
+)
+
+// rootCAConfigMap is the cluster CA bundle Kubernetes copies into every namespace
+const rootCAConfigMap = "kube-root-ca.crt"
+
+// diffNoneDisplay stands in for a field that is missing on one side
+const diffNoneDisplay = "<none>"
+
+// FieldDiff is a single field whose value differs between the two clusters
+type FieldDiff struct {
+	Path string `json:"path"`
+	A    string `json:"a"`
+	B    string `json:"b"`
+}
+
+// ResourceDiff describes one resource that isn't identical in both clusters
+type ResourceDiff struct {
+	Kind   string      `json:"kind"`
+	Name   string      `json:"name"`
+	Status string      `json:"status"`
+	Fields []FieldDiff `json:"fields,omitempty"`
+}
+
+// ClusterDiff is the full comparison of one namespace across two clusters
+type ClusterDiff struct {
+	ClusterA  string         `json:"clusterA"`
+	ClusterB  string         `json:"clusterB"`
+	Namespace string         `json:"namespace"`
+	Identical int            `json:"identical"`
+	Resources []ResourceDiff `json:"resources"`
+}
+
+// Count returns how many resources have the given diff status
+func (d *ClusterDiff) Count(status string) int {
+	count := 0
+	for _, resource := range d.Resources {
+		if resource.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// DiffClusters compares the deployments, services and configmaps of a namespace in two clusters
+// This is like laying two floor plans on top of each other: rooms only on one sheet,
+// and rooms on both sheets with different furniture, jump out immediately.
+//
+// Fields the API server fills in per cluster (UIDs, resource versions, cluster IPs,
+// node ports, status, ...) are ignored, so only differences in intent are reported.
+func (m *Manager) DiffClusters(clusterA, clusterB, namespace string) (*ClusterDiff, error) {
+	type snapshotResult struct {
+		objects map[string]map[string]interface{}
+		err     error
+	}
+
+	// Take both snapshots in parallel - they are independent clusters
+	results := make([]snapshotResult, 2)
+	done := make(chan struct{})
+	for i, clusterName := range []string{clusterA, clusterB} {
+		go func(i int, name string) {
+			objects, err := m.snapshotNamespace(name, namespace)
+			results[i] = snapshotResult{objects: objects, err: err}
+			done <- struct{}{}
+		}(i, clusterName)
+	}
+	<-done
+	<-done
+
+	for i, clusterName := range []string{clusterA, clusterB} {
+		if results[i].err != nil {
+			return nil, fmt.Errorf("failed to read namespace %s in cluster %s: %w", namespace, clusterName, results[i].err)
+		}
+	}
+
+	diff := diffSnapshots(results[0].objects, results[1].objects)
+	diff.ClusterA = clusterA
+	diff.ClusterB = clusterB
+	diff.Namespace = namespace
+	return diff, nil
+}
+
+// snapshotNamespace reads the compared resource types from one cluster,
+// normalized and keyed by "Kind/name"
+func (m *Manager) snapshotNamespace(clusterName, namespace string) (map[string]map[string]interface{}, error) {
+	client, err := m.clusterManager.GetClient(clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster client: %w", err)
+	}
+
+	var snapshot map[string]map[string]interface{}
+	err = client.Do(context.Background(), func(ctx context.Context) error {
+		var snapshotErr error
+		snapshot, snapshotErr = snapshotObjects(ctx, client.Clientset, namespace)
+		return snapshotErr
+	})
+	return snapshot, err
+}
+
+// snapshotObjects lists deployments, services and configmaps in a namespace
+func snapshotObjects(ctx context.Context, clientset kubernetes.Interface, namespace string) (map[string]map[string]interface{}, error) {
+	snapshot := make(map[string]map[string]interface{})
+	err := listComparedObjects(ctx, clientset, namespace, func(kind string, content map[string]interface{}) {
+		normalizeObject(kind, content)
+		name, _ := content["metadata"].(map[string]interface{})["name"].(string)
+		snapshot[kind+"/"+name] = content
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// listComparedObjects hands every deployment, service and configmap in a
+// namespace ("" for all namespaces) to add, converted to unstructured content
+func listComparedObjects(ctx context.Context, clientset kubernetes.Interface, namespace string,
+	add func(kind string, content map[string]interface{})) error {
+
+	convert := func(kind string, obj interface{}) error {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return fmt.Errorf("failed to convert %s: %w", kind, err)
+		}
+		add(kind, content)
+		return nil
+	}
+
+	deployments, err := clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for i := range deployments.Items {
+		if err := convert("Deployment", &deployments.Items[i]); err != nil {
+			return err
+		}
+	}
+
+	services, err := clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list services: %w", err)
+	}
+	for i := range services.Items {
+		if err := convert("Service", &services.Items[i]); err != nil {
+			return err
+		}
+	}
+
+	configMaps, err := clientset.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list configmaps: %w", err)
+	}
+	for i := range configMaps.Items {
+		// Every namespace gets its own copy of the cluster CA bundle, which always differs
+		if configMaps.Items[i].Name == rootCAConfigMap {
+			continue
+		}
+		if err := convert("ConfigMap", &configMaps.Items[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// normalizeObject strips fields that are assigned per cluster rather than declared,
+// so two clusters running the same manifests compare as identical
+func normalizeObject(kind string, obj map[string]interface{}) {
+	stripServerFields(kind, obj)
+	delete(obj, "apiVersion")
+	delete(obj, "kind")
+	if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "namespace")
+	}
+}
+
+// stripServerFields removes what the API server fills in on its own - status,
+// UIDs, resource versions, timestamps, cluster IPs, node ports - leaving what
+// a manifest would declare
+func stripServerFields(kind string, obj map[string]interface{}) {
+	delete(obj, "status")
+
+	if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+		for _, field := range []string{"uid", "resourceVersion", "generation", "creationTimestamp", "managedFields", "selfLink"} {
+			delete(metadata, field)
+		}
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			delete(annotations, "kubectl.kubernetes.io/last-applied-configuration")
+			delete(annotations, "deployment.kubernetes.io/revision")
+			if len(annotations) == 0 {
+				delete(metadata, "annotations")
+			}
+		}
+	}
+
+	if kind == "Service" {
+		if spec, ok := obj["spec"].(map[string]interface{}); ok {
+			delete(spec, "clusterIP")
+			delete(spec, "clusterIPs")
+			delete(spec, "healthCheckNodePort")
+			if ports, ok := spec["ports"].([]interface{}); ok {
+				for _, port := range ports {
+					if port, ok := port.(map[string]interface{}); ok {
+						delete(port, "nodePort")
+					}
+				}
+			}
+		}
+	}
+}
+
+// diffSnapshots produces the three-way report from two normalized snapshots
+func diffSnapshots(a, b map[string]map[string]interface{}) *ClusterDiff {
+	diff := &ClusterDiff{Resources: []ResourceDiff{}}
+
+	keys := make(map[string]bool, len(a)+len(b))
+	for key := range a {
+		keys[key] = true
+	}
+	for key := range b {
+		keys[key] = true
+	}
+
+	for key := range keys {
+		kind, name, _ := strings.Cut(key, "/")
+		objA, inA := a[key]
+		objB, inB := b[key]
+
+		switch {
+		case !inB:
+			diff.Resources = append(diff.Resources, ResourceDiff{Kind: kind, Name: name, Status: DiffOnlyInA})
+		case !inA:
+			diff.Resources = append(diff.Resources, ResourceDiff{Kind: kind, Name: name, Status: DiffOnlyInB})
+		default:
+			var fields []FieldDiff
+			diffValues("", objA, objB, &fields)
+			if len(fields) == 0 {
+				diff.Identical++
+				continue
+			}
+			sort.Slice(fields, func(i, j int) bool { return fields[i].Path < fields[j].Path })
+			diff.Resources = append(diff.Resources, ResourceDiff{Kind: kind, Name: name, Status: DiffDifferent, Fields: fields})
+		}
+	}
+
+	sort.Slice(diff.Resources, func(i, j int) bool {
+		ri, rj := diff.Resources[i], diff.Resources[j]
+		if ri.Status != rj.Status {
+			return ri.Status < rj.Status
+		}
+		if ri.Kind != rj.Kind {
+			return ri.Kind < rj.Kind
+		}
+		return ri.Name < rj.Name
+	})
+	return diff
+}
+
+// diffValues walks two unstructured values and records every leaf that differs
+// A missing field and an explicit null are treated as the same thing
+func diffValues(path string, a, b interface{}, fields *[]FieldDiff) {
+	mapA, aIsMap := a.(map[string]interface{})
+	mapB, bIsMap := b.(map[string]interface{})
+	if aIsMap && bIsMap {
+		keys := make(map[string]bool, len(mapA)+len(mapB))
+		for key := range mapA {
+			keys[key] = true
+		}
+		for key := range mapB {
+			keys[key] = true
+		}
+		for key := range keys {
+			diffValues(joinFieldPath(path, key), mapA[key], mapB[key], fields)
+		}
+		return
+	}
+
+	listA, aIsList := a.([]interface{})
+	listB, bIsList := b.([]interface{})
+	if aIsList && bIsList {
+		for i := 0; i < len(listA) || i < len(listB); i++ {
+			var itemA, itemB interface{}
+			if i < len(listA) {
+				itemA = listA[i]
+			}
+			if i < len(listB) {
+				itemB = listB[i]
+			}
+			diffValues(fmt.Sprintf("%s[%d]", path, i), itemA, itemB, fields)
+		}
+		return
+	}
+
+	if a == nil && b == nil {
+		return
+	}
+	valueA, valueB := formatFieldValue(a), formatFieldValue(b)
+	if valueA != valueB {
+		*fields = append(*fields, FieldDiff{Path: path, A: valueA, B: valueB})
+	}
+}
+
+// joinFieldPath appends a map key to a dotted path, quoting keys that contain dots
+// (as annotation and label names usually do) so the path stays unambiguous
+func joinFieldPath(path, key string) string {
+	if strings.Contains(key, ".") || strings.Contains(key, "/") {
+		key = fmt.Sprintf("[%q]", key)
+		return path + key
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// formatFieldValue renders a leaf (or a whole subtree present on only one side) compactly
+func formatFieldValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return diffNoneDisplay
+	case string:
+		return v
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
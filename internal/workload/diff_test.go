@@ -0,0 +1,111 @@
+package workload
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+func diffDeployment(image string, uid string) *appsv1.Deployment {
+	replicas := int32(2)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "app", UID: types.UID("uid-" + uid), ResourceVersion: uid},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "web", Image: image}},
+			}},
+		},
+		Status: appsv1.DeploymentStatus{ReadyReplicas: 2},
+	}
+}
+
+func diffService(clusterIP string, nodePort int32) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "app"},
+		Spec: corev1.ServiceSpec{
+			Type:      corev1.ServiceTypeNodePort,
+			ClusterIP: clusterIP,
+			Ports:     []corev1.ServicePort{{Port: 80, NodePort: nodePort}},
+		},
+	}
+}
+
+func diffConfigMap(name string, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "app"}, Data: data}
+}
+
+func TestDiffClusters(t *testing.T) {
+	fleet := func(objects ...runtime.Object) *cluster.ClusterClient {
+		return &cluster.ClusterClient{Clientset: fake.NewSimpleClientset(objects...), Connected: true, Config: config.ClusterConfig{}}
+	}
+	provider := &fakeProvider{clients: map[string]*cluster.ClusterClient{
+		"prod-us": fleet(
+			diffDeployment("web:1.0", "a"),
+			diffService("10.0.0.1", 30001),
+			diffConfigMap("settings", map[string]string{"mode": "fast"}),
+			diffConfigMap("legacy", nil),
+			diffConfigMap("kube-root-ca.crt", map[string]string{"ca.crt": "us"}),
+		),
+		"prod-eu": fleet(
+			diffDeployment("web:1.1", "b"),
+			diffService("10.9.9.9", 30002),
+			diffConfigMap("settings", map[string]string{"mode": "safe"}),
+			diffConfigMap("feature-flags", nil),
+			diffConfigMap("kube-root-ca.crt", map[string]string{"ca.crt": "eu"}),
+		),
+	}}
+
+	diff, err := NewManager(provider).DiffClusters("prod-us", "prod-eu", "app")
+	if err != nil {
+		t.Fatalf("DiffClusters failed: %v", err)
+	}
+
+	// The service differs only in cluster-assigned fields, so it counts as identical
+	if diff.Identical != 1 {
+		t.Errorf("identical = %d, want 1 (the service)", diff.Identical)
+	}
+
+	want := []struct{ kind, name, status, path, a, b string }{
+		{"ConfigMap", "settings", DiffDifferent, "data.mode", "fast", "safe"},
+		{"Deployment", "web", DiffDifferent, "spec.template.spec.containers[0].image", "web:1.0", "web:1.1"},
+		{"ConfigMap", "legacy", DiffOnlyInA, "", "", ""},
+		{"ConfigMap", "feature-flags", DiffOnlyInB, "", "", ""},
+	}
+	if len(diff.Resources) != len(want) {
+		t.Fatalf("got %d resource diffs, want %d: %+v", len(diff.Resources), len(want), diff.Resources)
+	}
+	for i, w := range want {
+		got := diff.Resources[i]
+		if got.Kind != w.kind || got.Name != w.name || got.Status != w.status {
+			t.Errorf("resource %d = %s/%s %s, want %s/%s %s", i, got.Kind, got.Name, got.Status, w.kind, w.name, w.status)
+			continue
+		}
+		if w.path == "" {
+			continue
+		}
+		if len(got.Fields) != 1 || got.Fields[0] != (FieldDiff{Path: w.path, A: w.a, B: w.b}) {
+			t.Errorf("%s/%s fields = %+v, want only %s: %s -> %s", w.kind, w.name, got.Fields, w.path, w.a, w.b)
+		}
+	}
+}
+
+func TestDiffValuesQuotesDottedKeys(t *testing.T) {
+	var fields []FieldDiff
+	diffValues("metadata",
+		map[string]interface{}{"labels": map[string]interface{}{"app.kubernetes.io/version": "1"}},
+		map[string]interface{}{"labels": map[string]interface{}{}},
+		&fields)
+
+	if len(fields) != 1 || fields[0].Path != `metadata.labels["app.kubernetes.io/version"]` || fields[0].B != diffNoneDisplay {
+		t.Errorf("unexpected field diffs: %+v", fields)
+	}
+}
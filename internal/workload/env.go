@@ -0,0 +1,276 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// What SetDeploymentEnv did to each variable
+const (
+	EnvAdded     = "added"
+	EnvChanged   = "changed"
+	EnvRemoved   = "removed"
+	EnvUnchanged = "unchanged"
+)
+
+// EnvAssignment is one KEY=VALUE (set) or KEY- (remove) from the command line
+type EnvAssignment struct {
+	Name   string
+	Value  string
+	Remove bool
+}
+
+// ParseEnvAssignments parses kubectl set env style arguments: KEY=VALUE sets a
+// variable (the value may itself contain '='), KEY- removes it
+func ParseEnvAssignments(args []string) ([]EnvAssignment, error) {
+	assignments := make([]EnvAssignment, 0, len(args))
+	seen := make(map[string]bool, len(args))
+	for _, arg := range args {
+		var assignment EnvAssignment
+		if name, value, ok := strings.Cut(arg, "="); ok {
+			assignment = EnvAssignment{Name: name, Value: value}
+		} else if strings.HasSuffix(arg, "-") {
+			assignment = EnvAssignment{Name: strings.TrimSuffix(arg, "-"), Remove: true}
+		} else {
+			return nil, fmt.Errorf("invalid environment change %q: use KEY=VALUE to set or KEY- to remove", arg)
+		}
+
+		if assignment.Name == "" {
+			return nil, fmt.Errorf("invalid environment change %q: missing variable name", arg)
+		}
+		if seen[assignment.Name] {
+			return nil, fmt.Errorf("variable %s is changed more than once", assignment.Name)
+		}
+		seen[assignment.Name] = true
+		assignments = append(assignments, assignment)
+	}
+	return assignments, nil
+}
+
+// EnvVarInfo is one environment variable of one container in one cluster
+type EnvVarInfo struct {
+	ClusterName string `json:"clusterName"`
+	Namespace   string `json:"namespace"`
+	Deployment  string `json:"deployment"`
+	Container   string `json:"container"`
+	Name        string `json:"name"`
+	Value       string `json:"value"` // Literal value, or where it comes from, e.g. "<secret db/password>"
+}
+
+// EnvVarChange is what happened to one variable of one container in one cluster
+type EnvVarChange struct {
+	ClusterName string `json:"clusterName"`
+	Namespace   string `json:"namespace"`
+	Deployment  string `json:"deployment"`
+	Container   string `json:"container"`
+	Name        string `json:"name"`
+	Change      string `json:"change"`           // added, changed, removed or unchanged
+	Before      string `json:"before,omitempty"` // Empty when the variable was added
+	After       string `json:"after,omitempty"`  // Empty when the variable was removed
+}
+
+// ListDeploymentEnv shows the environment of a deployment's containers in every given cluster
+// An empty container means all of them. Clusters without the deployment simply contribute nothing
+func (m *Manager) ListDeploymentEnv(clusterNames []string, namespace, name, container string) FleetResult[EnvVarInfo] {
+	clusterNames = m.connectedClusters(clusterNames)
+
+	return fanOut(clusterNames, m.timeouts, func(ctx context.Context, clusterName string) ([]EnvVarInfo, error) {
+		client, err := m.clusterManager.GetClient(clusterName)
+		if err != nil {
+			return nil, err
+		}
+
+		var deployment *appsv1.Deployment
+		err = client.Do(ctx, func(ctx context.Context) error {
+			var getErr error
+			deployment, getErr = client.Clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+			return getErr
+		})
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deployment: %w", err)
+		}
+
+		containers, err := selectContainers(deployment, container)
+		if err != nil {
+			return nil, err
+		}
+
+		var vars []EnvVarInfo
+		for _, c := range containers {
+			for _, env := range c.Env {
+				vars = append(vars, EnvVarInfo{
+					ClusterName: clusterName,
+					Namespace:   deployment.Namespace,
+					Deployment:  deployment.Name,
+					Container:   c.Name,
+					Name:        env.Name,
+					Value:       envValue(env),
+				})
+			}
+		}
+		return vars, nil
+	})
+}
+
+// SetDeploymentEnv applies the assignments to a deployment's containers in every given
+// cluster, like kubectl set env. Changing the pod template starts the usual rolling
+// update; a cluster where nothing changes is left untouched, so no rollout is started there.
+//
+// Unlike ListDeploymentEnv, a cluster without the deployment is an error: the user
+// asked for a change there and it didn't happen
+func (m *Manager) SetDeploymentEnv(clusterNames []string, namespace, name, container string, assignments []EnvAssignment) FleetResult[EnvVarChange] {
+	clusterNames = m.connectedClusters(clusterNames)
+
+	return fanOut(clusterNames, m.timeouts, func(ctx context.Context, clusterName string) ([]EnvVarChange, error) {
+		client, err := m.clusterManager.GetClient(clusterName)
+		if err != nil {
+			return nil, err
+		}
+		var changes []EnvVarChange
+		err = client.Do(ctx, func(ctx context.Context) error {
+			var setErr error
+			changes, setErr = setDeploymentEnv(ctx, client.Clientset, clusterName, namespace, name, container, assignments)
+			return setErr
+		})
+		return changes, err
+	})
+}
+
+// setDeploymentEnv updates one cluster's deployment, re-reading it if someone else
+// changed it between our read and our write
+func setDeploymentEnv(ctx context.Context, clientset kubernetes.Interface, clusterName, namespace, name, container string,
+	assignments []EnvAssignment) ([]EnvVarChange, error) {
+
+	deployments := clientset.AppsV1().Deployments(namespace)
+
+	var changes []EnvVarChange
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		deployment, err := deployments.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		containers, err := selectContainers(deployment, container)
+		if err != nil {
+			return err
+		}
+
+		changes = changes[:0]
+		modified := false
+		for _, c := range containers {
+			for _, assignment := range assignments {
+				change := applyEnvAssignment(c, assignment)
+				if change.Change != EnvUnchanged {
+					modified = true
+				}
+				change.ClusterName = clusterName
+				change.Namespace = deployment.Namespace
+				change.Deployment = deployment.Name
+				change.Container = c.Name
+				changes = append(changes, change)
+			}
+		}
+
+		if !modified {
+			return nil
+		}
+		_, err = deployments.Update(ctx, deployment, metav1.UpdateOptions{})
+		return err
+	})
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("deployment %s/%s not found", namespace, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update deployment: %w", err)
+	}
+	return changes, nil
+}
+
+// selectContainers picks the named container, or every container when name is empty
+// The returned pointers point into the deployment, so changes through them stick
+func selectContainers(deployment *appsv1.Deployment, name string) ([]*corev1.Container, error) {
+	containers := deployment.Spec.Template.Spec.Containers
+	var selected []*corev1.Container
+	var names []string
+	for i := range containers {
+		names = append(names, containers[i].Name)
+		if name == "" || containers[i].Name == name {
+			selected = append(selected, &containers[i])
+		}
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("deployment %s/%s has no container %q (containers: %s)",
+			deployment.Namespace, deployment.Name, name, strings.Join(names, ", "))
+	}
+	return selected, nil
+}
+
+// applyEnvAssignment changes one variable on a container and reports what it did
+// Setting a variable that came from a secret or config map replaces that reference
+// with the literal value, as kubectl set env does
+func applyEnvAssignment(container *corev1.Container, assignment EnvAssignment) EnvVarChange {
+	change := EnvVarChange{Name: assignment.Name}
+
+	index := -1
+	for i, env := range container.Env {
+		if env.Name == assignment.Name {
+			index = i
+			break
+		}
+	}
+
+	switch {
+	case assignment.Remove && index < 0:
+		change.Change = EnvUnchanged
+	case assignment.Remove:
+		change.Change = EnvRemoved
+		change.Before = envValue(container.Env[index])
+		container.Env = append(container.Env[:index], container.Env[index+1:]...)
+	case index < 0:
+		change.Change = EnvAdded
+		change.After = assignment.Value
+		container.Env = append(container.Env, corev1.EnvVar{Name: assignment.Name, Value: assignment.Value})
+	default:
+		current := container.Env[index]
+		change.Before = envValue(current)
+		change.After = assignment.Value
+		if current.ValueFrom == nil && current.Value == assignment.Value {
+			change.Change = EnvUnchanged
+			break
+		}
+		change.Change = EnvChanged
+		container.Env[index] = corev1.EnvVar{Name: assignment.Name, Value: assignment.Value}
+	}
+	return change
+}
+
+// envValue shows a variable's literal value, or where its value comes from
+// Secret values are never read - only the reference is shown
+func envValue(env corev1.EnvVar) string {
+	source := env.ValueFrom
+	switch {
+	case source == nil:
+		return env.Value
+	case source.SecretKeyRef != nil:
+		return fmt.Sprintf("<secret %s/%s>", source.SecretKeyRef.Name, source.SecretKeyRef.Key)
+	case source.ConfigMapKeyRef != nil:
+		return fmt.Sprintf("<configmap %s/%s>", source.ConfigMapKeyRef.Name, source.ConfigMapKeyRef.Key)
+	case source.FieldRef != nil:
+		return fmt.Sprintf("<field %s>", source.FieldRef.FieldPath)
+	case source.ResourceFieldRef != nil:
+		return fmt.Sprintf("<resource %s>", source.ResourceFieldRef.Resource)
+	default:
+		return "<reference>"
+	}
+}
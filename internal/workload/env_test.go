@@ -0,0 +1,119 @@
+package workload
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseEnvAssignments(t *testing.T) {
+	assignments, err := ParseEnvAssignments([]string{"LOG_LEVEL=debug", "DSN=postgres://db?sslmode=require", "OLD-", "EMPTY="})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []EnvAssignment{
+		{Name: "LOG_LEVEL", Value: "debug"},
+		{Name: "DSN", Value: "postgres://db?sslmode=require"},
+		{Name: "OLD", Remove: true},
+		{Name: "EMPTY", Value: ""},
+	}
+	for i := range want {
+		if assignments[i] != want[i] {
+			t.Errorf("Assignment %d: expected %+v, got %+v", i, want[i], assignments[i])
+		}
+	}
+
+	for _, bad := range [][]string{{"LOG_LEVEL"}, {"=value"}, {"-"}, {"A=1", "A-"}} {
+		if _, err := ParseEnvAssignments(bad); err == nil {
+			t.Errorf("Expected %v to be rejected", bad)
+		}
+	}
+}
+
+func envDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "prod"},
+		Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "web", Env: []corev1.EnvVar{
+				{Name: "LOG_LEVEL", Value: "info"},
+				{Name: "DB_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "db"}, Key: "password"}}},
+				{Name: "FEATURE_X", Value: "on"},
+			}},
+			{Name: "sidecar"},
+		}}}},
+	}
+}
+
+func TestSetDeploymentEnv(t *testing.T) {
+	clientset := fake.NewSimpleClientset(envDeployment())
+	assignments := []EnvAssignment{
+		{Name: "LOG_LEVEL", Value: "debug"},
+		{Name: "FEATURE_X", Remove: true},
+		{Name: "REGION", Value: "eu"},
+		{Name: "MISSING", Remove: true},
+	}
+
+	changes, err := setDeploymentEnv(context.Background(), clientset, "prod-eu", "prod", "web", "web", assignments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make([]string, 0, len(changes))
+	for _, change := range changes {
+		got = append(got, change.Name+" "+change.Change+" "+change.Before+"→"+change.After)
+	}
+	want := []string{"LOG_LEVEL changed info→debug", "FEATURE_X removed on→", "REGION added →eu", "MISSING unchanged →"}
+	if strings.Join(got, "; ") != strings.Join(want, "; ") {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	updated, _ := clientset.AppsV1().Deployments("prod").Get(context.Background(), "web", metav1.GetOptions{})
+	env := updated.Spec.Template.Spec.Containers[0].Env
+	if len(env) != 3 || env[0].Value != "debug" || env[1].Name != "DB_PASSWORD" || env[2].Name != "REGION" {
+		t.Errorf("Unexpected env after update: %+v", env)
+	}
+	if len(updated.Spec.Template.Spec.Containers[1].Env) != 0 {
+		t.Error("Expected the sidecar to be left alone when --container names web")
+	}
+}
+
+func TestSetDeploymentEnvWithoutChangesDoesNotUpdate(t *testing.T) {
+	clientset := fake.NewSimpleClientset(envDeployment())
+
+	changes, err := setDeploymentEnv(context.Background(), clientset, "prod-eu", "prod", "web", "web",
+		[]EnvAssignment{{Name: "LOG_LEVEL", Value: "info"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].Change != EnvUnchanged {
+		t.Errorf("Expected LOG_LEVEL to be unchanged, got %+v", changes)
+	}
+	for _, action := range clientset.Actions() {
+		if action.GetVerb() == "update" {
+			t.Fatal("Expected no update when nothing changed, so no rollout starts")
+		}
+	}
+}
+
+func TestSetDeploymentEnvReportsUnknownContainer(t *testing.T) {
+	clientset := fake.NewSimpleClientset(envDeployment())
+
+	_, err := setDeploymentEnv(context.Background(), clientset, "prod-eu", "prod", "web", "proxy",
+		[]EnvAssignment{{Name: "A", Value: "1"}})
+	if err == nil || !strings.Contains(err.Error(), "containers: web, sidecar") {
+		t.Errorf("Expected an error listing the real containers, got %v", err)
+	}
+}
+
+func TestEnvValueHidesSecrets(t *testing.T) {
+	env := envDeployment().Spec.Template.Spec.Containers[0].Env[1]
+	if got := envValue(env); got != "<secret db/password>" {
+		t.Errorf("Expected the secret reference, got %q", got)
+	}
+}
@@ -0,0 +1,117 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// EventInfo is one Kubernetes event about an object, tagged with its cluster
+type EventInfo struct {
+	ClusterName string    `json:"clusterName"`
+	Namespace   string    `json:"namespace"`
+	Object      string    `json:"object"`
+	Type        string    `json:"type"`
+	Reason      string    `json:"reason"`
+	Message     string    `json:"message"`
+	Count       int32     `json:"count"`
+	LastSeen    time.Time `json:"lastSeen"`
+	Age         string    `json:"age"`
+}
+
+// PodEvents retrieves the events for a single pod from the given clusters
+// This is the "why won't my pod start?" query, without the rest of a full describe
+func (m *Manager) PodEvents(clusterNames []string, namespace, podName string) FleetResult[EventInfo] {
+	clusterNames = m.connectedClusters(clusterNames)
+
+	result := fanOut(clusterNames, m.timeouts, func(ctx context.Context, name string) ([]EventInfo, error) {
+		return m.getPodEventsFromCluster(ctx, name, namespace, podName)
+	})
+
+	sortEvents(result.Items)
+	return result
+}
+
+// getPodEventsFromCluster asks one cluster's API server for the pod's events
+// The field selector makes the server do the filtering, so busy namespaces stay cheap
+func (m *Manager) getPodEventsFromCluster(ctx context.Context, clusterName, namespace, podName string) ([]EventInfo, error) {
+	client, err := m.clusterManager.GetClient(clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	selector := fields.Set{
+		"involvedObject.kind": "Pod",
+		"involvedObject.name": podName,
+	}.AsSelector().String()
+
+	var events *corev1.EventList
+	err = client.Do(ctx, func(ctx context.Context) error {
+		var listErr error
+		events, listErr = client.Clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: selector})
+		return listErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	return eventInfos(clusterName, events.Items), nil
+}
+
+// eventInfos converts raw events, normalizing the several places Kubernetes
+// records "when" and "how often" depending on which API version wrote the event
+func eventInfos(clusterName string, events []corev1.Event) []EventInfo {
+	result := make([]EventInfo, 0, len(events))
+	for _, event := range events {
+		lastSeen := event.LastTimestamp.Time
+		if lastSeen.IsZero() && event.Series != nil {
+			lastSeen = event.Series.LastObservedTime.Time
+		}
+		if lastSeen.IsZero() {
+			lastSeen = event.EventTime.Time
+		}
+		if lastSeen.IsZero() {
+			lastSeen = event.FirstTimestamp.Time
+		}
+		if lastSeen.IsZero() {
+			lastSeen = event.CreationTimestamp.Time
+		}
+
+		count := event.Count
+		if count == 0 && event.Series != nil {
+			count = event.Series.Count
+		}
+		if count == 0 {
+			count = 1
+		}
+
+		result = append(result, EventInfo{
+			ClusterName: clusterName,
+			Namespace:   event.Namespace,
+			Object:      event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name,
+			Type:        event.Type,
+			Reason:      event.Reason,
+			Message:     event.Message,
+			Count:       count,
+			LastSeen:    lastSeen,
+			Age:         formatDuration(time.Since(lastSeen)),
+		})
+	}
+	return result
+}
+
+// sortEvents groups events by cluster and orders them oldest first, like kubectl describe
+// so the most recent (usually most relevant) event ends up right above the prompt
+func sortEvents(events []EventInfo) {
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].ClusterName != events[j].ClusterName {
+			return events[i].ClusterName < events[j].ClusterName
+		}
+		return events[i].LastSeen.Before(events[j].LastSeen)
+	})
+}
@@ -0,0 +1,44 @@
+package workload
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEventInfosNormalizesTimestampsAndCounts(t *testing.T) {
+	now := time.Now()
+	events := []corev1.Event{
+		{
+			// Legacy core/v1 style event
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web-1"},
+			Reason:         "BackOff",
+			Count:          5,
+			LastTimestamp:  metav1.NewTime(now.Add(-time.Minute)),
+		},
+		{
+			// events.k8s.io style event: EventTime and Series instead
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web-1"},
+			Reason:         "Scheduled",
+			EventTime:      metav1.NewMicroTime(now.Add(-time.Hour)),
+		},
+	}
+
+	infos := eventInfos("prod", events)
+	sortEvents(infos)
+
+	if infos[0].Reason != "Scheduled" || infos[1].Reason != "BackOff" {
+		t.Fatalf("Expected events oldest first, got %s then %s", infos[0].Reason, infos[1].Reason)
+	}
+	if infos[0].Count != 1 {
+		t.Errorf("Expected missing count to default to 1, got %d", infos[0].Count)
+	}
+	if infos[1].Count != 5 {
+		t.Errorf("Expected count 5, got %d", infos[1].Count)
+	}
+	if infos[0].LastSeen.IsZero() {
+		t.Error("Expected EventTime to be used when LastTimestamp is missing")
+	}
+}
@@ -0,0 +1,232 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Planned actions reported per cluster by PlanDeploy
+const (
+	PlanCreate    = "create"
+	PlanUpdate    = "update"
+	PlanUnchanged = "unchanged"
+	PlanRefuse    = "refuse" // The deploy would fail on purpose, e.g. --if-not-exists
+	PlanUnknown   = "unknown"
+)
+
+// DeployPlan is what a deploy would do, worked out against each cluster's live
+// state without changing anything. It's the answer to "what happens if I press enter?"
+type DeployPlan struct {
+	Kind      string        `json:"kind"`
+	Name      string        `json:"name"`
+	Namespace string        `json:"namespace"`
+	Clusters  []ClusterPlan `json:"clusters"`
+}
+
+// ClusterPlan is the planned action for one cluster
+// Changes describes an update in plain words, e.g. "replicas 3→5"
+type ClusterPlan struct {
+	Cluster         string   `json:"cluster"`
+	Action          string   `json:"action"`
+	Changes         []string `json:"changes,omitempty"`
+	CreateNamespace bool     `json:"createNamespace,omitempty"`
+	Reason          string   `json:"reason,omitempty"` // Why a cluster is refused or unknown
+
+	// Live and Desired are the live object and the manifest as YAML, for creates
+	// and updates, trimmed so that a line diff shows only what the deploy changes
+	Live    string `json:"live,omitempty"`
+	Desired string `json:"desired,omitempty"`
+}
+
+// PlanDeploy works out, per cluster, what deploying the manifest would do
+// The manifest is parsed exactly as a real deploy parses it, so the plan
+// describes the same object the deploy would send
+func (m *Manager) PlanDeploy(clusterNames []string, namespace, yamlContent string, opts DeployOptions) (*DeployPlan, error) {
+	// With per-environment values each cluster is planned against its own rendering;
+	// the plan's object is named after the first target's, since overlays may
+	// supply values the base doesn't have
+	environment := ""
+	if opts.Values != nil && len(clusterNames) > 0 {
+		if client, err := m.clusterManager.GetClient(clusterNames[0]); err == nil {
+			environment = client.Config.Environment
+		}
+	}
+	desired, err := desiredDeploymentFor(environment, namespace, yamlContent, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &DeployPlan{Kind: "Deployment", Name: desired.Name, Namespace: desired.Namespace}
+	if desired.Name == "" {
+		plan.Name = generatedNamePlaceholder(desired.GenerateName)
+	}
+	plans := make([]ClusterPlan, len(clusterNames))
+
+	var wg sync.WaitGroup
+	for i, clusterName := range clusterNames {
+		wg.Add(1)
+		go func(index int, name string) {
+			defer wg.Done()
+			client, err := m.clusterManager.GetClient(name)
+			if err != nil {
+				plans[index] = ClusterPlan{Cluster: name, Action: PlanUnknown, Reason: err.Error()}
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			clusterDesired := desired
+			if opts.Values != nil {
+				clusterDesired, err = desiredDeploymentFor(client.Config.Environment, namespace, yamlContent, opts)
+				if err != nil {
+					plans[index] = ClusterPlan{Cluster: name, Action: PlanUnknown, Reason: err.Error()}
+					return
+				}
+			}
+			plans[index] = planDeployment(ctx, client.Clientset, name, clusterDesired, opts)
+		}(i, clusterName)
+	}
+	wg.Wait()
+
+	plan.Clusters = plans
+	return plan, nil
+}
+
+// planDeployment compares the desired deployment with the live one in a single cluster
+// It follows the same decisions DeployToClusterWithOptions makes, in the same order
+func planDeployment(ctx context.Context, clientset kubernetes.Interface, clusterName string, desired *appsv1.Deployment, opts DeployOptions) ClusterPlan {
+	plan := ClusterPlan{Cluster: clusterName}
+
+	var live *appsv1.Deployment
+	var err error
+	if desired.Name == "" && desired.GenerateName != "" {
+		// generateName deployments are always created fresh, so there's no live one to read
+		err = apierrors.NewNotFound(appsv1.Resource("deployments"), desired.GenerateName)
+	} else {
+		live, err = clientset.AppsV1().Deployments(desired.Namespace).Get(ctx, desired.Name, metav1.GetOptions{})
+	}
+	switch {
+	case apierrors.IsNotFound(err):
+		plan.Action = PlanCreate
+		// A missing namespace either gets created or makes the deploy fail
+		if _, err := clientset.CoreV1().Namespaces().Get(ctx, desired.Namespace, metav1.GetOptions{}); apierrors.IsNotFound(err) {
+			if !opts.CreateNamespace {
+				plan.Action = PlanRefuse
+				plan.Reason = fmt.Sprintf("namespace '%s' does not exist (use --create-namespace)", desired.Namespace)
+				return plan
+			}
+			plan.CreateNamespace = true
+		}
+		plan.setManifests(nil, desired)
+		return plan
+	case err != nil:
+		plan.Action = PlanUnknown
+		plan.Reason = fmt.Sprintf("failed to read the live deployment: %v", err)
+		return plan
+	case opts.CreateOnly:
+		plan.Action = PlanRefuse
+		plan.Reason = "it already exists (--if-not-exists)"
+		return plan
+	case opts.ManagedByKey != "" && live.Labels[opts.ManagedByKey] != opts.ManagedByValue:
+		plan.Action = PlanRefuse
+		plan.Reason = fmt.Sprintf("it is not managed by mcm (missing label %s=%s)", opts.ManagedByKey, opts.ManagedByValue)
+		return plan
+	}
+
+	plan.Changes = deploymentChanges(live, desired)
+	plan.Action = PlanUpdate
+	if len(plan.Changes) == 0 {
+		plan.Action = PlanUnchanged
+		return plan
+	}
+	plan.setManifests(live, desired)
+	return plan
+}
+
+// setManifests records the comparable YAML of both sides for diff output
+// A rendering failure only costs the diff, not the plan
+func (plan *ClusterPlan) setManifests(live, desired *appsv1.Deployment) {
+	var liveObject runtime.Object
+	if live != nil {
+		liveObject = live
+	}
+	liveYAML, desiredYAML, err := comparableManifests(liveObject, desired)
+	if err != nil {
+		return
+	}
+	plan.Live, plan.Desired = liveYAML, desiredYAML
+}
+
+// deploymentChanges describes how the desired deployment differs from the live one,
+// naming the fields people care about (replicas, images) and summarizing the rest
+func deploymentChanges(live, desired *appsv1.Deployment) []string {
+	var changes []string
+
+	if from, to := replicaCount(live.Spec.Replicas), replicaCount(desired.Spec.Replicas); from != to {
+		changes = append(changes, fmt.Sprintf("replicas %d→%d", from, to))
+	}
+
+	liveImages := make(map[string]string, len(live.Spec.Template.Spec.Containers))
+	for _, container := range live.Spec.Template.Spec.Containers {
+		liveImages[container.Name] = container.Image
+	}
+	desiredImages := make(map[string]bool, len(desired.Spec.Template.Spec.Containers))
+	for _, container := range desired.Spec.Template.Spec.Containers {
+		desiredImages[container.Name] = true
+		from, exists := liveImages[container.Name]
+		switch {
+		case !exists:
+			changes = append(changes, fmt.Sprintf("add container %s (%s)", container.Name, container.Image))
+		case from != container.Image:
+			changes = append(changes, fmt.Sprintf("image %s %s→%s", container.Name, from, container.Image))
+		}
+	}
+	var removed []string
+	for name := range liveImages {
+		if !desiredImages[name] {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(removed)
+	for _, name := range removed {
+		changes = append(changes, "remove container "+name)
+	}
+
+	// Anything else: the live object carries server defaults, so only fields the
+	// manifest actually sets are compared, the same way sync decides on updates
+	if len(changes) == 0 && !equality.Semantic.DeepDerivative(desired.Spec, live.Spec) {
+		changes = append(changes, "other spec changes")
+	}
+	if !labelsContained(desired.Labels, live.Labels) {
+		changes = append(changes, "labels")
+	}
+	return changes
+}
+
+// replicaCount reads spec.replicas, which Kubernetes defaults to 1 when unset
+func replicaCount(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+// labelsContained reports whether every desired label is already set on the live object
+func labelsContained(desired, live map[string]string) bool {
+	for key, value := range desired {
+		if live[key] != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,150 @@
+package workload
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const explainManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 5
+  selector:
+    matchLabels: {app: web}
+  template:
+    metadata:
+      labels: {app: web}
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.27
+`
+
+// liveWeb returns the "web" deployment as a cluster might currently run it
+func liveWeb(replicas int32, image string, labels map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "prod", Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{
+					{Name: "web", Image: image, ImagePullPolicy: corev1.PullIfNotPresent},
+				}},
+			},
+		},
+	}
+}
+
+func TestPlanDeployment(t *testing.T) {
+	prodNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod"}}
+
+	tests := []struct {
+		name        string
+		opts        DeployOptions
+		objects     []runtime.Object
+		wantAction  string
+		wantChanges []string
+		wantReason  string
+	}{
+		{
+			name:       "missing deployment is created",
+			objects:    []runtime.Object{prodNamespace},
+			wantAction: PlanCreate,
+		},
+		{
+			name:       "missing namespace without --create-namespace is refused",
+			wantAction: PlanRefuse,
+			wantReason: "--create-namespace",
+		},
+		{
+			name:        "replicas and image changes are named",
+			objects:     []runtime.Object{prodNamespace, liveWeb(3, "nginx:1.26", nil)},
+			wantAction:  PlanUpdate,
+			wantChanges: []string{"replicas 3→5", "image web nginx:1.26→nginx:1.27"},
+		},
+		{
+			name:       "matching deployment is unchanged despite server defaults",
+			objects:    []runtime.Object{prodNamespace, liveWeb(5, "nginx:1.27", nil)},
+			wantAction: PlanUnchanged,
+		},
+		{
+			name:       "existing deployment is refused with --if-not-exists",
+			opts:       DeployOptions{CreateOnly: true},
+			objects:    []runtime.Object{prodNamespace, liveWeb(5, "nginx:1.27", nil)},
+			wantAction: PlanRefuse,
+			wantReason: "--if-not-exists",
+		},
+		{
+			name:       "foreign deployment is refused with --managed-only",
+			opts:       DeployOptions{ManagedByKey: "app.kubernetes.io/managed-by", ManagedByValue: "mcm"},
+			objects:    []runtime.Object{prodNamespace, liveWeb(5, "nginx:1.27", map[string]string{"app.kubernetes.io/managed-by": "helm"})},
+			wantAction: PlanRefuse,
+			wantReason: "not managed by mcm",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired, err := desiredDeployment("prod", explainManifest, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			clientset := fake.NewSimpleClientset(tt.objects...)
+			plan := planDeployment(context.Background(), clientset, "prod-us", desired, tt.opts)
+
+			if plan.Action != tt.wantAction {
+				t.Errorf("Expected action %s, got %s (%s)", tt.wantAction, plan.Action, plan.Reason)
+			}
+			if !reflect.DeepEqual(plan.Changes, tt.wantChanges) {
+				t.Errorf("Expected changes %v, got %v", tt.wantChanges, plan.Changes)
+			}
+			if !strings.Contains(plan.Reason, tt.wantReason) {
+				t.Errorf("Expected reason mentioning %q, got %q", tt.wantReason, plan.Reason)
+			}
+		})
+	}
+}
+
+func TestPlanDeploymentManifestsHideServerFields(t *testing.T) {
+	desired, err := desiredDeployment("prod", explainManifest, DeployOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	live := liveWeb(3, "nginx:1.26", nil)
+	live.UID = "1234"
+	live.ResourceVersion = "99"
+	live.Status.ReadyReplicas = 3
+	clientset := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod"}}, live)
+
+	plan := planDeployment(context.Background(), clientset, "prod-us", desired, DeployOptions{})
+
+	liveLines := strings.Split(plan.Live, "\n")
+	desiredLines := strings.Split(plan.Desired, "\n")
+	if len(liveLines) != len(desiredLines) {
+		t.Fatalf("Expected line-for-line comparable manifests, got live:\n%s\ndesired:\n%s", plan.Live, plan.Desired)
+	}
+	var differing []string
+	for i := range liveLines {
+		if liveLines[i] != desiredLines[i] {
+			differing = append(differing, strings.TrimSpace(liveLines[i])+" → "+strings.TrimSpace(desiredLines[i]))
+		}
+	}
+	want := []string{"replicas: 3 → replicas: 5", "- image: nginx:1.26 → - image: nginx:1.27"}
+	if !reflect.DeepEqual(differing, want) {
+		t.Errorf("Expected only %v to differ, got %v", want, differing)
+	}
+}
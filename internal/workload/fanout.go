@@ -0,0 +1,256 @@
+package workload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+)
+
+// DefaultPerClusterTimeout bounds a single cluster's part of a fan-out query
+const DefaultPerClusterTimeout = 30 * time.Second
+
+// Timeouts bounds fleet-wide queries at two levels
+// PerCluster stops one slow cluster from holding up its own result forever;
+// Total caps the whole fan-out, so the answer arrives on time even if some
+// clusters never respond
+type Timeouts struct {
+	PerCluster time.Duration // 0 means DefaultPerClusterTimeout
+	Total      time.Duration // 0 means no overall limit
+}
+
+// SetTimeouts changes how long list operations wait for clusters
+func (m *Manager) SetTimeouts(timeouts Timeouts) {
+	m.timeouts = timeouts
+}
+
+// ErrClusterTimeout marks a cluster that didn't answer a fan-out query in time
+var ErrClusterTimeout = errors.New("timed out")
+
+// FleetResult is the outcome of one query across many clusters
+// Items holds everything the healthy clusters returned; Errors holds, per cluster,
+// why that cluster contributed nothing. Keeping the two apart means partial failures
+// are never silently dropped, and never disguised as placeholder rows in the data
+type FleetResult[T any] struct {
+	Items  []T
+	Errors map[string]error
+}
+
+// FailedClusters returns the names of the clusters that failed, sorted
+func (r FleetResult[T]) FailedClusters() []string {
+	names := make([]string, 0, len(r.Errors))
+	for name := range r.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ErrorMessages returns each failed cluster's full error message, for JSON/YAML output
+func (r FleetResult[T]) ErrorMessages() map[string]string {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+	messages := make(map[string]string, len(r.Errors))
+	for name, err := range r.Errors {
+		messages[name] = err.Error()
+	}
+	return messages
+}
+
+// Err aggregates the failed clusters into one error, or returns nil when every
+// cluster answered; the per-cluster errors stay reachable through errors.Is/As
+func (r FleetResult[T]) Err() error {
+	failed := r.FailedClusters()
+	if len(failed) == 0 {
+		return nil
+	}
+
+	errs := make([]error, 0, len(failed))
+	for _, name := range failed {
+		errs = append(errs, r.Errors[name])
+	}
+	return &PartialResultError{Clusters: failed, errs: errs}
+}
+
+// PartialResultError reports that a fleet-wide query is missing some clusters' data
+type PartialResultError struct {
+	Clusters []string // The clusters that failed, sorted
+	errs     []error  // Each cluster's error, in the same order
+}
+
+func (e *PartialResultError) Error() string {
+	reasons := make([]string, 0, len(e.Clusters))
+	for i, name := range e.Clusters {
+		reasons = append(reasons, fmt.Sprintf("%s (%s)", name, FailureReason(e.errs[i])))
+	}
+	noun := "clusters"
+	if len(e.Clusters) == 1 {
+		noun = "cluster"
+	}
+	return fmt.Sprintf("%d %s could not be queried: %s", len(e.Clusters), noun, strings.Join(reasons, ", "))
+}
+
+func (e *PartialResultError) Unwrap() []error { return e.errs }
+
+// FailureReason condenses a cluster error into a word or two for summaries,
+// e.g. "timeout" or "forbidden"; the full error is still in FleetResult.Errors
+func FailureReason(err error) string {
+	switch {
+	case errors.Is(err, ErrClusterTimeout), errors.Is(err, context.DeadlineExceeded),
+		apierrors.IsTimeout(err), apierrors.IsServerTimeout(err):
+		return "timeout"
+	case apierrors.IsForbidden(err):
+		return "forbidden"
+	case apierrors.IsUnauthorized(err):
+		return "unauthorized"
+	case cluster.IsUnknownCluster(err):
+		return "not in config"
+	case utilnet.IsConnectionRefused(err):
+		return "connection refused"
+	case errors.Is(err, cluster.ErrSkipped):
+		return "skipped"
+	case errors.Is(err, cluster.ErrNotConnected):
+		return "not connected"
+	default:
+		return "error"
+	}
+}
+
+// clusterResult is one cluster's answer to a fan-out query
+type clusterResult[T any] struct {
+	cluster string
+	items   []T
+	err     error
+}
+
+// fanOut runs query against every cluster in parallel and gathers the results
+// This is like sending the same question to every data center at once and
+// collecting the answers as they come in; when a deadline passes, whoever
+// hasn't answered yet is reported as timed out
+func fanOut[T any](clusterNames []string, timeouts Timeouts,
+	query func(ctx context.Context, clusterName string) ([]T, error)) FleetResult[T] {
+
+	// Keep each cluster's slice as-is and flatten once at the end, so the
+	// combined result is allocated exactly once instead of grown repeatedly
+	batches := make([][]T, 0, len(clusterNames))
+	failures := make(map[string]error)
+	for result := range streamFanOut(clusterNames, timeouts, query) {
+		if result.err != nil {
+			failures[result.cluster] = result.err
+			continue
+		}
+		batches = append(batches, result.items)
+	}
+
+	return FleetResult[T]{Items: flatten(batches), Errors: failures}
+}
+
+// streamFanOut runs query against every cluster in parallel and delivers each
+// cluster's result on the returned channel the moment it is ready, so fast
+// clusters never wait behind slow ones. Exactly one result arrives per cluster
+// and the channel is closed after the last.
+//
+// Each cluster has its own slot with its own deadline. A query that ignores its
+// context - say, a call stuck in a non-cancellable dial - doesn't hold the slot
+// past that deadline: the slot reports a timeout and is abandoned, and the stuck
+// goroutine is left to finish (or not) on its own
+func streamFanOut[T any](clusterNames []string, timeouts Timeouts,
+	query func(ctx context.Context, clusterName string) ([]T, error)) <-chan clusterResult[T] {
+
+	perCluster := timeouts.PerCluster
+	if perCluster <= 0 {
+		perCluster = DefaultPerClusterTimeout
+	}
+
+	ctx, cancel := context.Background(), func() {}
+	if timeouts.Total > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeouts.Total)
+	}
+
+	// Buffered so every slot can deliver even if the caller reads slowly
+	results := make(chan clusterResult[T], len(clusterNames))
+
+	var wg sync.WaitGroup
+	for _, clusterName := range clusterNames {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			results <- runSlot(ctx, name, perCluster, timeouts.Total, query)
+		}(clusterName)
+	}
+
+	// Every slot gives up by its deadline, so this never waits on a hung query
+	go func() {
+		wg.Wait()
+		cancel()
+		close(results)
+	}()
+
+	return results
+}
+
+// runSlot runs one cluster's query, giving up on it once its deadline passes
+func runSlot[T any](ctx context.Context, name string, perCluster, total time.Duration,
+	query func(ctx context.Context, clusterName string) ([]T, error)) clusterResult[T] {
+
+	clusterCtx, cancel := context.WithTimeout(ctx, perCluster)
+	defer cancel()
+
+	// Buffered so an abandoned query can still finish without blocking forever
+	done := make(chan clusterResult[T], 1)
+	go func() {
+		items, err := query(clusterCtx, name)
+		done <- clusterResult[T]{cluster: name, items: items, err: err}
+	}()
+
+	select {
+	case result := <-done:
+		if clusterCtx.Err() == nil {
+			return result
+		}
+	case <-clusterCtx.Done():
+	}
+
+	// Turn an opaque "context deadline exceeded" into a clear timeout report,
+	// naming whichever limit ran out
+	if ctx.Err() != nil {
+		return clusterResult[T]{cluster: name, err: fmt.Errorf("%w: no response within the overall timeout of %s (--timeout)", ErrClusterTimeout, total)}
+	}
+	return clusterResult[T]{cluster: name, err: fmt.Errorf("%w after %s (--timeout-per-cluster)", ErrClusterTimeout, perCluster)}
+}
+
+// connectedClusters defaults an empty cluster list to every connected cluster
+func (m *Manager) connectedClusters(clusterNames []string) []string {
+	if len(clusterNames) > 0 {
+		return clusterNames
+	}
+	for _, status := range m.clusterManager.ListClusters() {
+		if status.Connected {
+			clusterNames = append(clusterNames, status.Name)
+		}
+	}
+	return clusterNames
+}
+
+// flatten concatenates per-cluster results into one preallocated slice
+func flatten[T any](batches [][]T) []T {
+	total := 0
+	for _, batch := range batches {
+		total += len(batch)
+	}
+
+	all := make([]T, 0, total)
+	for _, batch := range batches {
+		all = append(all, batch...)
+	}
+	return all
+}
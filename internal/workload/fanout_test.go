@@ -0,0 +1,190 @@
+package workload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+)
+
+func TestFanOutReportsSlowClusters(t *testing.T) {
+	query := func(ctx context.Context, name string) ([]string, error) {
+		if name == "slow" {
+			select {
+			case <-ctx.Done():
+			case <-time.After(5 * time.Second):
+			}
+			return []string{"slow: late"}, nil
+		}
+		return []string{name + ": ok"}, nil
+	}
+
+	tests := []struct {
+		name     string
+		timeouts Timeouts
+		wantSlow string
+	}{
+		{name: "per-cluster timeout", timeouts: Timeouts{PerCluster: 50 * time.Millisecond}, wantSlow: "--timeout-per-cluster"},
+		{name: "total timeout", timeouts: Timeouts{PerCluster: time.Minute, Total: 50 * time.Millisecond}, wantSlow: "--timeout"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			result := fanOut([]string{"fast-1", "fast-2", "slow"}, tt.timeouts, query)
+
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("Expected fan-out to return promptly, took %s", elapsed)
+			}
+			if len(result.Items) != 2 {
+				t.Fatalf("Expected 2 items from the fast clusters, got %d: %v", len(result.Items), result.Items)
+			}
+
+			joined := strings.Join(result.Items, "\n")
+			if !strings.Contains(joined, "fast-1: ok") || !strings.Contains(joined, "fast-2: ok") {
+				t.Errorf("Expected fast clusters' results, got %v", result.Items)
+			}
+
+			err := result.Errors["slow"]
+			if !errors.Is(err, ErrClusterTimeout) || !strings.Contains(err.Error(), tt.wantSlow) {
+				t.Errorf("Expected slow cluster to be reported as timed out, got %v", err)
+			}
+			if len(result.Errors) != 1 {
+				t.Errorf("Expected only the slow cluster to fail, got %v", result.Errors)
+			}
+		})
+	}
+}
+
+func TestFanOutKeepsItemsAndErrorsApart(t *testing.T) {
+	result := fanOut([]string{"prod-us", "prod-eu", "dev"}, Timeouts{}, func(ctx context.Context, name string) ([]string, error) {
+		if name == "prod-us" {
+			return []string{"web", "api"}, nil
+		}
+		return nil, fmt.Errorf("failed to list on %s", name)
+	})
+
+	if !reflect.DeepEqual(result.Items, []string{"web", "api"}) {
+		t.Errorf("Expected only the healthy cluster's items, got %v", result.Items)
+	}
+	if got := result.FailedClusters(); !reflect.DeepEqual(got, []string{"dev", "prod-eu"}) {
+		t.Errorf("Expected sorted failed clusters, got %v", got)
+	}
+	if got := result.ErrorMessages()["dev"]; got != "failed to list on dev" {
+		t.Errorf("Expected the full error message, got %q", got)
+	}
+}
+
+func TestFailureReason(t *testing.T) {
+	deployments := schema.GroupResource{Group: "apps", Resource: "deployments"}
+
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{fmt.Errorf("%w after 30s", ErrClusterTimeout), "timeout"},
+		{fmt.Errorf("failed to list deployments: %w", context.DeadlineExceeded), "timeout"},
+		{fmt.Errorf("failed to list deployments: %w", apierrors.NewForbidden(deployments, "", errors.New("denied"))), "forbidden"},
+		{apierrors.NewUnauthorized("expired token"), "unauthorized"},
+		{&cluster.UnknownClusterError{Name: "old"}, "not in config"},
+		{fmt.Errorf("cluster 'dev' is %w: dial failed", cluster.ErrNotConnected), "not connected"},
+		{errors.New("something else"), "error"},
+	}
+
+	for _, tt := range tests {
+		if got := FailureReason(tt.err); got != tt.want {
+			t.Errorf("FailureReason(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestFanOutAbandonsQueriesThatIgnoreTheirContext(t *testing.T) {
+	// A query stuck in a call that can't be cancelled: it never looks at ctx
+	hung := make(chan struct{})
+	defer close(hung)
+	query := func(ctx context.Context, name string) ([]string, error) {
+		if name == "hung" {
+			<-hung
+		}
+		return []string{name + ": ok"}, nil
+	}
+
+	start := time.Now()
+	result := fanOut([]string{"fast", "hung"}, Timeouts{PerCluster: 50 * time.Millisecond}, query)
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Expected the hung cluster to be abandoned after its timeout, took %s", elapsed)
+	}
+	if !reflect.DeepEqual(result.Items, []string{"fast: ok"}) {
+		t.Errorf("Expected the fast cluster's result, got %v", result.Items)
+	}
+	if err := result.Errors["hung"]; !errors.Is(err, ErrClusterTimeout) || !strings.Contains(err.Error(), "--timeout-per-cluster") {
+		t.Errorf("Expected the hung cluster to be reported as timed out, got %v", err)
+	}
+}
+
+func TestStreamFanOutDeliversFastClustersFirst(t *testing.T) {
+	release := make(chan struct{})
+	query := func(ctx context.Context, name string) ([]string, error) {
+		if name == "slow" {
+			<-release
+		}
+		return []string{name}, nil
+	}
+
+	results := streamFanOut([]string{"slow", "fast"}, Timeouts{PerCluster: 5 * time.Second}, query)
+
+	select {
+	case first := <-results:
+		if first.cluster != "fast" {
+			t.Fatalf("Expected the fast cluster's result first, got %s", first.cluster)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the fast cluster's result without waiting for the slow one")
+	}
+
+	close(release)
+	second, ok := <-results
+	if !ok || second.cluster != "slow" || second.err != nil {
+		t.Errorf("Expected the slow cluster's result next, got %+v", second)
+	}
+	if _, open := <-results; open {
+		t.Error("Expected the stream to close after every cluster answered")
+	}
+}
+
+func TestFleetResultErr(t *testing.T) {
+	if err := (FleetResult[string]{Items: []string{"web"}}).Err(); err != nil {
+		t.Errorf("Expected no error when every cluster answered, got %v", err)
+	}
+
+	result := FleetResult[string]{
+		Items: []string{"web"},
+		Errors: map[string]error{
+			"prod-eu": fmt.Errorf("%w after 30s", ErrClusterTimeout),
+			"dev":     errors.New("connection reset"),
+		},
+	}
+	err := result.Err()
+	if err == nil {
+		t.Fatal("Expected an error when clusters failed")
+	}
+	if want := "2 clusters could not be queried: dev (error), prod-eu (timeout)"; err.Error() != want {
+		t.Errorf("Err() = %q, want %q", err.Error(), want)
+	}
+	if !errors.Is(err, ErrClusterTimeout) {
+		t.Error("Expected the per-cluster errors to stay reachable with errors.Is")
+	}
+	var partial *PartialResultError
+	if !errors.As(err, &partial) || !reflect.DeepEqual(partial.Clusters, []string{"dev", "prod-eu"}) {
+		t.Errorf("Expected a PartialResultError naming the failed clusters, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// FreezeAnnotation marks a namespace as closed for deploys when set to "true"
+// Setting it on kube-system freezes the whole cluster
+const FreezeAnnotation = "mcm.io/deploy-frozen"
+
+// clusterFreezeNamespace is the sentinel object for cluster-wide freezes
+// It exists in every cluster and is rarely touched by application teams
+const clusterFreezeNamespace = "kube-system"
+
+// FreezeStatus explains whether a cluster is closed for deploys and why
+type FreezeStatus struct {
+	Frozen bool
+	Reason string
+}
+
+// CheckFreeze reports whether deploys to a namespace in a cluster are frozen
+// This is like checking the "do not disturb" sign before walking into a room -
+// the cluster's config entry, the cluster sentinel, and the target namespace
+// can each hang the sign
+func (m *Manager) CheckFreeze(clusterName, namespace string) FreezeStatus {
+	client, err := m.clusterManager.GetClient(clusterName)
+	if err != nil {
+		return FreezeStatus{Frozen: true, Reason: fmt.Sprintf("could not check freeze status: %v", err)}
+	}
+
+	if client.Config.Frozen {
+		return FreezeStatus{Frozen: true, Reason: "cluster is marked frozen in the mcm configuration"}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	return checkFreezeAnnotations(ctx, client.Clientset, namespace)
+}
+
+// checkFreezeAnnotations looks for the freeze annotation on the cluster sentinel and target namespace
+// Errors other than "not found" fail closed: an unverifiable freeze is treated as a freeze
+func checkFreezeAnnotations(ctx context.Context, clientset kubernetes.Interface, namespace string) FreezeStatus {
+	candidates := []string{clusterFreezeNamespace}
+	if namespace != "" && namespace != clusterFreezeNamespace {
+		candidates = append(candidates, namespace)
+	}
+
+	for _, name := range candidates {
+		ns, err := clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return FreezeStatus{Frozen: true, Reason: fmt.Sprintf("could not check freeze status on namespace %s: %v", name, err)}
+		}
+
+		if ns.Annotations[FreezeAnnotation] == "true" {
+			scope := "namespace " + name
+			if name == clusterFreezeNamespace {
+				scope = "cluster (via " + clusterFreezeNamespace + ")"
+			}
+			return FreezeStatus{Frozen: true, Reason: fmt.Sprintf("%s has %s=true", scope, FreezeAnnotation)}
+		}
+	}
+
+	return FreezeStatus{}
+}
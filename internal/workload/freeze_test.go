@@ -0,0 +1,66 @@
+package workload
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func namespaceWithAnnotations(name string, annotations map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations}}
+}
+
+func TestCheckFreezeAnnotations(t *testing.T) {
+	frozen := map[string]string{FreezeAnnotation: "true"}
+
+	tests := []struct {
+		name       string
+		namespaces []*corev1.Namespace
+		target     string
+		wantFrozen bool
+	}{
+		{
+			name:       "no annotations",
+			namespaces: []*corev1.Namespace{namespaceWithAnnotations("kube-system", nil), namespaceWithAnnotations("app", nil)},
+			target:     "app",
+			wantFrozen: false,
+		},
+		{
+			name:       "cluster-wide freeze",
+			namespaces: []*corev1.Namespace{namespaceWithAnnotations("kube-system", frozen), namespaceWithAnnotations("app", nil)},
+			target:     "app",
+			wantFrozen: true,
+		},
+		{
+			name:       "namespace freeze",
+			namespaces: []*corev1.Namespace{namespaceWithAnnotations("kube-system", nil), namespaceWithAnnotations("app", frozen)},
+			target:     "app",
+			wantFrozen: true,
+		},
+		{
+			name:       "other namespace frozen",
+			namespaces: []*corev1.Namespace{namespaceWithAnnotations("kube-system", nil), namespaceWithAnnotations("other", frozen)},
+			target:     "app",
+			wantFrozen: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset()
+			for _, ns := range tt.namespaces {
+				if _, err := clientset.CoreV1().Namespaces().Create(context.Background(), ns, metav1.CreateOptions{}); err != nil {
+					t.Fatalf("Failed to create namespace: %v", err)
+				}
+			}
+
+			status := checkFreezeAnnotations(context.Background(), clientset, tt.target)
+			if status.Frozen != tt.wantFrozen {
+				t.Errorf("Expected frozen=%v, got %v (%s)", tt.wantFrozen, status.Frozen, status.Reason)
+			}
+		})
+	}
+}
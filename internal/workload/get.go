@@ -0,0 +1,288 @@
+package workload
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
+)
+
+// tableAcceptHeader asks for the server-side printed form of a list, the same
+// way kubectl does, falling back to plain JSON on servers that can't print
+const tableAcceptHeader = "application/json;as=Table;v=v1;g=meta.k8s.io,application/json"
+
+// noneCell is how kubectl shows an empty cell
+const noneCell = "<none>"
+
+// GetOptions narrows a get to a namespace and selection of objects
+type GetOptions struct {
+	Namespace     string // Empty means all namespaces
+	LabelSelector string
+	FieldSelector string
+	Wide          bool // Include the columns kubectl only shows with -o wide
+}
+
+// ResourceTable is one cluster's answer to a get, as its API server printed it
+// This is what lets mcm show any resource - CRDs included - exactly as kubectl
+// would, without knowing anything about its columns
+type ResourceTable struct {
+	ClusterName string
+	Namespaced  bool
+	Columns     []string
+	Rows        []ResourceRow
+
+	// ServerPrinted is false when the server couldn't print a table (some
+	// aggregated APIs can't) and the columns were filled in as NAME and AGE
+	ServerPrinted bool
+}
+
+// ResourceRow is one printed object
+type ResourceRow struct {
+	Namespace string
+	Name      string
+	Cells     []string
+}
+
+// ClusterAnnotation names the cluster an object was fetched from in
+// --output=object-yaml, so a multi-cluster stream of manifests stays traceable
+const ClusterAnnotation = "mcm.io/cluster"
+
+// ClusterObject is one object returned by a get, with the cluster it came from
+type ClusterObject struct {
+	ClusterName string                 `json:"cluster"`
+	Object      map[string]interface{} `json:"object"`
+}
+
+// GetTables fetches a resource from every cluster as server-side printed tables
+// name narrows it to one object; resource is anything kubectl get accepts
+func (m *Manager) GetTables(clusterNames []string, resource, name string, opts GetOptions) FleetResult[ResourceTable] {
+	clusterNames = m.connectedClusters(clusterNames)
+
+	return fanOut(clusterNames, m.timeouts, func(ctx context.Context, clusterName string) ([]ResourceTable, error) {
+		var table *ResourceTable
+		err := m.getResource(ctx, clusterName, resource, name, opts, true, func(data []byte, mapping *meta.RESTMapping) error {
+			var parseErr error
+			table, parseErr = parseResourceTable(data, opts.Wide)
+			if parseErr != nil {
+				return parseErr
+			}
+			table.ClusterName = clusterName
+			table.Namespaced = mapping.Scope.Name() == meta.RESTScopeNameNamespace
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return []ResourceTable{*table}, nil
+	})
+}
+
+// GetObjects fetches a resource from every cluster as full objects, for JSON and YAML output
+func (m *Manager) GetObjects(clusterNames []string, resource, name string, opts GetOptions) FleetResult[ClusterObject] {
+	clusterNames = m.connectedClusters(clusterNames)
+
+	return fanOut(clusterNames, m.timeouts, func(ctx context.Context, clusterName string) ([]ClusterObject, error) {
+		var objects []ClusterObject
+		err := m.getResource(ctx, clusterName, resource, name, opts, false, func(data []byte, _ *meta.RESTMapping) error {
+			items, parseErr := parseObjects(data)
+			if parseErr != nil {
+				return parseErr
+			}
+			objects = make([]ClusterObject, 0, len(items))
+			for _, item := range items {
+				objects = append(objects, ClusterObject{ClusterName: clusterName, Object: item.Object})
+			}
+			return nil
+		})
+		return objects, err
+	})
+}
+
+// getResource resolves the resource on one cluster and hands the raw response to parse
+func (m *Manager) getResource(ctx context.Context, clusterName, resource, name string, opts GetOptions, asTable bool,
+	parse func(data []byte, mapping *meta.RESTMapping) error) error {
+
+	client, err := m.clusterManager.GetClient(clusterName)
+	if err != nil {
+		return err
+	}
+	resolver, err := m.newKindResolver(clusterName)
+	if err != nil {
+		return err
+	}
+	mapping, err := resolver.ResourceFor(resource, client.Clientset.Discovery())
+	if err != nil {
+		return fmt.Errorf("cannot resolve resource %q: %w", resource, err)
+	}
+
+	namespace := opts.Namespace
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		namespace = ""
+	} else if name != "" && namespace == "" {
+		return fmt.Errorf("getting %s %q by name needs a namespace", mapping.Resource.Resource, name)
+	}
+
+	restClient := client.Clientset.Discovery().RESTClient()
+	return client.Do(ctx, func(ctx context.Context) error {
+		data, err := fetchResource(ctx, restClient, resourcePath(mapping, namespace, name), opts, asTable)
+		if err != nil {
+			return err
+		}
+		return parse(data, mapping)
+	})
+}
+
+// resourcePath builds the API path of a resource collection, or of one object in it
+func resourcePath(mapping *meta.RESTMapping, namespace, name string) string {
+	gvr := mapping.Resource
+	segments := []string{"/api", gvr.Version}
+	if gvr.Group != "" {
+		segments = []string{"/apis", gvr.Group, gvr.Version}
+	}
+	if namespace != "" {
+		segments = append(segments, "namespaces", namespace)
+	}
+	segments = append(segments, gvr.Resource)
+	if name != "" {
+		segments = append(segments, name)
+	}
+	return path.Join(segments...)
+}
+
+// fetchResource GETs a resource path, as a table when asTable is set
+func fetchResource(ctx context.Context, restClient rest.Interface, resourcePath string, opts GetOptions, asTable bool) ([]byte, error) {
+	request := restClient.Get().AbsPath(resourcePath)
+	if opts.LabelSelector != "" {
+		request = request.Param("labelSelector", opts.LabelSelector)
+	}
+	if opts.FieldSelector != "" {
+		request = request.Param("fieldSelector", opts.FieldSelector)
+	}
+	if asTable {
+		request = request.SetHeader("Accept", tableAcceptHeader)
+	}
+	return request.Do(ctx).Raw()
+}
+
+// parseResourceTable reads a server-printed table, or builds a NAME/AGE one
+// from a plain list or object when the server didn't print one
+// Columns kubectl hides without -o wide (priority above 0) are dropped unless wide is set
+func parseResourceTable(data []byte, wide bool) (*ResourceTable, error) {
+	var probe metav1.TypeMeta
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("failed to decode the response: %w", err)
+	}
+	if probe.Kind != "Table" {
+		return fallbackTable(data)
+	}
+
+	var table metav1.Table
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("failed to decode the table: %w", err)
+	}
+
+	var keep []int
+	result := &ResourceTable{ServerPrinted: true, Rows: make([]ResourceRow, 0, len(table.Rows))}
+	for i, column := range table.ColumnDefinitions {
+		if column.Priority == 0 || wide {
+			keep = append(keep, i)
+			result.Columns = append(result.Columns, column.Name)
+		}
+	}
+
+	for _, row := range table.Rows {
+		printed := ResourceRow{Cells: make([]string, 0, len(keep))}
+		for _, i := range keep {
+			var cell interface{}
+			if i < len(row.Cells) {
+				cell = row.Cells[i]
+			}
+			printed.Cells = append(printed.Cells, formatCell(cell))
+		}
+
+		// The row's object is its metadata by default; it names the row for -o name
+		var object metav1.PartialObjectMetadata
+		if len(row.Object.Raw) > 0 && json.Unmarshal(row.Object.Raw, &object) == nil {
+			printed.Namespace = object.Namespace
+			printed.Name = object.Name
+		}
+		result.Rows = append(result.Rows, printed)
+	}
+	return result, nil
+}
+
+// fallbackTable prints NAME and AGE for each object, which is what the API
+// server itself prints for resources that define no columns
+func fallbackTable(data []byte) (*ResourceTable, error) {
+	items, err := parseObjects(data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ResourceTable{Columns: []string{"NAME", "AGE"}, Rows: make([]ResourceRow, 0, len(items))}
+	for _, item := range items {
+		age := noneCell
+		if created := item.GetCreationTimestamp(); !created.IsZero() {
+			age = formatDuration(time.Since(created.Time))
+		}
+		result.Rows = append(result.Rows, ResourceRow{
+			Namespace: item.GetNamespace(),
+			Name:      item.GetName(),
+			Cells:     []string{item.GetName(), age},
+		})
+	}
+	return result, nil
+}
+
+// parseObjects decodes a list (or a single object) into its items
+func parseObjects(data []byte) ([]unstructured.Unstructured, error) {
+	var obj unstructured.Unstructured
+	if err := obj.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("failed to decode the response: %w", err)
+	}
+	if !obj.IsList() {
+		return []unstructured.Unstructured{obj}, nil
+	}
+	list, err := obj.ToList()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the list: %w", err)
+	}
+
+	// The API server leaves kind and apiVersion off the items of a typed list
+	// (a PodList's pods); put them back so every item stands on its own
+	itemKind := strings.TrimSuffix(obj.GetKind(), "List")
+	for i := range list.Items {
+		if list.Items[i].GetKind() == "" {
+			list.Items[i].SetKind(itemKind)
+		}
+		if list.Items[i].GetAPIVersion() == "" {
+			list.Items[i].SetAPIVersion(obj.GetAPIVersion())
+		}
+	}
+	return list.Items, nil
+}
+
+// formatCell renders a table cell the way kubectl does
+func formatCell(cell interface{}) string {
+	switch value := cell.(type) {
+	case nil:
+		return noneCell
+	case string:
+		return value
+	case float64:
+		// JSON numbers decode as float64; integer columns must not print as 3e+00
+		if value == float64(int64(value)) {
+			return fmt.Sprintf("%d", int64(value))
+		}
+		return fmt.Sprintf("%v", value)
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
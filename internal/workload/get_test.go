@@ -0,0 +1,147 @@
+package workload
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+// ingressTable is what an API server returns for a table request on ingresses
+const ingressTable = `{
+  "kind": "Table", "apiVersion": "meta.k8s.io/v1",
+  "columnDefinitions": [
+    {"name": "Name", "type": "string", "priority": 0},
+    {"name": "Class", "type": "string", "priority": 0},
+    {"name": "Ports", "type": "string", "priority": 0},
+    {"name": "Rules", "type": "integer", "priority": 1}
+  ],
+  "rows": [
+    {"cells": ["web", null, "80, 443", 3],
+     "object": {"kind": "PartialObjectMetadata", "apiVersion": "meta.k8s.io/v1", "metadata": {"name": "web", "namespace": "prod"}}}
+  ]
+}`
+
+// widgetList is a plain list, as returned by servers that can't print tables
+const widgetList = `{
+  "kind": "WidgetList", "apiVersion": "example.com/v1",
+  "items": [
+    {"kind": "Widget", "apiVersion": "example.com/v1", "metadata": {"name": "gear", "namespace": "prod", "creationTimestamp": null}}
+  ]
+}`
+
+// getTestProvider serves one cluster backed by a test API server that knows
+// ingresses (as a table) and widgets (as a plain list)
+func getTestProvider(t *testing.T) (*mapperProvider, *[]*http.Request) {
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/apis/networking.k8s.io/v1/namespaces/prod/ingresses":
+			requests = append(requests, r)
+			w.Write([]byte(ingressTable))
+		case "/apis/example.com/v1/namespaces/prod/widgets":
+			requests = append(requests, r)
+			w.Write([]byte(widgetList))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	clientset, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapper := mapperWith(
+		schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"},
+		schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"},
+	)
+	provider := &mapperProvider{
+		fakeProvider: fakeProvider{clients: map[string]*cluster.ClusterClient{
+			"prod-us": {Config: config.ClusterConfig{Name: "prod-us"}, Clientset: clientset, Connected: true},
+		}},
+		mappers: []meta.RESTMapper{mapper},
+	}
+	return provider, &requests
+}
+
+func TestGetTablesUsesServerColumns(t *testing.T) {
+	provider, requests := getTestProvider(t)
+	manager := NewManager(provider)
+
+	result := manager.GetTables(nil, "Ingress", "", GetOptions{Namespace: "prod", LabelSelector: "app=web"})
+	if len(result.Errors) != 0 || len(result.Items) != 1 {
+		t.Fatalf("GetTables = %+v", result)
+	}
+	table := result.Items[0]
+	if !table.ServerPrinted || !table.Namespaced || table.ClusterName != "prod-us" {
+		t.Errorf("Unexpected table %+v", table)
+	}
+	if strings.Join(table.Columns, ",") != "Name,Class,Ports" {
+		t.Errorf("columns = %v, want the priority 0 columns only", table.Columns)
+	}
+	row := table.Rows[0]
+	if strings.Join(row.Cells, "|") != "web|<none>|80, 443" || row.Name != "web" || row.Namespace != "prod" {
+		t.Errorf("Unexpected row %+v", row)
+	}
+
+	request := (*requests)[0]
+	if !strings.Contains(request.Header.Get("Accept"), "as=Table") {
+		t.Errorf("Accept = %q, want a table request", request.Header.Get("Accept"))
+	}
+	if request.URL.Query().Get("labelSelector") != "app=web" {
+		t.Errorf("query = %q, want the label selector passed through", request.URL.RawQuery)
+	}
+
+	wide := manager.GetTables(nil, "ingresses", "", GetOptions{Namespace: "prod", Wide: true})
+	if len(wide.Items) != 1 || len(wide.Items[0].Columns) != 4 || wide.Items[0].Rows[0].Cells[3] != "3" {
+		t.Errorf("Expected --wide to add the Rules column, got %+v", wide)
+	}
+}
+
+func TestGetTablesFallsBackToNameAndAge(t *testing.T) {
+	provider, _ := getTestProvider(t)
+
+	result := NewManager(provider).GetTables(nil, "widgets.example.com", "", GetOptions{Namespace: "prod"})
+	if len(result.Errors) != 0 || len(result.Items) != 1 {
+		t.Fatalf("GetTables = %+v", result)
+	}
+	table := result.Items[0]
+	if table.ServerPrinted || strings.Join(table.Columns, ",") != "NAME,AGE" {
+		t.Errorf("Expected a NAME/AGE fallback, got %+v", table)
+	}
+	if len(table.Rows) != 1 || table.Rows[0].Cells[0] != "gear" || table.Rows[0].Cells[1] != "<none>" {
+		t.Errorf("Unexpected rows %+v", table.Rows)
+	}
+}
+
+func TestGetReportsUnknownResources(t *testing.T) {
+	provider, requests := getTestProvider(t)
+
+	result := NewManager(provider).GetObjects(nil, "gadgets", "", GetOptions{Namespace: "prod"})
+	if err := result.Errors["prod-us"]; err == nil || !strings.Contains(err.Error(), `"gadgets"`) {
+		t.Fatalf("Expected an unresolved resource error, got %+v", result)
+	}
+	if len(*requests) != 0 {
+		t.Error("Nothing should be fetched for a resource the cluster doesn't serve")
+	}
+}
+
+func TestParseObjectsFillsInItemKinds(t *testing.T) {
+	items, err := parseObjects([]byte(`{"kind": "PodList", "apiVersion": "v1", "items": [{"metadata": {"name": "web-1"}}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].GetKind() != "Pod" || items[0].GetAPIVersion() != "v1" {
+		t.Errorf("Expected the item to be a v1 Pod, got %+v", items)
+	}
+}
@@ -0,0 +1,173 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// inventoryPageSize is how many objects one list call fetches while counting
+// Small enough that a cluster with tens of thousands of pods is never held in
+// memory at once, large enough that most clusters answer in a page or two
+const inventoryPageSize = 500
+
+// ResourceCounts is how much one cluster runs, for capacity reviews and audits
+type ResourceCounts struct {
+	ClusterName string `json:"clusterName,omitempty"` // Empty in fleet totals
+	Nodes       int    `json:"nodes"`
+	Namespaces  int    `json:"namespaces"`
+	Deployments int    `json:"deployments"`
+	Services    int    `json:"services"`
+	Pods        int    `json:"pods"`
+
+	// PodPhases breaks Pods down by phase; phases with no pods are left out
+	PodPhases map[corev1.PodPhase]int `json:"podPhases,omitempty"`
+}
+
+// Add folds another cluster's counts into these, for fleet totals
+func (c *ResourceCounts) Add(other ResourceCounts) {
+	c.Nodes += other.Nodes
+	c.Namespaces += other.Namespaces
+	c.Deployments += other.Deployments
+	c.Services += other.Services
+	c.Pods += other.Pods
+	for phase, count := range other.PodPhases {
+		if c.PodPhases == nil {
+			c.PodPhases = make(map[corev1.PodPhase]int)
+		}
+		c.PodPhases[phase] += count
+	}
+}
+
+// CountResources counts each cluster's nodes, namespaces, deployments, services
+// and pods (by phase). An empty namespace counts namespaced resources across the
+// whole cluster. Lists are read a page at a time, and when the API server says
+// how many items remain after the first page, that's used instead of fetching
+// them - so even a big fleet is counted quickly without loading it into memory.
+// Results are sorted by cluster name
+func (m *Manager) CountResources(clusterNames []string, namespace string) FleetResult[ResourceCounts] {
+	clusterNames = m.connectedClusters(clusterNames)
+
+	result := fanOut(clusterNames, m.timeouts, func(ctx context.Context, name string) ([]ResourceCounts, error) {
+		client, err := m.clusterManager.GetClient(name)
+		if err != nil {
+			return nil, err
+		}
+
+		var counts ResourceCounts
+		err = client.Do(ctx, func(ctx context.Context) error {
+			var countErr error
+			counts, countErr = countClusterResources(ctx, client.Clientset, namespace)
+			return countErr
+		})
+		if err != nil {
+			return nil, err
+		}
+		counts.ClusterName = name
+		return []ResourceCounts{counts}, nil
+	})
+
+	// One row per cluster, so keep them in a stable order
+	sort.Slice(result.Items, func(i, j int) bool {
+		return result.Items[i].ClusterName < result.Items[j].ClusterName
+	})
+	return result
+}
+
+// countClusterResources does one cluster's counting
+func countClusterResources(ctx context.Context, clientset kubernetes.Interface, namespace string) (ResourceCounts, error) {
+	var counts ResourceCounts
+	var err error
+
+	if counts.Nodes, err = countPages(ctx, "nodes", func(ctx context.Context, opts metav1.ListOptions) (metav1.ListInterface, int, error) {
+		list, err := clientset.CoreV1().Nodes().List(ctx, opts)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list, len(list.Items), nil
+	}); err != nil {
+		return counts, err
+	}
+
+	if counts.Namespaces, err = countPages(ctx, "namespaces", func(ctx context.Context, opts metav1.ListOptions) (metav1.ListInterface, int, error) {
+		list, err := clientset.CoreV1().Namespaces().List(ctx, opts)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list, len(list.Items), nil
+	}); err != nil {
+		return counts, err
+	}
+
+	if counts.Deployments, err = countPages(ctx, "deployments", func(ctx context.Context, opts metav1.ListOptions) (metav1.ListInterface, int, error) {
+		list, err := clientset.AppsV1().Deployments(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list, len(list.Items), nil
+	}); err != nil {
+		return counts, err
+	}
+
+	if counts.Services, err = countPages(ctx, "services", func(ctx context.Context, opts metav1.ListOptions) (metav1.ListInterface, int, error) {
+		list, err := clientset.CoreV1().Services(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list, len(list.Items), nil
+	}); err != nil {
+		return counts, err
+	}
+
+	// Phases live in each pod's status, so pods are always read in full pages
+	opts := metav1.ListOptions{Limit: inventoryPageSize}
+	for {
+		list, err := clientset.CoreV1().Pods(namespace).List(ctx, opts)
+		if err != nil {
+			return counts, fmt.Errorf("failed to count pods: %w", err)
+		}
+		for _, pod := range list.Items {
+			phase := pod.Status.Phase
+			if phase == "" {
+				phase = corev1.PodUnknown
+			}
+			if counts.PodPhases == nil {
+				counts.PodPhases = make(map[corev1.PodPhase]int)
+			}
+			counts.PodPhases[phase]++
+			counts.Pods++
+		}
+		if list.Continue == "" {
+			return counts, nil
+		}
+		opts.Continue = list.Continue
+	}
+}
+
+// countPages counts a list's items page by page. When the first page carries a
+// remaining item count, it's trusted rather than reading the rest of the list
+func countPages(ctx context.Context, what string,
+	list func(ctx context.Context, opts metav1.ListOptions) (metav1.ListInterface, int, error)) (int, error) {
+
+	total := 0
+	opts := metav1.ListOptions{Limit: inventoryPageSize}
+	for {
+		page, items, err := list(ctx, opts)
+		if err != nil {
+			return 0, fmt.Errorf("failed to count %s: %w", what, err)
+		}
+		total += items
+
+		if page.GetContinue() == "" {
+			return total, nil
+		}
+		if remaining := page.GetRemainingItemCount(); remaining != nil && opts.Continue == "" {
+			return total + int(*remaining), nil
+		}
+		opts.Continue = page.GetContinue()
+	}
+}
@@ -0,0 +1,92 @@
+package workload
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+func TestCountResources(t *testing.T) {
+	pod := func(namespace, name string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}, Status: corev1.PodStatus{Phase: phase}}
+	}
+	clientset := fake.NewClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web"}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web"}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "kubernetes"}},
+		pod("shop", "web-1", corev1.PodRunning),
+		pod("shop", "web-2", corev1.PodPending),
+		pod("default", "job-1", corev1.PodSucceeded),
+	)
+	provider := &fakeProvider{clients: map[string]*cluster.ClusterClient{
+		"prod": {Config: config.ClusterConfig{Name: "prod"}, Clientset: clientset, Connected: true},
+	}}
+	manager := NewManager(provider)
+
+	result := manager.CountResources([]string{"prod", "missing"}, "")
+	if len(result.Items) != 1 || len(result.Errors) != 1 {
+		t.Fatalf("Expected one counted and one failed cluster, got %+v", result)
+	}
+	got := result.Items[0]
+	if got.ClusterName != "prod" || got.Nodes != 2 || got.Namespaces != 2 || got.Deployments != 1 || got.Services != 2 || got.Pods != 3 {
+		t.Errorf("Unexpected counts %+v", got)
+	}
+	if got.PodPhases[corev1.PodRunning] != 1 || got.PodPhases[corev1.PodPending] != 1 || got.PodPhases[corev1.PodSucceeded] != 1 {
+		t.Errorf("Unexpected pod phases %v", got.PodPhases)
+	}
+
+	// Namespaced kinds follow the namespace; nodes and namespaces never do
+	shop := manager.CountResources([]string{"prod"}, "shop").Items[0]
+	if shop.Nodes != 2 || shop.Namespaces != 2 || shop.Services != 1 || shop.Pods != 2 {
+		t.Errorf("Unexpected counts in namespace shop %+v", shop)
+	}
+
+	var total ResourceCounts
+	total.Add(got)
+	total.Add(shop)
+	if total.Pods != 5 || total.PodPhases[corev1.PodRunning] != 2 {
+		t.Errorf("Unexpected fleet total %+v", total)
+	}
+}
+
+func TestCountPagesTrustsRemainingItemCount(t *testing.T) {
+	remaining := int64(1200)
+	calls := 0
+	count, err := countPages(context.Background(), "pods", func(ctx context.Context, opts metav1.ListOptions) (metav1.ListInterface, int, error) {
+		calls++
+		if opts.Limit != inventoryPageSize {
+			t.Errorf("Expected page size %d, got %d", inventoryPageSize, opts.Limit)
+		}
+		return &metav1.ListMeta{Continue: "next", RemainingItemCount: &remaining}, inventoryPageSize, nil
+	})
+	if err != nil || count != inventoryPageSize+1200 || calls != 1 {
+		t.Errorf("Expected %d from one call, got %d from %d (err %v)", inventoryPageSize+1200, count, calls, err)
+	}
+}
+
+func TestCountPagesFollowsContinueTokens(t *testing.T) {
+	pages := []string{"page-2", "page-3", ""}
+	var tokens []string
+	count, err := countPages(context.Background(), "pods", func(ctx context.Context, opts metav1.ListOptions) (metav1.ListInterface, int, error) {
+		tokens = append(tokens, opts.Continue)
+		next := pages[len(tokens)-1]
+		return &metav1.ListMeta{Continue: next}, 10, nil
+	})
+	if err != nil || count != 30 {
+		t.Errorf("Expected 30 over three pages, got %d (err %v)", count, err)
+	}
+	if len(tokens) != 3 || tokens[0] != "" || tokens[1] != "page-2" || tokens[2] != "page-3" {
+		t.Errorf("Expected each page to continue from the last, got %q", tokens)
+	}
+}
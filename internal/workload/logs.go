@@ -0,0 +1,347 @@
+package workload
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+)
+
+// LogDumpOptions controls which logs are captured and where they go
+type LogDumpOptions struct {
+	Dir         string        // Root directory; files land in <Dir>/<cluster>/<namespace>/<pod>.log
+	Since       time.Duration // Only logs newer than this (0 = everything the kubelet kept)
+	Previous    bool          // Logs of the previous container instance, e.g. after a crash
+	Concurrency int           // Max log downloads in flight across the whole fleet
+}
+
+// LogDumpResult summarizes the log capture for one cluster
+type LogDumpResult struct {
+	ClusterName string   `json:"clusterName"`
+	Captured    int      `json:"captured"`
+	Failed      int      `json:"failed"`
+	Errors      []string `json:"errors,omitempty"`
+}
+
+// podLogTarget is a single pod whose logs should be written to disk
+type podLogTarget struct {
+	clientset   kubernetes.Interface
+	clusterName string
+	pod         corev1.Pod
+}
+
+// DumpPodLogs writes the logs of every pod matching the selector to one file per pod
+// This is like pulling the black boxes from every aircraft involved in an incident:
+// each cluster gets its own folder, so evidence from different regions never mixes
+func (m *Manager) DumpPodLogs(clusterNames []string, namespace, labelSelector string, opts LogDumpOptions) []LogDumpResult {
+	if len(clusterNames) == 0 {
+		for _, status := range m.clusterManager.ListClusters() {
+			if status.Connected {
+				clusterNames = append(clusterNames, status.Name)
+			}
+		}
+	}
+
+	results := make(map[string]*LogDumpResult, len(clusterNames))
+	for _, clusterName := range clusterNames {
+		results[clusterName] = &LogDumpResult{ClusterName: clusterName}
+	}
+
+	// Find the matching pods in every cluster first, in parallel
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	var targets []podLogTarget
+
+	for _, clusterName := range clusterNames {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			found, err := m.findLogTargets(name, namespace, labelSelector)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				results[name].Errors = append(results[name].Errors, err.Error())
+				return
+			}
+			targets = append(targets, found...)
+		}(clusterName)
+	}
+	wg.Wait()
+
+	// Then download logs with a fleet-wide cap, so a large selector doesn't
+	// open hundreds of streams against the API servers at once
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	slots := make(chan struct{}, concurrency)
+
+	for _, target := range targets {
+		wg.Add(1)
+		go func(target podLogTarget) {
+			defer wg.Done()
+			slots <- struct{}{}
+			err := dumpPodLog(target.clientset, target.clusterName, target.pod, opts)
+			<-slots
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			result := results[target.clusterName]
+			if err != nil {
+				result.Failed++
+				result.Errors = append(result.Errors, fmt.Sprintf("%s/%s: %v", target.pod.Namespace, target.pod.Name, err))
+				return
+			}
+			result.Captured++
+		}(target)
+	}
+	wg.Wait()
+
+	summary := make([]LogDumpResult, 0, len(results))
+	for _, result := range results {
+		sort.Strings(result.Errors)
+		summary = append(summary, *result)
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		return summary[i].ClusterName < summary[j].ClusterName
+	})
+	return summary
+}
+
+// findLogTargets lists the pods in one cluster whose logs should be captured
+func (m *Manager) findLogTargets(clusterName, namespace, labelSelector string) ([]podLogTarget, error) {
+	client, err := m.clusterManager.GetClient(clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster client: %w", err)
+	}
+
+	var pods *corev1.PodList
+	err = client.Do(context.Background(), func(ctx context.Context) error {
+		var listErr error
+		pods, listErr = client.Clientset.CoreV1().Pods(namespace).List(ctx, podListOptions(labelSelector, ""))
+		return listErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	targets := make([]podLogTarget, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		targets = append(targets, podLogTarget{clientset: client.Clientset, clusterName: clusterName, pod: pod})
+	}
+	return targets, nil
+}
+
+// dumpPodLog streams every container's logs of one pod into <dir>/<cluster>/<namespace>/<pod>.log
+// Multi-container pods get a header before each container's section
+func dumpPodLog(clientset kubernetes.Interface, clusterName string, pod corev1.Pod, opts LogDumpOptions) error {
+	dir := filepath.Join(opts.Dir, clusterName, pod.Namespace)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	file, err := os.Create(filepath.Join(dir, pod.Name+".log"))
+	if err != nil {
+		return fmt.Errorf("failed to create log file: %w", err)
+	}
+	defer file.Close()
+
+	logOptions := &corev1.PodLogOptions{Previous: opts.Previous}
+	if opts.Since > 0 {
+		seconds := int64(opts.Since.Seconds())
+		logOptions.SinceSeconds = &seconds
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	for _, container := range pod.Spec.Containers {
+		if len(pod.Spec.Containers) > 1 {
+			fmt.Fprintf(file, "==> container %s <==\n", container.Name)
+		}
+
+		logOptions.Container = container.Name
+		stream, err := clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, logOptions).Stream(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get logs for container %s: %w", container.Name, err)
+		}
+		_, err = io.Copy(file, stream)
+		stream.Close()
+		if err != nil {
+			return fmt.Errorf("failed to write logs for container %s: %w", container.Name, err)
+		}
+	}
+
+	return file.Sync()
+}
+
+// PodLogOptions controls which logs GetPodLogs returns
+type PodLogOptions struct {
+	PodName   string        // Only this pod (alternative to a label selector)
+	Container string        // Only this container; pods without it are skipped (empty = every container)
+	Since     time.Duration // Only logs newer than this (0 = everything the kubelet kept)
+	Tail      int64         // Only the last N lines of each container (negative = all)
+	Follow    bool          // Keep streaming new lines until the context is cancelled
+}
+
+// LogLine is one line of a pod's logs, or the error that ended a stream early
+type LogLine struct {
+	ClusterName string
+	Namespace   string
+	Pod         string
+	Container   string
+	Text        string // Without the trailing newline
+	Err         error  // Set instead of Text when a cluster or stream failed
+
+	multiContainer bool // The pod has several containers, so Prefix names this one
+}
+
+// Prefix labels the line with where it came from, e.g. "[prod-eu/web/web-1]"
+// The container is only named for pods that have more than one
+func (l LogLine) Prefix() string {
+	if l.Pod == "" {
+		return fmt.Sprintf("[%s]", l.ClusterName)
+	}
+	if l.multiContainer {
+		return fmt.Sprintf("[%s/%s/%s/%s]", l.ClusterName, l.Namespace, l.Pod, l.Container)
+	}
+	return fmt.Sprintf("[%s/%s/%s]", l.ClusterName, l.Namespace, l.Pod)
+}
+
+// GetPodLogs streams the logs of every matching pod in every cluster into one channel
+// Each container gets its own goroutine, so a chatty pod never holds up a quiet
+// one; lines from the same container stay in order. The channel is closed once
+// every stream has ended - with Follow, that's when ctx is cancelled
+func (m *Manager) GetPodLogs(ctx context.Context, clusterNames []string, namespace, labelSelector string, opts PodLogOptions) <-chan LogLine {
+	clusterNames = m.connectedClusters(clusterNames)
+	lines := make(chan LogLine)
+
+	var wg sync.WaitGroup
+	for _, clusterName := range clusterNames {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			m.streamClusterLogs(ctx, name, namespace, labelSelector, opts, lines, &wg)
+		}(clusterName)
+	}
+
+	go func() {
+		wg.Wait()
+		close(lines)
+	}()
+	return lines
+}
+
+// streamClusterLogs finds the matching pods in one cluster and starts a stream
+// per container, adding each to wg before this cluster's own slot is released
+func (m *Manager) streamClusterLogs(ctx context.Context, clusterName, namespace, labelSelector string, opts PodLogOptions,
+	lines chan<- LogLine, wg *sync.WaitGroup) {
+
+	fail := func(err error) {
+		select {
+		case lines <- LogLine{ClusterName: clusterName, Namespace: namespace, Err: err}:
+		case <-ctx.Done():
+		}
+	}
+
+	client, err := m.clusterManager.GetClient(clusterName)
+	if err != nil {
+		fail(fmt.Errorf("failed to get cluster client: %w", err))
+		return
+	}
+
+	fieldSelector := ""
+	if opts.PodName != "" {
+		fieldSelector = fields.OneTermEqualSelector("metadata.name", opts.PodName).String()
+	}
+
+	var pods *corev1.PodList
+	err = client.Do(ctx, func(ctx context.Context) error {
+		var listErr error
+		pods, listErr = client.Clientset.CoreV1().Pods(namespace).List(ctx, podListOptions(labelSelector, fieldSelector))
+		return listErr
+	})
+	if err != nil {
+		fail(fmt.Errorf("failed to list pods: %w", err))
+		return
+	}
+
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			if opts.Container != "" && container.Name != opts.Container {
+				continue
+			}
+			wg.Add(1)
+			go func(pod corev1.Pod, container string) {
+				defer wg.Done()
+				streamContainerLogs(ctx, client.Clientset, clusterName, pod, container, opts, lines)
+			}(pod, container.Name)
+		}
+	}
+}
+
+// streamContainerLogs sends one container's logs to lines, line by line
+func streamContainerLogs(ctx context.Context, clientset kubernetes.Interface, clusterName string, pod corev1.Pod, container string,
+	opts PodLogOptions, lines chan<- LogLine) {
+
+	line := LogLine{ClusterName: clusterName, Namespace: pod.Namespace, Pod: pod.Name, Container: container,
+		multiContainer: len(pod.Spec.Containers) > 1}
+	send := func(line LogLine) bool {
+		select {
+		case lines <- line:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	logOptions := &corev1.PodLogOptions{Container: container, Follow: opts.Follow}
+	if opts.Since > 0 {
+		seconds := int64(opts.Since.Seconds())
+		logOptions.SinceSeconds = &seconds
+	}
+	if opts.Tail >= 0 {
+		tail := opts.Tail
+		logOptions.TailLines = &tail
+	}
+
+	stream, err := clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, logOptions).Stream(ctx)
+	if err != nil {
+		line.Err = fmt.Errorf("failed to get logs: %w", err)
+		send(line)
+		return
+	}
+	defer stream.Close()
+
+	// A Reader rather than a Scanner: a single huge log line must not end the stream
+	reader := bufio.NewReader(stream)
+	for {
+		text, err := reader.ReadString('\n')
+		if text != "" {
+			line.Text = strings.TrimSuffix(text, "\n")
+			if !send(line) {
+				return
+			}
+		}
+		if err != nil {
+			// Ctrl-C in follow mode ends every stream; that's not a failure
+			if err != io.EOF && ctx.Err() == nil && !errors.Is(err, context.Canceled) {
+				line.Text, line.Err = "", fmt.Errorf("log stream ended: %w", err)
+				send(line)
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package workload
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+func logPod(name string, appLabel string, containers ...string) *corev1.Pod {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "web", Labels: map[string]string{"app": appLabel}}}
+	for _, container := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: container})
+	}
+	return pod
+}
+
+func TestDumpPodLogs(t *testing.T) {
+	provider := &fakeProvider{clients: map[string]*cluster.ClusterClient{
+		"prod-us": {
+			Config:    config.ClusterConfig{Name: "prod-us"},
+			Clientset: fake.NewSimpleClientset(logPod("web-1", "web", "app"), logPod("web-2", "web", "app", "sidecar"), logPod("db-1", "db", "db")),
+			Connected: true,
+		},
+		"prod-eu": {
+			Config:    config.ClusterConfig{Name: "prod-eu"},
+			Clientset: fake.NewSimpleClientset(logPod("web-1", "web", "app")),
+			Connected: true,
+		},
+	}}
+	manager := NewManager(provider)
+	dir := t.TempDir()
+
+	results := manager.DumpPodLogs(nil, "", "app=web", LogDumpOptions{Dir: dir, Concurrency: 2})
+
+	if len(results) != 2 || results[0].ClusterName != "prod-eu" || results[1].ClusterName != "prod-us" {
+		t.Fatalf("expected one result per cluster sorted by name, got %+v", results)
+	}
+	if results[0].Captured != 1 || results[1].Captured != 2 {
+		t.Errorf("captured = %d/%d, want 1/2", results[0].Captured, results[1].Captured)
+	}
+
+	for _, path := range []string{"prod-us/web/web-1.log", "prod-us/web/web-2.log", "prod-eu/web/web-1.log"} {
+		if _, err := os.Stat(filepath.Join(dir, path)); err != nil {
+			t.Errorf("expected log file %s: %v", path, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "prod-us/web/db-1.log")); !os.IsNotExist(err) {
+		t.Errorf("pod outside the selector should not be dumped")
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "prod-us/web/web-2.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "==> container sidecar <==") {
+		t.Errorf("multi-container log should have per-container headers, got %q", data)
+	}
+}
+
+func TestGetPodLogsPrefixesEveryStream(t *testing.T) {
+	provider := &fakeProvider{clients: map[string]*cluster.ClusterClient{
+		"prod-us": {
+			Config:    config.ClusterConfig{Name: "prod-us"},
+			Clientset: fake.NewSimpleClientset(logPod("web-1", "web", "app"), logPod("web-2", "web", "app", "sidecar"), logPod("db-1", "db", "db")),
+			Connected: true,
+		},
+		"prod-eu": {
+			Config:    config.ClusterConfig{Name: "prod-eu"},
+			Clientset: fake.NewSimpleClientset(logPod("web-1", "web", "app")),
+			Connected: true,
+		},
+	}}
+	manager := NewManager(provider)
+
+	var prefixes []string
+	for line := range manager.GetPodLogs(context.Background(), nil, "", "app=web", PodLogOptions{Tail: -1}) {
+		if line.Err != nil {
+			t.Fatalf("unexpected error from %s: %v", line.Prefix(), line.Err)
+		}
+		prefixes = append(prefixes, line.Prefix())
+	}
+	sort.Strings(prefixes)
+
+	// The fake clientset answers every log request with a single line
+	want := []string{"[prod-eu/web/web-1]", "[prod-us/web/web-1]", "[prod-us/web/web-2/app]", "[prod-us/web/web-2/sidecar]"}
+	if !reflect.DeepEqual(prefixes, want) {
+		t.Errorf("prefixes = %v, want %v", prefixes, want)
+	}
+}
+
+func TestGetPodLogsOneContainerOfOnePod(t *testing.T) {
+	provider := &fakeProvider{clients: map[string]*cluster.ClusterClient{
+		"prod-us": {
+			Config:    config.ClusterConfig{Name: "prod-us"},
+			Clientset: fake.NewSimpleClientset(logPod("web-1", "web", "app"), logPod("web-2", "web", "app", "sidecar")),
+			Connected: true,
+		},
+	}}
+	manager := NewManager(provider)
+
+	var prefixes []string
+	opts := PodLogOptions{PodName: "web-2", Container: "sidecar", Tail: 10}
+	for line := range manager.GetPodLogs(context.Background(), nil, "web", "", opts) {
+		prefixes = append(prefixes, line.Prefix())
+	}
+	if !reflect.DeepEqual(prefixes, []string{"[prod-us/web/web-2/sidecar]"}) {
+		t.Errorf("prefixes = %v, want only the sidecar of web-2", prefixes)
+	}
+}
@@ -3,31 +3,67 @@ package workload
 import (
 	"context"
 	"fmt"
-	_ "strings"
+	"strings"
 	"sync"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
-	_ "k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/celikgo/autoz-control-tower/internal/cluster"
 )
 
+// ClientProvider hands out connections to the clusters we manage
+// *cluster.Manager is the real implementation; tests and benchmarks can
+// substitute a provider backed by fake clientsets
+type ClientProvider interface {
+	GetClient(clusterName string) (*cluster.ClusterClient, error)
+	ListClusters() []cluster.ClusterStatus
+
+	// RESTMapper returns the cluster's cached kind-to-resource mapper, and
+	// InvalidateRESTMapper forces the next call to rediscover it
+	RESTMapper(clusterName string) (meta.RESTMapper, error)
+	InvalidateRESTMapper(clusterName string)
+}
+
 // Manager handles workload operations across multiple clusters
 // This is like a "universal remote control" for your Kubernetes workloads
 type Manager struct {
-	clusterManager *cluster.Manager
+	clusterManager ClientProvider
+	timeouts       Timeouts // Bounds for fleet-wide list operations
+	listPageSize   int      // Objects per list call when paging; 0 means DefaultListPageSize
 }
 
 // NewManager creates a new workload manager
-func NewManager(clusterManager *cluster.Manager) *Manager {
+func NewManager(clusterManager ClientProvider) *Manager {
 	return &Manager{
 		clusterManager: clusterManager,
 	}
 }
 
+// DefaultListPageSize is how many pods one list call fetches unless
+// listPageSize in the config says otherwise
+const DefaultListPageSize = 500
+
+// SetListPageSize changes how many objects one paged list call fetches
+// 0 restores DefaultListPageSize
+func (m *Manager) SetListPageSize(size int) {
+	m.listPageSize = size
+}
+
+// pageSize is the page size paged lists use
+func (m *Manager) pageSize() int64 {
+	if m.listPageSize > 0 {
+		return int64(m.listPageSize)
+	}
+	return DefaultListPageSize
+}
+
 // DeploymentInfo contains information about a deployment across clusters
 type DeploymentInfo struct {
 	ClusterName   string `json:"clusterName"`
@@ -38,7 +74,6 @@ type DeploymentInfo struct {
 	Image         string `json:"image"`
 	Status        string `json:"status"`
 	Age           string `json:"age"`
-	Error         string `json:"error,omitempty"`
 }
 
 // PodInfo contains information about pods across clusters
@@ -52,300 +87,529 @@ type PodInfo struct {
 	Age         string    `json:"age"`
 	Node        string    `json:"node"`
 	CreatedAt   time.Time `json:"createdAt"`
+
+	// NodeStatus is set by FlagPodsOnBadNodes when the pod's node is NotReady
+	// or Unreachable, and empty otherwise
+	NodeStatus string `json:"nodeStatus,omitempty"`
 }
 
 // ListDeployments retrieves deployments from specified clusters
 // This is like asking "show me all my applications" across multiple data centers
-func (m *Manager) ListDeployments(clusterNames []string, namespace string) ([]DeploymentInfo, error) {
+// An empty labelSelector matches every deployment. Clusters that can't be listed
+// are reported in the result's Errors rather than failing the whole call
+func (m *Manager) ListDeployments(clusterNames []string, namespace, labelSelector string) FleetResult[DeploymentInfo] {
 	// If no clusters specified, use all available clusters
-	if len(clusterNames) == 0 {
-		for _, status := range m.clusterManager.ListClusters() {
-			if status.Connected {
-				clusterNames = append(clusterNames, status.Name)
-			}
-		}
+	clusterNames = m.connectedClusters(clusterNames)
+
+	// Query each cluster in parallel for better performance
+	// Slow clusters are reported as timed out instead of holding up the rest
+	return fanOut(clusterNames, m.timeouts, func(ctx context.Context, name string) ([]DeploymentInfo, error) {
+		return m.getDeploymentsFromCluster(ctx, name, namespace, labelSelector)
+	})
+}
+
+// getDeploymentsFromCluster retrieves deployments from a single cluster
+// This handles the actual Kubernetes API interaction for one cluster
+func (m *Manager) getDeploymentsFromCluster(ctx context.Context, clusterName, namespace, labelSelector string) ([]DeploymentInfo, error) {
+	client, err := m.clusterManager.GetClient(clusterName)
+	if err != nil {
+		return nil, err
 	}
 
-	// Use channels to collect results from multiple clusters in parallel
-	resultChan := make(chan []DeploymentInfo, len(clusterNames))
-	var wg sync.WaitGroup
+	// Get deployments from the Kubernetes API, riding out transient errors
+	var deployments *appsv1.DeploymentList
+	err = client.Do(ctx, func(ctx context.Context) error {
+		var listErr error
+		deployments, listErr = client.Clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+		return listErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
 
-	// Query each cluster in parallel for better performance
-	for _, clusterName := range clusterNames {
-		wg.Add(1)
-		go func(name string) {
-			defer wg.Done()
-			deployments := m.getDeploymentsFromCluster(name, namespace)
-			resultChan <- deployments
-		}(clusterName)
+	result := make([]DeploymentInfo, 0, len(deployments.Items))
+	for _, deployment := range deployments.Items {
+		result = append(result, deploymentInfo(clusterName, deployment))
 	}
 
-	// Wait for all goroutines to complete
-	go func() {
-		wg.Wait()
-		close(resultChan)
-	}()
+	return result, nil
+}
+
+// deploymentInfo summarizes one deployment for listing
+func deploymentInfo(clusterName string, deployment appsv1.Deployment) DeploymentInfo {
+	// Extract the main container image (usually the first container)
+	image := "unknown"
+	if len(deployment.Spec.Template.Spec.Containers) > 0 {
+		image = deployment.Spec.Template.Spec.Containers[0].Image
+	}
 
-	// Collect all results
-	var allDeployments []DeploymentInfo
-	for deployments := range resultChan {
-		allDeployments = append(allDeployments, deployments...)
+	// Spec.Replicas is optional; when it's unset (some HPA-managed deployments)
+	// fall back to the replica count the controller reports
+	replicas := deployment.Status.Replicas
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
 	}
 
-	return allDeployments, nil
+	// Determine deployment status based on replica counts
+	// We explicitly handle all cases to make the logic clear and maintainable
+	var status string
+	if deployment.Status.ReadyReplicas == replicas {
+		status = "Ready"
+	} else if deployment.Status.ReadyReplicas > 0 {
+		status = "Partial"
+	} else if deployment.Status.ReadyReplicas == 0 {
+		status = "NotReady"
+	} else {
+		// This case handles unexpected scenarios (e.g., negative replica counts)
+		// which could indicate API issues or edge cases we haven't considered
+		status = "Unknown"
+	}
+
+	// Calculate age of the deployment
+	age := time.Since(deployment.CreationTimestamp.Time).Round(time.Second)
+
+	return DeploymentInfo{
+		ClusterName:   clusterName,
+		Namespace:     deployment.Namespace,
+		Name:          deployment.Name,
+		Replicas:      replicas,
+		ReadyReplicas: deployment.Status.ReadyReplicas,
+		Image:         image,
+		Status:        status,
+		Age:           formatDuration(age),
+	}
 }
 
-// getDeploymentsFromCluster retrieves deployments from a single cluster
-// This handles the actual Kubernetes API interaction for one cluster
-func (m *Manager) getDeploymentsFromCluster(clusterName, namespace string) []DeploymentInfo {
+// ListPods retrieves pods from specified clusters with optional filtering
+// Label and field selectors are both evaluated by each API server, so only
+// matching pods ever cross the network
+func (m *Manager) ListPods(clusterNames []string, namespace, labelSelector, fieldSelector string) FleetResult[PodInfo] {
+	clusterNames = m.connectedClusters(clusterNames)
+
+	return fanOut(clusterNames, m.timeouts, func(ctx context.Context, name string) ([]PodInfo, error) {
+		return m.getPodsFromCluster(ctx, name, namespace, labelSelector, fieldSelector)
+	})
+}
+
+// getPodsFromCluster retrieves pods from a single cluster
+func (m *Manager) getPodsFromCluster(ctx context.Context, clusterName, namespace, labelSelector, fieldSelector string) ([]PodInfo, error) {
 	client, err := m.clusterManager.GetClient(clusterName)
 	if err != nil {
-		return []DeploymentInfo{{
-			ClusterName: clusterName,
-			Error:       fmt.Sprintf("Failed to get cluster client: %v", err),
-		}}
+		return nil, err
 	}
 
-	// Use a timeout to prevent hanging on slow clusters
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	// Get deployments from the Kubernetes API
-	deployments, err := client.Clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	// Pods are fetched a page at a time, so a cluster with tens of thousands of
+	// them never has more than one page of full pod objects in memory
+	listOptions := podListOptions(labelSelector, fieldSelector)
+	listOptions.Limit = m.pageSize()
+
+	var pods []PodInfo
+	err = client.Do(ctx, func(ctx context.Context) error {
+		var listErr error
+		pods, listErr = listPods(ctx, client.Clientset, clusterName, namespace, listOptions)
+		return listErr
+	})
 	if err != nil {
-		return []DeploymentInfo{{
-			ClusterName: clusterName,
-			Error:       fmt.Sprintf("Failed to list deployments: %v", err),
-		}}
+		return nil, fmt.Errorf("failed to list pods: %w", err)
 	}
 
-	var result []DeploymentInfo
-	for _, deployment := range deployments.Items {
-		// Extract the main container image (usually the first container)
-		image := "unknown"
-		if len(deployment.Spec.Template.Spec.Containers) > 0 {
-			image = deployment.Spec.Template.Spec.Containers[0].Image
+	return pods, nil
+}
+
+// podListOptions combines label and field selectors into a single request
+// so the API server applies both, rather than one of them being ignored
+func podListOptions(labelSelector, fieldSelector string) metav1.ListOptions {
+	return metav1.ListOptions{
+		LabelSelector: labelSelector,
+		FieldSelector: fieldSelector,
+	}
+}
+
+// listPods lists pods through the given clientset and converts them to PodInfo
+// With listOptions.Limit set, the pods are read a page at a time, following the
+// continue token, and each page is converted before the next is fetched. If
+// the token expires mid-way (the API server compacted its history), the
+// listing starts over once, as a consistent snapshot can't be resumed
+func listPods(ctx context.Context, clientset kubernetes.Interface, clusterName, namespace string, listOptions metav1.ListOptions) ([]PodInfo, error) {
+	var result []PodInfo
+	restarted := false
+	for {
+		pods, err := clientset.CoreV1().Pods(namespace).List(ctx, listOptions)
+		if apierrors.IsResourceExpired(err) && listOptions.Continue != "" && !restarted {
+			restarted = true
+			result = nil
+			listOptions.Continue = ""
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if result == nil {
+			result = make([]PodInfo, 0, len(pods.Items))
+		}
+		for _, pod := range pods.Items {
+			result = append(result, podInfo(clusterName, pod))
+		}
+
+		if pods.Continue == "" {
+			return result, nil
 		}
+		listOptions.Continue = pods.Continue
+	}
+}
 
-		// Determine deployment status based on replica counts
-		// We explicitly handle all cases to make the logic clear and maintainable
-		var status string
-		if deployment.Status.ReadyReplicas == *deployment.Spec.Replicas {
-			status = "Ready"
-		} else if deployment.Status.ReadyReplicas > 0 {
-			status = "Partial"
-		} else if deployment.Status.ReadyReplicas == 0 {
-			status = "NotReady"
-		} else {
-			// This case handles unexpected scenarios (e.g., negative replica counts)
-			// which could indicate API issues or edge cases we haven't considered
-			status = "Unknown"
+// podInfo summarizes one pod
+func podInfo(clusterName string, pod corev1.Pod) PodInfo {
+	// Calculate ready containers
+	readyContainers := 0
+	totalContainers := len(pod.Spec.Containers)
+	for _, condition := range pod.Status.ContainerStatuses {
+		if condition.Ready {
+			readyContainers++
 		}
+	}
+
+	// Count total restarts
+	var totalRestarts int32
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		totalRestarts += containerStatus.RestartCount
+	}
 
-		// Calculate age of the deployment
-		age := time.Since(deployment.CreationTimestamp.Time).Round(time.Second)
+	// Determine pod node
+	nodeName := pod.Spec.NodeName
+	if nodeName == "" {
+		nodeName = "unscheduled"
+	}
 
-		result = append(result, DeploymentInfo{
-			ClusterName:   clusterName,
-			Namespace:     deployment.Namespace,
-			Name:          deployment.Name,
-			Replicas:      *deployment.Spec.Replicas,
-			ReadyReplicas: deployment.Status.ReadyReplicas,
-			Image:         image,
-			Status:        status,
-			Age:           formatDuration(age),
-		})
+	return PodInfo{
+		ClusterName: clusterName,
+		Namespace:   pod.Namespace,
+		Name:        pod.Name,
+		Status:      string(pod.Status.Phase),
+		Ready:       fmt.Sprintf("%d/%d", readyContainers, totalContainers),
+		Restarts:    totalRestarts,
+		Age:         formatDuration(time.Since(pod.CreationTimestamp.Time)),
+		Node:        nodeName,
+		CreatedAt:   pod.CreationTimestamp.Time,
 	}
+}
 
-	return result
+// DeployOptions tunes how manifests are applied to each cluster
+type DeployOptions struct {
+	// CreateOnly refuses to touch resources that already exist, so a pipeline
+	// can be sure it created a resource rather than overwrote someone else's
+	CreateOnly bool
+
+	// Progress, when set, receives an event as each cluster starts and finishes
+	// so callers can render live status; per-cluster log lines are suppressed.
+	// The caller owns the channel and must keep draining it until the deploy returns
+	Progress chan<- DeployEvent
+
+	// ManagedByKey and ManagedByValue, when set, restrict the deploy to resources mcm
+	// owns: new resources get the label, and existing ones without it are left alone
+	ManagedByKey   string
+	ManagedByValue string
+
+	// Wait follows each Deployment's rollout until it completes, the controller
+	// reports it stuck, or WaitTimeout (DefaultRolloutTimeout when zero) passes
+	Wait        bool
+	WaitTimeout time.Duration
+
+	// ProgressDeadline, when set, overrides spec.progressDeadlineSeconds on applied
+	// Deployments, so a stuck rollout is flagged by Kubernetes (and fails --wait) sooner
+	ProgressDeadline time.Duration
+
+	// CreateNamespace creates the target namespace when it's missing, labeled
+	// so 'namespaces cleanup' can find it later; CreatedBy is recorded on it
+	CreateNamespace bool
+	CreatedBy       string
+
+	// Values, when set, makes the manifest a template rendered separately for each
+	// cluster with the values for that cluster's environment
+	Values *ManifestValues
+
+	// ForceConflicts takes ownership of fields another field manager set - kubectl
+	// edit, an autoscaler - when the manifest changes them. Without it the apply
+	// fails with a conflict and the cluster is left as it was
+	ForceConflicts bool
+
+	// ForceRecreate deletes and recreates an object when the API server refuses
+	// the update because it changes an immutable field. The object and everything
+	// it owns (a workload's pods) are gone in between, so this causes downtime.
+	// OnRecreate, when set, is told about each recreated object; it is called from
+	// every cluster's goroutine at once, so it must be safe for concurrent use
+	ForceRecreate bool
+	OnRecreate    func(RecreatedResource)
 }
 
-// ListPods retrieves pods from specified clusters with optional filtering
-func (m *Manager) ListPods(clusterNames []string, namespace string, labelSelector string) ([]PodInfo, error) {
-	if len(clusterNames) == 0 {
-		for _, status := range m.clusterManager.ListClusters() {
-			if status.Connected {
-				clusterNames = append(clusterNames, status.Name)
-			}
-		}
+// Deploy phases reported on DeployOptions.Progress
+const (
+	DeployStarted    = "deploying"
+	DeployRollingOut = "rolling out"
+	DeployDone       = "done"
+	DeployFailed     = "failed"
+)
+
+// DeployEvent reports a single cluster's deployment moving to a new phase
+type DeployEvent struct {
+	Cluster string
+	Phase   string
+	Err     error
+}
+
+// logf prints per-cluster progress lines unless a live progress renderer owns the terminal
+func (opts DeployOptions) logf(format string, args ...interface{}) {
+	if opts.Progress == nil {
+		fmt.Printf(format, args...)
 	}
+}
 
-	resultChan := make(chan []PodInfo, len(clusterNames))
-	var wg sync.WaitGroup
+// waitForRollout follows a freshly applied deployment until its rollout settles
+// The wait gets its own deadline so a slow rollout isn't cut short by the apply timeout
+func (opts DeployOptions) waitForRollout(clientset kubernetes.Interface, clusterName, namespace, name string) error {
+	timeout := opts.WaitTimeout
+	if timeout <= 0 {
+		timeout = DefaultRolloutTimeout
+	}
 
-	for _, clusterName := range clusterNames {
-		wg.Add(1)
-		go func(name string) {
-			defer wg.Done()
-			pods := m.getPodsFromCluster(name, namespace, labelSelector)
-			resultChan <- pods
-		}(clusterName)
+	if opts.Progress != nil {
+		opts.Progress <- DeployEvent{Cluster: clusterName, Phase: DeployRollingOut}
 	}
+	opts.logf("Waiting for rollout of deployment %s in cluster %s...\n", name, clusterName)
 
-	go func() {
-		wg.Wait()
-		close(resultChan)
-	}()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 
-	var allPods []PodInfo
-	for pods := range resultChan {
-		allPods = append(allPods, pods...)
+	if err := waitForRollout(ctx, clientset, namespace, name, rolloutPollInterval); err != nil {
+		return err
 	}
+	opts.logf("Deployment %s rolled out in cluster %s\n", name, clusterName)
+	return nil
+}
 
-	return allPods, nil
+// DeployToCluster deploys a YAML manifest to a specific cluster
+// This is like sending deployment instructions to a specific data center
+func (m *Manager) DeployToCluster(clusterName, namespace, yamlContent string) error {
+	return m.DeployToClusterWithOptions(clusterName, namespace, yamlContent, DeployOptions{})
 }
 
-// getPodsFromCluster retrieves pods from a single cluster
-func (m *Manager) getPodsFromCluster(clusterName, namespace, labelSelector string) []PodInfo {
+// DeployToClusterWithOptions deploys a YAML manifest to a specific cluster
+// using the given options
+func (m *Manager) DeployToClusterWithOptions(clusterName, namespace, yamlContent string, opts DeployOptions) error {
 	client, err := m.clusterManager.GetClient(clusterName)
 	if err != nil {
-		return []PodInfo{{
-			ClusterName: clusterName,
-			Name:        "error",
-			Status:      fmt.Sprintf("Failed to get cluster client: %v", err),
-		}}
+		return fmt.Errorf("failed to get cluster client for %s: %w", clusterName, err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+	yamlContent, err = opts.render(yamlContent, client.Config.Environment)
+	if err != nil {
+		return err
+	}
+	kind, err := manifestKind(yamlContent)
+	if err != nil {
+		return err
+	}
+	switch kind {
+	case "Deployment":
+		// The typed path below, which can also be planned and waited for
+	case "StatefulSet", "DaemonSet":
+		return deployAppsWorkload(client, clusterName, kind, namespace, yamlContent, opts)
+	default:
+		return m.deployUnstructured(client, clusterName, namespace, yamlContent, opts)
+	}
 
-	listOptions := metav1.ListOptions{}
-	if labelSelector != "" {
-		listOptions.LabelSelector = labelSelector
+	deployment, err := desiredDeployment(namespace, yamlContent, opts)
+	if err != nil {
+		return err
 	}
 
-	pods, err := client.Clientset.CoreV1().Pods(namespace).List(ctx, listOptions)
+	// Reading and writing run as one operation, so a retry after a transient error
+	// starts over from the Get and finds a create that did land instead of repeating it.
+	// A generateName create has no such safety net - every attempt makes a new
+	// deployment - so it gets exactly one try
+	run := client.Do
+	if deployment.Name == "" && deployment.GenerateName != "" {
+		run = client.DoOnce
+	}
+
+	var action, name string
+	err = run(context.Background(), func(ctx context.Context) error {
+		var applyErr error
+		action, name, applyErr = applyDeployment(ctx, client.Clientset, clusterName, deployment.DeepCopy(), opts)
+		return applyErr
+	})
 	if err != nil {
-		return []PodInfo{{
-			ClusterName: clusterName,
-			Name:        "error",
-			Status:      fmt.Sprintf("Failed to list pods: %v", err),
-		}}
+		// A webhook's refusal is the most common deploy failure; report its reason, not the raw API error
+		return asAdmissionError(err)
 	}
+	opts.logf("%s deployment %s in cluster %s\n", action, name, clusterName)
 
-	var result []PodInfo
-	for _, pod := range pods.Items {
-		// Calculate ready containers
-		readyContainers := 0
-		totalContainers := len(pod.Spec.Containers)
-		for _, condition := range pod.Status.ContainerStatuses {
-			if condition.Ready {
-				readyContainers++
-			}
-		}
+	if opts.Wait {
+		return opts.waitForRollout(client.Clientset, clusterName, deployment.Namespace, name)
+	}
 
-		// Count total restarts
-		var totalRestarts int32
-		for _, containerStatus := range pod.Status.ContainerStatuses {
-			totalRestarts += containerStatus.RestartCount
-		}
+	return nil
+}
 
-		// Determine pod node
-		nodeName := pod.Spec.NodeName
-		if nodeName == "" {
-			nodeName = "unscheduled"
-		}
+// deployAppsWorkload applies a StatefulSet or DaemonSet to one cluster
+// The manifest is parsed afresh for every attempt, so a retry starts clean
+func deployAppsWorkload(client *cluster.ClusterClient, clusterName, kind, namespace, yamlContent string, opts DeployOptions) error {
+	var action, name string
+	err := client.Do(context.Background(), func(ctx context.Context) error {
+		var applyErr error
+		action, name, applyErr = applyAppsWorkload(ctx, client.Clientset, clusterName, kind, namespace, yamlContent, opts)
+		return applyErr
+	})
+	if err != nil {
+		return asAdmissionError(err)
+	}
+	opts.logf("%s %s %s in cluster %s\n", action, strings.ToLower(kind), name, clusterName)
 
-		result = append(result, PodInfo{
-			ClusterName: clusterName,
-			Namespace:   pod.Namespace,
-			Name:        pod.Name,
-			Status:      string(pod.Status.Phase),
-			Ready:       fmt.Sprintf("%d/%d", readyContainers, totalContainers),
-			Restarts:    totalRestarts,
-			Age:         formatDuration(time.Since(pod.CreationTimestamp.Time)),
-			Node:        nodeName,
-			CreatedAt:   pod.CreationTimestamp.Time,
-		})
+	if opts.Wait {
+		opts.logf("Not waiting for %s %s: --wait only follows Deployment rollouts so far\n", strings.ToLower(kind), name)
+	}
+	return nil
+}
+
+// applyDeployment creates the deployment or updates the existing one with
+// server-side apply, returning what it did ("Created" or "Updated") and the
+// deployment's name
+func applyDeployment(ctx context.Context, clientset kubernetes.Interface, clusterName string, deployment *appsv1.Deployment, opts DeployOptions) (string, string, error) {
+	deployments := clientset.AppsV1().Deployments(deployment.Namespace)
+
+	// A manifest with generateName and no name asks the API server to pick a fresh
+	// name, so there's nothing to look up or apply to - every deploy is a new object
+	if deployment.Name == "" && deployment.GenerateName != "" {
+		if err := opts.ensureTargetNamespace(ctx, clientset, clusterName, deployment.Namespace); err != nil {
+			return "", "", err
+		}
+		created, err := deployments.Create(ctx, deployment, metav1.CreateOptions{FieldManager: FieldManager})
+		if err != nil {
+			return "", "", fmt.Errorf("failed to create deployment: %w", err)
+		}
+		return "Created", created.Name, nil
 	}
 
-	return result
+	return applyTyped(ctx, clientset, clusterName, "Deployment", deployments, deployment, opts)
 }
 
-// DeployToCluster deploys a YAML manifest to a specific cluster
-// This is like sending deployment instructions to a specific data center
-func (m *Manager) DeployToCluster(clusterName, namespace, yamlContent string) error {
-	client, err := m.clusterManager.GetClient(clusterName)
+// desiredDeploymentFor is desiredDeployment for a cluster in the given environment,
+// rendering the manifest with that environment's values first when opts has any
+func desiredDeploymentFor(environment, namespace, yamlContent string, opts DeployOptions) (*appsv1.Deployment, error) {
+	yamlContent, err := opts.render(yamlContent, environment)
 	if err != nil {
-		return fmt.Errorf("failed to get cluster client for %s: %w", clusterName, err)
+		return nil, err
 	}
+	return desiredDeployment(namespace, yamlContent, opts)
+}
+
+// render fills in a manifest template for a cluster in the given environment;
+// without Values the manifest is returned as written
+func (opts DeployOptions) render(yamlContent, environment string) (string, error) {
+	if opts.Values == nil {
+		return yamlContent, nil
+	}
+	return opts.Values.Render(yamlContent, environment)
+}
 
+// desiredDeployment parses a manifest into the Deployment a deploy would send,
+// with the namespace defaulted and the deploy options' overrides applied
+func desiredDeployment(namespace, yamlContent string, opts DeployOptions) (*appsv1.Deployment, error) {
 	// Parse the YAML content to determine what type of resource we're deploying
 	// This is a simplified parser - in production, you'd want more robust YAML handling
-	var obj map[string]interface{}
-	if err := yaml.Unmarshal([]byte(yamlContent), &obj); err != nil {
-		return fmt.Errorf("failed to parse YAML: %w", err)
+	kind, err := manifestKind(yamlContent)
+	if err != nil {
+		return nil, err
 	}
-
-	kind, ok := obj["kind"].(string)
-	if !ok {
-		return fmt.Errorf("YAML must specify a 'kind' field")
+	// Only Deployments can be planned and waited for; StatefulSets and DaemonSets
+	// are applied by DeployToClusterWithOptions without going through here
+	if kind != "Deployment" {
+		return nil, fmt.Errorf("resource kind '%s' is not supported yet", kind)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-	defer cancel()
+	var deployment appsv1.Deployment
+	if err := yaml.Unmarshal([]byte(yamlContent), &deployment); err != nil {
+		return nil, fmt.Errorf("failed to parse Deployment YAML: %w", err)
+	}
 
-	// Handle different resource types - this example handles Deployments
-	// In a full implementation, you'd want to handle many more resource types
-	switch kind {
-	case "Deployment":
-		var deployment appsv1.Deployment
-		if err := yaml.Unmarshal([]byte(yamlContent), &deployment); err != nil {
-			return fmt.Errorf("failed to parse Deployment YAML: %w", err)
-		}
+	// Set namespace if not specified in YAML
+	if deployment.Namespace == "" {
+		deployment.Namespace = namespace
+	}
 
-		// Set namespace if not specified in YAML
-		if deployment.Namespace == "" {
-			deployment.Namespace = namespace
-		}
+	if opts.ProgressDeadline > 0 {
+		seconds := int32(opts.ProgressDeadline.Seconds())
+		deployment.Spec.ProgressDeadlineSeconds = &seconds
+	}
 
-		// Try to update if exists, create if not
-		existing, err := client.Clientset.AppsV1().Deployments(deployment.Namespace).Get(ctx, deployment.Name, metav1.GetOptions{})
-		if err == nil {
-			// Update existing deployment
-			deployment.ResourceVersion = existing.ResourceVersion
-			_, err = client.Clientset.AppsV1().Deployments(deployment.Namespace).Update(ctx, &deployment, metav1.UpdateOptions{})
-			if err != nil {
-				return fmt.Errorf("failed to update deployment: %w", err)
-			}
-			fmt.Printf("Updated deployment %s in cluster %s\n", deployment.Name, clusterName)
-		} else {
-			// Create new deployment
-			_, err = client.Clientset.AppsV1().Deployments(deployment.Namespace).Create(ctx, &deployment, metav1.CreateOptions{})
-			if err != nil {
-				return fmt.Errorf("failed to create deployment: %w", err)
-			}
-			fmt.Printf("Created deployment %s in cluster %s\n", deployment.Name, clusterName)
+	// Stamp ownership so later --managed-only operations recognize the deployment
+	if opts.ManagedByKey != "" {
+		if deployment.Labels == nil {
+			deployment.Labels = make(map[string]string)
 		}
-
-	default:
-		return fmt.Errorf("resource kind '%s' is not supported yet", kind)
+		deployment.Labels[opts.ManagedByKey] = opts.ManagedByValue
 	}
 
-	return nil
+	return &deployment, nil
 }
 
+// DeployCallback is told that one cluster's deployment finished, with its
+// error (nil on success) and how long the cluster took
+type DeployCallback func(cluster string, err error, duration time.Duration)
+
 // DeployToMultipleClusters deploys to multiple clusters in parallel
 // This is like broadcasting deployment instructions to multiple data centers
-func (m *Manager) DeployToMultipleClusters(clusterNames []string, namespace, yamlContent string) map[string]error {
+func (m *Manager) DeployToMultipleClusters(clusterNames []string, namespace, yamlContent string, opts DeployOptions) map[string]error {
 	results := make(map[string]error)
-	var wg sync.WaitGroup
 	var mutex sync.Mutex
 
+	m.DeployToMultipleClustersWithCallback(clusterNames, namespace, yamlContent, opts, func(name string, err error, _ time.Duration) {
+		mutex.Lock()
+		results[name] = err
+		mutex.Unlock()
+	})
+	return results
+}
+
+// DeployToMultipleClustersWithCallback deploys to multiple clusters in parallel
+// and calls callback as each cluster finishes, so programs embedding the
+// Manager can stream progress to their own UI. It returns once every cluster
+// has finished and its callback has returned.
+//
+// The callback runs on the cluster's own goroutine: it may be called from
+// several goroutines at once and must synchronize any state it shares, and a
+// slow callback holds up only its own cluster
+func (m *Manager) DeployToMultipleClustersWithCallback(clusterNames []string, namespace, yamlContent string, opts DeployOptions, callback DeployCallback) {
+	var wg sync.WaitGroup
+
 	for _, clusterName := range clusterNames {
 		wg.Add(1)
 		go func(name string) {
 			defer wg.Done()
-			err := m.DeployToCluster(name, namespace, yamlContent)
+			if opts.Progress != nil {
+				opts.Progress <- DeployEvent{Cluster: name, Phase: DeployStarted}
+			}
+
+			start := time.Now()
+			err := m.DeployToClusterWithOptions(name, namespace, yamlContent, opts)
+			duration := time.Since(start)
 
-			mutex.Lock()
-			results[name] = err
-			mutex.Unlock()
+			if opts.Progress != nil {
+				event := DeployEvent{Cluster: name, Phase: DeployDone, Err: err}
+				if err != nil {
+					event.Phase = DeployFailed
+				}
+				opts.Progress <- event
+			}
+
+			if callback != nil {
+				callback(name, err, duration)
+			}
 		}(clusterName)
 	}
 
 	wg.Wait()
-	return results
 }
 
 // formatDuration converts a time.Duration to a human-readable string
@@ -0,0 +1,245 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+func TestListPodsPassesBothSelectors(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+
+	var restrictions k8stesting.ListRestrictions
+	clientset.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		restrictions = action.(k8stesting.ListAction).GetListRestrictions()
+		return false, nil, nil
+	})
+
+	opts := podListOptions("app=web,tier=frontend", "status.phase=Running")
+	if _, err := listPods(context.Background(), clientset, "test", "default", opts); err != nil {
+		t.Fatalf("listPods failed: %v", err)
+	}
+
+	if got := restrictions.Labels.String(); got != "app=web,tier=frontend" {
+		t.Errorf("Expected label selector to reach the API server, got %q", got)
+	}
+	if got := restrictions.Fields.String(); got != "status.phase=Running" {
+		t.Errorf("Expected field selector to reach the API server, got %q", got)
+	}
+}
+
+func TestListPodsFollowsContinueTokens(t *testing.T) {
+	clientset := fake.NewClientset()
+
+	web := func(name string) corev1.Pod {
+		return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"app": "web"}}}
+	}
+	var requests []metav1.ListOptions
+	clientset.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		opts := action.(k8stesting.ListActionImpl).ListOptions
+		requests = append(requests, opts)
+		if opts.Continue == "" {
+			return true, &corev1.PodList{
+				ListMeta: metav1.ListMeta{Continue: "page-2"},
+				Items:    []corev1.Pod{web("web-1"), web("web-2")},
+			}, nil
+		}
+		return true, &corev1.PodList{Items: []corev1.Pod{web("web-3")}}, nil
+	})
+
+	manager := NewManager(&fakeProvider{clients: map[string]*cluster.ClusterClient{
+		"prod": {Config: config.ClusterConfig{Name: "prod"}, Clientset: clientset, Connected: true},
+	}})
+	manager.SetListPageSize(2)
+
+	result := manager.ListPods(nil, "default", "app=web", "")
+	if len(result.Errors) != 0 || len(result.Items) != 3 {
+		t.Fatalf("Expected all 3 pods across both pages, got %+v", result)
+	}
+	if len(requests) != 2 || requests[0].Limit != 2 || requests[1].Continue != "page-2" || requests[1].LabelSelector != "app=web" {
+		t.Errorf("Expected two requests of 2 pods, the second continuing the first, got %+v", requests)
+	}
+}
+
+func TestListPodsRestartsOnExpiredContinueToken(t *testing.T) {
+	clientset := fake.NewClientset()
+
+	calls := 0
+	clientset.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		calls++
+		if action.(k8stesting.ListActionImpl).ListOptions.Continue == "" {
+			return true, &corev1.PodList{
+				ListMeta: metav1.ListMeta{Continue: fmt.Sprintf("token-%d", calls)},
+				Items:    []corev1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("web-%d", calls)}}},
+			}, nil
+		}
+		if calls == 2 {
+			return true, nil, apierrors.NewResourceExpired("continue token expired")
+		}
+		return true, &corev1.PodList{}, nil
+	})
+
+	pods, err := listPods(context.Background(), clientset, "prod", "default", metav1.ListOptions{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pods) != 1 || pods[0].Name != "web-3" {
+		t.Errorf("Expected only the pods of the restarted listing, got %+v", pods)
+	}
+}
+
+func TestPodListOptionsEmpty(t *testing.T) {
+	if opts := podListOptions("", ""); opts != (metav1.ListOptions{}) {
+		t.Errorf("Expected empty list options, got %+v", opts)
+	}
+}
+
+func TestDeployManagedOnly(t *testing.T) {
+	foreign := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Name: "shared", Namespace: "default", Labels: map[string]string{"app.kubernetes.io/managed-by": "helm"},
+	}}
+	clientset := fake.NewClientset(foreign)
+	provider := &fakeProvider{clients: map[string]*cluster.ClusterClient{
+		"prod": {Config: config.ClusterConfig{Name: "prod"}, Clientset: clientset, Connected: true},
+	}}
+	manager := NewManager(provider)
+	opts := DeployOptions{ManagedByKey: "app.kubernetes.io/managed-by", ManagedByValue: "mcm"}
+
+	manifest := "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: %s\nspec:\n  replicas: 1\n"
+
+	// A deployment owned by another tool must not be overwritten
+	err := manager.DeployToClusterWithOptions("prod", "default", fmt.Sprintf(manifest, "shared"), opts)
+	if err == nil || !strings.Contains(err.Error(), "not managed by mcm") {
+		t.Fatalf("Expected refusal to modify a foreign deployment, got %v", err)
+	}
+
+	// New deployments are stamped so later --managed-only lists find them
+	if err := manager.DeployToClusterWithOptions("prod", "default", fmt.Sprintf(manifest, "web"), opts); err != nil {
+		t.Fatalf("Deploy failed: %v", err)
+	}
+	created, err := clientset.AppsV1().Deployments("default").Get(context.Background(), "web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Labels["app.kubernetes.io/managed-by"] != "mcm" {
+		t.Errorf("Expected ownership label on created deployment, got %v", created.Labels)
+	}
+
+	deployments := manager.ListDeployments(nil, "default", "app.kubernetes.io/managed-by=mcm").Items
+	if len(deployments) != 1 || deployments[0].Name != "web" {
+		t.Errorf("Expected only the managed deployment to be listed, got %+v", deployments)
+	}
+}
+
+func TestDeployRendersValuesPerEnvironment(t *testing.T) {
+	devClientset, prodClientset := fake.NewClientset(), fake.NewClientset()
+	provider := &fakeProvider{clients: map[string]*cluster.ClusterClient{
+		"dev":  {Config: config.ClusterConfig{Name: "dev", Environment: "development"}, Clientset: devClientset, Connected: true},
+		"prod": {Config: config.ClusterConfig{Name: "prod", Environment: "production"}, Clientset: prodClientset, Connected: true},
+	}}
+	manager := NewManager(provider)
+	opts := DeployOptions{Values: &ManifestValues{
+		Base:         map[string]interface{}{"replicas": 1},
+		Environments: map[string]map[string]interface{}{"production": {"replicas": 4}},
+	}}
+
+	manifest := "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\nspec:\n  replicas: {{ .Values.replicas }}\n"
+	for name, err := range manager.DeployToMultipleClusters([]string{"dev", "prod"}, "default", manifest, opts) {
+		if err != nil {
+			t.Fatalf("Deploy to %s failed: %v", name, err)
+		}
+	}
+
+	for _, tt := range []struct {
+		clientset *fake.Clientset
+		want      int32
+	}{{devClientset, 1}, {prodClientset, 4}} {
+		deployment, err := tt.clientset.AppsV1().Deployments("default").Get(context.Background(), "web", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *deployment.Spec.Replicas != tt.want {
+			t.Errorf("Expected %d replicas, got %d", tt.want, *deployment.Spec.Replicas)
+		}
+	}
+}
+
+func TestDeployOverridesProgressDeadline(t *testing.T) {
+	clientset := fake.NewClientset()
+	provider := &fakeProvider{clients: map[string]*cluster.ClusterClient{
+		"prod": {Config: config.ClusterConfig{Name: "prod"}, Clientset: clientset, Connected: true},
+	}}
+
+	manifest := "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\nspec:\n  progressDeadlineSeconds: 600\n"
+	opts := DeployOptions{ProgressDeadline: 2 * time.Minute}
+	if err := NewManager(provider).DeployToClusterWithOptions("prod", "default", manifest, opts); err != nil {
+		t.Fatalf("Deploy failed: %v", err)
+	}
+
+	created, err := clientset.AppsV1().Deployments("default").Get(context.Background(), "web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Spec.ProgressDeadlineSeconds == nil || *created.Spec.ProgressDeadlineSeconds != 120 {
+		t.Errorf("Expected progressDeadlineSeconds 120, got %v", created.Spec.ProgressDeadlineSeconds)
+	}
+}
+
+func TestDeploymentInfoWithoutSpecReplicas(t *testing.T) {
+	deployment := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Status:     appsv1.DeploymentStatus{Replicas: 3, ReadyReplicas: 3},
+	}
+
+	info := deploymentInfo("prod", deployment)
+	if info.Replicas != 3 || info.Status != "Ready" {
+		t.Errorf("Expected 3 replicas from the status and Ready, got %d and %s", info.Replicas, info.Status)
+	}
+
+	deployment.Status = appsv1.DeploymentStatus{}
+	info = deploymentInfo("prod", deployment)
+	if info.Replicas != 0 || info.Status != "Ready" {
+		t.Errorf("Expected a deployment with no replicas at all to report 0 and Ready, got %d and %s", info.Replicas, info.Status)
+	}
+}
+
+func TestDeployCallbackPerCluster(t *testing.T) {
+	provider := &fakeProvider{clients: map[string]*cluster.ClusterClient{
+		"dev":  {Config: config.ClusterConfig{Name: "dev"}, Clientset: fake.NewClientset(), Connected: true},
+		"prod": {Config: config.ClusterConfig{Name: "prod"}, Clientset: fake.NewClientset(), Connected: true},
+	}}
+	manifest := "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n"
+
+	var mutex sync.Mutex
+	called := make(map[string]error)
+	NewManager(provider).DeployToMultipleClustersWithCallback([]string{"dev", "prod", "missing"}, "default", manifest, DeployOptions{},
+		func(name string, err error, duration time.Duration) {
+			if duration < 0 {
+				t.Errorf("Negative duration for %s: %v", name, duration)
+			}
+			mutex.Lock()
+			defer mutex.Unlock()
+			if _, seen := called[name]; seen {
+				t.Errorf("Callback called twice for %s", name)
+			}
+			called[name] = err
+		})
+
+	if len(called) != 3 || called["dev"] != nil || called["prod"] != nil || called["missing"] == nil {
+		t.Errorf("Expected one callback per cluster, failing only for missing: %v", called)
+	}
+}
@@ -0,0 +1,114 @@
+package workload
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// comparableManifests renders the live and desired objects as YAML that can be
+// diffed line by line. Live objects carry a lot the manifest never mentions -
+// status, managedFields, every defaulted field - so the live side is trimmed to
+// the fields the manifest sets. What remains differs only where applying the
+// manifest would change something. A nil live object renders as empty, so a
+// create diffs as pure additions
+func comparableManifests(live, desired runtime.Object) (string, string, error) {
+	desiredMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(desired)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to convert the manifest: %w", err)
+	}
+	pruned, _ := pruneEmpty(desiredMap).(map[string]interface{})
+	delete(pruned, "status")
+
+	desiredYAML, err := yaml.Marshal(pruned)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to render the manifest: %w", err)
+	}
+	if live == nil {
+		return "", string(desiredYAML), nil
+	}
+
+	liveMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(live)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to convert the live object: %w", err)
+	}
+	// Typed clients drop apiVersion and kind from what they return
+	for _, key := range []string{"apiVersion", "kind"} {
+		if _, ok := liveMap[key]; !ok {
+			if value, ok := pruned[key]; ok {
+				liveMap[key] = value
+			}
+		}
+	}
+	liveYAML, err := yaml.Marshal(projectOnto(liveMap, pruned))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to render the live object: %w", err)
+	}
+	return string(liveYAML), string(desiredYAML), nil
+}
+
+// projectOnto keeps only the parts of live that shape also has
+// Lists are matched by position; live items beyond the end of shape's list are
+// kept whole, since applying the manifest would remove them
+func projectOnto(live, shape interface{}) interface{} {
+	switch shapeValue := shape.(type) {
+	case map[string]interface{}:
+		liveMap, ok := live.(map[string]interface{})
+		if !ok {
+			return live
+		}
+		projected := make(map[string]interface{}, len(shapeValue))
+		for key, child := range shapeValue {
+			if liveChild, exists := liveMap[key]; exists {
+				projected[key] = projectOnto(liveChild, child)
+			}
+		}
+		return projected
+	case []interface{}:
+		liveList, ok := live.([]interface{})
+		if !ok {
+			return live
+		}
+		projected := make([]interface{}, len(liveList))
+		for i, item := range liveList {
+			if i < len(shapeValue) {
+				projected[i] = projectOnto(item, shapeValue[i])
+			} else {
+				projected[i] = item
+			}
+		}
+		return projected
+	default:
+		return live
+	}
+}
+
+// pruneEmpty drops nulls and empty maps and lists, which typed objects are full
+// of (creationTimestamp: null, resources: {}) but no manifest author wrote
+func pruneEmpty(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		pruned := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			if child = pruneEmpty(child); child != nil {
+				pruned[key] = child
+			}
+		}
+		if len(pruned) == 0 {
+			return nil
+		}
+		return pruned
+	case []interface{}:
+		if len(v) == 0 {
+			return nil
+		}
+		pruned := make([]interface{}, len(v))
+		for i, item := range v {
+			pruned[i] = pruneEmpty(item)
+		}
+		return pruned
+	default:
+		return v
+	}
+}
@@ -0,0 +1,283 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Markers stamped on namespaces that deploy --create-namespace creates
+// The label is what cleanup selects on; the annotations record when and by whom,
+// since neither fits the character rules for label values
+const (
+	NamespaceCreatedByLabel      = "mcm.io/created-by"
+	NamespaceCreatedByValue      = "mcm"
+	NamespaceCreatedAtAnnotation = "mcm.io/created-at"
+	NamespaceCreatorAnnotation   = "mcm.io/created-by-user"
+)
+
+// CreatedNamespace is a namespace mcm created, with whether anything still lives in it
+type CreatedNamespace struct {
+	ClusterName string    `json:"clusterName"`
+	Name        string    `json:"name"`
+	CreatedBy   string    `json:"createdBy,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	Age         string    `json:"age"`
+	Empty       bool      `json:"empty"`
+	Contents    string    `json:"contents,omitempty"` // First thing found when not empty, e.g. "deployments/web"
+}
+
+// NamespaceInfo is one namespace in one cluster, for tenancy audits
+type NamespaceInfo struct {
+	ClusterName string            `json:"clusterName"`
+	Name        string            `json:"name"`
+	Status      string            `json:"status"` // Active or Terminating
+	Labels      map[string]string `json:"labels,omitempty"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	Age         string            `json:"age"`
+}
+
+// ensureNamespace creates the namespace if it doesn't exist yet, labeled as mcm's
+// Existing namespaces are left exactly as they are - whoever made them owns them
+func ensureNamespace(ctx context.Context, clientset kubernetes.Interface, name, user string) (bool, error) {
+	_, err := clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err == nil {
+		return false, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to check namespace %s: %w", name, err)
+	}
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   name,
+		Labels: map[string]string{NamespaceCreatedByLabel: NamespaceCreatedByValue},
+		Annotations: map[string]string{
+			NamespaceCreatedAtAnnotation: time.Now().UTC().Format(time.RFC3339),
+		},
+	}}
+	if user != "" {
+		namespace.Annotations[NamespaceCreatorAnnotation] = user
+	}
+
+	_, err = clientset.CoreV1().Namespaces().Create(ctx, namespace, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		// Another deploy (or person) got there first; theirs it is
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create namespace %s: %w", name, err)
+	}
+	return true, nil
+}
+
+// ListNamespaces lists every namespace in each cluster, optionally only those
+// matching a label selector, e.g. to see which clusters a tenant is provisioned in
+func (m *Manager) ListNamespaces(clusterNames []string, labelSelector string) FleetResult[NamespaceInfo] {
+	clusterNames = m.connectedClusters(clusterNames)
+
+	return fanOut(clusterNames, m.timeouts, func(ctx context.Context, name string) ([]NamespaceInfo, error) {
+		client, err := m.clusterManager.GetClient(name)
+		if err != nil {
+			return nil, err
+		}
+		var namespaces []NamespaceInfo
+		err = client.Do(ctx, func(ctx context.Context) error {
+			var listErr error
+			namespaces, listErr = listNamespaces(ctx, client.Clientset, name, labelSelector)
+			return listErr
+		})
+		return namespaces, err
+	})
+}
+
+// listNamespaces lists one cluster's namespaces
+func listNamespaces(ctx context.Context, clientset kubernetes.Interface, clusterName, labelSelector string) ([]NamespaceInfo, error) {
+	namespaces, err := clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	result := make([]NamespaceInfo, 0, len(namespaces.Items))
+	for _, namespace := range namespaces.Items {
+		// A namespace being deleted has its deletion timestamp set before its phase catches up
+		status := string(namespace.Status.Phase)
+		if namespace.DeletionTimestamp != nil {
+			status = string(corev1.NamespaceTerminating)
+		} else if status == "" {
+			status = string(corev1.NamespaceActive)
+		}
+
+		result = append(result, NamespaceInfo{
+			ClusterName: clusterName,
+			Name:        namespace.Name,
+			Status:      status,
+			Labels:      namespace.Labels,
+			CreatedAt:   namespace.CreationTimestamp.Time,
+			Age:         formatDuration(time.Since(namespace.CreationTimestamp.Time)),
+		})
+	}
+	return result, nil
+}
+
+// ListCreatedNamespaces finds the namespaces mcm created in each cluster and
+// checks whether they still hold anything
+// This is the inventory step before cleanup: what did repeated
+// --create-namespace deploys leave behind?
+func (m *Manager) ListCreatedNamespaces(clusterNames []string) FleetResult[CreatedNamespace] {
+	clusterNames = m.connectedClusters(clusterNames)
+
+	return fanOut(clusterNames, m.timeouts, func(ctx context.Context, name string) ([]CreatedNamespace, error) {
+		client, err := m.clusterManager.GetClient(name)
+		if err != nil {
+			return nil, err
+		}
+		var namespaces []CreatedNamespace
+		err = client.Do(ctx, func(ctx context.Context) error {
+			var listErr error
+			namespaces, listErr = listCreatedNamespaces(ctx, client.Clientset, name)
+			return listErr
+		})
+		return namespaces, err
+	})
+}
+
+// listCreatedNamespaces lists one cluster's mcm-created namespaces
+func listCreatedNamespaces(ctx context.Context, clientset kubernetes.Interface, clusterName string) ([]CreatedNamespace, error) {
+	namespaces, err := clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{
+		LabelSelector: NamespaceCreatedByLabel + "=" + NamespaceCreatedByValue,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	result := make([]CreatedNamespace, 0, len(namespaces.Items))
+	for _, namespace := range namespaces.Items {
+		contents, err := namespaceContents(ctx, clientset, namespace.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		createdAt := namespace.CreationTimestamp.Time
+		if stamped, err := time.Parse(time.RFC3339, namespace.Annotations[NamespaceCreatedAtAnnotation]); err == nil {
+			createdAt = stamped
+		}
+
+		result = append(result, CreatedNamespace{
+			ClusterName: clusterName,
+			Name:        namespace.Name,
+			CreatedBy:   namespace.Annotations[NamespaceCreatorAnnotation],
+			CreatedAt:   createdAt,
+			Age:         formatDuration(time.Since(createdAt)),
+			Empty:       contents == "",
+			Contents:    contents,
+		})
+	}
+	return result, nil
+}
+
+// namespaceContents returns the first resource found in a namespace, or "" if it's empty
+// Kubernetes puts a root CA configmap into every namespace, so that one doesn't
+// count. Small pages keep the check cheap even in busy namespaces
+func namespaceContents(ctx context.Context, clientset kubernetes.Interface, namespace string) (string, error) {
+	page := metav1.ListOptions{Limit: 1}
+
+	checks := []struct {
+		resource string
+		list     func() (runtime.Object, error)
+	}{
+		{"deployments", func() (runtime.Object, error) { return clientset.AppsV1().Deployments(namespace).List(ctx, page) }},
+		{"statefulsets", func() (runtime.Object, error) { return clientset.AppsV1().StatefulSets(namespace).List(ctx, page) }},
+		{"daemonsets", func() (runtime.Object, error) { return clientset.AppsV1().DaemonSets(namespace).List(ctx, page) }},
+		{"pods", func() (runtime.Object, error) { return clientset.CoreV1().Pods(namespace).List(ctx, page) }},
+		{"services", func() (runtime.Object, error) { return clientset.CoreV1().Services(namespace).List(ctx, page) }},
+		{"persistentvolumeclaims", func() (runtime.Object, error) {
+			return clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, page)
+		}},
+		// Older clusters generate a token secret per service account; those aren't content either
+		{"secrets", func() (runtime.Object, error) {
+			return clientset.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{
+				Limit:         1,
+				FieldSelector: "type!=" + string(corev1.SecretTypeServiceAccountToken),
+			})
+		}},
+		// Two items, so the ever-present root CA configmap can't hide a real one
+		{"configmaps", func() (runtime.Object, error) {
+			return clientset.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{Limit: 2})
+		}},
+	}
+
+	for _, check := range checks {
+		list, err := check.list()
+		if err != nil {
+			return "", fmt.Errorf("failed to list %s in namespace %s: %w", check.resource, namespace, err)
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s list: %w", check.resource, err)
+		}
+		for _, item := range items {
+			accessor, err := meta.Accessor(item)
+			if err != nil {
+				return "", fmt.Errorf("failed to read %s metadata: %w", check.resource, err)
+			}
+			if check.resource == "configmaps" && accessor.GetName() == rootCAConfigMap {
+				continue
+			}
+			return check.resource + "/" + accessor.GetName(), nil
+		}
+	}
+	return "", nil
+}
+
+// DeleteCreatedNamespace deletes a namespace mcm created, after checking again that
+// it carries mcm's label and is still empty - something may have been deployed
+// into it since it was listed
+func (m *Manager) DeleteCreatedNamespace(clusterName, namespace string) error {
+	client, err := m.clusterManager.GetClient(clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to get cluster client for %s: %w", clusterName, err)
+	}
+
+	// The checks run again on every retry, so a retried delete re-proves the
+	// namespace is still mcm's and still empty
+	return client.Do(context.Background(), func(ctx context.Context) error {
+		return deleteCreatedNamespace(ctx, client.Clientset, namespace)
+	})
+}
+
+// deleteCreatedNamespace checks and deletes one namespace in a single cluster
+func deleteCreatedNamespace(ctx context.Context, clientset kubernetes.Interface, namespace string) error {
+	ns, err := clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+	}
+	if ns.Labels[NamespaceCreatedByLabel] != NamespaceCreatedByValue {
+		return fmt.Errorf("namespace %s was not created by mcm (missing label %s=%s); refusing to delete it",
+			namespace, NamespaceCreatedByLabel, NamespaceCreatedByValue)
+	}
+
+	contents, err := namespaceContents(ctx, clientset, namespace)
+	if err != nil {
+		return err
+	}
+	if contents != "" {
+		return fmt.Errorf("namespace %s is no longer empty (found %s); refusing to delete it", namespace, contents)
+	}
+
+	// Preconditions make the delete fail if the namespace was recreated in between
+	uid := ns.UID
+	err = clientset.CoreV1().Namespaces().Delete(ctx, namespace, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &uid},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete namespace %s: %w", namespace, err)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package workload
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+// createdNamespace builds a namespace carrying mcm's creation label
+func createdNamespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        name,
+		Labels:      map[string]string{NamespaceCreatedByLabel: NamespaceCreatedByValue},
+		Annotations: map[string]string{NamespaceCreatorAnnotation: "alice"},
+	}}
+}
+
+func TestDeployCreatesLabeledNamespace(t *testing.T) {
+	clientset := fake.NewClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "existing"}})
+	provider := &fakeProvider{clients: map[string]*cluster.ClusterClient{
+		"dev": {Config: config.ClusterConfig{Name: "dev"}, Clientset: clientset, Connected: true},
+	}}
+	manager := NewManager(provider)
+	opts := DeployOptions{CreateNamespace: true, CreatedBy: "alice"}
+
+	manifest := "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\nspec:\n  replicas: 1\n"
+	for _, namespace := range []string{"preview-1", "existing"} {
+		if err := manager.DeployToClusterWithOptions("dev", namespace, manifest, opts); err != nil {
+			t.Fatalf("Deploy to %s failed: %v", namespace, err)
+		}
+	}
+
+	created, err := clientset.CoreV1().Namespaces().Get(context.Background(), "preview-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected namespace to be created: %v", err)
+	}
+	if created.Labels[NamespaceCreatedByLabel] != NamespaceCreatedByValue {
+		t.Errorf("Expected creation label, got %v", created.Labels)
+	}
+	if created.Annotations[NamespaceCreatorAnnotation] != "alice" || created.Annotations[NamespaceCreatedAtAnnotation] == "" {
+		t.Errorf("Expected creator and timestamp annotations, got %v", created.Annotations)
+	}
+
+	existing, _ := clientset.CoreV1().Namespaces().Get(context.Background(), "existing", metav1.GetOptions{})
+	if _, labeled := existing.Labels[NamespaceCreatedByLabel]; labeled {
+		t.Error("Expected a pre-existing namespace to be left unlabeled")
+	}
+}
+
+func TestListCreatedNamespaces(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		createdNamespace("empty"),
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: rootCAConfigMap, Namespace: "empty"}},
+		createdNamespace("busy"),
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "busy"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "unrelated"}},
+	)
+
+	namespaces, err := listCreatedNamespaces(context.Background(), clientset, "dev")
+	if err != nil {
+		t.Fatalf("listCreatedNamespaces failed: %v", err)
+	}
+	if len(namespaces) != 2 {
+		t.Fatalf("Expected only the two mcm-created namespaces, got %+v", namespaces)
+	}
+
+	for _, namespace := range namespaces {
+		switch namespace.Name {
+		case "empty":
+			if !namespace.Empty {
+				t.Errorf("Expected the root CA configmap not to count, got contents %q", namespace.Contents)
+			}
+		case "busy":
+			if namespace.Empty || namespace.Contents != "deployments/web" {
+				t.Errorf("Expected busy namespace to report its deployment, got %+v", namespace)
+			}
+		}
+		if namespace.CreatedBy != "alice" {
+			t.Errorf("Expected creator from annotation, got %q", namespace.CreatedBy)
+		}
+	}
+}
+
+func TestDeleteCreatedNamespaceRefusesUnsafeDeletes(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		createdNamespace("busy"),
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "busy"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "foreign"}},
+		createdNamespace("empty"),
+	)
+	provider := &fakeProvider{clients: map[string]*cluster.ClusterClient{
+		"dev": {Config: config.ClusterConfig{Name: "dev"}, Clientset: clientset, Connected: true},
+	}}
+	manager := NewManager(provider)
+
+	if err := manager.DeleteCreatedNamespace("dev", "busy"); err == nil || !strings.Contains(err.Error(), "no longer empty") {
+		t.Errorf("Expected a non-empty namespace to be kept, got %v", err)
+	}
+	if err := manager.DeleteCreatedNamespace("dev", "foreign"); err == nil || !strings.Contains(err.Error(), "not created by mcm") {
+		t.Errorf("Expected an unlabeled namespace to be kept, got %v", err)
+	}
+	if err := manager.DeleteCreatedNamespace("dev", "empty"); err != nil {
+		t.Fatalf("Expected the empty namespace to be deleted, got %v", err)
+	}
+	if _, err := clientset.CoreV1().Namespaces().Get(context.Background(), "empty", metav1.GetOptions{}); err == nil {
+		t.Error("Expected the empty namespace to be gone")
+	}
+}
+
+func TestListNamespaces(t *testing.T) {
+	deleting := metav1.Now()
+	clientset := fake.NewClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop", Labels: map[string]string{"team": "payments"}},
+			Status: corev1.NamespaceStatus{Phase: corev1.NamespaceActive}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "old-shop", Labels: map[string]string{"team": "payments"},
+			DeletionTimestamp: &deleting, Finalizers: []string{"kubernetes"}},
+			Status: corev1.NamespaceStatus{Phase: corev1.NamespaceActive}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "search", Labels: map[string]string{"team": "discovery"}}},
+	)
+	provider := &fakeProvider{clients: map[string]*cluster.ClusterClient{
+		"dev": {Config: config.ClusterConfig{Name: "dev"}, Clientset: clientset, Connected: true},
+	}}
+
+	result := NewManager(provider).ListNamespaces([]string{"dev"}, "team=payments")
+	if len(result.Errors) > 0 {
+		t.Fatal(result.Errors)
+	}
+	statuses := map[string]string{}
+	for _, namespace := range result.Items {
+		statuses[namespace.Name] = namespace.Status
+		if namespace.ClusterName != "dev" || namespace.Labels["team"] != "payments" {
+			t.Errorf("Unexpected namespace %+v", namespace)
+		}
+	}
+	if len(statuses) != 2 || statuses["shop"] != "Active" || statuses["old-shop"] != "Terminating" {
+		t.Errorf("Expected shop Active and old-shop Terminating, got %v", statuses)
+	}
+}
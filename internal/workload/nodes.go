@@ -0,0 +1,110 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Node statuses, from the node's Ready condition
+const (
+	NodeReady       = "Ready"
+	NodeNotReady    = "NotReady"    // The kubelet reports the node isn't healthy
+	NodeUnreachable = "Unreachable" // The kubelet stopped reporting at all
+)
+
+// NodeInfo is one node in one cluster and whether it can run pods
+type NodeInfo struct {
+	ClusterName   string    `json:"clusterName"`
+	Name          string    `json:"name"`
+	Status        string    `json:"status"` // NodeReady, NodeNotReady or NodeUnreachable
+	Unschedulable bool      `json:"unschedulable,omitempty"`
+	Age           string    `json:"age"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// ListNodes retrieves the nodes of the specified clusters
+func (m *Manager) ListNodes(clusterNames []string) FleetResult[NodeInfo] {
+	clusterNames = m.connectedClusters(clusterNames)
+
+	return fanOut(clusterNames, m.timeouts, func(ctx context.Context, name string) ([]NodeInfo, error) {
+		client, err := m.clusterManager.GetClient(name)
+		if err != nil {
+			return nil, err
+		}
+		var nodes []NodeInfo
+		err = client.Do(ctx, func(ctx context.Context) error {
+			var listErr error
+			nodes, listErr = listNodes(ctx, client.Clientset, name)
+			return listErr
+		})
+		return nodes, err
+	})
+}
+
+// listNodes lists one cluster's nodes
+func listNodes(ctx context.Context, clientset kubernetes.Interface, clusterName string) ([]NodeInfo, error) {
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	result := make([]NodeInfo, 0, len(nodes.Items))
+	for _, node := range nodes.Items {
+		result = append(result, NodeInfo{
+			ClusterName:   clusterName,
+			Name:          node.Name,
+			Status:        nodeStatus(node),
+			Unschedulable: node.Spec.Unschedulable,
+			CreatedAt:     node.CreationTimestamp.Time,
+			Age:           formatDuration(time.Since(node.CreationTimestamp.Time)),
+		})
+	}
+	return result, nil
+}
+
+// nodeStatus reads a node's Ready condition. The node controller sets it to
+// Unknown once the kubelet misses its heartbeats, which is what an unreachable
+// node looks like; a node without the condition has never reported in
+func nodeStatus(node corev1.Node) string {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type != corev1.NodeReady {
+			continue
+		}
+		switch condition.Status {
+		case corev1.ConditionTrue:
+			return NodeReady
+		case corev1.ConditionFalse:
+			return NodeNotReady
+		}
+		return NodeUnreachable
+	}
+	return NodeUnreachable
+}
+
+// FlagPodsOnBadNodes sets NodeStatus on each pod whose node is NotReady or
+// Unreachable, and returns how many it flagged. Such a pod can still report
+// Running - its phase is whatever the kubelet last said - while nothing is
+// actually serving. Pods on nodes missing from nodes are left alone, so a
+// cluster whose nodes couldn't be listed isn't flagged wholesale
+func FlagPodsOnBadNodes(pods []PodInfo, nodes []NodeInfo) int {
+	statuses := make(map[string]string, len(nodes))
+	for _, node := range nodes {
+		statuses[node.ClusterName+"/"+node.Name] = node.Status
+	}
+
+	flagged := 0
+	for i := range pods {
+		status, ok := statuses[pods[i].ClusterName+"/"+pods[i].Node]
+		if !ok || status == NodeReady {
+			continue
+		}
+		pods[i].NodeStatus = status
+		flagged++
+	}
+	return flagged
+}
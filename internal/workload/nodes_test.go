@@ -0,0 +1,47 @@
+package workload
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestFlagPodsOnBadNodes(t *testing.T) {
+	readyNode := func(name string, status corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}}},
+		}
+	}
+	clientset := fake.NewClientset(
+		readyNode("node-a", corev1.ConditionTrue),
+		readyNode("node-b", corev1.ConditionFalse),
+		readyNode("node-c", corev1.ConditionUnknown),
+	)
+
+	nodes, err := listNodes(context.Background(), clientset, "prod-us")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pods := []PodInfo{
+		{ClusterName: "prod-us", Name: "web-1", Status: "Running", Node: "node-a"},
+		{ClusterName: "prod-us", Name: "web-2", Status: "Running", Node: "node-b"},
+		{ClusterName: "prod-us", Name: "web-3", Status: "Running", Node: "node-c"},
+		{ClusterName: "prod-us", Name: "web-4", Status: "Pending", Node: "unscheduled"},
+		{ClusterName: "prod-eu", Name: "web-5", Status: "Running", Node: "node-b"}, // Same node name, other cluster
+	}
+	if flagged := FlagPodsOnBadNodes(pods, nodes); flagged != 2 {
+		t.Errorf("Expected 2 pods flagged, got %d", flagged)
+	}
+
+	want := []string{"", NodeNotReady, NodeUnreachable, "", ""}
+	for i, pod := range pods {
+		if pod.NodeStatus != want[i] {
+			t.Errorf("%s: expected node status %q, got %q", pod.Name, want[i], pod.NodeStatus)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package workload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+)
+
+// PlanRecreate is planned for an update that changes an immutable field when
+// the deploy may delete and recreate (ForceRecreate)
+const PlanRecreate = "recreate"
+
+// ResourcePlan is what a deploy would do to one resource in one cluster
+type ResourcePlan struct {
+	Cluster   string   `json:"cluster"`
+	Kind      string   `json:"kind"`
+	Namespace string   `json:"namespace,omitempty"`
+	Name      string   `json:"name"`
+	Action    string   `json:"action"`            // PlanCreate, PlanUpdate, PlanRecreate, PlanUnchanged or PlanRefuse
+	Changes   []string `json:"changes,omitempty"` // Field paths an update changes, e.g. spec.replicas
+	Reason    string   `json:"reason,omitempty"`  // Why a resource is refused, or a note on a create
+}
+
+// Resource names the planned resource as Kind/namespace/name
+func (p ResourcePlan) Resource() string {
+	return p.Kind + "/" + objectPath(p.Namespace, p.Name)
+}
+
+// PlanManifest works out, per cluster and per resource, what deploying the
+// manifest would do. Every object is sent to the API server as a server-side
+// apply with DryRun=All, and the answer is compared with the live object, so
+// an update is only planned when the server would really store something
+// different - defaults and webhooks included. Nothing is changed. Results are
+// sorted by resource, then cluster
+func (m *Manager) PlanManifest(clusterNames []string, namespace, yamlContent string, opts DeployOptions) FleetResult[ResourcePlan] {
+	clusterNames = m.connectedClusters(clusterNames)
+
+	result := fanOut(clusterNames, m.timeouts, func(ctx context.Context, name string) ([]ResourcePlan, error) {
+		client, err := m.clusterManager.GetClient(name)
+		if err != nil {
+			return nil, err
+		}
+		rendered, err := opts.render(yamlContent, client.Config.Environment)
+		if err != nil {
+			return nil, err
+		}
+		objects, err := DecodeManifests(rendered)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse YAML: %w", err)
+		}
+		if client.Dynamic == nil {
+			return nil, fmt.Errorf("cluster has no dynamic client available")
+		}
+		resolver, err := m.newKindResolver(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover API resources: %w", err)
+		}
+
+		plans := make([]ResourcePlan, 0, len(objects))
+		for _, obj := range objects {
+			plan := ResourcePlan{Cluster: name, Kind: obj.GetKind(), Namespace: obj.GetNamespace(), Name: obj.GetName()}
+			mapping, err := resolver.RESTMapping(obj.GroupVersionKind())
+			if err != nil {
+				plan.Action = PlanRefuse
+				plan.Reason = fmt.Sprintf("kind %s is not served by this cluster", obj.GetKind())
+				plans = append(plans, plan)
+				continue
+			}
+			resource := resourceInterface(client.Dynamic, mapping, obj, namespace)
+			prepareObject(obj, obj.GetNamespace(), opts)
+			if obj.GetKind() == "Deployment" && opts.ProgressDeadline > 0 {
+				_ = unstructured.SetNestedField(obj.Object, int64(opts.ProgressDeadline.Seconds()), "spec", "progressDeadlineSeconds")
+			}
+			plan.Namespace = obj.GetNamespace()
+			namespaced := mapping.Scope.Name() == meta.RESTScopeNameNamespace
+
+			err = client.Do(ctx, func(ctx context.Context) error {
+				var planErr error
+				plan, planErr = planResource(ctx, client.Clientset, resource, obj, namespaced, plan, opts)
+				return planErr
+			})
+			if err != nil {
+				return nil, err
+			}
+			plans = append(plans, plan)
+		}
+		return plans, nil
+	})
+
+	sort.SliceStable(result.Items, func(i, j int) bool {
+		a, b := result.Items[i], result.Items[j]
+		if a.Resource() != b.Resource() {
+			return a.Resource() < b.Resource()
+		}
+		return a.Cluster < b.Cluster
+	})
+	return result
+}
+
+// planResource plans one object in one cluster, following the same decisions
+// a deploy makes, in the same order. Only failing to reach the cluster is an
+// error; anything the deploy itself would fail on is planned as a refusal
+func planResource(ctx context.Context, clientset kubernetes.Interface, resource dynamic.ResourceInterface,
+	obj *unstructured.Unstructured, namespaced bool, plan ResourcePlan, opts DeployOptions) (ResourcePlan, error) {
+
+	refuse := func(reason string) (ResourcePlan, error) {
+		plan.Action = PlanRefuse
+		plan.Reason = reason
+		return plan, nil
+	}
+
+	// generateName objects are created afresh by every deploy
+	if obj.GetName() == "" {
+		plan.Name = generatedNamePlaceholder(obj.GetGenerateName())
+		plan.Action = PlanCreate
+		plan.Reason = "generateName: a new object on every deploy"
+		return plan, nil
+	}
+
+	live, err := resource.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		live = nil
+		// There's nothing to dry-run against in a namespace that doesn't exist yet
+		if namespaced {
+			_, err := clientset.CoreV1().Namespaces().Get(ctx, obj.GetNamespace(), metav1.GetOptions{})
+			switch {
+			case apierrors.IsNotFound(err) && !opts.CreateNamespace:
+				return refuse(fmt.Sprintf("namespace '%s' does not exist (use --create-namespace)", obj.GetNamespace()))
+			case apierrors.IsNotFound(err):
+				plan.Action = PlanCreate
+				plan.Reason = fmt.Sprintf("namespace '%s' is created first", obj.GetNamespace())
+				return plan, nil
+			case err != nil:
+				return plan, fmt.Errorf("failed to read namespace %s: %w", obj.GetNamespace(), err)
+			}
+		}
+	case err != nil:
+		return plan, fmt.Errorf("failed to read %s: %w", plan.Resource(), err)
+	case opts.CreateOnly:
+		return refuse("it already exists (--if-not-exists)")
+	case opts.ManagedByKey != "" && live.GetLabels()[opts.ManagedByKey] != opts.ManagedByValue:
+		return refuse(fmt.Sprintf("it is not managed by mcm (missing label %s=%s)", opts.ManagedByKey, opts.ManagedByValue))
+	}
+
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return plan, fmt.Errorf("failed to encode %s: %w", plan.Resource(), err)
+	}
+	force := opts.ForceConflicts
+	applied, err := resource.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+		FieldManager: FieldManager,
+		Force:        &force,
+		DryRun:       []string{metav1.DryRunAll},
+	})
+	switch {
+	case err != nil && live != nil && opts.ForceRecreate && IsImmutableFieldError(err):
+		plan.Action = PlanRecreate
+		plan.Reason = err.Error()
+		return plan, nil
+	case err != nil && !isRefusal(err):
+		return plan, err
+	case err != nil:
+		return refuse(asAdmissionError(withConflictHint(err)).Error())
+	case live == nil:
+		plan.Action = PlanCreate
+		return plan, nil
+	}
+
+	var fields []FieldDiff
+	diffValues("", comparableObject(live.Object), comparableObject(applied.Object), &fields)
+	if len(fields) == 0 {
+		plan.Action = PlanUnchanged
+		return plan, nil
+	}
+	plan.Action = PlanUpdate
+	for _, field := range fields {
+		plan.Changes = append(plan.Changes, field.Path)
+	}
+	sort.Strings(plan.Changes)
+	return plan, nil
+}
+
+// isRefusal tells the API server saying no to an object - invalid, conflicting,
+// denied by a webhook - apart from failing to reach it
+func isRefusal(err error) bool {
+	var status apierrors.APIStatus
+	return errors.As(err, &status) && !cluster.IsRetryable(err)
+}
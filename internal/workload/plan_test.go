@@ -0,0 +1,98 @@
+package workload
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+func TestPlanManifest(t *testing.T) {
+	configMap := func(mode string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("ConfigMap")
+		obj.SetName("settings")
+		obj.SetNamespace("web")
+		_ = unstructured.SetNestedField(obj.Object, mode, "data", "mode")
+		return obj
+	}
+	web := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "web"}}
+
+	// blue already runs the manifest, green runs an older one, new has nothing
+	// yet and bare doesn't even have the namespace
+	applies := 0
+	clusterWith := func(name string, namespaced bool, objects ...runtime.Object) *cluster.ClusterClient {
+		dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), objects...)
+		// The fake can't merge an apply into a live object, and drops the patch
+		// options; answering with the manifest stands in for an API server that
+		// adds no defaults
+		dynamicClient.PrependReactor("patch", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			applies++
+			patch := action.(k8stesting.PatchAction)
+			applied := &unstructured.Unstructured{}
+			return true, applied, applied.UnmarshalJSON(patch.GetPatch())
+		})
+		clientset := fake.NewClientset()
+		if namespaced {
+			clientset = fake.NewClientset(web.DeepCopy())
+		}
+		return &cluster.ClusterClient{Config: config.ClusterConfig{Name: name}, Clientset: clientset, Dynamic: dynamicClient, Connected: true}
+	}
+	provider := &mapperProvider{
+		fakeProvider: fakeProvider{clients: map[string]*cluster.ClusterClient{
+			"blue":  clusterWith("blue", true, configMap("dark")),
+			"green": clusterWith("green", true, configMap("light")),
+			"new":   clusterWith("new", true),
+			"bare":  clusterWith("bare", false),
+		}},
+		mappers: []meta.RESTMapper{mapperWith(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"})},
+	}
+
+	manifest := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\ndata:\n  mode: dark\n---\napiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: w\n"
+	result := NewManager(provider).PlanManifest([]string{"blue", "green", "new", "bare"}, "web", manifest, DeployOptions{})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors %v", result.Errors)
+	}
+
+	var got []string
+	for _, plan := range result.Items {
+		got = append(got, plan.Resource()+"@"+plan.Cluster+"="+plan.Action+" "+strings.Join(plan.Changes, ","))
+	}
+	want := []string{
+		"ConfigMap/web/settings@bare=refuse ",
+		"ConfigMap/web/settings@blue=unchanged ",
+		"ConfigMap/web/settings@green=update data.mode",
+		"ConfigMap/web/settings@new=create ",
+		"Widget/w@bare=refuse ",
+		"Widget/w@blue=refuse ",
+		"Widget/w@green=refuse ",
+		"Widget/w@new=refuse ",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected plan:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+	if applies != 3 {
+		t.Errorf("Expected an apply in each cluster with the namespace, got %d", applies)
+	}
+	if reason := result.Items[0].Reason; !strings.Contains(reason, "--create-namespace") {
+		t.Errorf("Expected the missing namespace to point at --create-namespace, got %q", reason)
+	}
+
+	// Create-only deploys refuse what already exists
+	createOnly := NewManager(provider).PlanManifest([]string{"blue"}, "web", manifest, DeployOptions{CreateOnly: true})
+	if plan := createOnly.Items[0]; plan.Action != PlanRefuse || !strings.Contains(plan.Reason, "--if-not-exists") {
+		t.Errorf("Expected --if-not-exists to refuse the existing ConfigMap, got %+v", plan)
+	}
+}
@@ -0,0 +1,101 @@
+package workload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// recreatePollInterval is how often a recreate checks whether the deleted object is gone
+var recreatePollInterval = time.Second
+
+// immutableFieldMarkers are the API server's ways of saying an update touched a
+// field that can only be set when the object is created
+var immutableFieldMarkers = []string{
+	"field is immutable",
+	"updates to statefulset spec for fields other than",
+}
+
+// RecreatedResource is an object ForceRecreate deleted and created again because
+// the manifest changed one of its immutable fields
+type RecreatedResource struct {
+	Cluster   string
+	Kind      string
+	Namespace string // Empty for cluster-scoped objects
+	Name      string
+	Reason    string // The API server's refusal of the update
+}
+
+// IsImmutableFieldError reports whether err is the API server refusing an update
+// because it changes a field fixed at creation, such as a Job's selector or a
+// Service's clusterIP - the one kind of failure --force-recreate gets past
+func IsImmutableFieldError(err error) bool {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) || !apierrors.IsInvalid(err) {
+		return false
+	}
+
+	messages := []string{status.Status().Message}
+	if details := status.Status().Details; details != nil {
+		for _, cause := range details.Causes {
+			messages = append(messages, cause.Message)
+		}
+	}
+	for _, message := range messages {
+		for _, marker := range immutableFieldMarkers {
+			if strings.Contains(message, marker) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// recreate deletes an object, waits until the API server no longer has it, and
+// applies it again. The delete cascades in the background, so the object's pods
+// go with it: this is the disruption --force-recreate warns about
+func recreate(ctx context.Context, get, remove, apply func(ctx context.Context) error) error {
+	if err := remove(ctx); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete for recreation: %w", err)
+	}
+
+	// Finalizers can keep a deleted object around for a while; creating it again
+	// before it's gone would fail with "object is being deleted"
+	for {
+		err := get(ctx)
+		if apierrors.IsNotFound(err) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to check the deleted object is gone: %w", err)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("deleted, but still waiting for it to go away when recreating: %w", ctx.Err())
+		case <-time.After(recreatePollInterval):
+		}
+	}
+
+	if err := apply(ctx); err != nil {
+		return fmt.Errorf("deleted, but failed to create it again: %w", err)
+	}
+	return nil
+}
+
+// recreated reports a recreated object to OnRecreate, when it's set
+func (opts DeployOptions) recreated(resource RecreatedResource) {
+	if opts.OnRecreate != nil {
+		opts.OnRecreate(resource)
+	}
+}
+
+// backgroundDeletion cascades a recreate's delete to the object's dependents
+func backgroundDeletion() metav1.DeleteOptions {
+	propagation := metav1.DeletePropagationBackground
+	return metav1.DeleteOptions{PropagationPolicy: &propagation}
+}
@@ -0,0 +1,97 @@
+package workload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+func immutableFieldError() error {
+	return apierrors.NewInvalid(schema.GroupKind{Group: "apps", Kind: "StatefulSet"}, "db", field.ErrorList{
+		field.Forbidden(field.NewPath("spec"), "updates to statefulset spec for fields other than 'replicas', 'template' and 'updateStrategy' are forbidden"),
+	})
+}
+
+func TestIsImmutableFieldError(t *testing.T) {
+	serviceIP := apierrors.NewInvalid(schema.GroupKind{Kind: "Service"}, "web", field.ErrorList{
+		field.Invalid(field.NewPath("spec", "clusterIP"), "10.0.0.9", "field is immutable"),
+	})
+	otherInvalid := apierrors.NewInvalid(schema.GroupKind{Kind: "Service"}, "web", field.ErrorList{
+		field.Required(field.NewPath("spec", "ports"), ""),
+	})
+
+	for _, tt := range []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"statefulset spec", immutableFieldError(), true},
+		{"service clusterIP, wrapped", fmt.Errorf("failed to apply service: %w", serviceIP), true},
+		{"other validation error", otherInvalid, false},
+		{"conflict", apierrors.NewConflict(schema.GroupResource{Resource: "services"}, "web", errors.New("field is immutable")), false},
+	} {
+		if got := IsImmutableFieldError(tt.err); got != tt.want {
+			t.Errorf("%s: IsImmutableFieldError() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDeployForceRecreateOnImmutableChange(t *testing.T) {
+	clientset := fake.NewClientset()
+	provider := &fakeProvider{clients: map[string]*cluster.ClusterClient{
+		"prod": {Config: config.ClusterConfig{Name: "prod"}, Clientset: clientset, Connected: true},
+	}}
+	manager := NewManager(provider)
+
+	manifest := "apiVersion: apps/v1\nkind: StatefulSet\nmetadata:\n  name: db\nspec:\n  serviceName: %s\n"
+	if err := manager.DeployToClusterWithOptions("prod", "data", fmt.Sprintf(manifest, "db"), DeployOptions{}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	// The fake doesn't validate, so refuse changes to an existing StatefulSet like the API server would
+	clientset.PrependReactor("patch", "statefulsets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if _, err := clientset.Tracker().Get(action.GetResource(), "data", "db"); err == nil {
+			return true, nil, immutableFieldError()
+		}
+		return false, nil, nil
+	})
+
+	err := manager.DeployToClusterWithOptions("prod", "data", fmt.Sprintf(manifest, "db-headless"), DeployOptions{})
+	if !IsImmutableFieldError(err) {
+		t.Fatalf("Expected the immutable-field error without --force-recreate, got %v", err)
+	}
+
+	var recreated []RecreatedResource
+	opts := DeployOptions{ForceRecreate: true, OnRecreate: func(resource RecreatedResource) {
+		recreated = append(recreated, resource)
+	}}
+	if err := manager.DeployToClusterWithOptions("prod", "data", fmt.Sprintf(manifest, "db-headless"), opts); err != nil {
+		t.Fatalf("Forced recreate failed: %v", err)
+	}
+
+	if len(recreated) != 1 {
+		t.Fatalf("Expected one recreated resource, got %+v", recreated)
+	}
+	if got := recreated[0]; got.Cluster != "prod" || got.Kind != "StatefulSet" || got.Namespace != "data" || got.Name != "db" || got.Reason == "" {
+		t.Errorf("Unexpected recreated resource %+v", got)
+	}
+	statefulSet, err := clientset.AppsV1().StatefulSets("data").Get(context.Background(), "db", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected the StatefulSet to exist again: %v", err)
+	}
+	if statefulSet.Spec.ServiceName != "db-headless" {
+		t.Errorf("Expected the recreated StatefulSet to have the new serviceName, got %q", statefulSet.Spec.ServiceName)
+	}
+}
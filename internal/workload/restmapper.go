@@ -0,0 +1,93 @@
+package workload
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/restmapper"
+)
+
+// kindResolver resolves kinds to API resources in one cluster for the length of a single operation
+// It starts from the cluster's cached mapper and rediscovers at most once, the first time a kind
+// is unknown - usually a CRD installed after the mapper was cached. Capping it at once keeps a
+// manifest full of genuinely unsupported kinds from triggering a discovery per object.
+type kindResolver struct {
+	provider    ClientProvider
+	clusterName string
+	mapper      meta.RESTMapper
+	refreshed   bool
+}
+
+// newKindResolver starts a resolver from the cluster's cached REST mapper
+func (m *Manager) newKindResolver(clusterName string) (*kindResolver, error) {
+	mapper, err := m.clusterManager.RESTMapper(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return &kindResolver{provider: m.clusterManager, clusterName: clusterName, mapper: mapper}, nil
+}
+
+// RESTMapping resolves a kind, refreshing the cluster's cached mapper if the kind is unknown
+func (r *kindResolver) RESTMapping(gvk schema.GroupVersionKind) (*meta.RESTMapping, error) {
+	mapping, err := r.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err == nil || !meta.IsNoMatchError(err) || r.refreshed {
+		return mapping, err
+	}
+
+	r.refreshed = true
+	r.provider.InvalidateRESTMapper(r.clusterName)
+	mapper, refreshErr := r.provider.RESTMapper(r.clusterName)
+	if refreshErr != nil {
+		return nil, refreshErr
+	}
+	r.mapper = mapper
+	return r.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+}
+
+// ResourceFor resolves a resource as typed on the command line - "deployments",
+// "deployment", "deploy", "ingresses.networking.k8s.io" - to its REST mapping.
+// Short names come from the cluster's discovery data, since the same short name
+// can mean different things on clusters with different CRDs installed
+func (r *kindResolver) ResourceFor(resource string, discoveryClient discovery.DiscoveryInterface) (*meta.RESTMapping, error) {
+	mapping, err := r.resourceFor(resource, discoveryClient)
+	if err == nil || !meta.IsNoMatchError(err) || r.refreshed {
+		return mapping, err
+	}
+
+	r.refreshed = true
+	r.provider.InvalidateRESTMapper(r.clusterName)
+	mapper, refreshErr := r.provider.RESTMapper(r.clusterName)
+	if refreshErr != nil {
+		return nil, refreshErr
+	}
+	r.mapper = mapper
+	return r.resourceFor(resource, discoveryClient)
+}
+
+// resourceFor resolves a resource name against the current mapper, like kubectl:
+// a name that spells out a version ("deployments.v1.apps") is tried as such
+// first, and otherwise as resource.group
+func (r *kindResolver) resourceFor(resource string, discoveryClient discovery.DiscoveryInterface) (*meta.RESTMapping, error) {
+	mapper := restmapper.NewShortcutExpander(r.mapper, discoveryClient, nil)
+
+	fullySpecified, groupResource := schema.ParseResourceArg(strings.ToLower(resource))
+	var gvr schema.GroupVersionResource
+	var err error
+	if fullySpecified != nil {
+		gvr, err = mapper.ResourceFor(*fullySpecified)
+	}
+	if fullySpecified == nil || err != nil {
+		gvr, err = mapper.ResourceFor(groupResource.WithVersion(""))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	gvk, err := mapper.KindFor(gvr)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+}
@@ -0,0 +1,62 @@
+package workload
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// mapperProvider hands out a fixed sequence of mappers and records invalidations
+type mapperProvider struct {
+	fakeProvider
+	mappers       []meta.RESTMapper
+	calls         int
+	invalidations int
+}
+
+func (p *mapperProvider) RESTMapper(clusterName string) (meta.RESTMapper, error) {
+	mapper := p.mappers[p.calls]
+	if p.calls < len(p.mappers)-1 {
+		p.calls++
+	}
+	return mapper, nil
+}
+
+func (p *mapperProvider) InvalidateRESTMapper(clusterName string) {
+	p.invalidations++
+}
+
+func mapperWith(kinds ...schema.GroupVersionKind) meta.RESTMapper {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	for _, gvk := range kinds {
+		mapper.Add(gvk, meta.RESTScopeNamespace)
+	}
+	return mapper
+}
+
+func TestKindResolverRefreshesOnceForUnknownKinds(t *testing.T) {
+	configMap := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	widget := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	gadget := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Gadget"}
+
+	// The cached mapper predates the Widget CRD; rediscovery finds it
+	provider := &mapperProvider{mappers: []meta.RESTMapper{mapperWith(configMap), mapperWith(configMap, widget)}}
+	resolver, err := NewManager(provider).newKindResolver("prod")
+	if err != nil {
+		t.Fatalf("newKindResolver failed: %v", err)
+	}
+
+	if _, err := resolver.RESTMapping(configMap); err != nil || provider.invalidations != 0 {
+		t.Fatalf("known kind: err=%v invalidations=%d, want no refresh", err, provider.invalidations)
+	}
+	if _, err := resolver.RESTMapping(widget); err != nil {
+		t.Fatalf("expected the newly installed CRD to resolve after refresh, got %v", err)
+	}
+	if _, err := resolver.RESTMapping(gadget); err == nil {
+		t.Fatal("expected a kind no cluster serves to stay unresolved")
+	}
+	if provider.invalidations != 1 {
+		t.Errorf("invalidations = %d, want exactly 1 per operation", provider.invalidations)
+	}
+}
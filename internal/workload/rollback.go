@@ -0,0 +1,160 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// revisionAnnotation numbers a deployment's rollouts; the deployment controller
+// sets it on the deployment and on the ReplicaSet each pod template got
+const revisionAnnotation = "deployment.kubernetes.io/revision"
+
+// RollbackResult is what RollbackDeployment did in one cluster
+// FromRevision equals ToRevision when the deployment already ran that revision
+// and was left alone
+type RollbackResult struct {
+	ClusterName  string `json:"clusterName"`
+	Namespace    string `json:"namespace"`
+	Deployment   string `json:"deployment"`
+	FromRevision int64  `json:"fromRevision"`
+	ToRevision   int64  `json:"toRevision"`
+}
+
+// RolledBack reports whether the deployment's pod template was changed
+func (r RollbackResult) RolledBack() bool {
+	return r.FromRevision != r.ToRevision
+}
+
+// RollbackDeployment rolls a deployment back to an earlier revision in every
+// given cluster, like kubectl rollout undo: the pod template of the ReplicaSet
+// holding that revision is copied back into the deployment, which then rolls
+// out as usual. A toRevision of 0 means the revision before the current one.
+// A cluster without that revision - or without any earlier one - fails on its own
+func (m *Manager) RollbackDeployment(clusterNames []string, namespace, name string, toRevision int64) FleetResult[RollbackResult] {
+	clusterNames = m.connectedClusters(clusterNames)
+
+	return fanOut(clusterNames, m.timeouts, func(ctx context.Context, clusterName string) ([]RollbackResult, error) {
+		client, err := m.clusterManager.GetClient(clusterName)
+		if err != nil {
+			return nil, err
+		}
+		var result RollbackResult
+		err = client.Do(ctx, func(ctx context.Context) error {
+			var rollbackErr error
+			result, rollbackErr = rollbackDeployment(ctx, client.Clientset, namespace, name, toRevision)
+			return rollbackErr
+		})
+		if err != nil {
+			return nil, err
+		}
+		result.ClusterName = clusterName
+		return []RollbackResult{result}, nil
+	})
+}
+
+// rollbackDeployment rolls back one cluster's deployment, re-reading it if someone
+// else changed it between our read and our write
+func rollbackDeployment(ctx context.Context, clientset kubernetes.Interface, namespace, name string, toRevision int64) (RollbackResult, error) {
+	deployments := clientset.AppsV1().Deployments(namespace)
+	path := objectPath(namespace, name)
+
+	var result RollbackResult
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		deployment, err := deployments.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if deployment.Spec.Paused {
+			return fmt.Errorf("deployment %s is paused; resume it before rolling back", path)
+		}
+
+		current, _ := revisionOf(deployment.ObjectMeta)
+		result = RollbackResult{Namespace: deployment.Namespace, Deployment: deployment.Name, FromRevision: current}
+
+		history, err := revisionHistory(ctx, clientset, deployment)
+		if err != nil {
+			return err
+		}
+		target, err := rollbackTarget(history, current, toRevision)
+		if err != nil {
+			return fmt.Errorf("deployment %s: %w", path, err)
+		}
+		result.ToRevision = target
+		if target == current {
+			return nil
+		}
+
+		template := history[target].Spec.Template.DeepCopy()
+		delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+		deployment.Spec.Template = *template
+		_, err = deployments.Update(ctx, deployment, metav1.UpdateOptions{})
+		return err
+	})
+	if apierrors.IsNotFound(err) {
+		return result, fmt.Errorf("deployment %s not found", path)
+	}
+	if err != nil {
+		return result, fmt.Errorf("failed to roll back: %w", err)
+	}
+	return result, nil
+}
+
+// revisionHistory finds the ReplicaSets the deployment controls, by revision
+func revisionHistory(ctx context.Context, clientset kubernetes.Interface, deployment *appsv1.Deployment) (map[int64]*appsv1.ReplicaSet, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("deployment has an invalid selector: %w", err)
+	}
+	replicaSets, err := clientset.AppsV1().ReplicaSets(deployment.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replica sets: %w", err)
+	}
+
+	history := make(map[int64]*appsv1.ReplicaSet, len(replicaSets.Items))
+	for i := range replicaSets.Items {
+		rs := &replicaSets.Items[i]
+		owner := metav1.GetControllerOf(rs)
+		if owner == nil || owner.UID != deployment.UID {
+			continue
+		}
+		if revision, ok := revisionOf(rs.ObjectMeta); ok {
+			history[revision] = rs
+		}
+	}
+	return history, nil
+}
+
+// rollbackTarget picks the revision to go back to: the one asked for, or the
+// newest one before current
+func rollbackTarget(history map[int64]*appsv1.ReplicaSet, current, toRevision int64) (int64, error) {
+	if toRevision > 0 {
+		if _, ok := history[toRevision]; !ok {
+			return 0, fmt.Errorf("revision %d not found in the rollout history", toRevision)
+		}
+		return toRevision, nil
+	}
+
+	var previous int64
+	for revision := range history {
+		if revision < current && revision > previous {
+			previous = revision
+		}
+	}
+	if previous == 0 {
+		return 0, fmt.Errorf("no previous revision to roll back to (current revision is %d)", current)
+	}
+	return previous, nil
+}
+
+// revisionOf reads the deployment controller's revision annotation
+func revisionOf(meta metav1.ObjectMeta) (int64, bool) {
+	revision, err := strconv.ParseInt(meta.Annotations[revisionAnnotation], 10, 64)
+	return revision, err == nil
+}
@@ -0,0 +1,112 @@
+package workload
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// rollbackFixture is a deployment at revision current with one ReplicaSet per
+// given revision, each running image web:<revision>
+func rollbackFixture(current int64, revisions ...int64) []runtime.Object {
+	labels := map[string]string{"app": "web"}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "prod", UID: types.UID("web-uid"),
+			Annotations: map[string]string{revisionAnnotation: strconv.FormatInt(current, 10)}},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "web", Image: "web:" + strconv.FormatInt(current, 10)}}},
+			},
+		},
+	}
+	objects := []runtime.Object{deployment}
+
+	isController := true
+	for _, revision := range revisions {
+		version := strconv.FormatInt(revision, 10)
+		objects = append(objects, &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-" + version, Namespace: "prod", Labels: labels,
+				Annotations:     map[string]string{revisionAnnotation: version},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", UID: "web-uid", Controller: &isController}}},
+			Spec: appsv1.ReplicaSetSpec{Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web", appsv1.DefaultDeploymentUniqueLabelKey: "hash-" + version}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "web", Image: "web:" + version}}},
+			}},
+		})
+	}
+	return objects
+}
+
+func TestRollbackDeployment(t *testing.T) {
+	tests := []struct {
+		name       string
+		current    int64
+		revisions  []int64
+		toRevision int64
+		wantTo     int64
+		wantErr    string
+	}{
+		{name: "previous", current: 3, revisions: []int64{1, 2, 3}, wantTo: 2},
+		{name: "skips gaps", current: 5, revisions: []int64{2, 5}, wantTo: 2},
+		{name: "to revision", current: 3, revisions: []int64{1, 2, 3}, toRevision: 1, wantTo: 1},
+		{name: "already there", current: 3, revisions: []int64{1, 2, 3}, toRevision: 3, wantTo: 3},
+		{name: "no history", current: 1, revisions: []int64{1}, wantErr: "no previous revision"},
+		{name: "unknown revision", current: 3, revisions: []int64{2, 3}, toRevision: 1, wantErr: "revision 1 not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset(rollbackFixture(tt.current, tt.revisions...)...)
+			result, err := rollbackDeployment(context.Background(), clientset, "prod", "web", tt.toRevision)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.FromRevision != tt.current || result.ToRevision != tt.wantTo {
+				t.Errorf("Expected %d → %d, got %d → %d", tt.current, tt.wantTo, result.FromRevision, result.ToRevision)
+			}
+
+			deployment, _ := clientset.AppsV1().Deployments("prod").Get(context.Background(), "web", metav1.GetOptions{})
+			template := deployment.Spec.Template
+			if image := template.Spec.Containers[0].Image; image != "web:"+strconv.FormatInt(tt.wantTo, 10) {
+				t.Errorf("Expected the template of revision %d, got image %s", tt.wantTo, image)
+			}
+			if _, ok := template.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; ok {
+				t.Errorf("The ReplicaSet's pod-template-hash label must not be copied: %v", template.Labels)
+			}
+		})
+	}
+}
+
+func TestRollbackDeploymentRefusesPaused(t *testing.T) {
+	objects := rollbackFixture(2, 1, 2)
+	objects[0].(*appsv1.Deployment).Spec.Paused = true
+	clientset := fake.NewSimpleClientset(objects...)
+
+	_, err := rollbackDeployment(context.Background(), clientset, "prod", "web", 0)
+	if err == nil || !strings.Contains(err.Error(), "paused") {
+		t.Errorf("Expected a paused deployment to be refused, got %v", err)
+	}
+}
+
+func TestRollbackDeploymentNotFound(t *testing.T) {
+	_, err := rollbackDeployment(context.Background(), fake.NewSimpleClientset(), "prod", "web", 0)
+	if err == nil || !strings.Contains(err.Error(), "deployment prod/web not found") {
+		t.Errorf("Expected not found, got %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package workload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DefaultRolloutTimeout bounds how long deploy --wait follows a single rollout
+const DefaultRolloutTimeout = 5 * time.Minute
+
+// rolloutPollInterval is how often a waiting rollout re-reads its deployment
+const rolloutPollInterval = 2 * time.Second
+
+// progressDeadlineExceeded is the Progressing condition reason the deployment
+// controller sets once a rollout has made no progress for progressDeadlineSeconds
+const progressDeadlineExceeded = "ProgressDeadlineExceeded"
+
+// RolloutStatus reports whether a deployment has finished rolling out, using the same
+// rules as `kubectl rollout status`. Comparing ReadyReplicas to Replicas is not enough:
+// it looks finished while old pods are still draining, and it happily waits forever on
+// a rollout the controller has already given up on.
+//
+// The checks, in order:
+//   - the controller must have observed the latest spec (Generation <= ObservedGeneration),
+//     which also covers specs rewritten by mutating webhooks after we applied them
+//   - a Progressing condition with reason ProgressDeadlineExceeded is a hard failure,
+//     and so are pods an admission webhook or policy refuses to let in
+//   - every desired replica must be updated, no old replicas may remain,
+//     and every updated replica must be available
+//
+// The returned message describes what the rollout is still waiting for.
+func RolloutStatus(deployment *appsv1.Deployment) (message string, done bool, err error) {
+	if deployment.Generation > deployment.Status.ObservedGeneration {
+		return "waiting for deployment spec update to be observed", false, nil
+	}
+
+	if cond := deploymentCondition(deployment.Status, appsv1.DeploymentProgressing); cond != nil && cond.Reason == progressDeadlineExceeded {
+		return "", false, fmt.Errorf("deployment %q exceeded its progress deadline", deployment.Name)
+	}
+	if err := podAdmissionFailure(deployment); err != nil {
+		return "", false, err
+	}
+
+	status := deployment.Status
+	if deployment.Spec.Replicas != nil && status.UpdatedReplicas < *deployment.Spec.Replicas {
+		return fmt.Sprintf("%d out of %d new replicas have been updated", status.UpdatedReplicas, *deployment.Spec.Replicas), false, nil
+	}
+	if status.Replicas > status.UpdatedReplicas {
+		return fmt.Sprintf("%d old replicas are pending termination", status.Replicas-status.UpdatedReplicas), false, nil
+	}
+	if status.AvailableReplicas < status.UpdatedReplicas {
+		return fmt.Sprintf("%d of %d updated replicas are available", status.AvailableReplicas, status.UpdatedReplicas), false, nil
+	}
+
+	return fmt.Sprintf("deployment %q successfully rolled out", deployment.Name), true, nil
+}
+
+// deploymentCondition returns the condition of the given type, or nil if it isn't set
+func deploymentCondition(status appsv1.DeploymentStatus, condType appsv1.DeploymentConditionType) *appsv1.DeploymentCondition {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == condType {
+			return &status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// waitForRollout polls a deployment until RolloutStatus reports it done, fails,
+// or the context expires. A stuck rollout fails as soon as the controller marks it,
+// not when our own timeout runs out.
+func waitForRollout(ctx context.Context, clientset kubernetes.Interface, namespace, name string, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastMessage := "waiting for first status"
+	for {
+		deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("timed out waiting for rollout of %s/%s: %s", namespace, name, lastMessage)
+			}
+			return fmt.Errorf("failed to get deployment %s/%s: %w", namespace, name, err)
+		}
+
+		message, done, err := RolloutStatus(deployment)
+		if err != nil {
+			return rolloutStuckError(deployment, err)
+		}
+		if done {
+			return nil
+		}
+		lastMessage = message
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for rollout of %s/%s: %s", namespace, name, lastMessage)
+		case <-ticker.C:
+		}
+	}
+}
+
+// rolloutStuckError explains a rollout the controller has given up on, using the
+// controller's own message (which names the ReplicaSet that timed out) when there is one
+func rolloutStuckError(deployment *appsv1.Deployment, err error) error {
+	var rejection *AdmissionError
+	if errors.As(err, &rejection) {
+		return err // Already says exactly why
+	}
+	if cond := deploymentCondition(deployment.Status, appsv1.DeploymentProgressing); cond != nil && cond.Message != "" {
+		return fmt.Errorf("rollout stuck: %s", cond.Message)
+	}
+	return fmt.Errorf("rollout stuck: %w", err)
+}
@@ -0,0 +1,152 @@
+package workload
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// rolloutDeployment builds a deployment at the given generation with the given status
+func rolloutDeployment(generation int64, replicas int32, status appsv1.DeploymentStatus) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Generation: generation},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status:     status,
+	}
+}
+
+func progressing(reason string) []appsv1.DeploymentCondition {
+	status := corev1.ConditionTrue
+	if reason == progressDeadlineExceeded {
+		status = corev1.ConditionFalse
+	}
+	return []appsv1.DeploymentCondition{
+		{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue, Reason: "MinimumReplicasAvailable"},
+		{Type: appsv1.DeploymentProgressing, Status: status, Reason: reason, Message: `ReplicaSet "web-abc" has timed out progressing.`},
+	}
+}
+
+func TestRolloutStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		deployment  *appsv1.Deployment
+		wantDone    bool
+		wantErr     bool
+		wantMessage string
+	}{
+		{
+			name: "complete",
+			deployment: rolloutDeployment(2, 3, appsv1.DeploymentStatus{
+				ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 3,
+				Conditions: progressing("NewReplicaSetAvailable"),
+			}),
+			wantDone: true,
+		},
+		{
+			// A mutating webhook bumped the generation after we applied; the old
+			// status still looks complete but describes the previous spec
+			name: "spec not yet observed",
+			deployment: rolloutDeployment(3, 3, appsv1.DeploymentStatus{
+				ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 3,
+			}),
+			wantMessage: "spec update to be observed",
+		},
+		{
+			name: "progress deadline exceeded",
+			deployment: rolloutDeployment(2, 3, appsv1.DeploymentStatus{
+				ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 1, ReadyReplicas: 3, AvailableReplicas: 3,
+				Conditions: progressing(progressDeadlineExceeded),
+			}),
+			wantErr: true,
+		},
+		{
+			name: "new replicas still being updated",
+			deployment: rolloutDeployment(2, 3, appsv1.DeploymentStatus{
+				ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 1, ReadyReplicas: 3, AvailableReplicas: 3,
+				Conditions: progressing("ReplicaSetUpdated"),
+			}),
+			wantMessage: "1 out of 3 new replicas have been updated",
+		},
+		{
+			// Ready == desired, but old pods are still around - a naive check passes here
+			name: "old replicas pending termination",
+			deployment: rolloutDeployment(2, 3, appsv1.DeploymentStatus{
+				ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 3,
+				Conditions: progressing("ReplicaSetUpdated"),
+			}),
+			wantMessage: "1 old replicas are pending termination",
+		},
+		{
+			name: "updated replicas not yet available",
+			deployment: rolloutDeployment(2, 3, appsv1.DeploymentStatus{
+				ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 2, AvailableReplicas: 2,
+				Conditions: progressing("ReplicaSetUpdated"),
+			}),
+			wantMessage: "2 of 3 updated replicas are available",
+		},
+		{
+			name: "scaled to zero",
+			deployment: rolloutDeployment(4, 0, appsv1.DeploymentStatus{
+				ObservedGeneration: 4,
+			}),
+			wantDone: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, done, err := RolloutStatus(tt.deployment)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if done != tt.wantDone {
+				t.Errorf("done = %v, want %v", done, tt.wantDone)
+			}
+			if !strings.Contains(message, tt.wantMessage) {
+				t.Errorf("message = %q, want it to contain %q", message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestWaitForRolloutFailsFastOnProgressDeadline(t *testing.T) {
+	deployment := rolloutDeployment(2, 3, appsv1.DeploymentStatus{
+		ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 1, AvailableReplicas: 3,
+		Conditions: progressing(progressDeadlineExceeded),
+	})
+	clientset := fake.NewSimpleClientset(deployment)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	start := time.Now()
+	err := waitForRollout(ctx, clientset, "default", "web", time.Hour)
+	if err == nil || err.Error() != `rollout stuck: ReplicaSet "web-abc" has timed out progressing.` {
+		t.Fatalf("expected a rollout stuck error with the controller's message, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("wait took %s, expected it to fail immediately", elapsed)
+	}
+}
+
+func TestWaitForRolloutTimesOut(t *testing.T) {
+	deployment := rolloutDeployment(2, 3, appsv1.DeploymentStatus{
+		ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 1,
+		Conditions: progressing("ReplicaSetUpdated"),
+	})
+	clientset := fake.NewSimpleClientset(deployment)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := waitForRollout(ctx, clientset, "default", "web", 10*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "1 of 3 updated replicas are available") {
+		t.Fatalf("expected timeout naming the pending condition, got %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+package workload
+
+import (
+	"encoding/json"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// sanitizedMetadata are the metadata fields the API server fills in, or that
+// point at other objects by their identity in the source cluster
+var sanitizedMetadata = []string{
+	"resourceVersion", "uid", "creationTimestamp", "generation", "managedFields", "selfLink", "ownerReferences",
+}
+
+// sanitizedAnnotations are annotations that describe an object's history in
+// one cluster rather than the object itself
+var sanitizedAnnotations = []string{
+	ClusterAnnotation,
+	revisionAnnotation,
+	"kubectl.kubernetes.io/last-applied-configuration",
+}
+
+// SanitizeForApply strips what an object picked up in the cluster it was read
+// from, so it can be applied cleanly to another: the metadata the API server
+// assigns (resourceVersion, uid, creationTimestamp, generation, managedFields),
+// owner references, status, and the spec fields the cluster allocated - a
+// Service's clusterIP and clusterIPs, its node ports, a Pod's nodeName.
+//
+// Node ports are only stripped when the API server picked them. One a field
+// manager set explicitly (recorded in managedFields) is part of the manifest
+// and kept, and so is a headless Service's clusterIP: None. Without
+// managedFields there is no telling who picked a node port, so all of them
+// are kept - an explicit one silently changing would be worse than a clash
+func SanitizeForApply(obj *unstructured.Unstructured) {
+	// Read before managedFields goes with the rest of the metadata
+	explicit, known := explicitServicePorts(obj)
+
+	for _, field := range sanitizedMetadata {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	unstructured.RemoveNestedField(obj.Object, "status")
+
+	if annotations := obj.GetAnnotations(); annotations != nil {
+		for _, annotation := range sanitizedAnnotations {
+			delete(annotations, annotation)
+		}
+		if len(annotations) == 0 {
+			unstructured.RemoveNestedField(obj.Object, "metadata", "annotations")
+		} else {
+			obj.SetAnnotations(annotations)
+		}
+	}
+
+	switch obj.GetKind() {
+	case "Service":
+		sanitizeService(obj, explicit, known)
+	case "Pod":
+		unstructured.RemoveNestedField(obj.Object, "spec", "nodeName")
+	}
+}
+
+// sanitizeService strips a Service's allocated addresses and, when known is
+// set, the node ports no field manager set explicitly
+func sanitizeService(obj *unstructured.Unstructured, explicit map[string]bool, known bool) {
+	spec, ok := obj.Object["spec"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	// Allocated from the source cluster's service CIDR; "None" is a choice, not an allocation
+	if spec["clusterIP"] != "None" {
+		delete(spec, "clusterIP")
+		delete(spec, "clusterIPs")
+	}
+	if !known {
+		return
+	}
+
+	if !explicit["healthCheckNodePort"] {
+		delete(spec, "healthCheckNodePort")
+	}
+	ports, _ := spec["ports"].([]interface{})
+	for _, port := range ports {
+		if port, ok := port.(map[string]interface{}); ok && !explicit[servicePortKey(port)] {
+			delete(port, "nodePort")
+		}
+	}
+}
+
+// explicitServicePorts reads a Service's managedFields for the node ports a
+// field manager set itself - keyed by servicePortKey, plus
+// "healthCheckNodePort" - rather than leaving them to the API server.
+// known is false when the object carries no managedFields to read
+func explicitServicePorts(obj *unstructured.Unstructured) (explicit map[string]bool, known bool) {
+	if obj.GetKind() != "Service" {
+		return nil, false
+	}
+	entries, found, _ := unstructured.NestedSlice(obj.Object, "metadata", "managedFields")
+	if !found || len(entries) == 0 {
+		return nil, false
+	}
+
+	explicit = make(map[string]bool)
+	for _, entry := range entries {
+		spec, _, _ := unstructured.NestedMap(asMap(entry), "fieldsV1", "f:spec")
+		if _, ok := spec["f:healthCheckNodePort"]; ok {
+			explicit["healthCheckNodePort"] = true
+		}
+		ports, _ := spec["f:ports"].(map[string]interface{})
+		for key, fields := range ports {
+			// Ports are keyed by their port and protocol, e.g. k:{"port":443,"protocol":"TCP"}
+			if _, ok := asMap(fields)["f:nodePort"]; !ok || !strings.HasPrefix(key, "k:") {
+				continue
+			}
+			var port map[string]interface{}
+			if json.Unmarshal([]byte(strings.TrimPrefix(key, "k:")), &port) == nil {
+				explicit[servicePortKey(port)] = true
+			}
+		}
+	}
+	return explicit, true
+}
+
+// servicePortKey identifies a Service port by its number and protocol, the
+// way managedFields does
+func servicePortKey(port map[string]interface{}) string {
+	protocol, _ := port["protocol"].(string)
+	if protocol == "" {
+		protocol = "TCP"
+	}
+	number, _ := json.Marshal(port["port"])
+	return string(number) + "/" + protocol
+}
+
+// asMap returns value as a JSON object, or nil when it isn't one
+func asMap(value interface{}) map[string]interface{} {
+	m, _ := value.(map[string]interface{})
+	return m
+}
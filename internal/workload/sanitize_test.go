@@ -0,0 +1,164 @@
+package workload
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// serverMetadata is the metadata an API server adds to every object it returns
+func serverMetadata(name string, extra map[string]interface{}) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"name":              name,
+		"namespace":         "shop",
+		"uid":               "4f1c2a9e",
+		"resourceVersion":   "81734",
+		"generation":        int64(7),
+		"creationTimestamp": "2026-09-01T10:00:00Z",
+		"managedFields":     []interface{}{map[string]interface{}{"manager": "kubectl", "operation": "Apply"}},
+		"labels":            map[string]interface{}{"app": "web"},
+	}
+	for key, value := range extra {
+		metadata[key] = value
+	}
+	return metadata
+}
+
+func TestSanitizeForApplyDeployment(t *testing.T) {
+	deployment := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": serverMetadata("web", map[string]interface{}{
+			"annotations": map[string]interface{}{revisionAnnotation: "4", "team": "payments"},
+		}),
+		"spec":   map[string]interface{}{"replicas": int64(3)},
+		"status": map[string]interface{}{"readyReplicas": int64(3)},
+	}}
+
+	SanitizeForApply(deployment)
+
+	want := map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":        "web",
+			"namespace":   "shop",
+			"labels":      map[string]interface{}{"app": "web"},
+			"annotations": map[string]interface{}{"team": "payments"},
+		},
+		"spec": map[string]interface{}{"replicas": int64(3)},
+	}
+	if !reflect.DeepEqual(deployment.Object, want) {
+		t.Errorf("SanitizeForApply =\n%v\nwant\n%v", deployment.Object, want)
+	}
+}
+
+func TestSanitizeForApplyService(t *testing.T) {
+	service := func(managedFields []interface{}) *unstructured.Unstructured {
+		metadata := serverMetadata("web", map[string]interface{}{
+			"annotations": map[string]interface{}{ClusterAnnotation: "staging"},
+		})
+		metadata["managedFields"] = managedFields
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Service",
+			"metadata":   metadata,
+			"spec": map[string]interface{}{
+				"type":                  "LoadBalancer",
+				"clusterIP":             "10.96.4.12",
+				"clusterIPs":            []interface{}{"10.96.4.12"},
+				"externalTrafficPolicy": "Local",
+				"healthCheckNodePort":   int64(31200),
+				"ports": []interface{}{
+					map[string]interface{}{"port": int64(443), "protocol": "TCP", "nodePort": int64(30443)},
+					map[string]interface{}{"port": int64(80), "protocol": "TCP", "nodePort": int64(31877)},
+				},
+			},
+			"status": map[string]interface{}{"loadBalancer": map[string]interface{}{}},
+		}}
+	}
+	nodePorts := func(obj *unstructured.Unstructured) []interface{} {
+		ports, _, _ := unstructured.NestedSlice(obj.Object, "spec", "ports")
+		var found []interface{}
+		for _, port := range ports {
+			found = append(found, port.(map[string]interface{})["nodePort"])
+		}
+		return found
+	}
+
+	// 443's node port was pinned in the manifest; 80's and the health check port were allocated
+	applied := service([]interface{}{map[string]interface{}{
+		"manager": "mcm",
+		"fieldsV1": map[string]interface{}{"f:spec": map[string]interface{}{
+			"f:ports": map[string]interface{}{
+				`k:{"port":443,"protocol":"TCP"}`: map[string]interface{}{"f:nodePort": map[string]interface{}{}, "f:port": map[string]interface{}{}},
+				`k:{"port":80,"protocol":"TCP"}`:  map[string]interface{}{"f:port": map[string]interface{}{}},
+			},
+		}},
+	}})
+	SanitizeForApply(applied)
+
+	if _, found, _ := unstructured.NestedFieldNoCopy(applied.Object, "spec", "clusterIP"); found {
+		t.Error("Expected the allocated clusterIP stripped")
+	}
+	if _, found, _ := unstructured.NestedFieldNoCopy(applied.Object, "spec", "clusterIPs"); found {
+		t.Error("Expected the allocated clusterIPs stripped")
+	}
+	if _, found, _ := unstructured.NestedFieldNoCopy(applied.Object, "spec", "healthCheckNodePort"); found {
+		t.Error("Expected the allocated healthCheckNodePort stripped")
+	}
+	if got := nodePorts(applied); !reflect.DeepEqual(got, []interface{}{int64(30443), nil}) {
+		t.Errorf("Expected only the pinned node port kept, got %v", got)
+	}
+	if _, found, _ := unstructured.NestedFieldNoCopy(applied.Object, "status"); found {
+		t.Error("Expected status stripped")
+	}
+	if annotations := applied.GetAnnotations(); len(annotations) != 0 {
+		t.Errorf("Expected the cluster annotation stripped, got %v", annotations)
+	}
+
+	// Without managedFields there's no telling which ports were pinned, so they stay
+	unknown := service(nil)
+	SanitizeForApply(unknown)
+	if got := nodePorts(unknown); !reflect.DeepEqual(got, []interface{}{int64(30443), int64(31877)}) {
+		t.Errorf("Expected node ports kept without managedFields, got %v", got)
+	}
+
+	headless := &unstructured.Unstructured{Object: map[string]interface{}{
+		"kind": "Service",
+		"spec": map[string]interface{}{"clusterIP": "None", "clusterIPs": []interface{}{"None"}},
+	}}
+	SanitizeForApply(headless)
+	if clusterIP, _, _ := unstructured.NestedString(headless.Object, "spec", "clusterIP"); clusterIP != "None" {
+		t.Errorf("Expected a headless service to stay headless, got clusterIP %q", clusterIP)
+	}
+}
+
+func TestSanitizeForApplyConfigMap(t *testing.T) {
+	configMap := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": serverMetadata("app-settings", map[string]interface{}{
+			"ownerReferences": []interface{}{map[string]interface{}{"kind": "Deployment", "name": "web", "uid": "9b2d"}},
+			"annotations":     map[string]interface{}{"kubectl.kubernetes.io/last-applied-configuration": "{}"},
+		}),
+		"data": map[string]interface{}{"LOG_LEVEL": "info"},
+	}}
+
+	SanitizeForApply(configMap)
+
+	want := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      "app-settings",
+			"namespace": "shop",
+			"labels":    map[string]interface{}{"app": "web"},
+		},
+		"data": map[string]interface{}{"LOG_LEVEL": "info"},
+	}
+	if !reflect.DeepEqual(configMap.Object, want) {
+		t.Errorf("SanitizeForApply =\n%v\nwant\n%v", configMap.Object, want)
+	}
+}
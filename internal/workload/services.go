@@ -0,0 +1,120 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ServiceInfo is one service in one cluster, with the addresses it is reached on
+type ServiceInfo struct {
+	ClusterName string `json:"clusterName"`
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	Type        string `json:"type"` // ClusterIP, NodePort, LoadBalancer or ExternalName
+	ClusterIP   string `json:"clusterIP,omitempty"`
+
+	// ExternalAddresses are where the service is reachable from outside the
+	// cluster: a LoadBalancer's provisioned ingress IPs or hostnames, the
+	// spec's externalIPs, or an ExternalName's target
+	ExternalAddresses []string `json:"externalAddresses,omitempty"`
+
+	// Pending is set for a LoadBalancer whose cloud load balancer isn't
+	// provisioned (yet), so it has no external address
+	Pending bool `json:"pending,omitempty"`
+
+	Ports     []string  `json:"ports,omitempty"` // e.g. 443/TCP, or 80:30080/TCP with a node port
+	Age       string    `json:"age"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ListServices retrieves services from the specified clusters
+// An empty namespace lists all namespaces; an empty labelSelector matches every service
+func (m *Manager) ListServices(clusterNames []string, namespace, labelSelector string) FleetResult[ServiceInfo] {
+	clusterNames = m.connectedClusters(clusterNames)
+
+	return fanOut(clusterNames, m.timeouts, func(ctx context.Context, name string) ([]ServiceInfo, error) {
+		client, err := m.clusterManager.GetClient(name)
+		if err != nil {
+			return nil, err
+		}
+		var services []ServiceInfo
+		err = client.Do(ctx, func(ctx context.Context) error {
+			var listErr error
+			services, listErr = listServices(ctx, client.Clientset, name, namespace, labelSelector)
+			return listErr
+		})
+		return services, err
+	})
+}
+
+// listServices lists one cluster's services
+func listServices(ctx context.Context, clientset kubernetes.Interface, clusterName, namespace, labelSelector string) ([]ServiceInfo, error) {
+	services, err := clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+
+	result := make([]ServiceInfo, 0, len(services.Items))
+	for _, service := range services.Items {
+		info := ServiceInfo{
+			ClusterName:       clusterName,
+			Namespace:         service.Namespace,
+			Name:              service.Name,
+			Type:              string(service.Spec.Type),
+			ClusterIP:         service.Spec.ClusterIP,
+			ExternalAddresses: externalAddresses(service),
+			Ports:             servicePorts(service.Spec.Ports),
+			CreatedAt:         service.CreationTimestamp.Time,
+			Age:               formatDuration(time.Since(service.CreationTimestamp.Time)),
+		}
+		if info.Type == "" {
+			info.Type = string(corev1.ServiceTypeClusterIP)
+		}
+		info.Pending = service.Spec.Type == corev1.ServiceTypeLoadBalancer && len(service.Status.LoadBalancer.Ingress) == 0
+		result = append(result, info)
+	}
+	return result, nil
+}
+
+// externalAddresses collects where a service is reachable from outside the cluster
+func externalAddresses(service corev1.Service) []string {
+	var addresses []string
+	if service.Spec.Type == corev1.ServiceTypeExternalName {
+		return append(addresses, service.Spec.ExternalName)
+	}
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			addresses = append(addresses, ingress.IP)
+		}
+		if ingress.Hostname != "" {
+			addresses = append(addresses, ingress.Hostname)
+		}
+	}
+	return append(addresses, service.Spec.ExternalIPs...)
+}
+
+// servicePorts formats ports the way kubectl get services does
+func servicePorts(ports []corev1.ServicePort) []string {
+	formatted := make([]string, 0, len(ports))
+	for _, port := range ports {
+		protocol := string(port.Protocol)
+		if protocol == "" {
+			protocol = string(corev1.ProtocolTCP)
+		}
+		var entry strings.Builder
+		entry.WriteString(strconv.Itoa(int(port.Port)))
+		if port.NodePort != 0 {
+			entry.WriteString(":" + strconv.Itoa(int(port.NodePort)))
+		}
+		entry.WriteString("/" + protocol)
+		formatted = append(formatted, entry.String())
+	}
+	return formatted
+}
@@ -0,0 +1,57 @@
+package workload
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestListServices(t *testing.T) {
+	clientset := fake.NewClientset(
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop", Labels: map[string]string{"app": "web"}},
+			Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, ClusterIP: "10.0.0.10",
+				Ports: []corev1.ServicePort{{Port: 443, NodePort: 30443, Protocol: corev1.ProtocolTCP}}},
+			Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{
+				{Hostname: "a1b2.elb.eu-west-1.amazonaws.com"},
+			}}},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop", Labels: map[string]string{"app": "web"}},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, Ports: []corev1.ServicePort{{Port: 80, Protocol: corev1.ProtocolUDP}}},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop", Labels: map[string]string{"app": "web"}},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "db.example.com"},
+		},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "shop"}},
+	)
+
+	services, err := listServices(context.Background(), clientset, "prod-eu", "shop", "app=web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := make(map[string]ServiceInfo)
+	for _, service := range services {
+		byName[service.Name] = service
+	}
+	if len(byName) != 3 {
+		t.Fatalf("Expected the three app=web services, got %+v", services)
+	}
+
+	web := byName["web"]
+	if !reflect.DeepEqual(web.ExternalAddresses, []string{"a1b2.elb.eu-west-1.amazonaws.com"}) || web.Pending ||
+		!reflect.DeepEqual(web.Ports, []string{"443:30443/TCP"}) || web.ClusterName != "prod-eu" {
+		t.Errorf("Unexpected web service: %+v", web)
+	}
+	if api := byName["api"]; !api.Pending || len(api.ExternalAddresses) != 0 || !reflect.DeepEqual(api.Ports, []string{"80/UDP"}) {
+		t.Errorf("Expected api to be a pending load balancer, got %+v", api)
+	}
+	if db := byName["db"]; !reflect.DeepEqual(db.ExternalAddresses, []string{"db.example.com"}) {
+		t.Errorf("Expected db to point at its external name, got %+v", db)
+	}
+}
@@ -0,0 +1,268 @@
+package workload
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// Layout of a snapshot archive: an index at the top, and one directory per
+// cluster holding that cluster's resources as a multi-document YAML file. Each
+// cluster directory is a plain manifest directory, so after extracting the
+// archive it can be handed to 'mcm sync' to put the cluster back the way it was
+const (
+	snapshotIndexFile     = "snapshot.yaml"
+	snapshotResourcesFile = "resources.yaml"
+	snapshotClustersDir   = "clusters"
+)
+
+// snapshotAPIVersions restores the apiVersion typed clients drop from listed objects
+var snapshotAPIVersions = map[string]string{
+	"Deployment": "apps/v1",
+	"Service":    "v1",
+	"ConfigMap":  "v1",
+}
+
+// ClusterSnapshot is what one cluster was running when the snapshot was taken
+// Objects are manifests - apiVersion and kind filled in, everything the API
+// server assigns stripped - sorted by kind, namespace and name
+type ClusterSnapshot struct {
+	Cluster string
+	Objects []map[string]interface{}
+}
+
+// FleetSnapshot is a point-in-time record of what is deployed where
+// Clusters that couldn't be read are kept in Errors, so an incomplete snapshot
+// says so instead of passing for a fleet with fewer clusters
+type FleetSnapshot struct {
+	TakenAt   time.Time         `json:"takenAt"`
+	Namespace string            `json:"namespace,omitempty"` // Empty means all namespaces
+	Clusters  []string          `json:"clusters"`
+	Errors    map[string]string `json:"errors,omitempty"`
+
+	Snapshots []ClusterSnapshot `json:"-"`
+}
+
+// SnapshotDiff compares one cluster's current state (B, "live") with what it
+// was running in the snapshot (A, "snapshot"); resource names are namespace/name
+type SnapshotDiff struct {
+	Cluster string `json:"cluster"`
+	*ClusterDiff
+}
+
+// SnapshotFleet records the deployments, services and configmaps of every
+// cluster (or just the named ones), in one namespace or all of them
+func (m *Manager) SnapshotFleet(clusterNames []string, namespace string) *FleetSnapshot {
+	clusterNames = m.connectedClusters(clusterNames)
+	takenAt := time.Now().UTC()
+
+	result := fanOut(clusterNames, m.timeouts, func(ctx context.Context, name string) ([]ClusterSnapshot, error) {
+		objects, err := m.snapshotCluster(ctx, name, namespace)
+		if err != nil {
+			return nil, err
+		}
+		return []ClusterSnapshot{{Cluster: name, Objects: objects}}, nil
+	})
+
+	sort.Slice(result.Items, func(i, j int) bool { return result.Items[i].Cluster < result.Items[j].Cluster })
+	snapshot := &FleetSnapshot{
+		TakenAt:   takenAt,
+		Namespace: namespace,
+		Clusters:  make([]string, 0, len(result.Items)),
+		Errors:    result.ErrorMessages(),
+		Snapshots: result.Items,
+	}
+	for _, cluster := range result.Items {
+		snapshot.Clusters = append(snapshot.Clusters, cluster.Cluster)
+	}
+	return snapshot
+}
+
+// snapshotCluster lists one cluster's resources as restorable manifests
+func (m *Manager) snapshotCluster(ctx context.Context, clusterName, namespace string) ([]map[string]interface{}, error) {
+	client, err := m.clusterManager.GetClient(clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []map[string]interface{}
+	err = client.Do(ctx, func(ctx context.Context) error {
+		objects = nil // A retry starts over
+		return listComparedObjects(ctx, client.Clientset, namespace, func(kind string, content map[string]interface{}) {
+			content["apiVersion"] = snapshotAPIVersions[kind]
+			content["kind"] = kind
+			SanitizeForApply(&unstructured.Unstructured{Object: content})
+			objects = append(objects, content)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(objects, func(i, j int) bool { return snapshotKey(objects[i]) < snapshotKey(objects[j]) })
+	return objects, nil
+}
+
+// snapshotKey identifies a snapshotted object as "Kind/namespace/name"
+func snapshotKey(obj map[string]interface{}) string {
+	kind, _ := obj["kind"].(string)
+	metadata, _ := obj["metadata"].(map[string]interface{})
+	namespace, _ := metadata["namespace"].(string)
+	name, _ := metadata["name"].(string)
+	return kind + "/" + namespace + "/" + name
+}
+
+// DiffSnapshot compares each cluster's current state with the snapshot
+// Only the clusters and namespace the snapshot covered are read, so a snapshot
+// of one namespace isn't reported as missing everything else
+func (m *Manager) DiffSnapshot(snapshot *FleetSnapshot, clusterNames []string) FleetResult[SnapshotDiff] {
+	saved := make(map[string]ClusterSnapshot, len(snapshot.Snapshots))
+	for _, cluster := range snapshot.Snapshots {
+		saved[cluster.Cluster] = cluster
+	}
+	if len(clusterNames) == 0 {
+		clusterNames = snapshot.Clusters
+	}
+
+	return fanOut(clusterNames, m.timeouts, func(ctx context.Context, name string) ([]SnapshotDiff, error) {
+		then, ok := saved[name]
+		if !ok {
+			return nil, fmt.Errorf("cluster %s is not in the snapshot", name)
+		}
+		now, err := m.snapshotCluster(ctx, name, snapshot.Namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		diff := diffSnapshots(comparableSnapshot(then.Objects), comparableSnapshot(now))
+		diff.ClusterA = "snapshot"
+		diff.ClusterB = "live"
+		diff.Namespace = snapshot.Namespace
+		return []SnapshotDiff{{Cluster: name, ClusterDiff: diff}}, nil
+	})
+}
+
+// comparableSnapshot keys normalized copies of a cluster's objects by
+// "Kind/namespace/name", the form diffSnapshots compares
+func comparableSnapshot(objects []map[string]interface{}) map[string]map[string]interface{} {
+	comparable := make(map[string]map[string]interface{}, len(objects))
+	for _, obj := range objects {
+		key := snapshotKey(obj)
+		content := runtime.DeepCopyJSON(obj) // Normalizing must leave the snapshot itself alone
+		kind, _ := content["kind"].(string)
+		normalizeObject(kind, content)
+		comparable[key] = content
+	}
+	return comparable
+}
+
+// WriteSnapshotArchive writes a snapshot as a tar archive
+func WriteSnapshotArchive(w io.Writer, snapshot *FleetSnapshot) error {
+	archive := tar.NewWriter(w)
+
+	add := func(name string, data []byte) error {
+		header := &tar.Header{Name: name, Mode: 0600, Size: int64(len(data)), ModTime: snapshot.TakenAt}
+		if err := archive.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		if _, err := archive.Write(data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		return nil
+	}
+
+	index, err := yaml.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to render the snapshot index: %w", err)
+	}
+	if err := add(snapshotIndexFile, index); err != nil {
+		return err
+	}
+
+	for _, cluster := range snapshot.Snapshots {
+		var resources bytes.Buffer
+		for _, obj := range cluster.Objects {
+			data, err := yaml.Marshal(obj)
+			if err != nil {
+				return fmt.Errorf("failed to render %s in cluster %s: %w", snapshotKey(obj), cluster.Cluster, err)
+			}
+			resources.WriteString("---\n")
+			resources.Write(data)
+		}
+		if err := add(path.Join(snapshotClustersDir, cluster.Cluster, snapshotResourcesFile), resources.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+// ReadSnapshotArchive reads a snapshot written by WriteSnapshotArchive
+func ReadSnapshotArchive(r io.Reader) (*FleetSnapshot, error) {
+	archive := tar.NewReader(r)
+
+	var snapshot *FleetSnapshot
+	resources := make(map[string][]map[string]interface{})
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("not a snapshot archive: %w", err)
+		}
+		data, err := io.ReadAll(archive)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", header.Name, err)
+		}
+
+		if header.Name == snapshotIndexFile {
+			snapshot = &FleetSnapshot{}
+			if err := yaml.Unmarshal(data, snapshot); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", snapshotIndexFile, err)
+			}
+			continue
+		}
+
+		cluster, ok := snapshotClusterName(header.Name)
+		if !ok {
+			continue // Not ours; leave room for files added by hand
+		}
+		objects, err := DecodeManifests(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", header.Name, err)
+		}
+		for _, obj := range objects {
+			resources[cluster] = append(resources[cluster], obj.Object)
+		}
+	}
+
+	if snapshot == nil {
+		return nil, fmt.Errorf("not a snapshot archive: %s is missing", snapshotIndexFile)
+	}
+	for _, cluster := range snapshot.Clusters {
+		snapshot.Snapshots = append(snapshot.Snapshots, ClusterSnapshot{Cluster: cluster, Objects: resources[cluster]})
+	}
+	return snapshot, nil
+}
+
+// snapshotClusterName extracts the cluster from a "clusters/<name>/resources.yaml" entry
+func snapshotClusterName(name string) (string, bool) {
+	rest, ok := strings.CutPrefix(name, snapshotClustersDir+"/")
+	if !ok {
+		return "", false
+	}
+	cluster, file, ok := strings.Cut(rest, "/")
+	return cluster, ok && file == snapshotResourcesFile && cluster != ""
+}
@@ -0,0 +1,137 @@
+package workload
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+	"github.com/celikgo/autoz-control-tower/internal/config"
+)
+
+func TestSnapshotRoundTripAndDiff(t *testing.T) {
+	fleet := func(objects ...runtime.Object) *cluster.ClusterClient {
+		return &cluster.ClusterClient{Clientset: fake.NewSimpleClientset(objects...), Connected: true, Config: config.ClusterConfig{}}
+	}
+	provider := &fakeProvider{clients: map[string]*cluster.ClusterClient{
+		"prod-us": fleet(
+			diffDeployment("web:1.0", "a"),
+			diffService("10.0.0.1", 30001),
+			diffConfigMap("settings", map[string]string{"mode": "fast"}),
+			diffConfigMap("kube-root-ca.crt", map[string]string{"ca.crt": "us"}),
+		),
+	}}
+	manager := NewManager(provider)
+
+	snapshot := manager.SnapshotFleet([]string{"prod-us", "prod-eu"}, "")
+	if len(snapshot.Clusters) != 1 || snapshot.Clusters[0] != "prod-us" {
+		t.Fatalf("clusters = %v, want [prod-us]", snapshot.Clusters)
+	}
+	if _, ok := snapshot.Errors["prod-eu"]; !ok {
+		t.Errorf("Expected the unreadable cluster to be recorded, got errors %v", snapshot.Errors)
+	}
+
+	var archive bytes.Buffer
+	if err := WriteSnapshotArchive(&archive, snapshot); err != nil {
+		t.Fatalf("WriteSnapshotArchive failed: %v", err)
+	}
+	saved, err := ReadSnapshotArchive(&archive)
+	if err != nil {
+		t.Fatalf("ReadSnapshotArchive failed: %v", err)
+	}
+	if len(saved.Errors) != 1 || len(saved.Snapshots) != 1 {
+		t.Fatalf("Snapshot didn't survive the round trip: %+v", saved)
+	}
+
+	// Saved objects are restorable manifests: kind and apiVersion kept, server fields gone
+	objects := saved.Snapshots[0].Objects
+	if len(objects) != 3 {
+		t.Fatalf("Expected 3 saved objects (no root CA configmap), got %d", len(objects))
+	}
+	for _, obj := range objects {
+		metadata := obj["metadata"].(map[string]interface{})
+		if obj["apiVersion"] == "" || metadata["namespace"] != "app" {
+			t.Errorf("Saved object is not a restorable manifest: %v", obj)
+		}
+		if _, ok := metadata["uid"]; ok {
+			t.Errorf("Saved object kept its UID: %v", obj)
+		}
+	}
+
+	// Change the cluster: a new image, a deleted configmap, and a new one
+	client, _ := provider.GetClient("prod-us")
+	ctx := context.Background()
+	if _, err := client.Clientset.AppsV1().Deployments("app").Update(ctx, diffDeployment("web:2.0", "b"), metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Clientset.CoreV1().ConfigMaps("app").Delete(ctx, "settings", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Clientset.CoreV1().ConfigMaps("app").Create(ctx, diffConfigMap("flags", nil), metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	result := manager.DiffSnapshot(saved, nil)
+	if len(result.Errors) != 0 || len(result.Items) != 1 {
+		t.Fatalf("DiffSnapshot = %+v", result)
+	}
+	diff := result.Items[0]
+	if diff.Cluster != "prod-us" || diff.Identical != 1 {
+		t.Errorf("cluster = %s, identical = %d; want prod-us with 1 (the service)", diff.Cluster, diff.Identical)
+	}
+
+	want := []struct{ kind, name, status string }{
+		{"Deployment", "app/web", DiffDifferent},
+		{"ConfigMap", "app/settings", DiffOnlyInA},
+		{"ConfigMap", "app/flags", DiffOnlyInB},
+	}
+	if len(diff.Resources) != len(want) {
+		t.Fatalf("Expected %d differences, got %+v", len(want), diff.Resources)
+	}
+	for _, w := range want {
+		found := false
+		for _, resource := range diff.Resources {
+			if resource.Kind == w.kind && resource.Name == w.name && resource.Status == w.status {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Missing %s %s %s in %+v", w.status, w.kind, w.name, diff.Resources)
+		}
+	}
+
+	// The diff must not have normalized the loaded snapshot in place
+	if objects[0]["apiVersion"] == nil {
+		t.Error("DiffSnapshot modified the snapshot it was given")
+	}
+}
+
+func TestDiffSnapshotRejectsClustersNotInIt(t *testing.T) {
+	provider := &fakeProvider{clients: map[string]*cluster.ClusterClient{
+		"dev": {Clientset: fake.NewSimpleClientset(), Connected: true},
+	}}
+	snapshot := &FleetSnapshot{Clusters: []string{"prod-us"}, Snapshots: []ClusterSnapshot{{Cluster: "prod-us"}}}
+
+	result := NewManager(provider).DiffSnapshot(snapshot, []string{"dev"})
+	if err := result.Errors["dev"]; err == nil {
+		t.Fatalf("Expected an error for a cluster the snapshot doesn't cover, got %+v", result)
+	}
+}
+
+func TestReadSnapshotArchiveRejectsOtherFiles(t *testing.T) {
+	if _, err := ReadSnapshotArchive(bytes.NewReader([]byte("clusters: []"))); err == nil {
+		t.Fatal("Expected an error for a file that isn't a snapshot archive")
+	}
+
+	var empty bytes.Buffer
+	if err := WriteSnapshotArchive(&empty, &FleetSnapshot{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadSnapshotArchive(&empty); err != nil {
+		t.Fatalf("An empty snapshot should still read back: %v", err)
+	}
+}
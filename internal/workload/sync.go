@@ -0,0 +1,475 @@
+package workload
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/flowcontrol"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+)
+
+const (
+	// ManagedByLabel marks every object mcm applies so it can be told apart
+	// from resources owned by other tools sharing the same namespace
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	ManagedByValue = "mcm"
+
+	// SyncIDLabel ties an object to the sync source that created it
+	// Pruning only ever considers objects carrying the current sync ID
+	SyncIDLabel = "mcm.io/sync-id"
+
+	// FieldManager is the server-side apply field manager name used by mcm
+	FieldManager = "mcm"
+)
+
+// Sync actions reported per object
+const (
+	SyncActionCreate    = "create"
+	SyncActionUpdate    = "update"
+	SyncActionUnchanged = "unchanged"
+	SyncActionPrune     = "prune"
+)
+
+// SyncOptions controls how a desired state is reconciled against a cluster
+type SyncOptions struct {
+	Namespace string  // Namespace for objects that don't declare one
+	SyncID    string  // Identifies objects owned by this sync source
+	DiffOnly  bool    // Report what would change without applying anything
+	Prune     bool    // Delete owned objects that are no longer desired
+	RateLimit float32 // Maximum API write operations per second, per cluster
+}
+
+// SyncAction describes what happened (or would happen) to a single object
+type SyncAction struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Action    string `json:"action"`
+	Error     string `json:"error,omitempty"`
+
+	// Changes lists the keys an update touches, for ConfigMaps and Secrets
+	Changes []DataChange `json:"changes,omitempty"`
+}
+
+// SyncResult holds the outcome of reconciling one cluster
+type SyncResult struct {
+	ClusterName string       `json:"clusterName"`
+	Actions     []SyncAction `json:"actions"`
+	Error       string       `json:"error,omitempty"`
+}
+
+// Failed reports whether the cluster or any of its objects failed to sync
+func (r SyncResult) Failed() bool {
+	if r.Error != "" {
+		return true
+	}
+	for _, action := range r.Actions {
+		if action.Error != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadManifestDir reads every YAML/JSON manifest in a directory tree and
+// decodes it into a flat list of objects
+// This is the "desired state" half of a GitOps reconcile loop
+func LoadManifestDir(dir string) ([]*unstructured.Unstructured, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// Skip hidden directories such as .git
+		if d.IsDir() && path != dir && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest directory %s: %w", dir, err)
+	}
+
+	// Sort for a stable apply order between runs
+	sort.Strings(files)
+
+	var objects []*unstructured.Unstructured
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read manifest %s: %w", file, err)
+		}
+		decoded, err := DecodeManifests(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode manifest %s: %w", file, err)
+		}
+		objects = append(objects, decoded...)
+	}
+
+	return objects, nil
+}
+
+// DecodeManifests splits a (possibly multi-document) YAML or JSON string into
+// individual Kubernetes objects, skipping empty documents
+func DecodeManifests(content string) ([]*unstructured.Unstructured, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(strings.NewReader(content), 4096)
+
+	var objects []*unstructured.Unstructured
+	for {
+		var raw runtime.RawExtension
+		if err := decoder.Decode(&raw); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+
+		raw.Raw = bytes.TrimSpace(raw.Raw)
+		if len(raw.Raw) == 0 || string(raw.Raw) == "null" {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(raw.Raw); err != nil {
+			return nil, err
+		}
+		if obj.GetKind() == "" {
+			return nil, fmt.Errorf("object is missing a 'kind' field")
+		}
+		if obj.GetName() == "" && obj.GetGenerateName() == "" {
+			return nil, fmt.Errorf("%s object is missing metadata.name (or metadata.generateName)", obj.GetKind())
+		}
+		objects = append(objects, obj)
+	}
+
+	return objects, nil
+}
+
+// Revision computes a stable fingerprint of a desired state
+// Two directories with identical manifests produce the same revision, which
+// lets callers tell whether a cluster has already been synced to it
+func Revision(objects []*unstructured.Unstructured) string {
+	hash := sha256.New()
+	for _, obj := range objects {
+		data, err := obj.MarshalJSON()
+		if err != nil {
+			continue
+		}
+		hash.Write(data)
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:12]
+}
+
+// SyncCallback is told that one cluster's sync finished, with its result
+type SyncCallback func(result SyncResult)
+
+// SyncClusters reconciles the desired objects against several clusters in parallel
+// Each cluster is independent - a failure in one never stops the others
+func (m *Manager) SyncClusters(clusterNames []string, desired []*unstructured.Unstructured, opts SyncOptions) []SyncResult {
+	return m.SyncClustersWithCallback(clusterNames, desired, opts, nil)
+}
+
+// SyncClustersWithCallback is SyncClusters, calling callback as each cluster
+// finishes so progress can be recorded before the slowest cluster is done.
+//
+// The callback runs on the cluster's own goroutine: it may be called from
+// several goroutines at once and must synchronize any state it shares
+func (m *Manager) SyncClustersWithCallback(clusterNames []string, desired []*unstructured.Unstructured, opts SyncOptions, callback SyncCallback) []SyncResult {
+	results := make([]SyncResult, len(clusterNames))
+	var wg sync.WaitGroup
+
+	for i, clusterName := range clusterNames {
+		wg.Add(1)
+		go func(index int, name string) {
+			defer wg.Done()
+			results[index] = m.SyncCluster(name, desired, opts)
+			if callback != nil {
+				callback(results[index])
+			}
+		}(i, clusterName)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// SyncCluster reconciles the desired objects against a single cluster
+// For every desired object it decides between create, update and unchanged,
+// and (with pruning enabled) removes owned objects that left the desired set
+func (m *Manager) SyncCluster(clusterName string, desired []*unstructured.Unstructured, opts SyncOptions) SyncResult {
+	result := SyncResult{ClusterName: clusterName}
+
+	client, err := m.clusterManager.GetClient(clusterName)
+	if err != nil {
+		result.Error = fmt.Sprintf("Failed to get cluster client: %v", err)
+		return result
+	}
+	if client.Dynamic == nil {
+		result.Error = "cluster has no dynamic client available"
+		return result
+	}
+
+	// Resolve kinds to API resources using the cluster's own discovery data,
+	// so CRDs installed in one cluster but not another are handled correctly
+	resolver, err := m.newKindResolver(clusterName)
+	if err != nil {
+		result.Error = fmt.Sprintf("Failed to discover API resources: %v", err)
+		return result
+	}
+
+	// Rate limit API operations so a large sync doesn't overwhelm the API server
+	var limiter flowcontrol.RateLimiter
+	if opts.RateLimit > 0 {
+		limiter = flowcontrol.NewTokenBucketRateLimiter(opts.RateLimit, 1)
+	}
+	throttle := func() {
+		if limiter != nil {
+			limiter.Accept()
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	desiredKeys := make(map[string]bool)
+	syncedKinds := make(map[schema.GroupVersionKind]bool)
+
+	for _, original := range desired {
+		obj := original.DeepCopy()
+		action := SyncAction{Kind: obj.GetKind(), Name: obj.GetName()}
+
+		mapping, err := resolver.RESTMapping(obj.GroupVersionKind())
+		if err != nil {
+			action.Error = fmt.Sprintf("kind not served by cluster: %v", err)
+			result.Actions = append(result.Actions, action)
+			continue
+		}
+
+		resource := resourceInterface(client.Dynamic, mapping, obj, opts.Namespace)
+		action.Namespace = obj.GetNamespace()
+		labelOwnedObject(obj, opts.SyncID)
+
+		// generateName objects (one-off Jobs, debug pods) get a fresh name from the
+		// API server every time, so there is nothing to look up or update
+		if obj.GetName() == "" {
+			action.Action = SyncActionCreate
+			action.Name = generatedNamePlaceholder(obj.GetGenerateName())
+			if !opts.DiffOnly {
+				// A retried create could leave two objects behind, so it gets one try
+				err := client.DoOnce(ctx, func(ctx context.Context) error {
+					throttle()
+					created, err := resource.Create(ctx, obj, metav1.CreateOptions{FieldManager: FieldManager})
+					if err != nil {
+						return err
+					}
+					action.Name = created.GetName()
+					return nil
+				})
+				if err != nil {
+					action.Error = fmt.Sprintf("failed to create %s %s: %v", obj.GetKind(), action.Name, err)
+				}
+			}
+			result.Actions = append(result.Actions, action)
+			continue
+		}
+
+		desiredKeys[objectKey(obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())] = true
+		syncedKinds[obj.GroupVersionKind()] = true
+
+		// Server-side apply is idempotent, so the whole read-compare-apply step
+		// can be retried as one
+		err = client.Do(ctx, func(ctx context.Context) error {
+			throttle()
+			live, err := resource.Get(ctx, obj.GetName(), metav1.GetOptions{})
+			switch {
+			case apierrors.IsNotFound(err):
+				action.Action = SyncActionCreate
+			case err != nil:
+				return fmt.Errorf("failed to get live object: %w", err)
+			case equality.Semantic.DeepDerivative(obj.Object, live.Object):
+				action.Action = SyncActionUnchanged
+			default:
+				action.Action = SyncActionUpdate
+				action.Changes = DiffObjectData(obj.GetKind(), live.Object, obj.Object)
+			}
+
+			if opts.DiffOnly || action.Action == SyncActionUnchanged {
+				return nil
+			}
+			throttle()
+			return applyObject(ctx, resource, obj, true)
+		})
+		if err != nil {
+			action.Error = err.Error()
+		}
+
+		result.Actions = append(result.Actions, action)
+	}
+
+	if opts.Prune {
+		result.Actions = append(result.Actions,
+			m.pruneCluster(ctx, client, resolver, syncedKinds, desiredKeys, opts, throttle)...)
+	}
+
+	return result
+}
+
+// pruneCluster removes objects owned by this sync source that are no longer desired
+// Only kinds present in the desired set are inspected, mirroring how
+// kubectl apply --prune limits itself to an allowlist of kinds
+func (m *Manager) pruneCluster(ctx context.Context, client *cluster.ClusterClient, resolver *kindResolver,
+	kinds map[schema.GroupVersionKind]bool, desiredKeys map[string]bool, opts SyncOptions, throttle func()) []SyncAction {
+
+	var actions []SyncAction
+	selector := fmt.Sprintf("%s=%s,%s=%s", ManagedByLabel, ManagedByValue, SyncIDLabel, opts.SyncID)
+
+	for gvk := range kinds {
+		mapping, err := resolver.RESTMapping(gvk)
+		if err != nil {
+			continue
+		}
+
+		var list *unstructured.UnstructuredList
+		err = client.Do(ctx, func(ctx context.Context) error {
+			throttle()
+			var listErr error
+			list, listErr = client.Dynamic.Resource(mapping.Resource).List(ctx, metav1.ListOptions{LabelSelector: selector})
+			return listErr
+		})
+		if err != nil {
+			actions = append(actions, SyncAction{
+				Kind:   gvk.Kind,
+				Action: SyncActionPrune,
+				Error:  fmt.Sprintf("failed to list owned objects: %v", err),
+			})
+			continue
+		}
+
+		for _, item := range list.Items {
+			// Objects created from a generateName never appear in the desired set by
+			// name; they're one-off runs, not state to converge on, so leave them be
+			if desiredKeys[objectKey(gvk, item.GetNamespace(), item.GetName())] || item.GetGenerateName() != "" {
+				continue
+			}
+
+			action := SyncAction{
+				Kind:      gvk.Kind,
+				Namespace: item.GetNamespace(),
+				Name:      item.GetName(),
+				Action:    SyncActionPrune,
+			}
+
+			if !opts.DiffOnly {
+				var resource dynamic.ResourceInterface = client.Dynamic.Resource(mapping.Resource)
+				if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+					resource = client.Dynamic.Resource(mapping.Resource).Namespace(item.GetNamespace())
+				}
+				err := client.Do(ctx, func(ctx context.Context) error {
+					throttle()
+					return resource.Delete(ctx, item.GetName(), metav1.DeleteOptions{})
+				})
+				if err != nil && !apierrors.IsNotFound(err) {
+					action.Error = fmt.Sprintf("failed to prune: %v", err)
+				}
+			}
+
+			actions = append(actions, action)
+		}
+	}
+
+	// Map iteration order is random, so sort for a readable report
+	sort.Slice(actions, func(i, j int) bool {
+		if actions[i].Kind != actions[j].Kind {
+			return actions[i].Kind < actions[j].Kind
+		}
+		if actions[i].Namespace != actions[j].Namespace {
+			return actions[i].Namespace < actions[j].Namespace
+		}
+		return actions[i].Name < actions[j].Name
+	})
+
+	return actions
+}
+
+// resourceInterface returns the dynamic client scoped correctly for the object,
+// defaulting the namespace of namespaced objects that don't declare one
+func resourceInterface(client dynamic.Interface, mapping *meta.RESTMapping, obj *unstructured.Unstructured, namespace string) dynamic.ResourceInterface {
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		obj.SetNamespace("")
+		return client.Resource(mapping.Resource)
+	}
+
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(namespace)
+	}
+	return client.Resource(mapping.Resource).Namespace(obj.GetNamespace())
+}
+
+// applyObject sends the object to the cluster using server-side apply
+// With force, mcm takes ownership of fields other managers set; sync always forces,
+// since its manifests are the source of truth
+func applyObject(ctx context.Context, resource dynamic.ResourceInterface, obj *unstructured.Unstructured, force bool) error {
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("failed to encode %s %s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+
+	_, err = resource.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+		FieldManager: FieldManager,
+		Force:        &force,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply %s %s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+
+	return nil
+}
+
+// labelOwnedObject stamps the ownership labels used for pruning onto an object
+func labelOwnedObject(obj *unstructured.Unstructured, syncID string) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[ManagedByLabel] = ManagedByValue
+	if syncID != "" {
+		labels[SyncIDLabel] = syncID
+	}
+	obj.SetLabels(labels)
+}
+
+// generatedNamePlaceholder stands in for the name the API server will generate,
+// for reports made before the object exists
+func generatedNamePlaceholder(generateName string) string {
+	return generateName + "<generated>"
+}
+
+// objectKey builds a unique identity for an object within a cluster
+func objectKey(gvk schema.GroupVersionKind, namespace, name string) string {
+	return gvk.GroupKind().String() + "/" + namespace + "/" + name
+}
@@ -0,0 +1,187 @@
+package workload
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/celikgo/autoz-control-tower/internal/cluster"
+)
+
+func TestDecodeManifests(t *testing.T) {
+	content := `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+data:
+  key: value
+---
+# empty documents are skipped
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: prod
+spec:
+  replicas: 3
+`
+
+	objects, err := DecodeManifests(content)
+	if err != nil {
+		t.Fatalf("DecodeManifests() error = %v", err)
+	}
+
+	if len(objects) != 2 {
+		t.Fatalf("Expected 2 objects, got %d", len(objects))
+	}
+
+	if objects[0].GetKind() != "ConfigMap" || objects[0].GetName() != "settings" {
+		t.Errorf("Unexpected first object: %s/%s", objects[0].GetKind(), objects[0].GetName())
+	}
+
+	if objects[1].GetNamespace() != "prod" {
+		t.Errorf("Expected namespace 'prod', got '%s'", objects[1].GetNamespace())
+	}
+
+	// Numbers must decode as int64 so they compare equal to live objects
+	replicas, found, _ := unstructured.NestedInt64(objects[1].Object, "spec", "replicas")
+	if !found || replicas != 3 {
+		t.Errorf("Expected replicas 3, got %d (found=%v)", replicas, found)
+	}
+}
+
+func TestDecodeManifestsRequiresName(t *testing.T) {
+	_, err := DecodeManifests("apiVersion: v1\nkind: ConfigMap\n")
+	if err == nil {
+		t.Error("Expected error for object without a name, got nil")
+	}
+}
+
+func TestSyncCreatesGenerateNameObjects(t *testing.T) {
+	objects, err := DecodeManifests(`
+apiVersion: batch/v1
+kind: Job
+metadata:
+  generateName: migrate-
+  namespace: prod
+spec:
+  template:
+    spec:
+      restartPolicy: Never
+      containers:
+      - name: migrate
+        image: migrate:1.0
+`)
+	if err != nil {
+		t.Fatalf("DecodeManifests() error = %v", err)
+	}
+
+	// The fake client doesn't generate names, so stand in for the API server
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	created := 0
+	dynamicClient.PrependReactor("create", "jobs", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		obj := action.(k8stesting.CreateAction).GetObject().(*unstructured.Unstructured)
+		if obj.GetName() == "" {
+			created++
+			obj.SetName(fmt.Sprintf("%s%05d", obj.GetGenerateName(), created))
+		}
+		return false, nil, nil
+	})
+
+	job := schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"}
+	provider := &mapperProvider{
+		fakeProvider: fakeProvider{clients: map[string]*cluster.ClusterClient{
+			"prod": {Dynamic: dynamicClient, Connected: true},
+		}},
+		mappers: []meta.RESTMapper{mapperWith(job)},
+	}
+	manager := NewManager(provider)
+	opts := SyncOptions{SyncID: "jobs", Prune: true}
+
+	// Every sync is a new run: nothing is looked up, updated or pruned
+	for _, want := range []string{"migrate-00001", "migrate-00002"} {
+		result := manager.SyncCluster("prod", objects, opts)
+		if result.Failed() {
+			t.Fatalf("SyncCluster failed: %+v", result)
+		}
+		if len(result.Actions) != 1 {
+			t.Fatalf("actions = %+v, want a single create", result.Actions)
+		}
+		if action := result.Actions[0]; action.Action != SyncActionCreate || action.Name != want {
+			t.Errorf("action = %s %s, want create %s", action.Action, action.Name, want)
+		}
+	}
+
+	for _, action := range dynamicClient.Actions() {
+		if action.GetVerb() != "create" {
+			t.Errorf("unexpected %s of %s, generateName objects should only be created", action.GetVerb(), action.GetResource().Resource)
+		}
+	}
+
+	// Without applying, the report names the object by its prefix
+	preview := manager.SyncCluster("prod", objects, SyncOptions{SyncID: "jobs", DiffOnly: true})
+	if got := preview.Actions[0].Name; got != "migrate-<generated>" {
+		t.Errorf("diff-only name = %q, want migrate-<generated>", got)
+	}
+}
+
+func TestSyncClustersReportsEachCluster(t *testing.T) {
+	provider := &mapperProvider{
+		fakeProvider: fakeProvider{clients: map[string]*cluster.ClusterClient{
+			"prod": {Dynamic: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), Connected: true},
+		}},
+		mappers: []meta.RESTMapper{mapperWith(schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"})},
+	}
+	manager := NewManager(provider)
+
+	var mu sync.Mutex
+	reported := map[string]SyncResult{}
+	results := manager.SyncClustersWithCallback([]string{"prod", "gone"}, nil, SyncOptions{SyncID: "app"}, func(result SyncResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		if _, seen := reported[result.ClusterName]; seen {
+			t.Errorf("%s reported twice", result.ClusterName)
+		}
+		reported[result.ClusterName] = result
+	})
+
+	if len(reported) != 2 {
+		t.Fatalf("reported %d clusters, want 2", len(reported))
+	}
+	if reported["prod"].Failed() {
+		t.Errorf("prod failed: %+v", reported["prod"])
+	}
+	if !reported["gone"].Failed() {
+		t.Error("expected the unknown cluster to be reported as failed")
+	}
+	for _, result := range results {
+		if reported[result.ClusterName].Error != result.Error {
+			t.Errorf("%s: callback saw %q, result has %q", result.ClusterName, reported[result.ClusterName].Error, result.Error)
+		}
+	}
+}
+
+func TestRevisionIsStable(t *testing.T) {
+	content := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n"
+
+	first, _ := DecodeManifests(content)
+	second, _ := DecodeManifests(content)
+
+	if Revision(first) != Revision(second) {
+		t.Error("Expected identical manifests to produce the same revision")
+	}
+
+	changed, _ := DecodeManifests(content + "data:\n  k: v\n")
+	if Revision(first) == Revision(changed) {
+		t.Error("Expected changed manifests to produce a different revision")
+	}
+}
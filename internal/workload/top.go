@@ -0,0 +1,182 @@
+package workload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// metricsGroupVersion is the resource metrics API metrics-server serves
+// It is read through the dynamic client, so mcm needs no metrics clientset
+var metricsGroupVersion = schema.GroupVersion{Group: "metrics.k8s.io", Version: "v1beta1"}
+
+// Resources of the metrics API
+var (
+	nodeMetricsResource = metricsGroupVersion.WithResource("nodes")
+	podMetricsResource  = metricsGroupVersion.WithResource("pods")
+)
+
+// ErrMetricsUnavailable marks a cluster whose metrics API isn't there or isn't
+// answering, almost always because metrics-server isn't installed (or is still
+// starting). It says nothing about the cluster's health otherwise
+var ErrMetricsUnavailable = errors.New("metrics-server not installed")
+
+// NodeUsage is one node's current CPU and memory use
+// The percentages are of the node's allocatable capacity, and zero when that
+// isn't known
+type NodeUsage struct {
+	ClusterName   string  `json:"clusterName"`
+	Name          string  `json:"name"`
+	CPUMillicores int64   `json:"cpuMillicores"`
+	MemoryBytes   int64   `json:"memoryBytes"`
+	CPUPercent    float64 `json:"cpuPercent,omitempty"`
+	MemoryPercent float64 `json:"memoryPercent,omitempty"`
+}
+
+// PodUsage is one pod's current CPU and memory use, summed over its containers
+type PodUsage struct {
+	ClusterName   string `json:"clusterName"`
+	Namespace     string `json:"namespace"`
+	Name          string `json:"name"`
+	CPUMillicores int64  `json:"cpuMillicores"`
+	MemoryBytes   int64  `json:"memoryBytes"`
+}
+
+// TopNodes reads every node's resource usage from each cluster's metrics API,
+// like kubectl top nodes. A cluster without metrics-server fails with
+// ErrMetricsUnavailable on its own; the others are still reported
+func (m *Manager) TopNodes(clusterNames []string) FleetResult[NodeUsage] {
+	clusterNames = m.connectedClusters(clusterNames)
+
+	return fanOut(clusterNames, m.timeouts, func(ctx context.Context, name string) ([]NodeUsage, error) {
+		client, err := m.clusterManager.GetClient(name)
+		if err != nil {
+			return nil, err
+		}
+		if client.Dynamic == nil {
+			return nil, fmt.Errorf("cluster has no dynamic client available")
+		}
+
+		var usage []NodeUsage
+		err = client.Do(ctx, func(ctx context.Context) error {
+			var topErr error
+			usage, topErr = topNodes(ctx, client.Clientset, client.Dynamic, name)
+			return topErr
+		})
+		return usage, err
+	})
+}
+
+// TopPods reads the resource usage of the pods in a namespace (all namespaces
+// when empty) from each cluster's metrics API, like kubectl top pods
+func (m *Manager) TopPods(clusterNames []string, namespace, labelSelector string) FleetResult[PodUsage] {
+	clusterNames = m.connectedClusters(clusterNames)
+
+	return fanOut(clusterNames, m.timeouts, func(ctx context.Context, name string) ([]PodUsage, error) {
+		client, err := m.clusterManager.GetClient(name)
+		if err != nil {
+			return nil, err
+		}
+		if client.Dynamic == nil {
+			return nil, fmt.Errorf("cluster has no dynamic client available")
+		}
+
+		var usage []PodUsage
+		err = client.Do(ctx, func(ctx context.Context) error {
+			var topErr error
+			usage, topErr = topPods(ctx, client.Dynamic, name, namespace, labelSelector)
+			return topErr
+		})
+		return usage, err
+	})
+}
+
+// topNodes reads one cluster's node metrics and relates them to each node's allocatable capacity
+func topNodes(ctx context.Context, clientset kubernetes.Interface, dynamicClient dynamic.Interface, clusterName string) ([]NodeUsage, error) {
+	list, err := dynamicClient.Resource(nodeMetricsResource).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, metricsError(err)
+	}
+
+	// Percentages are a bonus; without the node list they're simply left out
+	allocatable := make(map[string][2]int64)
+	if nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{}); err == nil {
+		for _, node := range nodes.Items {
+			allocatable[node.Name] = [2]int64{node.Status.Allocatable.Cpu().MilliValue(), node.Status.Allocatable.Memory().Value()}
+		}
+	}
+
+	usage := make([]NodeUsage, 0, len(list.Items))
+	for _, item := range list.Items {
+		cpu, memory := usageOf(item.Object, "usage")
+		node := NodeUsage{ClusterName: clusterName, Name: item.GetName(), CPUMillicores: cpu, MemoryBytes: memory}
+		if capacity, ok := allocatable[node.Name]; ok {
+			node.CPUPercent = percentOf(cpu, capacity[0])
+			node.MemoryPercent = percentOf(memory, capacity[1])
+		}
+		usage = append(usage, node)
+	}
+	return usage, nil
+}
+
+// topPods reads one cluster's pod metrics
+func topPods(ctx context.Context, dynamicClient dynamic.Interface, clusterName, namespace, labelSelector string) ([]PodUsage, error) {
+	list, err := dynamicClient.Resource(podMetricsResource).Namespace(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, metricsError(err)
+	}
+
+	usage := make([]PodUsage, 0, len(list.Items))
+	for _, item := range list.Items {
+		pod := PodUsage{ClusterName: clusterName, Namespace: item.GetNamespace(), Name: item.GetName()}
+		containers, _, _ := unstructured.NestedSlice(item.Object, "containers")
+		for _, container := range containers {
+			if fields, ok := container.(map[string]interface{}); ok {
+				cpu, memory := usageOf(fields, "usage")
+				pod.CPUMillicores += cpu
+				pod.MemoryBytes += memory
+			}
+		}
+		usage = append(usage, pod)
+	}
+	return usage, nil
+}
+
+// usageOf parses the cpu and memory quantities under the given field
+// A quantity that doesn't parse counts as zero rather than failing the listing
+func usageOf(object map[string]interface{}, field string) (cpuMillicores, memoryBytes int64) {
+	values, _, _ := unstructured.NestedStringMap(object, field)
+	if quantity, err := resource.ParseQuantity(values["cpu"]); err == nil {
+		cpuMillicores = quantity.MilliValue()
+	}
+	if quantity, err := resource.ParseQuantity(values["memory"]); err == nil {
+		memoryBytes = quantity.Value()
+	}
+	return cpuMillicores, memoryBytes
+}
+
+// percentOf is used as a percentage of capacity, to one decimal (rounded down)
+func percentOf(used, capacity int64) float64 {
+	if capacity <= 0 {
+		return 0
+	}
+	return float64(used*1000/capacity) / 10
+}
+
+// metricsError tells a missing metrics API apart from other failures
+// Without metrics-server the API server doesn't know the resource (404); with
+// its APIService registered but the pod down, the aggregator answers 503
+func metricsError(err error) error {
+	if apierrors.IsNotFound(err) || apierrors.IsServiceUnavailable(err) {
+		return fmt.Errorf("%w: %w", ErrMetricsUnavailable, err)
+	}
+	return fmt.Errorf("failed to read metrics: %w", err)
+}
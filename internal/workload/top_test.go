@@ -0,0 +1,110 @@
+package workload
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// metricsClient is a dynamic client that serves the given metrics objects
+// They are tracked under the metrics API's resource names (nodes, pods), which
+// the fake can't guess from the NodeMetrics and PodMetrics kinds
+func metricsClient(t *testing.T, objects ...*unstructured.Unstructured) *dynamicfake.FakeDynamicClient {
+	t.Helper()
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		nodeMetricsResource: "NodeMetricsList",
+		podMetricsResource:  "PodMetricsList",
+	})
+	for _, object := range objects {
+		gvr := nodeMetricsResource
+		if object.GetKind() == "PodMetrics" {
+			gvr = podMetricsResource
+		}
+		if err := client.Tracker().Create(gvr, object, object.GetNamespace()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return client
+}
+
+func metricsObject(kind, namespace, name string, fields map[string]interface{}) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{Object: fields}
+	object.SetAPIVersion(metricsGroupVersion.String())
+	object.SetKind(kind)
+	object.SetNamespace(namespace)
+	object.SetName(name)
+	return object
+}
+
+func TestTopNodes(t *testing.T) {
+	dynamicClient := metricsClient(t,
+		metricsObject("NodeMetrics", "", "node-a", map[string]interface{}{"usage": map[string]interface{}{"cpu": "1500m", "memory": "2Gi"}}),
+		metricsObject("NodeMetrics", "", "node-b", map[string]interface{}{"usage": map[string]interface{}{"cpu": "250000001n", "memory": "512Mi"}}),
+	)
+	clientset := fake.NewSimpleClientset(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-a"},
+		Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("4"),
+			corev1.ResourceMemory: resource.MustParse("8Gi"),
+		}},
+	})
+
+	usage, err := topNodes(context.Background(), clientset, dynamicClient, "prod-eu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := map[string]NodeUsage{}
+	for _, node := range usage {
+		byName[node.Name] = node
+	}
+
+	a := byName["node-a"]
+	if a.CPUMillicores != 1500 || a.MemoryBytes != 2<<30 || a.CPUPercent != 37.5 || a.MemoryPercent != 25 {
+		t.Errorf("Unexpected usage for node-a: %+v", a)
+	}
+	// Nanocores round up to the next millicore; without the node, no percentages
+	b := byName["node-b"]
+	if b.CPUMillicores != 251 || b.MemoryBytes != 512<<20 || b.CPUPercent != 0 {
+		t.Errorf("Unexpected usage for node-b: %+v", b)
+	}
+}
+
+func TestTopPodsSumsContainers(t *testing.T) {
+	dynamicClient := metricsClient(t,
+		metricsObject("PodMetrics", "shop", "web-1", map[string]interface{}{"containers": []interface{}{
+			map[string]interface{}{"name": "web", "usage": map[string]interface{}{"cpu": "100m", "memory": "64Mi"}},
+			map[string]interface{}{"name": "proxy", "usage": map[string]interface{}{"cpu": "20m", "memory": "16Mi"}},
+		}}),
+	)
+
+	usage, err := topPods(context.Background(), dynamicClient, "prod-eu", "shop", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(usage) != 1 || usage[0].CPUMillicores != 120 || usage[0].MemoryBytes != 80<<20 || usage[0].Namespace != "shop" {
+		t.Errorf("Unexpected pod usage: %+v", usage)
+	}
+}
+
+func TestTopWithoutMetricsServer(t *testing.T) {
+	dynamicClient := metricsClient(t)
+	dynamicClient.PrependReactor("list", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewNotFound(nodeMetricsResource.GroupResource(), "")
+	})
+
+	_, err := topNodes(context.Background(), fake.NewSimpleClientset(), dynamicClient, "dev")
+	if !errors.Is(err, ErrMetricsUnavailable) {
+		t.Errorf("Expected a missing metrics API to be ErrMetricsUnavailable, got %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package workload
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ParseValues reads a values file (YAML or JSON) into the data manifests are rendered with
+// An empty file gives empty values rather than an error
+func ParseValues(content []byte) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse values: %w", err)
+	}
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	return values, nil
+}
+
+// SetValue applies a --set style override, key=value, on top of values. Dotted
+// keys (image.tag=1.2) reach into nested maps, creating them as needed; the
+// value is always a string, which is all plain substitution ever prints
+func SetValue(values map[string]interface{}, assignment string) error {
+	key, value, ok := strings.Cut(assignment, "=")
+	if !ok || strings.TrimSpace(key) == "" {
+		return fmt.Errorf("invalid value %q: expected key=value", assignment)
+	}
+
+	path := strings.Split(key, ".")
+	current := values
+	for i, part := range path[:len(path)-1] {
+		if part == "" {
+			return fmt.Errorf("invalid value %q: empty key segment", assignment)
+		}
+		next, ok := current[part].(map[string]interface{})
+		if !ok {
+			if _, exists := current[part]; exists {
+				return fmt.Errorf("invalid value %q: %s is not a map", assignment, strings.Join(path[:i+1], "."))
+			}
+			next = map[string]interface{}{}
+			current[part] = next
+		}
+		current = next
+	}
+	if last := path[len(path)-1]; last != "" {
+		current[last] = value
+		return nil
+	}
+	return fmt.Errorf("invalid value %q: empty key segment", assignment)
+}
+
+// ManifestValues holds everything a deploy renders its manifest with: base
+// values, per-environment overlays merged on top for clusters in that
+// environment, and --set overrides applied last, to every cluster
+type ManifestValues struct {
+	Name         string                            `json:"-"` // The manifest's file name, for error messages
+	Base         map[string]interface{}            `json:"base,omitempty"`
+	Environments map[string]map[string]interface{} `json:"environments,omitempty"` // Keys match cluster environments case-insensitively
+	Overrides    []string                          `json:"overrides,omitempty"`    // key=value, as given to --set
+}
+
+// For returns the values a cluster in the given environment is rendered with
+// Nested maps are merged key by key, so an overlay only needs the keys it changes
+func (v *ManifestValues) For(environment string) (map[string]interface{}, error) {
+	values := mergeValues(map[string]interface{}{}, v.Base)
+	for name, overlay := range v.Environments {
+		if environment != "" && strings.EqualFold(name, environment) {
+			values = mergeValues(values, overlay)
+		}
+	}
+	for _, assignment := range v.Overrides {
+		if err := SetValue(values, assignment); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// Render fills in the manifest for a cluster in the given environment
+func (v *ManifestValues) Render(manifest, environment string) (string, error) {
+	values, err := v.For(environment)
+	if err != nil {
+		return "", err
+	}
+	rendered, err := RenderManifest(v.Name, manifest, values)
+	if err != nil && environment != "" {
+		return "", fmt.Errorf("%w (environment %s)", err, environment)
+	}
+	return rendered, err
+}
+
+// mergeValues copies overlay into values, descending into maps both sides have
+// Maps are copied rather than shared, so merging never changes the overlay
+func mergeValues(values, overlay map[string]interface{}) map[string]interface{} {
+	for key, value := range overlay {
+		overlayMap, isMap := value.(map[string]interface{})
+		if !isMap {
+			values[key] = value
+			continue
+		}
+		existing, _ := values[key].(map[string]interface{})
+		if existing == nil {
+			existing = map[string]interface{}{}
+		}
+		values[key] = mergeValues(existing, overlayMap)
+	}
+	return values
+}
+
+// RenderManifest substitutes {{ .Values.x }} placeholders in a manifest
+// This is deliberately plain Go templating, not Helm: there are no extra
+// functions, and a placeholder without a value is an error rather than an
+// empty string, so a typo can't quietly deploy a blank image tag
+func RenderManifest(name, manifest string, values map[string]interface{}) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(manifest)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s as a template: %w", name, err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, map[string]interface{}{"Values": values}); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return rendered.String(), nil
+}
@@ -0,0 +1,75 @@
+package workload
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderManifestWithValues(t *testing.T) {
+	values, err := ParseValues([]byte("replicas: 3\nimage:\n  tag: \"1.0\"\n"))
+	if err != nil {
+		t.Fatalf("ParseValues: %v", err)
+	}
+	if err := SetValue(values, "image.tag=1.4.2"); err != nil {
+		t.Fatalf("SetValue: %v", err)
+	}
+	if err := SetValue(values, "env.name=prod"); err != nil {
+		t.Fatalf("SetValue: %v", err)
+	}
+
+	manifest := "replicas: {{ .Values.replicas }}\nimage: web:{{ .Values.image.tag }}\nenv: {{ .Values.env.name }}\n"
+	got, err := RenderManifest("app.yaml", manifest, values)
+	if err != nil {
+		t.Fatalf("RenderManifest: %v", err)
+	}
+	if want := "replicas: 3\nimage: web:1.4.2\nenv: prod\n"; got != want {
+		t.Errorf("RenderManifest() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderManifestMissingValue(t *testing.T) {
+	_, err := RenderManifest("app.yaml", "image: web:{{ .Values.image.tag }}\n", map[string]interface{}{})
+	if err == nil || !strings.Contains(err.Error(), "app.yaml") {
+		t.Errorf("Expected an error naming the manifest for a missing value, got %v", err)
+	}
+}
+
+func TestSetValueRejectsInvalidAssignments(t *testing.T) {
+	for _, assignment := range []string{"noequals", "=value", "image..tag=1", "replicas.count=2"} {
+		values := map[string]interface{}{"replicas": 3}
+		if err := SetValue(values, assignment); err == nil {
+			t.Errorf("Expected %q to be rejected", assignment)
+		}
+	}
+}
+
+func TestManifestValuesMergeEnvironmentOverlay(t *testing.T) {
+	values := &ManifestValues{
+		Name: "app.yaml",
+		Base: map[string]interface{}{"replicas": 1, "image": map[string]interface{}{"repo": "web", "tag": "1.0"}},
+		Environments: map[string]map[string]interface{}{
+			"production": {"replicas": 5, "image": map[string]interface{}{"tag": "1.0-stable"}},
+		},
+		Overrides: []string{"image.repo=registry/web"},
+	}
+	manifest := "{{ .Values.replicas }} {{ .Values.image.repo }}:{{ .Values.image.tag }}"
+
+	tests := []struct{ environment, want string }{
+		{"development", "1 registry/web:1.0"},
+		{"Production", "5 registry/web:1.0-stable"},
+	}
+	for _, tt := range tests {
+		got, err := values.Render(manifest, tt.environment)
+		if err != nil {
+			t.Fatalf("Render(%s): %v", tt.environment, err)
+		}
+		if got != tt.want {
+			t.Errorf("Render(%s) = %q, want %q", tt.environment, got, tt.want)
+		}
+	}
+
+	// Rendering one environment must not leak into the next
+	if tag := values.Base["image"].(map[string]interface{})["tag"]; tag != "1.0" {
+		t.Errorf("Expected the base values to stay unchanged, got tag %v", tag)
+	}
+}
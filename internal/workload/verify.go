@@ -0,0 +1,143 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Verification statuses reported by VerifyDeployments
+const (
+	VerifyOK       = "OK"       // Ready replica count is backed by real, ready pods
+	VerifyOrphaned = "Orphaned" // Deployment claims ready replicas but its selector matches no pods
+	VerifyMismatch = "Mismatch" // Fewer ready pods match the selector than the deployment reports
+)
+
+// DeploymentVerification cross-checks a deployment's reported status against its pods
+// This is like counting the people in the room instead of trusting the sign-in sheet
+type DeploymentVerification struct {
+	ClusterName   string `json:"clusterName"`
+	Namespace     string `json:"namespace"`
+	Name          string `json:"name"`
+	Selector      string `json:"selector"`
+	ReadyReplicas int32  `json:"readyReplicas"`
+	MatchingPods  int    `json:"matchingPods"`
+	ReadyPods     int    `json:"readyPods"`
+	Status        string `json:"status"`
+	Error         string `json:"error,omitempty"`
+}
+
+// Flagged reports whether the deployment needs attention
+func (v DeploymentVerification) Flagged() bool {
+	return v.Error != "" || v.Status != VerifyOK
+}
+
+// VerifyDeployments joins deployments with the pods their selectors match in each cluster
+// and flags deployments whose claimed readiness isn't backed by actual pods
+func (m *Manager) VerifyDeployments(clusterNames []string, namespace string) FleetResult[DeploymentVerification] {
+	clusterNames = m.connectedClusters(clusterNames)
+
+	return fanOut(clusterNames, m.timeouts, func(ctx context.Context, name string) ([]DeploymentVerification, error) {
+		return m.verifyCluster(ctx, name, namespace)
+	})
+}
+
+// verifyCluster fetches deployments and pods from one cluster and cross-checks them
+func (m *Manager) verifyCluster(ctx context.Context, clusterName, namespace string) ([]DeploymentVerification, error) {
+	client, err := m.clusterManager.GetClient(clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	var deployments *appsv1.DeploymentList
+	err = client.Do(ctx, func(ctx context.Context) error {
+		var listErr error
+		deployments, listErr = client.Clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+		return listErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+
+	// One pod listing per cluster is far cheaper than one per deployment
+	var pods *corev1.PodList
+	err = client.Do(ctx, func(ctx context.Context) error {
+		var listErr error
+		pods, listErr = client.Clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		return listErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	return verifyDeployments(clusterName, deployments.Items, pods.Items), nil
+}
+
+// verifyDeployments matches each deployment's selector against the given pods
+func verifyDeployments(clusterName string, deployments []appsv1.Deployment, pods []corev1.Pod) []DeploymentVerification {
+	// Index pods by namespace since selectors never match across namespaces
+	podsByNamespace := make(map[string][]corev1.Pod)
+	for _, pod := range pods {
+		podsByNamespace[pod.Namespace] = append(podsByNamespace[pod.Namespace], pod)
+	}
+
+	var result []DeploymentVerification
+	for _, deployment := range deployments {
+		verification := DeploymentVerification{
+			ClusterName:   clusterName,
+			Namespace:     deployment.Namespace,
+			Name:          deployment.Name,
+			ReadyReplicas: deployment.Status.ReadyReplicas,
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+		if err != nil {
+			verification.Error = fmt.Sprintf("invalid selector: %v", err)
+			result = append(result, verification)
+			continue
+		}
+		verification.Selector = selector.String()
+
+		for _, pod := range podsByNamespace[deployment.Namespace] {
+			if !selector.Matches(labels.Set(pod.Labels)) {
+				continue
+			}
+			verification.MatchingPods++
+			if isPodReady(pod) {
+				verification.ReadyPods++
+			}
+		}
+
+		// More matching pods than replicas is normal mid-rollout, so only
+		// flag deployments that claim more readiness than their pods show
+		switch {
+		case verification.ReadyReplicas > 0 && verification.MatchingPods == 0:
+			verification.Status = VerifyOrphaned
+		case int32(verification.ReadyPods) < verification.ReadyReplicas:
+			verification.Status = VerifyMismatch
+		default:
+			verification.Status = VerifyOK
+		}
+
+		result = append(result, verification)
+	}
+
+	return result
+}
+
+// isPodReady reports whether a pod is running, not terminating, and passing readiness checks
+func isPodReady(pod corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
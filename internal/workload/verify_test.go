@@ -0,0 +1,54 @@
+package workload
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testDeployment(name string, selector map[string]string, readyReplicas int32) appsv1.Deployment {
+	return appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: selector}},
+		Status:     appsv1.DeploymentStatus{ReadyReplicas: readyReplicas},
+	}
+}
+
+func testReadyPod(name string, podLabels map[string]string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: podLabels},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func TestVerifyDeployments(t *testing.T) {
+	deployments := []appsv1.Deployment{
+		testDeployment("web", map[string]string{"app": "web"}, 2),
+		testDeployment("api", map[string]string{"app": "api"}, 3),
+		testDeployment("worker", map[string]string{"app": "worker"}, 2),
+	}
+	pods := []corev1.Pod{
+		testReadyPod("web-1", map[string]string{"app": "web"}),
+		testReadyPod("web-2", map[string]string{"app": "web"}),
+		testReadyPod("worker-1", map[string]string{"app": "worker"}),
+		// Same labels in another namespace must not count
+		{ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "other", Labels: map[string]string{"app": "api"}}},
+	}
+
+	want := map[string]string{
+		"web":    VerifyOK,
+		"api":    VerifyOrphaned,
+		"worker": VerifyMismatch,
+	}
+
+	for _, verification := range verifyDeployments("test", deployments, pods) {
+		if verification.Status != want[verification.Name] {
+			t.Errorf("%s: expected status %s, got %s", verification.Name, want[verification.Name], verification.Status)
+		}
+	}
+}